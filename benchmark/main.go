@@ -6,7 +6,9 @@ import (
 	"flag"
 	"fmt"
 	"net"
+	"os"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -14,19 +16,44 @@ import (
 
 func main() {
 	addr := flag.String("addr", "localhost:6379", "서버 주소")
-	clients := flag.Int("c", 50, "동시 클라이언트 수")
+	clients := flag.String("c", "50", "동시 클라이언트 수 (쉼표로 여러 개를 주면 차례로 실행해 확장성을 비교)")
 	requests := flag.Int("n", 10000, "총 요청 수")
 	tests := flag.String("t", "set,get", "테스트할 명령어 (쉼표 구분)")
 	flag.Parse()
 
+	clientCounts, err := parseClientCounts(*clients)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
 	commands := strings.Split(*tests, ",")
 	for _, cmd := range commands {
 		cmd = strings.TrimSpace(strings.ToLower(cmd))
-		latencies, elapsed := runBenchmark(*addr, *clients, *requests, cmd)
-		printResult(cmd, latencies, elapsed, *clients)
+		throughputs := make([]float64, len(clientCounts))
+		for i, count := range clientCounts {
+			latencies, elapsed := runBenchmark(*addr, count, *requests, cmd)
+			throughputs[i] = printResult(cmd, latencies, elapsed, count)
+		}
+		if len(clientCounts) > 1 {
+			printScaling(cmd, clientCounts, throughputs)
+		}
 	}
 }
 
+// "1,4,16" 같은 클라이언트 수 목록을 파싱한다.
+func parseClientCounts(raw string) ([]int, error) {
+	var counts []int
+	for _, part := range strings.Split(raw, ",") {
+		count, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil || count < 1 {
+			return nil, fmt.Errorf("잘못된 클라이언트 수: %q", part)
+		}
+		counts = append(counts, count)
+	}
+	return counts, nil
+}
+
 // RESP 프로토콜 형식의 명령어 바이트를 생성한다.
 // buildCommand("SET", "key:000001", "value")
 // -> "*3\r\n$3\r\nSET\r\n$10\r\nkey:000001\r\n$5\r\nvalue\r\n"
@@ -162,8 +189,8 @@ func percentile(sorted []time.Duration, p float64) time.Duration {
 	return sorted[index]
 }
 
-// 벤치마크 결과를 출력한다.
-func printResult(cmd string, latencies []time.Duration, elapsed time.Duration, clients int) {
+// 벤치마크 결과를 출력하고 처리량(requests/sec)을 반환한다.
+func printResult(cmd string, latencies []time.Duration, elapsed time.Duration, clients int) float64 {
 	sort.Slice(latencies, func(i, j int) bool {
 		return latencies[i] < latencies[j]
 	})
@@ -183,4 +210,16 @@ func printResult(cmd string, latencies []time.Duration, elapsed time.Duration, c
 	fmt.Println()
 	fmt.Printf("  Throughput: %.2f requests/sec\n", throughput)
 	fmt.Println()
+	return throughput
+}
+
+// 클라이언트 수별 처리량을 첫 번째 실행 대비 배수로 비교해 출력한다.
+// 서버가 코어를 고르게 쓰면 클라이언트 수를 늘릴수록 배수가 코어 수까지 커진다.
+func printScaling(cmd string, clientCounts []int, throughputs []float64) {
+	fmt.Printf("====== %s scaling ======\n", strings.ToUpper(cmd))
+	fmt.Printf("  %8s  %16s  %8s\n", "clients", "requests/sec", "speedup")
+	for i, count := range clientCounts {
+		fmt.Printf("  %8d  %16.2f  %7.2fx\n", count, throughputs[i], throughputs[i]/throughputs[0])
+	}
+	fmt.Println()
 }
@@ -0,0 +1,221 @@
+// Package inmemorydb는 데이터베이스 서버를 다른 Go 프로그램 안에 띄우는 API다.
+// 통합 테스트에서 빈 포트에 서버를 띄우거나, 서비스가 RESP 서버와 같은 데이터를 직접 읽고 쓸 때 쓴다.
+//
+//	db, err := inmemorydb.New(inmemorydb.Options{})
+//	if err != nil { ... }
+//	if err := db.Start(ctx); err != nil { ... }
+//	defer db.Close()
+//	conn, err := net.Dial("tcp", db.Addr().String())
+package inmemorydb
+
+import (
+	"context"
+	"errors"
+	"inmemory-db/internal/server"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"sync"
+)
+
+// 서버 설정. 제로 값이면 127.0.0.1의 빈 포트에서 스냅샷 없이 동작한다.
+type Options struct {
+	// 수신할 주소 ("host:port"). 비우면 "127.0.0.1:0" (빈 포트)
+	Addr string
+	// 스냅샷 파일을 둘 디렉터리. 여기와 ConfigFile, Config 어디에서도 정하지 않으면
+	// 스냅샷을 읽지도 쓰지도 않는다 (SAVE는 에러)
+	Dir string
+	// 스냅샷 파일 이름. 비우면 dump.rdb
+	DBFilename string
+	// pprof 디버그 서버 주소. 비우면 띄우지 않는다
+	DebugAddr string
+	// 먼저 읽을 설정 파일 (CONFIG REWRITE가 고쳐 쓴다). 위 항목과 Config가 파일의 값보다 우선한다
+	ConfigFile string
+	// 그 밖의 설정 항목. 이름과 값은 CONFIG SET과 같다 (예: "maxmemory": "100mb")
+	Config map[string]string
+	// 서버 로그 출력. nil이면 버린다
+	Logger *log.Logger
+}
+
+var (
+	ErrAlreadyStarted = errors.New("inmemorydb: server already started")
+	ErrNotStarted     = errors.New("inmemorydb: server not started")
+)
+
+// 프로세스 안에서 도는 서버
+type Server struct {
+	srv        *server.Server
+	snapshots  bool
+	mu         sync.Mutex
+	started    bool
+	done       chan struct{}
+	serveError error
+}
+
+// 옵션으로 서버를 만든다. 잘못된 설정이면 에러를 반환한다. 연결은 Start부터 받는다.
+func New(options Options) (*Server, error) {
+	srv := server.New("")
+
+	// 설정 파일, Config, Dir 중 어디에서든 dir을 정했으면 스냅샷을 쓴다
+	snapshots := options.Dir != "" || options.Config["dir"] != ""
+	if options.ConfigFile != "" {
+		if err := srv.LoadConfigFile(options.ConfigFile); err != nil {
+			return nil, err
+		}
+		dir, _ := srv.GetConfig("dir")
+		snapshots = snapshots || dir != "."
+	}
+
+	addr := options.Addr
+	if addr == "" {
+		addr = "127.0.0.1:0"
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	config := map[string]string{
+		"bind":       host,
+		"port":       port,
+		"debug-addr": options.DebugAddr,
+	}
+	if options.Dir != "" {
+		config["dir"] = options.Dir
+	}
+	if options.DBFilename != "" {
+		config["dbfilename"] = options.DBFilename
+	}
+	for name, value := range options.Config {
+		config[name] = value
+	}
+	// 적용 순서가 매번 같도록 이름순으로 적용한다
+	names := make([]string, 0, len(config))
+	for name := range config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := srv.SetConfig(name, config[name]); err != nil {
+			return nil, errors.New("inmemorydb: " + name + ": " + err.Error())
+		}
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = log.New(io.Discard, "", 0)
+	}
+	srv.SetLogger(logger)
+
+	if !snapshots {
+		srv.DisableSnapshots()
+	}
+
+	return &Server{srv: srv, snapshots: snapshots, done: make(chan struct{})}, nil
+}
+
+// 스냅샷이 있으면 읽고, 주소를 연 뒤 반환한다. 연결은 백그라운드에서 받는다.
+// 스냅샷을 읽지 못하면 시작하지 않고 에러를 반환한다.
+// ctx는 주소를 여는 동안에만 쓰인다. 서버를 멈추려면 Shutdown이나 Close를 호출한다.
+func (s *Server) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return ErrAlreadyStarted
+	}
+	if err := s.srv.Listen(ctx); err != nil {
+		return err
+	}
+	s.started = true
+
+	go func() {
+		err := s.srv.Serve()
+		s.mu.Lock()
+		s.serveError = err
+		s.mu.Unlock()
+		close(s.done)
+	}()
+	return nil
+}
+
+// 수신 중인 주소. 포트를 0으로 줬으면 실제로 열린 포트가 들어 있다. Start 전에는 nil이다.
+func (s *Server) Addr() net.Addr {
+	return s.srv.Addr()
+}
+
+// index번 데이터베이스 (0부터 Databases()-1까지).
+// RESP 연결과 같은 데이터를 직접 읽고 쓰며, 키 공간 알림과 블로킹 명령어 깨우기도 똑같이 일어난다.
+func (s *Server) DB(index int) *Store {
+	return &Store{store: s.srv.DB(index)}
+}
+
+// 데이터베이스 수
+func (s *Server) Databases() int {
+	return s.srv.Databases()
+}
+
+// 설정 항목 하나를 바꾼다 (CONFIG SET과 같다). 시작한 뒤에는 bind, port 등을 바꿀 수 없다.
+func (s *Server) SetConfig(name, value string) error {
+	return s.srv.SetConfig(name, value)
+}
+
+// 설정 항목 하나의 현재 값 (CONFIG GET과 같다).
+func (s *Server) GetConfig(name string) (string, bool) {
+	return s.srv.GetConfig(name)
+}
+
+// 새 연결을 받지 않고, 실행 중인 명령어가 끝나길 기다린 뒤 연결을 닫고,
+// 스냅샷을 쓰도록 설정했으면 마지막 스냅샷을 쓴다. ctx가 먼저 끝나면 남은 연결을 바로 끊고 ctx의 에러를 반환한다.
+// 서버가 SHUTDOWN 명령어로 이미 멈췄으면 그 결과를 반환한다.
+func (s *Server) Shutdown(ctx context.Context) error {
+	if !s.isStarted() {
+		return ErrNotStarted
+	}
+
+	s.srv.Shutdown(s.snapshots)
+	select {
+	case <-s.done:
+		return s.Wait()
+	case <-ctx.Done():
+		s.srv.ForceShutdown(s.snapshots)
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+// 실행 중인 명령어를 기다리지 않고 연결을 모두 끊고 서버를 멈춘다. 스냅샷은 쓰지 않는다.
+func (s *Server) Close() error {
+	if !s.isStarted() {
+		return ErrNotStarted
+	}
+
+	s.srv.ForceShutdown(false)
+	<-s.done
+	return s.Wait()
+}
+
+// 서버가 멈출 때까지 기다리고, 마지막 스냅샷을 쓰다 난 에러를 반환한다.
+// SHUTDOWN 명령어로 멈춘 것을 알아챌 때 쓴다.
+func (s *Server) Wait() error {
+	if !s.isStarted() {
+		return ErrNotStarted
+	}
+
+	<-s.done
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.serveError
+}
+
+// 서버가 멈추면 닫히는 채널
+func (s *Server) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *Server) isStarted() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.started
+}
@@ -0,0 +1,219 @@
+package inmemorydb
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 서버를 만들고 시작한다. 테스트가 끝나면 닫는다.
+func startServer(t *testing.T, options Options) *Server {
+	t.Helper()
+
+	db, err := New(options)
+	if err != nil {
+		t.Fatalf("New 에러: %v", err)
+	}
+	if err := db.Start(context.Background()); err != nil {
+		t.Fatalf("Start 에러: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// 명령어 하나를 보내고 응답 한 줄을 읽는다.
+func do(t *testing.T, conn net.Conn, reader *bufio.Reader, args ...string) string {
+	t.Helper()
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&sb, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	conn.Write([]byte(sb.String()))
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("응답 읽기 실패: %v", err)
+	}
+	if line[0] == '$' && line != "$-1\r\n" {
+		body, _ := reader.ReadString('\n')
+		line += body
+	}
+	return line
+}
+
+func dial(t *testing.T, db *Server) (net.Conn, *bufio.Reader) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", db.Addr().String())
+	if err != nil {
+		t.Fatalf("연결 실패: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, bufio.NewReader(conn)
+}
+
+func TestStartOnRandomPortsAndShareStore(t *testing.T) {
+	// given: 두 서버를 빈 포트에 함께 띄운다
+	first := startServer(t, Options{})
+	second := startServer(t, Options{})
+
+	// then
+	firstPort := first.Addr().(*net.TCPAddr).Port
+	if firstPort == 0 || firstPort == second.Addr().(*net.TCPAddr).Port {
+		t.Fatalf("포트: %v, %v", first.Addr(), second.Addr())
+	}
+	if port, _ := first.GetConfig("port"); port != fmt.Sprint(firstPort) {
+		t.Fatalf("CONFIG port: %s, expected: %d", port, firstPort)
+	}
+
+	// when: 연결과 Store로 번갈아 쓴다
+	conn, reader := dial(t, first)
+	do(t, conn, reader, "SET", "from-conn", "a")
+	first.DB(0).Set("from-store", "b")
+
+	// then: 서로의 쓰기가 보이고, 다른 서버와는 데이터를 나누지 않는다
+	if value, ok := first.DB(0).Get("from-conn"); !ok || value != "a" {
+		t.Fatalf("Store Get: %q, %v", value, ok)
+	}
+	if response := do(t, conn, reader, "GET", "from-store"); response != "$1\r\nb\r\n" {
+		t.Fatalf("GET 응답: %q", response)
+	}
+	if _, ok := second.DB(0).Get("from-conn"); ok {
+		t.Fatal("다른 서버에 키가 있습니다")
+	}
+}
+
+func TestStoreWriteWakesBlockedClient(t *testing.T) {
+	// given
+	db := startServer(t, Options{})
+	conn, reader := dial(t, db)
+	conn.Write([]byte("*3\r\n$5\r\nBLPOP\r\n$4\r\njobs\r\n$1\r\n5\r\n"))
+	time.Sleep(50 * time.Millisecond)
+
+	// when
+	if _, err := db.DB(0).RPush("jobs", "job-1"); err != nil {
+		t.Fatalf("RPush 에러: %v", err)
+	}
+
+	// then
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if header, err := reader.ReadString('\n'); err != nil || header != "*2\r\n" {
+		t.Fatalf("BLPOP 응답: %q, %v", header, err)
+	}
+}
+
+func TestShutdownWritesSnapshotAndRestarts(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	db := startServer(t, Options{Dir: dir})
+	db.DB(3).Set("persisted", "v")
+	addr := db.Addr().String()
+
+	// when
+	if err := db.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown 에러: %v", err)
+	}
+
+	// then: 스냅샷이 남고 더 이상 연결을 받지 않는다
+	if _, err := os.Stat(filepath.Join(dir, "dump.rdb")); err != nil {
+		t.Fatalf("스냅샷 파일이 없습니다: %v", err)
+	}
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Fatal("종료한 뒤에도 연결을 받습니다")
+	}
+	select {
+	case <-db.Done():
+	default:
+		t.Fatal("Done이 닫히지 않았습니다")
+	}
+
+	// when & then: 같은 디렉터리로 띄우면 데이터가 복구된다
+	restarted := startServer(t, Options{Dir: dir})
+	if value, ok := restarted.DB(3).Get("persisted"); !ok || value != "v" {
+		t.Fatalf("복구된 값: %q, %v", value, ok)
+	}
+}
+
+func TestCloseSkipsSnapshot(t *testing.T) {
+	// given
+	dir := t.TempDir()
+	db := startServer(t, Options{Dir: dir})
+	db.DB(0).Set("key", "v")
+	conn, reader := dial(t, db)
+	do(t, conn, reader, "PING")
+
+	// when
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close 에러: %v", err)
+	}
+
+	// then
+	if _, err := os.Stat(filepath.Join(dir, "dump.rdb")); !os.IsNotExist(err) {
+		t.Fatalf("Close가 스냅샷을 썼습니다: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := reader.ReadByte(); err == nil {
+		t.Fatal("연결이 닫히지 않았습니다")
+	}
+}
+
+func TestShutdownCommandEndsWait(t *testing.T) {
+	// given: 스냅샷 설정이 없으면 SAVE는 에러다
+	db := startServer(t, Options{})
+	conn, reader := dial(t, db)
+	if response := do(t, conn, reader, "SAVE"); response != "-ERR snapshots are disabled\r\n" {
+		t.Fatalf("SAVE 응답: %q", response)
+	}
+
+	// when
+	conn.Write([]byte("*1\r\n$8\r\nSHUTDOWN\r\n"))
+
+	// then
+	stopped := make(chan error, 1)
+	go func() { stopped <- db.Wait() }()
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("Wait 에러: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("SHUTDOWN 뒤에도 서버가 멈추지 않았습니다")
+	}
+}
+
+func TestLifecycleErrors(t *testing.T) {
+	// given & when & then: 잘못된 설정은 New가 거부한다
+	if _, err := New(Options{Config: map[string]string{"maxmemory-policy": "sometimes"}}); err == nil {
+		t.Fatal("잘못된 설정으로 서버를 만들었습니다")
+	}
+	if _, err := New(Options{Addr: "6379"}); err == nil {
+		t.Fatal("포트 없는 주소로 서버를 만들었습니다")
+	}
+
+	db, err := New(Options{})
+	if err != nil {
+		t.Fatalf("New 에러: %v", err)
+	}
+	if err := db.Close(); !errors.Is(err, ErrNotStarted) {
+		t.Fatalf("시작 전 Close 에러: %v", err)
+	}
+	if err := db.Start(context.Background()); err != nil {
+		t.Fatalf("Start 에러: %v", err)
+	}
+	defer db.Close()
+	if err := db.Start(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Fatalf("두 번째 Start 에러: %v", err)
+	}
+	if err := db.SetConfig("port", "1"); err == nil {
+		t.Fatal("시작한 뒤에 port를 바꿨습니다")
+	}
+}
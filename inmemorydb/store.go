@@ -0,0 +1,539 @@
+package inmemorydb
+
+import (
+	"context"
+	"inmemory-db/internal/storage"
+	"time"
+)
+
+// 데이터베이스 하나. 메서드는 같은 이름의 명령어와 같게 동작하고, 여러 고루틴에서 함께 써도 안전하다.
+// 만료 루프, 스냅샷, 키 공간 알림은 서버가 관리하므로 여기에는 데이터를 읽고 쓰는 메서드만 있다.
+type Store struct {
+	store *storage.Store
+}
+
+// ========== 문자열 ==========
+
+func (s *Store) Set(key, value string) {
+	s.store.Set(key, value)
+}
+
+func (s *Store) Get(key string) (string, bool) {
+	return s.store.Get(key)
+}
+
+func (s *Store) IncrBy(key string, delta int64) (int64, error) {
+	return s.store.IncrBy(key, delta)
+}
+
+func (s *Store) IncrByFloat(key string, delta float64) (string, error) {
+	return s.store.IncrByFloat(key, delta)
+}
+
+func (s *Store) Append(key, value string) (int, error) {
+	return s.store.Append(key, value)
+}
+
+func (s *Store) StrLen(key string) (int, error) {
+	return s.store.StrLen(key)
+}
+
+func (s *Store) GetRange(key string, start, end int) (string, error) {
+	return s.store.GetRange(key, start, end)
+}
+
+func (s *Store) SetRange(key string, offset int, value string) (int, error) {
+	return s.store.SetRange(key, offset, value)
+}
+
+func (s *Store) SetWithOptions(key, value string, options SetOptions) (old string, exist, set bool, err error) {
+	return s.store.SetWithOptions(key, value, options)
+}
+
+func (s *Store) MGet(keys ...string) (values []string, exists []bool) {
+	return s.store.MGet(keys...)
+}
+
+func (s *Store) MSet(keyValues ...string) {
+	s.store.MSet(keyValues...)
+}
+
+func (s *Store) MSetNX(keyValues ...string) bool {
+	return s.store.MSetNX(keyValues...)
+}
+
+func (s *Store) SetNX(key, value string) bool {
+	return s.store.SetNX(key, value)
+}
+
+func (s *Store) GetSet(key, value string) (string, bool, error) {
+	return s.store.GetSet(key, value)
+}
+
+func (s *Store) GetDel(key string) (string, bool, error) {
+	return s.store.GetDel(key)
+}
+
+func (s *Store) GetEx(key string, options GetExOptions) (string, bool, error) {
+	return s.store.GetEx(key, options)
+}
+
+// ========== 키와 만료 ==========
+
+func (s *Store) Now() time.Time {
+	return s.store.Now()
+}
+
+func (s *Store) Expire(key string, seconds int) int {
+	return s.store.Expire(key, seconds)
+}
+
+func (s *Store) ExpireAt(key string, at time.Time, condition ExpireCondition) int {
+	return s.store.ExpireAt(key, at, condition)
+}
+
+func (s *Store) TTL(key string) int {
+	return s.store.TTL(key)
+}
+
+func (s *Store) PTTL(key string) int64 {
+	return s.store.PTTL(key)
+}
+
+func (s *Store) PExpireTime(key string) int64 {
+	return s.store.PExpireTime(key)
+}
+
+func (s *Store) Persist(key string) int {
+	return s.store.Persist(key)
+}
+
+// ========== 키스페이스 ==========
+
+func (s *Store) Exists(keys ...string) int {
+	return s.store.Exists(keys...)
+}
+
+func (s *Store) Type(key string) string {
+	return s.store.Type(key)
+}
+
+func (s *Store) Rename(source, destination string) error {
+	return s.store.Rename(source, destination)
+}
+
+func (s *Store) RenameNX(source, destination string) (bool, error) {
+	return s.store.RenameNX(source, destination)
+}
+
+func (s *Store) Copy(source, destination string, replace bool) (bool, error) {
+	return s.store.Copy(source, destination, replace)
+}
+
+func (s *Store) DBSize() int {
+	return s.store.DBSize()
+}
+
+func (s *Store) RandomKey() (string, bool) {
+	return s.store.RandomKey()
+}
+
+func (s *Store) Scan(cursor uint64, pattern string, count int, typeName string) (uint64, []string) {
+	return s.store.Scan(cursor, pattern, count, typeName)
+}
+
+func (s *Store) Keys(pattern string) []string {
+	return s.store.Keys(pattern)
+}
+
+func (s *Store) Touch(keys ...string) int {
+	return s.store.Touch(keys...)
+}
+
+func (s *Store) Del(keys ...string) int {
+	return s.store.Del(keys...)
+}
+
+func (s *Store) Unlink(keys ...string) int {
+	return s.store.Unlink(keys...)
+}
+
+func (s *Store) Flush(async bool) {
+	s.store.Flush(async)
+}
+
+// ========== 리스트 ==========
+
+func (s *Store) LPush(key string, values ...string) (int, error) {
+	return s.store.LPush(key, values...)
+}
+
+func (s *Store) RPush(key string, values ...string) (int, error) {
+	return s.store.RPush(key, values...)
+}
+
+func (s *Store) LPop(key string) (string, bool, error) {
+	return s.store.LPop(key)
+}
+
+func (s *Store) RPop(key string) (string, bool, error) {
+	return s.store.RPop(key)
+}
+
+func (s *Store) BlockingPop(ctx context.Context, keys []string, left bool) (key, value string, ok bool, err error) {
+	return s.store.BlockingPop(ctx, keys, left)
+}
+
+func (s *Store) BlockingMove(ctx context.Context, source, destination string, fromLeft, toLeft bool) (value string, ok bool, err error) {
+	return s.store.BlockingMove(ctx, source, destination, fromLeft, toLeft)
+}
+
+func (s *Store) LRange(key string, start, stop int) ([]string, error) {
+	return s.store.LRange(key, start, stop)
+}
+
+func (s *Store) LPushX(key string, values ...string) (int, error) {
+	return s.store.LPushX(key, values...)
+}
+
+func (s *Store) RPushX(key string, values ...string) (int, error) {
+	return s.store.RPushX(key, values...)
+}
+
+func (s *Store) LPopCount(key string, count int) ([]string, bool, error) {
+	return s.store.LPopCount(key, count)
+}
+
+func (s *Store) RPopCount(key string, count int) ([]string, bool, error) {
+	return s.store.RPopCount(key, count)
+}
+
+func (s *Store) LLen(key string) (int, error) {
+	return s.store.LLen(key)
+}
+
+func (s *Store) LIndex(key string, index int) (string, bool, error) {
+	return s.store.LIndex(key, index)
+}
+
+func (s *Store) LSet(key string, index int, value string) error {
+	return s.store.LSet(key, index, value)
+}
+
+func (s *Store) LInsert(key string, before bool, pivot, value string) (int, error) {
+	return s.store.LInsert(key, before, pivot, value)
+}
+
+func (s *Store) LRem(key string, count int, value string) (int, error) {
+	return s.store.LRem(key, count, value)
+}
+
+func (s *Store) LTrim(key string, start, stop int) error {
+	return s.store.LTrim(key, start, stop)
+}
+
+func (s *Store) LPos(key, value string, rank, count, maxlen int) ([]int, error) {
+	return s.store.LPos(key, value, rank, count, maxlen)
+}
+
+func (s *Store) LMove(source, destination string, fromLeft, toLeft bool) (string, bool, error) {
+	return s.store.LMove(source, destination, fromLeft, toLeft)
+}
+
+func (s *Store) BlockedClients() int {
+	return s.store.BlockedClients()
+}
+
+// ========== 해시 ==========
+
+func (s *Store) HSet(key string, fieldValues ...string) (int, error) {
+	return s.store.HSet(key, fieldValues...)
+}
+
+func (s *Store) HSetNX(key, field, value string) (bool, error) {
+	return s.store.HSetNX(key, field, value)
+}
+
+func (s *Store) HGet(key, field string) (string, bool, error) {
+	return s.store.HGet(key, field)
+}
+
+func (s *Store) HMGet(key string, fields ...string) (values []string, exists []bool, err error) {
+	return s.store.HMGet(key, fields...)
+}
+
+func (s *Store) HDel(key string, fields ...string) (int, error) {
+	return s.store.HDel(key, fields...)
+}
+
+func (s *Store) HExists(key, field string) (bool, error) {
+	return s.store.HExists(key, field)
+}
+
+func (s *Store) HLen(key string) (int, error) {
+	return s.store.HLen(key)
+}
+
+func (s *Store) HStrLen(key, field string) (int, error) {
+	return s.store.HStrLen(key, field)
+}
+
+func (s *Store) HKeys(key string) ([]string, error) {
+	return s.store.HKeys(key)
+}
+
+func (s *Store) HVals(key string) ([]string, error) {
+	return s.store.HVals(key)
+}
+
+func (s *Store) HGetAll(key string) ([]string, error) {
+	return s.store.HGetAll(key)
+}
+
+func (s *Store) HIncrBy(key, field string, delta int64) (int64, error) {
+	return s.store.HIncrBy(key, field, delta)
+}
+
+func (s *Store) HIncrByFloat(key, field string, delta float64) (string, error) {
+	return s.store.HIncrByFloat(key, field, delta)
+}
+
+func (s *Store) HRandField(key string, count int) (fields, values []string, err error) {
+	return s.store.HRandField(key, count)
+}
+
+func (s *Store) HScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	return s.store.HScan(key, cursor, pattern, count)
+}
+
+// ========== 셋 ==========
+
+func (s *Store) SAdd(key string, members ...string) (int, error) {
+	return s.store.SAdd(key, members...)
+}
+
+func (s *Store) SRem(key string, members ...string) (int, error) {
+	return s.store.SRem(key, members...)
+}
+
+func (s *Store) SIsMember(key, member string) (bool, error) {
+	return s.store.SIsMember(key, member)
+}
+
+func (s *Store) SMIsMember(key string, members ...string) ([]bool, error) {
+	return s.store.SMIsMember(key, members...)
+}
+
+func (s *Store) SCard(key string) (int, error) {
+	return s.store.SCard(key)
+}
+
+func (s *Store) SMembers(key string) ([]string, error) {
+	return s.store.SMembers(key)
+}
+
+func (s *Store) SPop(key string, count int) ([]string, error) {
+	return s.store.SPop(key, count)
+}
+
+func (s *Store) SRandMember(key string, count int) ([]string, error) {
+	return s.store.SRandMember(key, count)
+}
+
+func (s *Store) SMove(source, destination, member string) (bool, error) {
+	return s.store.SMove(source, destination, member)
+}
+
+func (s *Store) SInter(keys ...string) ([]string, error) {
+	return s.store.SInter(keys...)
+}
+
+func (s *Store) SUnion(keys ...string) ([]string, error) {
+	return s.store.SUnion(keys...)
+}
+
+func (s *Store) SDiff(keys ...string) ([]string, error) {
+	return s.store.SDiff(keys...)
+}
+
+func (s *Store) SInterStore(destination string, keys ...string) (int, error) {
+	return s.store.SInterStore(destination, keys...)
+}
+
+func (s *Store) SUnionStore(destination string, keys ...string) (int, error) {
+	return s.store.SUnionStore(destination, keys...)
+}
+
+func (s *Store) SDiffStore(destination string, keys ...string) (int, error) {
+	return s.store.SDiffStore(destination, keys...)
+}
+
+func (s *Store) SInterCard(limit int, keys ...string) (int, error) {
+	return s.store.SInterCard(limit, keys...)
+}
+
+func (s *Store) SScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	return s.store.SScan(key, cursor, pattern, count)
+}
+
+// ========== 정렬된 셋 ==========
+
+func (s *Store) ZAdd(key string, options ZAddOptions, members ...ZMember) (int, error) {
+	return s.store.ZAdd(key, options, members...)
+}
+
+func (s *Store) ZAddIncr(key string, options ZAddOptions, member string, increment float64) (score float64, ok bool, err error) {
+	return s.store.ZAddIncr(key, options, member, increment)
+}
+
+func (s *Store) ZIncrBy(key, member string, increment float64) (float64, error) {
+	return s.store.ZIncrBy(key, member, increment)
+}
+
+func (s *Store) ZRem(key string, members ...string) (int, error) {
+	return s.store.ZRem(key, members...)
+}
+
+func (s *Store) ZCard(key string) (int, error) {
+	return s.store.ZCard(key)
+}
+
+func (s *Store) ZScore(key, member string) (float64, bool, error) {
+	return s.store.ZScore(key, member)
+}
+
+func (s *Store) ZRank(key, member string, reverse bool) (int, bool, error) {
+	return s.store.ZRank(key, member, reverse)
+}
+
+func (s *Store) ZRangeByRank(key string, start, stop int, reverse bool) ([]ZMember, error) {
+	return s.store.ZRangeByRank(key, start, stop, reverse)
+}
+
+func (s *Store) ZRangeByScore(key string, r ScoreRange, reverse bool, offset, count int) ([]ZMember, error) {
+	return s.store.ZRangeByScore(key, r, reverse, offset, count)
+}
+
+func (s *Store) ZRangeByLex(key string, r LexRange, reverse bool, offset, count int) ([]ZMember, error) {
+	return s.store.ZRangeByLex(key, r, reverse, offset, count)
+}
+
+func (s *Store) ZCount(key string, r ScoreRange) (int, error) {
+	return s.store.ZCount(key, r)
+}
+
+func (s *Store) ZPopMin(key string, count int) ([]ZMember, error) {
+	return s.store.ZPopMin(key, count)
+}
+
+func (s *Store) ZPopMax(key string, count int) ([]ZMember, error) {
+	return s.store.ZPopMax(key, count)
+}
+
+func (s *Store) BlockingZPop(ctx context.Context, keys []string, max bool) (key string, member ZMember, ok bool, err error) {
+	return s.store.BlockingZPop(ctx, keys, max)
+}
+
+func (s *Store) ZUnionStore(destination string, keys []string, weights []float64, aggregate ZAggregate) (int, error) {
+	return s.store.ZUnionStore(destination, keys, weights, aggregate)
+}
+
+func (s *Store) ZInterStore(destination string, keys []string, weights []float64, aggregate ZAggregate) (int, error) {
+	return s.store.ZInterStore(destination, keys, weights, aggregate)
+}
+
+func (s *Store) ZScan(key string, cursor uint64, pattern string, count int) (uint64, []ZMember, error) {
+	return s.store.ZScan(key, cursor, pattern, count)
+}
+
+// ========== 스트림 ==========
+
+func (s *Store) XAdd(key string, options XAddOptions, id XAddID, fields []string) (StreamID, bool, error) {
+	return s.store.XAdd(key, options, id, fields)
+}
+
+func (s *Store) XLen(key string) (int, error) {
+	return s.store.XLen(key)
+}
+
+func (s *Store) XRange(key string, start, end StreamID, reverse bool, count int) ([]StreamEntry, error) {
+	return s.store.XRange(key, start, end, reverse, count)
+}
+
+func (s *Store) XRead(streams []XReadStream, count int) ([]StreamReadResult, error) {
+	return s.store.XRead(streams, count)
+}
+
+func (s *Store) BlockingXRead(ctx context.Context, streams []XReadStream, count int) ([]StreamReadResult, error) {
+	return s.store.BlockingXRead(ctx, streams, count)
+}
+
+func (s *Store) XGroupCreate(key, group string, id StreamID, last, mkStream bool, entriesRead int64) error {
+	return s.store.XGroupCreate(key, group, id, last, mkStream, entriesRead)
+}
+
+func (s *Store) XGroupDestroy(key, group string) (bool, error) {
+	return s.store.XGroupDestroy(key, group)
+}
+
+func (s *Store) XReadGroup(group, consumer string, streams []XReadStream, count int, noAck bool) ([]StreamReadResult, error) {
+	return s.store.XReadGroup(group, consumer, streams, count, noAck)
+}
+
+func (s *Store) BlockingXReadGroup(ctx context.Context, group, consumer string, streams []XReadStream, count int, noAck bool) ([]StreamReadResult, error) {
+	return s.store.BlockingXReadGroup(ctx, group, consumer, streams, count, noAck)
+}
+
+func (s *Store) XAck(key, group string, ids ...StreamID) (int, error) {
+	return s.store.XAck(key, group, ids...)
+}
+
+func (s *Store) XPendingSummary(key, group string) (PendingSummary, error) {
+	return s.store.XPendingSummary(key, group)
+}
+
+func (s *Store) XPending(key, group string, options PendingOptions) ([]PendingInfo, error) {
+	return s.store.XPending(key, group, options)
+}
+
+func (s *Store) XClaim(key, group, consumer string, minIdle time.Duration, ids []StreamID, options XClaimOptions) ([]StreamEntry, error) {
+	return s.store.XClaim(key, group, consumer, minIdle, ids, options)
+}
+
+func (s *Store) XAutoClaim(key, group, consumer string, minIdle time.Duration, start StreamID, count int, justID bool) (StreamID, []StreamEntry, []StreamID, error) {
+	return s.store.XAutoClaim(key, group, consumer, minIdle, start, count, justID)
+}
+
+func (s *Store) XInfoStream(key string) (StreamInfo, error) {
+	return s.store.XInfoStream(key)
+}
+
+func (s *Store) XInfoGroups(key string) ([]GroupInfo, error) {
+	return s.store.XInfoGroups(key)
+}
+
+func (s *Store) XInfoConsumers(key, group string) ([]ConsumerInfo, error) {
+	return s.store.XInfoConsumers(key, group)
+}
+
+// ========== 메모리와 통계 ==========
+
+func (s *Store) Object(key string) (ObjectInfo, bool) {
+	return s.store.Object(key)
+}
+
+func (s *Store) MemoryUsage(key string, samples int) (int64, bool) {
+	return s.store.MemoryUsage(key, samples)
+}
+
+func (s *Store) MemoryStats() MemoryStats {
+	return s.store.MemoryStats()
+}
+
+func (s *Store) UsedMemory() int64 {
+	return s.store.UsedMemory()
+}
+
+func (s *Store) ExpiryStats() ExpiryStats {
+	return s.store.ExpiryStats()
+}
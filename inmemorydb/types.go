@@ -0,0 +1,93 @@
+package inmemorydb
+
+import "inmemory-db/internal/storage"
+
+// 키의 자료형과 명령어 옵션, 결과 타입
+type (
+	EntryType       = storage.EntryType
+	ExpireCondition = storage.ExpireCondition
+
+	SetOptions   = storage.SetOptions
+	GetExOptions = storage.GetExOptions
+
+	ZMember     = storage.ZMember
+	ZAddOptions = storage.ZAddOptions
+	ZAggregate  = storage.ZAggregate
+	ScoreRange  = storage.ScoreRange
+	LexBound    = storage.LexBound
+	LexRange    = storage.LexRange
+
+	StreamID         = storage.StreamID
+	StreamEntry      = storage.StreamEntry
+	XAddID           = storage.XAddID
+	XAddOptions      = storage.XAddOptions
+	StreamTrim       = storage.StreamTrim
+	XReadStream      = storage.XReadStream
+	StreamReadResult = storage.StreamReadResult
+	PendingSummary   = storage.PendingSummary
+	ConsumerPending  = storage.ConsumerPending
+	PendingOptions   = storage.PendingOptions
+	PendingInfo      = storage.PendingInfo
+	XClaimOptions    = storage.XClaimOptions
+	StreamInfo       = storage.StreamInfo
+	GroupInfo        = storage.GroupInfo
+	ConsumerInfo     = storage.ConsumerInfo
+	NoGroupError     = storage.NoGroupError
+
+	ObjectInfo  = storage.ObjectInfo
+	MemoryStats = storage.MemoryStats
+	ExpiryStats = storage.ExpiryStats
+)
+
+const (
+	TypeString = storage.TypeString
+	TypeList   = storage.TypeList
+	TypeHash   = storage.TypeHash
+	TypeSet    = storage.TypeSet
+	TypeZSet   = storage.TypeZSet
+	TypeStream = storage.TypeStream
+
+	ExpireNX = storage.ExpireNX
+	ExpireXX = storage.ExpireXX
+	ExpireGT = storage.ExpireGT
+	ExpireLT = storage.ExpireLT
+
+	ZAggregateSum = storage.ZAggregateSum
+	ZAggregateMin = storage.ZAggregateMin
+	ZAggregateMax = storage.ZAggregateMax
+)
+
+// Store 메서드가 반환하는 에러
+var (
+	ErrWrongType           = storage.ErrWrongType
+	ErrNoSuchKey           = storage.ErrNoSuchKey
+	ErrIndexOutOfRange     = storage.ErrIndexOutOfRange
+	ErrSameObject          = storage.ErrSameObject
+	ErrNotInteger          = storage.ErrNotInteger
+	ErrNotFloat            = storage.ErrNotFloat
+	ErrStringTooLarge      = storage.ErrStringTooLarge
+	ErrHashValueNotInteger = storage.ErrHashValueNotInteger
+	ErrHashValueNotFloat   = storage.ErrHashValueNotFloat
+	ErrIncrOverflow        = storage.ErrIncrOverflow
+	ErrIncrNaNOrInfinity   = storage.ErrIncrNaNOrInfinity
+	ErrScoreNaN            = storage.ErrScoreNaN
+	ErrInvalidStreamID     = storage.ErrInvalidStreamID
+	ErrStreamIDTooSmall    = storage.ErrStreamIDTooSmall
+	ErrStreamIDZero        = storage.ErrStreamIDZero
+	ErrStreamExhausted     = storage.ErrStreamExhausted
+	ErrBusyGroup           = storage.ErrBusyGroup
+	ErrXGroupNoKey         = storage.ErrXGroupNoKey
+)
+
+// 가능한 가장 큰 스트림 ID (XRANGE의 "+")
+var MaxStreamID = storage.MaxStreamID
+
+// "ms-seq" 또는 "ms" 형식의 스트림 ID를 파싱한다. 시퀀스가 없으면 missingSeq를 쓴다.
+func ParseStreamID(raw string, missingSeq uint64) (StreamID, error) {
+	return storage.ParseStreamID(raw, missingSeq)
+}
+
+// key를 source에서 destination 데이터베이스로 옮긴다 (MOVE).
+func Move(source, destination *Store, key string) (bool, error) {
+	return storage.Move(source.store, destination.store, key)
+}
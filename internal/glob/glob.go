@@ -0,0 +1,127 @@
+package glob
+
+// Redis 스타일의 glob 패턴 매칭.
+// 지원 문법:
+//   - *      : 0개 이상의 임의 문자
+//   - ?      : 정확히 1개의 임의 문자
+//   - [abc]  : 괄호 안의 문자 중 하나, [^abc]는 부정, [a-z]는 범위
+//   - \x     : 특수문자 x를 그대로 매칭
+//
+// path.Match와 달리 '/'를 특별하게 취급하지 않고,
+// 잘못된 패턴이어도 에러 없이 최대한 매칭을 시도한다.
+//
+// 마지막 '*'의 위치만 기억해 두고 실패하면 그 '*'가 한 글자 더 먹도록 되돌아간다.
+// 앞선 '*'로는 되돌아가지 않아도 되므로 '*'가 많아도 O(len(pattern) * len(str))이다.
+func Match(pattern, str string) bool {
+	p, s := 0, 0
+	// 마지막 '*' 다음 패턴 위치와, 그 '*'가 먹기 시작할 문자열 위치. '*'가 없었으면 starP는 -1
+	starP, starS := -1, 0
+
+	for s < len(str) {
+		if p < len(pattern) {
+			switch pattern[p] {
+			case '*':
+				// 연속된 '*'는 하나로 취급한다
+				for p < len(pattern) && pattern[p] == '*' {
+					p++
+				}
+				if p == len(pattern) {
+					return true
+				}
+				starP, starS = p, s
+				continue
+
+			case '?':
+				p++
+				s++
+				continue
+
+			case '[':
+				matched, rest := matchClass(pattern[p+1:], str[s])
+				if matched {
+					p = len(pattern) - len(rest)
+					s++
+					continue
+				}
+
+			case '\\':
+				literal := p
+				if p+1 < len(pattern) {
+					literal = p + 1
+				}
+				if pattern[literal] == str[s] {
+					p = literal + 1
+					s++
+					continue
+				}
+
+			default:
+				if pattern[p] == str[s] {
+					p++
+					s++
+					continue
+				}
+			}
+		}
+
+		// 어긋났으면 마지막 '*'가 한 글자 더 먹게 하고 다시 맞춰 본다
+		if starP < 0 {
+			return false
+		}
+		starS++
+		p, s = starP, starS
+	}
+
+	// 문자열을 다 썼으면 남은 패턴은 '*'뿐이어야 한다
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// 문자 클래스 [...]를 평가한다. pattern은 '[' 다음부터 시작한다.
+// 매칭 여부와 닫는 ']' 이후의 나머지 패턴을 반환한다.
+func matchClass(pattern string, c byte) (bool, string) {
+	not := false
+	if len(pattern) > 0 && pattern[0] == '^' {
+		not = true
+		pattern = pattern[1:]
+	}
+
+	matched := false
+	for len(pattern) > 0 && pattern[0] != ']' {
+		switch {
+		case pattern[0] == '\\' && len(pattern) >= 2:
+			if pattern[1] == c {
+				matched = true
+			}
+			pattern = pattern[2:]
+
+		case len(pattern) >= 3 && pattern[1] == '-' && pattern[2] != ']':
+			start, end := pattern[0], pattern[2]
+			if start > end {
+				start, end = end, start
+			}
+			if c >= start && c <= end {
+				matched = true
+			}
+			pattern = pattern[3:]
+
+		default:
+			if pattern[0] == c {
+				matched = true
+			}
+			pattern = pattern[1:]
+		}
+	}
+
+	// 닫는 ']'가 없으면 패턴 끝까지를 클래스로 취급한다
+	if len(pattern) > 0 {
+		pattern = pattern[1:]
+	}
+
+	if not {
+		matched = !matched
+	}
+	return matched, pattern
+}
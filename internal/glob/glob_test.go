@@ -0,0 +1,100 @@
+package glob
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMatch_Literal(t *testing.T) {
+	if !Match("news", "news") {
+		t.Fatal("같은 문자열이 매칭되지 않습니다")
+	}
+	if Match("news", "newss") {
+		t.Fatal("길이가 다른 문자열이 매칭됩니다")
+	}
+}
+
+func TestMatch_Star(t *testing.T) {
+	cases := []struct {
+		pattern, str string
+		expected     bool
+	}{
+		{"*", "", true},
+		{"*", "anything", true},
+		{"news.*", "news.sport", true},
+		{"news.*", "news.", true},
+		{"news.*", "new.sport", false},
+		{"*.sport", "news.sport", true},
+		{"a*b*c", "aXXbYYc", true},
+		{"a*b*c", "aXXbYY", false},
+		{"a**b", "aXb", true},
+	}
+
+	for _, c := range cases {
+		if actual := Match(c.pattern, c.str); actual != c.expected {
+			t.Errorf("Match(%q, %q) actual: %v, expected: %v", c.pattern, c.str, actual, c.expected)
+		}
+	}
+}
+
+func TestMatch_QuestionMark(t *testing.T) {
+	if !Match("h?llo", "hello") {
+		t.Fatal("h?llo가 hello와 매칭되지 않습니다")
+	}
+	if Match("h?llo", "hllo") {
+		t.Fatal("h?llo가 hllo와 매칭됩니다")
+	}
+}
+
+func TestMatch_CharClass(t *testing.T) {
+	cases := []struct {
+		pattern, str string
+		expected     bool
+	}{
+		{"h[ae]llo", "hello", true},
+		{"h[ae]llo", "hallo", true},
+		{"h[ae]llo", "hillo", false},
+		{"h[^e]llo", "hallo", true},
+		{"h[^e]llo", "hello", false},
+		{"h[a-c]llo", "hbllo", true},
+		{"h[a-c]llo", "hdllo", false},
+		{"key:[0-9]", "key:7", true},
+	}
+
+	for _, c := range cases {
+		if actual := Match(c.pattern, c.str); actual != c.expected {
+			t.Errorf("Match(%q, %q) actual: %v, expected: %v", c.pattern, c.str, actual, c.expected)
+		}
+	}
+}
+
+func TestMatch_Escape(t *testing.T) {
+	if !Match(`news\*`, "news*") {
+		t.Fatal(`news\*가 news*와 매칭되지 않습니다`)
+	}
+	if Match(`news\*`, "newsX") {
+		t.Fatal(`news\*가 newsX와 매칭됩니다`)
+	}
+}
+
+func TestMatch_ManyStarsFinishQuickly(t *testing.T) {
+	// given: 되돌아가기를 '*'마다 다시 하면 끝나지 않는 패턴
+	pattern := strings.Repeat("*a", 12) + "b"
+	str := strings.Repeat("a", 40)
+
+	// when
+	start := time.Now()
+	matched := Match(pattern, str)
+
+	// then
+	if matched {
+		t.Fatal("매칭되지 않아야 합니다")
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Fatalf("매칭이 너무 오래 걸립니다: %v", elapsed)
+	}
+	if !Match(strings.Repeat("*a", 12), str) {
+		t.Fatal("'*a' 12개가 a 40개와 매칭되지 않습니다")
+	}
+}
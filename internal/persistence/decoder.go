@@ -7,25 +7,32 @@ import (
 	"fmt"
 	"hash/crc32"
 	"io"
+	"math"
 	"os"
 	"time"
 )
 
 type Decoder struct {
 	r *bufio.Reader
+	// 마지막으로 읽은 SelectDB의 데이터베이스 번호
+	db int
 }
 
 // 디코딩한 엔트리를 담는 구조체
 type DecodedEntry struct {
 	Type     byte
+	DB       int // 엔트리가 속한 데이터베이스 번호
 	Key      string
 	Value    string
-	Values   []string
+	Values   []string  // List/Set의 요소, Hash의 값, ZSet의 원소
+	Fields   []string  // Hash의 필드. Fields[i]의 값은 Values[i]
+	Scores   []float64 // ZSet의 점수. Values[i]의 점수는 Scores[i]
+	Stream   *StreamData
 	ExpireAt *time.Time
 }
 
 func NewDecoder(r io.Reader) *Decoder {
-	return &Decoder{bufio.NewReader(r)}
+	return &Decoder{r: bufio.NewReader(r)}
 }
 
 func (d *Decoder) ReadHeader() error {
@@ -58,13 +65,21 @@ func (d *Decoder) ReadEntry() (*DecodedEntry, error) {
 	if typeBuf[0] == EOF {
 		return nil, nil
 	}
+	if typeBuf[0] == SelectDB {
+		index, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		d.db = int(index)
+		return d.ReadEntry()
+	}
 
 	key, err := d.readString()
 	if err != nil {
 		return nil, err
 	}
 
-	entry := &DecodedEntry{Type: typeBuf[0], Key: key}
+	entry := &DecodedEntry{Type: typeBuf[0], DB: d.db, Key: key}
 
 	switch typeBuf[0] {
 	case TypeString:
@@ -74,7 +89,7 @@ func (d *Decoder) ReadEntry() (*DecodedEntry, error) {
 		}
 		entry.Value = value
 
-	case TypeList:
+	case TypeList, TypeSet:
 		count, err := d.readUint32()
 		if err != nil {
 			return nil, err
@@ -89,6 +104,57 @@ func (d *Decoder) ReadEntry() (*DecodedEntry, error) {
 		}
 		entry.Values = values
 
+	case TypeHash:
+		count, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]string, 0, count)
+		values := make([]string, 0, count)
+		for i := 0; i < int(count); i++ {
+			field, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			value, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+			values = append(values, value)
+		}
+		entry.Fields = fields
+		entry.Values = values
+
+	case TypeZSet:
+		count, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		members := make([]string, 0, count)
+		scores := make([]float64, 0, count)
+		for i := 0; i < int(count); i++ {
+			member, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			score, err := d.readFloat64()
+			if err != nil {
+				return nil, err
+			}
+			members = append(members, member)
+			scores = append(scores, score)
+		}
+		entry.Values = members
+		entry.Scores = scores
+
+	case TypeStream:
+		stream, err := d.readStream()
+		if err != nil {
+			return nil, err
+		}
+		entry.Stream = stream
+
 	default:
 		return nil, fmt.Errorf("unknown entry type: 0x%02x", typeBuf[0])
 	}
@@ -149,6 +215,14 @@ func (d *Decoder) readInt64() (int64, error) {
 	return int64(binary.BigEndian.Uint64(buf)), nil
 }
 
+func (d *Decoder) readFloat64() (float64, error) {
+	n, err := d.readInt64()
+	if err != nil {
+		return 0, err
+	}
+	return math.Float64frombits(uint64(n)), nil
+}
+
 func (d *Decoder) readString() (string, error) {
 	length, err := d.readUint32()
 	if err != nil {
@@ -162,6 +236,139 @@ func (d *Decoder) readString() (string, error) {
 	return string(buf), nil
 }
 
+func (d *Decoder) readStreamID() (StreamID, error) {
+	ms, err := d.readInt64()
+	if err != nil {
+		return StreamID{}, err
+	}
+	seq, err := d.readInt64()
+	if err != nil {
+		return StreamID{}, err
+	}
+	return StreamID{Ms: uint64(ms), Seq: uint64(seq)}, nil
+}
+
+// Unix 밀리초를 읽는다. 0이면 zero 시각이다.
+func (d *Decoder) readTime() (time.Time, error) {
+	ms, err := d.readInt64()
+	if err != nil || ms == 0 {
+		return time.Time{}, err
+	}
+	return time.UnixMilli(ms), nil
+}
+
+func (d *Decoder) readStream() (*StreamData, error) {
+	stream := &StreamData{}
+
+	count, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	stream.Entries = make([]StreamEntry, 0, count)
+	for i := 0; i < int(count); i++ {
+		id, err := d.readStreamID()
+		if err != nil {
+			return nil, err
+		}
+		fieldCount, err := d.readUint32()
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]string, 0, fieldCount)
+		for j := 0; j < int(fieldCount); j++ {
+			field, err := d.readString()
+			if err != nil {
+				return nil, err
+			}
+			fields = append(fields, field)
+		}
+		stream.Entries = append(stream.Entries, StreamEntry{ID: id, Fields: fields})
+	}
+
+	if stream.LastID, err = d.readStreamID(); err != nil {
+		return nil, err
+	}
+	entriesAdded, err := d.readInt64()
+	if err != nil {
+		return nil, err
+	}
+	stream.EntriesAdded = uint64(entriesAdded)
+	if stream.MaxDeletedID, err = d.readStreamID(); err != nil {
+		return nil, err
+	}
+
+	groupCount, err := d.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	stream.Groups = make([]StreamGroup, 0, groupCount)
+	for i := 0; i < int(groupCount); i++ {
+		group, err := d.readStreamGroup()
+		if err != nil {
+			return nil, err
+		}
+		stream.Groups = append(stream.Groups, group)
+	}
+	return stream, nil
+}
+
+func (d *Decoder) readStreamGroup() (StreamGroup, error) {
+	var group StreamGroup
+	var err error
+
+	if group.Name, err = d.readString(); err != nil {
+		return group, err
+	}
+	if group.LastID, err = d.readStreamID(); err != nil {
+		return group, err
+	}
+	if group.EntriesRead, err = d.readInt64(); err != nil {
+		return group, err
+	}
+
+	count, err := d.readUint32()
+	if err != nil {
+		return group, err
+	}
+	group.Pending = make([]StreamPending, 0, count)
+	for i := 0; i < int(count); i++ {
+		var pending StreamPending
+		if pending.ID, err = d.readStreamID(); err != nil {
+			return group, err
+		}
+		if pending.Consumer, err = d.readString(); err != nil {
+			return group, err
+		}
+		if pending.DeliveryTime, err = d.readTime(); err != nil {
+			return group, err
+		}
+		if pending.DeliveryCount, err = d.readUint32(); err != nil {
+			return group, err
+		}
+		group.Pending = append(group.Pending, pending)
+	}
+
+	count, err = d.readUint32()
+	if err != nil {
+		return group, err
+	}
+	group.Consumers = make([]StreamConsumer, 0, count)
+	for i := 0; i < int(count); i++ {
+		var consumer StreamConsumer
+		if consumer.Name, err = d.readString(); err != nil {
+			return group, err
+		}
+		if consumer.SeenTime, err = d.readTime(); err != nil {
+			return group, err
+		}
+		if consumer.ActiveTime, err = d.readTime(); err != nil {
+			return group, err
+		}
+		group.Consumers = append(group.Consumers, consumer)
+	}
+	return group, nil
+}
+
 func (d *Decoder) readExpiry() (*time.Time, error) {
 	buf, err := d.readBytes(1)
 	if err != nil {
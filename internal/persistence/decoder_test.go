@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"hash/crc32"
+	"math"
 	"os"
 	"path/filepath"
 	"testing"
@@ -129,6 +130,139 @@ func TestReadListEntry(t *testing.T) {
 	}
 }
 
+func TestReadHashEntry(t *testing.T) {
+	// given: Header + Hash("user", {name: gopher, lang: go}) + EOF
+	data := encodeToBytes(t, func(enc *Encoder) {
+		enc.WriteHeader()
+		enc.WriteHashEntry("user", []string{"name", "lang"}, []string{"gopher", "go"}, nil)
+		enc.WriteEOF()
+	})
+	decoder := NewDecoder(bytes.NewReader(data))
+	decoder.ReadHeader()
+
+	// when
+	entry, err := decoder.ReadEntry()
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	if entry.Type != TypeHash {
+		t.Fatalf("Type: 0x%02x, expected: 0x%02x", entry.Type, TypeHash)
+	}
+	if len(entry.Fields) != 2 || len(entry.Values) != 2 {
+		t.Fatalf("Fields: %v, Values: %v", entry.Fields, entry.Values)
+	}
+	if entry.Fields[0] != "name" || entry.Values[0] != "gopher" || entry.Fields[1] != "lang" || entry.Values[1] != "go" {
+		t.Fatalf("Fields: %v, Values: %v", entry.Fields, entry.Values)
+	}
+}
+
+func TestReadSetEntry(t *testing.T) {
+	// given: Header + Set("tags", ["go", "redis"]) + EOF
+	data := encodeToBytes(t, func(enc *Encoder) {
+		enc.WriteHeader()
+		enc.WriteSetEntry("tags", []string{"go", "redis"}, nil)
+		enc.WriteEOF()
+	})
+	decoder := NewDecoder(bytes.NewReader(data))
+	decoder.ReadHeader()
+
+	// when
+	entry, err := decoder.ReadEntry()
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	if entry.Type != TypeSet {
+		t.Fatalf("Type: 0x%02x, expected: 0x%02x", entry.Type, TypeSet)
+	}
+	if len(entry.Values) != 2 || entry.Values[0] != "go" || entry.Values[1] != "redis" {
+		t.Fatalf("Values: %v", entry.Values)
+	}
+}
+
+func TestReadZSetEntry(t *testing.T) {
+	// given: Header + ZSet("rank", {a: 1.5, b: -inf}) + EOF
+	data := encodeToBytes(t, func(enc *Encoder) {
+		enc.WriteHeader()
+		enc.WriteZSetEntry("rank", []string{"a", "b"}, []float64{1.5, math.Inf(-1)}, nil)
+		enc.WriteEOF()
+	})
+	decoder := NewDecoder(bytes.NewReader(data))
+	decoder.ReadHeader()
+
+	// when
+	entry, err := decoder.ReadEntry()
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	if entry.Type != TypeZSet {
+		t.Fatalf("Type: 0x%02x, expected: 0x%02x", entry.Type, TypeZSet)
+	}
+	if len(entry.Values) != 2 || len(entry.Scores) != 2 {
+		t.Fatalf("Values: %v, Scores: %v", entry.Values, entry.Scores)
+	}
+	if entry.Values[0] != "a" || entry.Scores[0] != 1.5 || entry.Values[1] != "b" || !math.IsInf(entry.Scores[1], -1) {
+		t.Fatalf("Values: %v, Scores: %v", entry.Values, entry.Scores)
+	}
+}
+
+func TestReadStreamEntry(t *testing.T) {
+	// given: Header + Stream("events", 엔트리 2개, 그룹 1개) + EOF
+	deliveryTime := time.UnixMilli(1700000000000)
+	stream := &StreamData{
+		Entries: []StreamEntry{
+			{ID: StreamID{Ms: 1, Seq: 0}, Fields: []string{"n", "1"}},
+			{ID: StreamID{Ms: 2, Seq: 0}, Fields: []string{"n", "2"}},
+		},
+		LastID:       StreamID{Ms: 2, Seq: 0},
+		EntriesAdded: 2,
+		Groups: []StreamGroup{{
+			Name:        "g",
+			LastID:      StreamID{Ms: 1, Seq: 0},
+			EntriesRead: 1,
+			Pending:     []StreamPending{{ID: StreamID{Ms: 1, Seq: 0}, Consumer: "alice", DeliveryTime: deliveryTime, DeliveryCount: 3}},
+			Consumers:   []StreamConsumer{{Name: "alice", SeenTime: deliveryTime}},
+		}},
+	}
+	data := encodeToBytes(t, func(enc *Encoder) {
+		enc.WriteHeader()
+		enc.WriteStreamEntry("events", stream, nil)
+		enc.WriteEOF()
+	})
+	decoder := NewDecoder(bytes.NewReader(data))
+	decoder.ReadHeader()
+
+	// when
+	entry, err := decoder.ReadEntry()
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	if entry.Type != TypeStream || entry.Stream == nil {
+		t.Fatalf("Type: 0x%02x, Stream: %v", entry.Type, entry.Stream)
+	}
+	decoded := entry.Stream
+	if len(decoded.Entries) != 2 || decoded.Entries[1].Fields[1] != "2" || decoded.LastID != stream.LastID || decoded.EntriesAdded != 2 {
+		t.Fatalf("Stream: %+v", decoded)
+	}
+	if len(decoded.Groups) != 1 || decoded.Groups[0].Name != "g" || decoded.Groups[0].EntriesRead != 1 {
+		t.Fatalf("Groups: %+v", decoded.Groups)
+	}
+	pending := decoded.Groups[0].Pending
+	if len(pending) != 1 || pending[0].Consumer != "alice" || pending[0].DeliveryCount != 3 || !pending[0].DeliveryTime.Equal(deliveryTime) {
+		t.Fatalf("Pending: %+v", pending)
+	}
+	if consumer := decoded.Groups[0].Consumers[0]; !consumer.SeenTime.Equal(deliveryTime) || !consumer.ActiveTime.IsZero() {
+		t.Fatalf("Consumer: %+v", consumer)
+	}
+}
+
 func TestReadEntryWithTTL(t *testing.T) {
 	// given: TTL이 설정된 String 엔트리
 	expireAt := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
@@ -216,6 +350,34 @@ func TestReadMultipleEntries(t *testing.T) {
 	}
 }
 
+func TestReadEntry_SelectDB(t *testing.T) {
+	// given: 0번 DB에 k0, 3번 DB에 k3
+	data := encodeToBytes(t, func(enc *Encoder) {
+		enc.WriteHeader()
+		enc.WriteStringEntry("k0", "v", nil)
+		enc.WriteSelectDB(3)
+		enc.WriteStringEntry("k3", "v", nil)
+		enc.WriteEOF()
+	})
+	decoder := NewDecoder(bytes.NewReader(data))
+	decoder.ReadHeader()
+
+	// when
+	first, err1 := decoder.ReadEntry()
+	second, err2 := decoder.ReadEntry()
+
+	// then
+	if err1 != nil || err2 != nil {
+		t.Fatalf("에러 발생: %v, %v", err1, err2)
+	}
+	if first.Key != "k0" || first.DB != 0 {
+		t.Fatalf("first: key=%s, db=%d", first.Key, first.DB)
+	}
+	if second.Key != "k3" || second.DB != 3 {
+		t.Fatalf("second: key=%s, db=%d", second.Key, second.DB)
+	}
+}
+
 func TestReadEntry_UnknownType(t *testing.T) {
 	// given: 알 수 없는 타입 바이트 (0xAA) + 더미 키
 	buf := []byte{0xAA, 0x00, 0x00, 0x00, 0x01, 'x'}
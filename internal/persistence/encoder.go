@@ -6,6 +6,7 @@ import (
 	"hash"
 	"hash/crc32"
 	"io"
+	"math"
 	"time"
 )
 
@@ -64,6 +65,130 @@ func (e *Encoder) WriteListEntry(key string, values []string, expireAt *time.Tim
 	return e.writeExpiry(expireAt)
 }
 
+// Hash 타입 엔트리를 쓴다. fields[i]의 값은 values[i]다.
+// [Type 0x02] [Key] [FieldCount] [Field1] [Value1] [Field2] [Value2] ... [TTL]
+func (e *Encoder) WriteHashEntry(key string, fields, values []string, expireAt *time.Time) error {
+	if err := e.writeBytes([]byte{TypeHash}); err != nil {
+		return err
+	}
+	if err := e.writeString(key); err != nil {
+		return err
+	}
+	if err := e.writeUint32(uint32(len(fields))); err != nil {
+		return err
+	}
+	for i, field := range fields {
+		if err := e.writeString(field); err != nil {
+			return err
+		}
+		if err := e.writeString(values[i]); err != nil {
+			return err
+		}
+	}
+	return e.writeExpiry(expireAt)
+}
+
+// Set 타입 엔트리를 쓴다.
+// [Type 0x03] [Key] [MemberCount] [Member1] [Member2] ... [TTL]
+func (e *Encoder) WriteSetEntry(key string, members []string, expireAt *time.Time) error {
+	if err := e.writeBytes([]byte{TypeSet}); err != nil {
+		return err
+	}
+	if err := e.writeString(key); err != nil {
+		return err
+	}
+	if err := e.writeUint32(uint32(len(members))); err != nil {
+		return err
+	}
+	for _, member := range members {
+		if err := e.writeString(member); err != nil {
+			return err
+		}
+	}
+	return e.writeExpiry(expireAt)
+}
+
+// ZSet 타입 엔트리를 쓴다. members[i]의 점수는 scores[i]다.
+// [Type 0x04] [Key] [MemberCount] [Member1] [Score1] [Member2] [Score2] ... [TTL]
+func (e *Encoder) WriteZSetEntry(key string, members []string, scores []float64, expireAt *time.Time) error {
+	if err := e.writeBytes([]byte{TypeZSet}); err != nil {
+		return err
+	}
+	if err := e.writeString(key); err != nil {
+		return err
+	}
+	if err := e.writeUint32(uint32(len(members))); err != nil {
+		return err
+	}
+	for i, member := range members {
+		if err := e.writeString(member); err != nil {
+			return err
+		}
+		if err := e.writeFloat64(scores[i]); err != nil {
+			return err
+		}
+	}
+	return e.writeExpiry(expireAt)
+}
+
+// Stream 타입 엔트리를 쓴다.
+// [Type 0x05] [Key] [EntryCount] [ID] [FieldCount] [Field1] [Value1] ... [LastID] [EntriesAdded] [MaxDeletedID]
+// [GroupCount] [Name] [LastID] [EntriesRead] [PendingCount] [ID] [Consumer] [DeliveryTime] [DeliveryCount] ...
+// [ConsumerCount] [Name] [SeenTime] [ActiveTime] ... [TTL]
+func (e *Encoder) WriteStreamEntry(key string, stream *StreamData, expireAt *time.Time) error {
+	if err := e.writeBytes([]byte{TypeStream}); err != nil {
+		return err
+	}
+	if err := e.writeString(key); err != nil {
+		return err
+	}
+
+	if err := e.writeUint32(uint32(len(stream.Entries))); err != nil {
+		return err
+	}
+	for _, entry := range stream.Entries {
+		if err := e.writeStreamID(entry.ID); err != nil {
+			return err
+		}
+		if err := e.writeUint32(uint32(len(entry.Fields))); err != nil {
+			return err
+		}
+		for _, field := range entry.Fields {
+			if err := e.writeString(field); err != nil {
+				return err
+			}
+		}
+	}
+	if err := e.writeStreamID(stream.LastID); err != nil {
+		return err
+	}
+	if err := e.writeInt64(int64(stream.EntriesAdded)); err != nil {
+		return err
+	}
+	if err := e.writeStreamID(stream.MaxDeletedID); err != nil {
+		return err
+	}
+
+	if err := e.writeUint32(uint32(len(stream.Groups))); err != nil {
+		return err
+	}
+	for _, group := range stream.Groups {
+		if err := e.writeStreamGroup(group); err != nil {
+			return err
+		}
+	}
+	return e.writeExpiry(expireAt)
+}
+
+// 이후 엔트리가 속할 데이터베이스 번호를 쓴다.
+// [SelectDB 0xFE] [DB index]
+func (e *Encoder) WriteSelectDB(index int) error {
+	if err := e.writeBytes([]byte{SelectDB}); err != nil {
+		return err
+	}
+	return e.writeUint32(uint32(index))
+}
+
 // EOF 마커를 쓴다. 파일의 끝을 명시적으로 표시
 func (e *Encoder) WriteEOF() error {
 	return e.writeBytes([]byte{EOF})
@@ -109,6 +234,73 @@ func (e *Encoder) writeInt64(n int64) error {
 	return e.writeBytes(buf)
 }
 
+// float64 값을 IEEE 754 비트 그대로 Big Endian 8바이트로 쓴다.
+// ZSet 점수를 기록할 때 사용 (inf, -inf도 그대로 보존된다)
+func (e *Encoder) writeFloat64(f float64) error {
+	return e.writeInt64(int64(math.Float64bits(f)))
+}
+
+func (e *Encoder) writeStreamID(id StreamID) error {
+	if err := e.writeInt64(int64(id.Ms)); err != nil {
+		return err
+	}
+	return e.writeInt64(int64(id.Seq))
+}
+
+// 시각을 Unix 밀리초로 쓴다. zero면 0을 쓴다.
+func (e *Encoder) writeTime(t time.Time) error {
+	if t.IsZero() {
+		return e.writeInt64(0)
+	}
+	return e.writeInt64(t.UnixMilli())
+}
+
+func (e *Encoder) writeStreamGroup(group StreamGroup) error {
+	if err := e.writeString(group.Name); err != nil {
+		return err
+	}
+	if err := e.writeStreamID(group.LastID); err != nil {
+		return err
+	}
+	if err := e.writeInt64(group.EntriesRead); err != nil {
+		return err
+	}
+
+	if err := e.writeUint32(uint32(len(group.Pending))); err != nil {
+		return err
+	}
+	for _, pending := range group.Pending {
+		if err := e.writeStreamID(pending.ID); err != nil {
+			return err
+		}
+		if err := e.writeString(pending.Consumer); err != nil {
+			return err
+		}
+		if err := e.writeTime(pending.DeliveryTime); err != nil {
+			return err
+		}
+		if err := e.writeUint32(pending.DeliveryCount); err != nil {
+			return err
+		}
+	}
+
+	if err := e.writeUint32(uint32(len(group.Consumers))); err != nil {
+		return err
+	}
+	for _, consumer := range group.Consumers {
+		if err := e.writeString(consumer.Name); err != nil {
+			return err
+		}
+		if err := e.writeTime(consumer.SeenTime); err != nil {
+			return err
+		}
+		if err := e.writeTime(consumer.ActiveTime); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Length-Prefixed 문자열을 쓴다.
 // [4바이트 길이] + [문자열 바이트] 형태
 // 읽는 쪽에서 "앞 4바이트를 읽으면 뒤에 몇 바이트가 오는지 알 수 있다"는 것이 핵심
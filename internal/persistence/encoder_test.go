@@ -222,6 +222,33 @@ func TestWriteEOF(t *testing.T) {
 	}
 }
 
+func TestWriteHashEntry(t *testing.T) {
+	// given
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+
+	// when: key="h", {f: v}, TTL 없음
+	err := enc.WriteHashEntry("h", []string{"f"}, []string{"v"}, nil)
+	enc.Flush()
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	data := buf.Bytes()
+
+	// 총 길이: Type(1) + KeyLen(4) + Key(1) + Count(4) + FieldLen(4) + Field(1) + ValLen(4) + Val(1) + NoExpiry(1) = 21
+	if len(data) != 21 {
+		t.Fatalf("총 길이: %d, expected: 21", len(data))
+	}
+	if data[0] != TypeHash {
+		t.Fatalf("Type: 0x%02x, expected: 0x02", data[0])
+	}
+	if count := binary.BigEndian.Uint32(data[6:10]); count != 1 {
+		t.Fatalf("Field count: %d, expected: 1", count)
+	}
+}
+
 func TestEncodeFullFile(t *testing.T) {
 	// given
 	var buf bytes.Buffer
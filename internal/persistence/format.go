@@ -1,5 +1,7 @@
 package persistence
 
+import "time"
+
 var MagicBytes = [6]byte{'M', 'I', 'N', 'I', 'D', 'B'}
 
 const (
@@ -7,11 +9,61 @@ const (
 
 	TypeString byte = 0x00
 	TypeList   byte = 0x01
+	TypeHash   byte = 0x02
+	TypeSet    byte = 0x03
+	TypeZSet   byte = 0x04
+	TypeStream byte = 0x05
 
 	NoExpiry  byte = 0x00
 	HasExpiry byte = 0x01
 
-	EOF byte = 0xFF
+	// 이후 엔트리가 속한 데이터베이스 번호. [0xFE] [DB index uint32]
+	// 없으면 0번 데이터베이스로 본다
+	SelectDB byte = 0xFE
+	EOF      byte = 0xFF
 
 	ChecksumSize = 4
 )
+
+// 스트림 엔트리의 ID
+type StreamID struct {
+	Ms, Seq uint64
+}
+
+// 스트림 타입 엔트리의 내용. 소비자 그룹과 PEL까지 포함한다.
+type StreamData struct {
+	Entries      []StreamEntry
+	LastID       StreamID
+	EntriesAdded uint64
+	MaxDeletedID StreamID
+	Groups       []StreamGroup
+}
+
+// Fields는 [field1, value1, field2, value2, ...] 형태다.
+type StreamEntry struct {
+	ID     StreamID
+	Fields []string
+}
+
+type StreamGroup struct {
+	Name        string
+	LastID      StreamID
+	EntriesRead int64
+	Pending     []StreamPending
+	Consumers   []StreamConsumer
+}
+
+// PEL의 엔트리. Consumer는 Consumers에 있는 소비자의 이름이다.
+type StreamPending struct {
+	ID            StreamID
+	Consumer      string
+	DeliveryTime  time.Time
+	DeliveryCount uint32
+}
+
+// ActiveTime이 zero면 엔트리를 받은 적이 없는 소비자다.
+type StreamConsumer struct {
+	Name       string
+	SeenTime   time.Time
+	ActiveTime time.Time
+}
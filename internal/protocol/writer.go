@@ -23,6 +23,12 @@ func (w *Writer) WriteError(s string) error {
 	return nil
 }
 
+// 에러 코드를 직접 정하는 RESP Error: "-OOM command not allowed ...\r\n"
+func (w *Writer) WriteErrorCode(code, s string) error {
+	w.writer.Write([]byte("-" + code + " " + s + "\r\n"))
+	return nil
+}
+
 func (w *Writer) WriteBulkString(s string) error {
 	length := strconv.Itoa(len(s))
 	w.writer.Write([]byte("$" + length + "\r\n" + s + "\r\n"))
@@ -53,3 +59,17 @@ func (w *Writer) WriteArray(values []string) error {
 	}
 	return nil
 }
+
+// RESP Array 헤더만 쓴다: "*3\r\n"
+// 정수/중첩 배열 등 서로 다른 타입의 요소를 담을 때, 헤더를 쓴 뒤 요소를 하나씩 쓴다.
+func (w *Writer) WriteArrayLen(n int) error {
+	w.writer.Write([]byte("*" + strconv.Itoa(n) + "\r\n"))
+	return nil
+}
+
+// RESP Null Array: "*-1\r\n"
+// BLPOP 타임아웃처럼 배열 응답 자리에 값이 없을 때 사용한다.
+func (w *Writer) WriteNullArray() error {
+	w.writer.Write([]byte("*-1\r\n"))
+	return nil
+}
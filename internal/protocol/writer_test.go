@@ -33,6 +33,20 @@ func TestWriteError(t *testing.T) {
 	}
 }
 
+func TestWriteErrorCode(t *testing.T) {
+	// given
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+
+	// when
+	writer.WriteErrorCode("OOM", "command not allowed")
+
+	// then
+	if buf.String() != "-OOM command not allowed\r\n" {
+		t.Fatalf("문자열이 다릅니다: %s", buf.String())
+	}
+}
+
 func TestWriteBulkString(t *testing.T) {
 	// given
 	var buf bytes.Buffer
@@ -102,3 +116,34 @@ func TestWriteArray_Empty(t *testing.T) {
 		t.Fatalf("문자열이 다릅니다: %s", buf.String())
 	}
 }
+
+func TestWriteArrayLen(t *testing.T) {
+	// given
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+
+	// when: 서로 다른 타입의 요소를 가진 배열
+	writer.WriteArrayLen(2)
+	writer.WriteBulkString("subscribe")
+	writer.WriteInteger(1)
+
+	// then
+	expected := "*2\r\n$9\r\nsubscribe\r\n:1\r\n"
+	if buf.String() != expected {
+		t.Fatalf("문자열이 다릅니다.\nactual:   %q\nexpected: %q", buf.String(), expected)
+	}
+}
+
+func TestWriteNullArray(t *testing.T) {
+	// given
+	var buf bytes.Buffer
+	writer := NewWriter(&buf)
+
+	// when
+	writer.WriteNullArray()
+
+	// then
+	if buf.String() != "*-1\r\n" {
+		t.Fatalf("문자열이 다릅니다: %s", buf.String())
+	}
+}
@@ -0,0 +1,131 @@
+package pubsub
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// 키스페이스 알림 플래그 (notify-keyspace-events 설정값의 각 문자)
+const (
+	NotifyKeyspace = 1 << iota // K: __keyspace@<db>__:<key> 채널로 발행
+	NotifyKeyevent             // E: __keyevent@<db>__:<event> 채널로 발행
+	NotifyGeneric              // g: DEL, EXPIRE, PERSIST 등 타입과 무관한 명령어
+	NotifyString               // $: String 명령어
+	NotifyList                 // l: List 명령어
+	NotifySet                  // s: Set 명령어
+	NotifyHash                 // h: Hash 명령어
+	NotifyZSet                 // z: Sorted Set 명령어
+	NotifyExpired              // x: 키 만료
+	NotifyEvicted              // e: maxmemory로 인한 키 축출
+	NotifyStream               // t: Stream 명령어
+	NotifyKeyMiss              // m: 존재하지 않는 키 조회
+	NotifyNew                  // n: 새 키 생성
+
+	// A: g$lshzxet 의 별칭. m과 n은 포함하지 않는다.
+	NotifyAll = NotifyGeneric | NotifyString | NotifyList | NotifySet | NotifyHash |
+		NotifyZSet | NotifyExpired | NotifyEvicted | NotifyStream
+)
+
+var ErrInvalidEventClass = errors.New("Invalid event class character. Use 'Ag$lshzxeKEtmn'.")
+
+// 클래스 문자와 플래그의 대응. 문자열로 되돌릴 때도 이 순서를 따른다.
+var classChars = []struct {
+	char byte
+	flag int
+}{
+	{'g', NotifyGeneric},
+	{'$', NotifyString},
+	{'l', NotifyList},
+	{'s', NotifySet},
+	{'h', NotifyHash},
+	{'z', NotifyZSet},
+	{'x', NotifyExpired},
+	{'e', NotifyEvicted},
+	{'t', NotifyStream},
+	{'K', NotifyKeyspace},
+	{'E', NotifyKeyevent},
+	{'m', NotifyKeyMiss},
+	{'n', NotifyNew},
+}
+
+// notify-keyspace-events 문자열("KEA", "Elx" 등)을 플래그로 변환한다.
+func ParseKeyspaceEvents(s string) (int, error) {
+	flags := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] == 'A' {
+			flags |= NotifyAll
+			continue
+		}
+
+		found := false
+		for _, c := range classChars {
+			if c.char == s[i] {
+				flags |= c.flag
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, ErrInvalidEventClass
+		}
+	}
+	return flags, nil
+}
+
+// 플래그를 notify-keyspace-events 문자열로 변환한다.
+// 모든 클래스가 켜져 있으면 "A"로 줄여서 쓴다 (예: "AKE").
+func FormatKeyspaceEvents(flags int) string {
+	var sb strings.Builder
+
+	all := flags&NotifyAll == NotifyAll
+	if all {
+		sb.WriteByte('A')
+	}
+	for _, c := range classChars {
+		if all && c.flag&NotifyAll != 0 {
+			continue
+		}
+		if flags&c.flag != 0 {
+			sb.WriteByte(c.char)
+		}
+	}
+	return sb.String()
+}
+
+// KeyspaceNotifier는 데이터 변경 이벤트를 키스페이스/키이벤트 채널로 발행한다.
+type KeyspaceNotifier struct {
+	hub   *Hub
+	flags atomic.Int64
+}
+
+func NewKeyspaceNotifier(hub *Hub) *KeyspaceNotifier {
+	return &KeyspaceNotifier{hub: hub}
+}
+
+func (n *KeyspaceNotifier) Flags() int {
+	return int(n.flags.Load())
+}
+
+func (n *KeyspaceNotifier) SetFlags(flags int) {
+	n.flags.Store(int64(flags))
+}
+
+// 이벤트를 발행한다. class가 설정에서 꺼져 있으면 아무것도 하지 않는다.
+//   - K: __keyspace@<db>__:<key>  채널에 이벤트 이름을 발행
+//   - E: __keyevent@<db>__:<event> 채널에 키 이름을 발행
+func (n *KeyspaceNotifier) Notify(db int, class int, event, key string) {
+	flags := n.Flags()
+	if flags&class == 0 {
+		return
+	}
+
+	prefix := "@" + strconv.Itoa(db) + "__:"
+	if flags&NotifyKeyspace != 0 {
+		n.hub.Publish("__keyspace"+prefix+key, event)
+	}
+	if flags&NotifyKeyevent != 0 {
+		n.hub.Publish("__keyevent"+prefix+event, key)
+	}
+}
@@ -0,0 +1,100 @@
+package pubsub
+
+import "testing"
+
+func TestParseKeyspaceEvents(t *testing.T) {
+	// given & when
+	flags, err := ParseKeyspaceEvents("Kx")
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	if flags != NotifyKeyspace|NotifyExpired {
+		t.Fatalf("플래그: %b", flags)
+	}
+}
+
+func TestParseKeyspaceEvents_All(t *testing.T) {
+	// given & when
+	flags, _ := ParseKeyspaceEvents("KEA")
+
+	// then: A는 m, n을 포함하지 않는다
+	if flags&NotifyAll != NotifyAll {
+		t.Fatal("A 플래그가 모든 클래스를 포함하지 않습니다")
+	}
+	if flags&(NotifyKeyMiss|NotifyNew) != 0 {
+		t.Fatal("A 플래그가 m 또는 n을 포함합니다")
+	}
+}
+
+func TestParseKeyspaceEvents_Invalid(t *testing.T) {
+	if _, err := ParseKeyspaceEvents("KQ"); err != ErrInvalidEventClass {
+		t.Fatalf("에러: %v, expected: ErrInvalidEventClass", err)
+	}
+}
+
+func TestFormatKeyspaceEvents(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{"KEA", "AKE"},
+		{"Elg", "glE"},
+		{"AKEmn", "AKEmn"},
+	}
+
+	for _, c := range cases {
+		flags, _ := ParseKeyspaceEvents(c.input)
+		if actual := FormatKeyspaceEvents(flags); actual != c.expected {
+			t.Errorf("Format(Parse(%q)) actual: %q, expected: %q", c.input, actual, c.expected)
+		}
+	}
+}
+
+func TestKeyspaceNotifier_Notify(t *testing.T) {
+	// given: keyspace/keyevent 채널을 모두 구독
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.Subscribe(sub, "__keyspace@0__:mykey")
+	hub.Subscribe(sub, "__keyevent@0__:set")
+
+	notifier := NewKeyspaceNotifier(hub)
+	flags, _ := ParseKeyspaceEvents("KE$")
+	notifier.SetFlags(flags)
+
+	// when
+	notifier.Notify(0, NotifyString, "set", "mykey")
+
+	// then: keyspace 채널엔 이벤트 이름, keyevent 채널엔 키 이름
+	first := <-sub.Messages()
+	if first.Channel != "__keyspace@0__:mykey" || first.Payload != "set" {
+		t.Fatalf("keyspace 메시지: %+v", first)
+	}
+	second := <-sub.Messages()
+	if second.Channel != "__keyevent@0__:set" || second.Payload != "mykey" {
+		t.Fatalf("keyevent 메시지: %+v", second)
+	}
+}
+
+func TestKeyspaceNotifier_DisabledClass(t *testing.T) {
+	// given: List 이벤트만 켜져 있다
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.PSubscribe(sub, "__key*")
+
+	notifier := NewKeyspaceNotifier(hub)
+	flags, _ := ParseKeyspaceEvents("KEl")
+	notifier.SetFlags(flags)
+
+	// when: String 이벤트 발생
+	notifier.Notify(0, NotifyString, "set", "mykey")
+
+	// then: 발행되지 않는다
+	select {
+	case msg := <-sub.Messages():
+		t.Fatalf("꺼진 클래스의 이벤트가 발행되었습니다: %+v", msg)
+	default:
+	}
+}
@@ -0,0 +1,258 @@
+package pubsub
+
+import (
+	"inmemory-db/internal/glob"
+	"sort"
+	"sync"
+)
+
+// 구독자 한 명의 수신 버퍼 크기.
+// 버퍼가 가득 찰 만큼 느린 구독자는 발행자를 막지 않고 연결을 끊는다.
+const DefaultBufferSize = 1024
+
+// 구독자에게 전달되는 메시지.
+// Pattern이 비어있지 않으면 패턴 구독으로 전달된 메시지(pmessage)다.
+type Message struct {
+	Pattern string
+	Channel string
+	Payload string
+}
+
+// Subscriber는 연결 하나의 구독 상태를 나타낸다.
+// channels/patterns는 Hub의 락으로 보호된다.
+type Subscriber struct {
+	messages chan Message
+	overflow chan struct{}
+	once     sync.Once
+
+	channels map[string]struct{}
+	patterns map[string]struct{}
+}
+
+func NewSubscriber(bufferSize int) *Subscriber {
+	return &Subscriber{
+		messages: make(chan Message, bufferSize),
+		overflow: make(chan struct{}),
+		channels: make(map[string]struct{}),
+		patterns: make(map[string]struct{}),
+	}
+}
+
+// 수신 메시지 채널. 연결 쪽 전달 고루틴이 읽는다.
+func (sub *Subscriber) Messages() <-chan Message {
+	return sub.messages
+}
+
+// 수신 버퍼가 넘쳐 메시지를 잃었을 때 닫히는 채널.
+// 연결 쪽에서는 이 신호를 받으면 클라이언트 연결을 끊어야 한다.
+func (sub *Subscriber) Overflow() <-chan struct{} {
+	return sub.overflow
+}
+
+// 발행자를 블로킹하지 않도록 버퍼에 넣을 수 없으면 즉시 포기한다.
+func (sub *Subscriber) deliver(msg Message) {
+	select {
+	case sub.messages <- msg:
+	default:
+		sub.once.Do(func() { close(sub.overflow) })
+	}
+}
+
+// Hub는 채널/패턴 구독 정보를 관리하고 메시지를 팬아웃한다.
+type Hub struct {
+	mu       sync.RWMutex
+	channels map[string]map[*Subscriber]struct{}
+	patterns map[string]map[*Subscriber]struct{}
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		channels: make(map[string]map[*Subscriber]struct{}),
+		patterns: make(map[string]map[*Subscriber]struct{}),
+	}
+}
+
+// 채널을 구독한다. 구독자의 전체 구독 수(채널 + 패턴)를 반환한다.
+func (h *Hub) Subscribe(sub *Subscriber, channel string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exist := sub.channels[channel]; !exist {
+		sub.channels[channel] = struct{}{}
+		subs, exist := h.channels[channel]
+		if !exist {
+			subs = make(map[*Subscriber]struct{})
+			h.channels[channel] = subs
+		}
+		subs[sub] = struct{}{}
+	}
+	return len(sub.channels) + len(sub.patterns)
+}
+
+// 채널 구독을 해제한다. 구독자의 남은 구독 수를 반환한다.
+func (h *Hub) Unsubscribe(sub *Subscriber, channel string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.unsubscribe(sub, channel)
+	return len(sub.channels) + len(sub.patterns)
+}
+
+// 패턴을 구독한다. 구독자의 전체 구독 수를 반환한다.
+func (h *Hub) PSubscribe(sub *Subscriber, pattern string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, exist := sub.patterns[pattern]; !exist {
+		sub.patterns[pattern] = struct{}{}
+		subs, exist := h.patterns[pattern]
+		if !exist {
+			subs = make(map[*Subscriber]struct{})
+			h.patterns[pattern] = subs
+		}
+		subs[sub] = struct{}{}
+	}
+	return len(sub.channels) + len(sub.patterns)
+}
+
+// 패턴 구독을 해제한다. 구독자의 남은 구독 수를 반환한다.
+func (h *Hub) PUnsubscribe(sub *Subscriber, pattern string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.punsubscribe(sub, pattern)
+	return len(sub.channels) + len(sub.patterns)
+}
+
+// 구독자의 전체 구독 수(채널 + 패턴)를 반환한다.
+// 0보다 크면 연결이 구독 모드에 있다는 뜻이다.
+func (h *Hub) Count(sub *Subscriber) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(sub.channels) + len(sub.patterns)
+}
+
+// 구독자가 구독 중인 채널 목록을 정렬해서 반환한다.
+func (h *Hub) SubscribedChannels(sub *Subscriber) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return sortedKeys(sub.channels)
+}
+
+// 구독자가 구독 중인 패턴 목록을 정렬해서 반환한다.
+func (h *Hub) SubscribedPatterns(sub *Subscriber) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return sortedKeys(sub.patterns)
+}
+
+// 구독자의 모든 채널/패턴 구독을 해제한다. 연결 종료 시 호출한다.
+func (h *Hub) UnsubscribeAll(sub *Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for channel := range sub.channels {
+		h.unsubscribe(sub, channel)
+	}
+	for pattern := range sub.patterns {
+		h.punsubscribe(sub, pattern)
+	}
+}
+
+// 채널에 메시지를 발행한다. 메시지를 받은 구독자 수를 반환한다.
+// 구독자 버퍼에 비블로킹으로 넣기 때문에 느린 구독자가 발행자를 막지 않는다.
+func (h *Hub) Publish(channel, payload string) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	receivers := 0
+	for sub := range h.channels[channel] {
+		sub.deliver(Message{Channel: channel, Payload: payload})
+		receivers++
+	}
+
+	for pattern, subs := range h.patterns {
+		if !glob.Match(pattern, channel) {
+			continue
+		}
+		for sub := range subs {
+			sub.deliver(Message{Pattern: pattern, Channel: channel, Payload: payload})
+			receivers++
+		}
+	}
+	return receivers
+}
+
+// 구독자가 한 명 이상 있는 채널 목록을 반환한다.
+// pattern이 비어있지 않으면 glob 패턴과 매칭되는 채널만 반환한다.
+func (h *Hub) Channels(pattern string) []string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]string, 0, len(h.channels))
+	for channel := range h.channels {
+		if pattern == "" || glob.Match(pattern, channel) {
+			result = append(result, channel)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+// 채널별 구독자 수를 반환한다 (패턴 구독자는 제외).
+func (h *Hub) NumSub(channels ...string) []int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make([]int, len(channels))
+	for i, channel := range channels {
+		result[i] = len(h.channels[channel])
+	}
+	return result
+}
+
+// 구독 중인 고유 패턴의 개수를 반환한다.
+func (h *Hub) NumPat() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.patterns)
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// h.mu.Lock()을 잡은 상태에서 호출해야 한다.
+func (h *Hub) unsubscribe(sub *Subscriber, channel string) {
+	if _, exist := sub.channels[channel]; !exist {
+		return
+	}
+	delete(sub.channels, channel)
+
+	subs := h.channels[channel]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.channels, channel)
+	}
+}
+
+// h.mu.Lock()을 잡은 상태에서 호출해야 한다.
+func (h *Hub) punsubscribe(sub *Subscriber, pattern string) {
+	if _, exist := sub.patterns[pattern]; !exist {
+		return
+	}
+	delete(sub.patterns, pattern)
+
+	subs := h.patterns[pattern]
+	delete(subs, sub)
+	if len(subs) == 0 {
+		delete(h.patterns, pattern)
+	}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	result := make([]string, 0, len(set))
+	for k := range set {
+		result = append(result, k)
+	}
+	sort.Strings(result)
+	return result
+}
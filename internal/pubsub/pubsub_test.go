@@ -0,0 +1,169 @@
+package pubsub
+
+import (
+	"testing"
+)
+
+func TestSubscribeAndPublish(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.Subscribe(sub, "news")
+
+	// when
+	receivers := hub.Publish("news", "hello")
+
+	// then
+	if receivers != 1 {
+		t.Fatalf("수신자 수: %d, expected: 1", receivers)
+	}
+	msg := <-sub.Messages()
+	if msg.Channel != "news" || msg.Payload != "hello" || msg.Pattern != "" {
+		t.Fatalf("메시지가 다릅니다: %+v", msg)
+	}
+}
+
+func TestPublish_NoSubscribers(t *testing.T) {
+	// given
+	hub := NewHub()
+
+	// when
+	receivers := hub.Publish("nobody", "hello")
+
+	// then
+	if receivers != 0 {
+		t.Fatalf("수신자 수: %d, expected: 0", receivers)
+	}
+}
+
+func TestPSubscribe_GlobPattern(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.PSubscribe(sub, "news.*")
+
+	// when
+	matched := hub.Publish("news.sport", "goal")
+	unmatched := hub.Publish("weather", "rain")
+
+	// then
+	if matched != 1 || unmatched != 0 {
+		t.Fatalf("수신자 수 matched: %d, unmatched: %d", matched, unmatched)
+	}
+	msg := <-sub.Messages()
+	if msg.Pattern != "news.*" || msg.Channel != "news.sport" || msg.Payload != "goal" {
+		t.Fatalf("메시지가 다릅니다: %+v", msg)
+	}
+}
+
+func TestSubscribe_CountIncludesPatterns(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+
+	// when
+	hub.Subscribe(sub, "a")
+	hub.Subscribe(sub, "a") // 중복 구독은 무시
+	count := hub.PSubscribe(sub, "b*")
+
+	// then
+	if count != 2 {
+		t.Fatalf("구독 수: %d, expected: 2", count)
+	}
+}
+
+func TestUnsubscribe(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.Subscribe(sub, "a")
+	hub.Subscribe(sub, "b")
+
+	// when
+	remaining := hub.Unsubscribe(sub, "a")
+
+	// then
+	if remaining != 1 {
+		t.Fatalf("남은 구독 수: %d, expected: 1", remaining)
+	}
+	if hub.Publish("a", "x") != 0 {
+		t.Fatal("구독 해제된 채널로 메시지가 전달됩니다")
+	}
+	if channels := hub.Channels(""); len(channels) != 1 || channels[0] != "b" {
+		t.Fatalf("활성 채널: %v, expected: [b]", channels)
+	}
+}
+
+func TestUnsubscribeAll(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.Subscribe(sub, "a")
+	hub.PSubscribe(sub, "b*")
+
+	// when
+	hub.UnsubscribeAll(sub)
+
+	// then
+	if hub.Count(sub) != 0 || hub.NumPat() != 0 || len(hub.Channels("")) != 0 {
+		t.Fatal("구독 정보가 남아있습니다")
+	}
+}
+
+func TestChannelsWithPattern(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub := NewSubscriber(DefaultBufferSize)
+	hub.Subscribe(sub, "news.sport")
+	hub.Subscribe(sub, "news.tech")
+	hub.Subscribe(sub, "weather")
+
+	// when
+	channels := hub.Channels("news.*")
+
+	// then
+	if len(channels) != 2 || channels[0] != "news.sport" || channels[1] != "news.tech" {
+		t.Fatalf("채널 목록: %v", channels)
+	}
+}
+
+func TestNumSubAndNumPat(t *testing.T) {
+	// given
+	hub := NewHub()
+	sub1 := NewSubscriber(DefaultBufferSize)
+	sub2 := NewSubscriber(DefaultBufferSize)
+	hub.Subscribe(sub1, "a")
+	hub.Subscribe(sub2, "a")
+	hub.PSubscribe(sub1, "x*")
+	hub.PSubscribe(sub2, "x*")
+
+	// when
+	numsub := hub.NumSub("a", "b")
+
+	// then
+	if numsub[0] != 2 || numsub[1] != 0 {
+		t.Fatalf("NUMSUB: %v, expected: [2 0]", numsub)
+	}
+	if hub.NumPat() != 1 {
+		t.Fatalf("NUMPAT: %d, expected: 1", hub.NumPat())
+	}
+}
+
+func TestSlowSubscriberDoesNotBlockPublisher(t *testing.T) {
+	// given: 버퍼 크기 2인 구독자가 메시지를 읽지 않는다
+	hub := NewHub()
+	sub := NewSubscriber(2)
+	hub.Subscribe(sub, "busy")
+
+	// when: 버퍼보다 많은 메시지를 발행
+	for i := 0; i < 10; i++ {
+		hub.Publish("busy", "msg")
+	}
+
+	// then: 발행자는 블로킹되지 않고, 구독자에게 overflow 신호가 간다
+	select {
+	case <-sub.Overflow():
+	default:
+		t.Fatal("overflow 신호가 없습니다")
+	}
+}
@@ -0,0 +1,798 @@
+package server
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"inmemory-db/internal/glob"
+	"inmemory-db/internal/protocol"
+	"maps"
+	"net"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 처음부터 있고 지울 수 없는 사용자. 비밀번호 없이 접속한 연결은 이 사용자로 인증된다
+const defaultUserName = "default"
+
+// 같은 이유로 거부된 요청을 ACL LOG의 항목 하나로 묶는 시간
+const aclLogGroupWindow = 60 * time.Second
+
+var errUnknownACLCommand = errors.New("Unknown command or category name in ACL")
+
+// ACL 사용자. 한 번 공개한 뒤에는 바꾸지 않는다. ACL SETUSER는 복사본을 고쳐서 통째로 바꿔 끼운다.
+type aclUser struct {
+	name    string
+	enabled bool
+	// 비밀번호 없이 인증할 수 있다
+	nopass bool
+	// 비밀번호의 SHA-256 (16진수 소문자)
+	passwords []string
+
+	// 접근할 수 있는 키 패턴. allKeys면 패턴 "*"가 있다
+	keyPatterns []string
+	allKeys     bool
+
+	// 명령어 허용 여부. 명령어 이름(대문자)이나 "명령어|하위 명령어"로 찾는다
+	commands map[string]bool
+	// 하위 명령어 규칙이 있는 명령어. 이 명령어만 하위 명령어까지 찾아본다
+	subcommands map[string]bool
+	// ACL LIST에 보일 명령어 규칙 (+@all, -@all 뒤로 적용한 순서대로)
+	commandRules []string
+}
+
+// 아무 권한도 없는 꺼진 사용자 (ACL SETUSER로 새로 만들 때)
+func newACLUser(name string) *aclUser {
+	return &aclUser{
+		name:         name,
+		commands:     make(map[string]bool),
+		subcommands:  make(map[string]bool),
+		commandRules: []string{"-@all"},
+	}
+}
+
+// 기본 사용자: 켜져 있고, 비밀번호 없이 모든 키와 명령어를 쓸 수 있다
+func newDefaultUser() *aclUser {
+	user := newACLUser(defaultUserName)
+	for _, rule := range []string{"on", "nopass", "allkeys", "allcommands"} {
+		user.apply(rule)
+	}
+	return user
+}
+
+func (u *aclUser) clone() *aclUser {
+	clone := *u
+	clone.passwords = slices.Clone(u.passwords)
+	clone.keyPatterns = slices.Clone(u.keyPatterns)
+	clone.commands = maps.Clone(u.commands)
+	clone.subcommands = maps.Clone(u.subcommands)
+	clone.commandRules = slices.Clone(u.commandRules)
+	return &clone
+}
+
+// 규칙 하나를 적용한다 (ACL SETUSER의 인자 하나).
+func (u *aclUser) apply(rule string) error {
+	switch strings.ToLower(rule) {
+	case "on":
+		u.enabled = true
+		return nil
+	case "off":
+		u.enabled = false
+		return nil
+	case "nopass":
+		u.nopass = true
+		u.passwords = nil
+		return nil
+	case "resetpass":
+		u.nopass = false
+		u.passwords = nil
+		return nil
+	case "allkeys":
+		u.keyPatterns = []string{"*"}
+		u.allKeys = true
+		return nil
+	case "resetkeys":
+		u.keyPatterns = nil
+		u.allKeys = false
+		return nil
+	case "allcommands":
+		return u.applyCommandRule("+@all")
+	case "nocommands":
+		return u.applyCommandRule("-@all")
+	case "reset":
+		*u = *newACLUser(u.name)
+		return nil
+	}
+
+	if rule == "" {
+		return errors.New("Syntax error")
+	}
+	switch value := rule[1:]; rule[0] {
+	case '>':
+		u.addPassword(hashPassword(value))
+	case '#':
+		if !validPasswordHash(value) {
+			return errors.New("The password hash must be exactly 64 characters and contain only lowercase hexadecimal characters")
+		}
+		u.addPassword(value)
+	case '<':
+		return u.removePassword(hashPassword(value))
+	case '!':
+		return u.removePassword(value)
+	case '~':
+		if !slices.Contains(u.keyPatterns, value) {
+			u.keyPatterns = append(u.keyPatterns, value)
+		}
+		u.allKeys = u.allKeys || value == "*"
+	case '+', '-':
+		return u.applyCommandRule(rule)
+	default:
+		return errors.New("Syntax error")
+	}
+	return nil
+}
+
+func (u *aclUser) addPassword(hash string) {
+	u.nopass = false
+	if !slices.Contains(u.passwords, hash) {
+		u.passwords = append(u.passwords, hash)
+	}
+}
+
+func (u *aclUser) removePassword(hash string) error {
+	index := slices.Index(u.passwords, hash)
+	if index < 0 {
+		return errors.New("no such password")
+	}
+	u.passwords = slices.Delete(u.passwords, index, index+1)
+	return nil
+}
+
+// +command, -command, +command|subcommand, +@category, -@category
+func (u *aclUser) applyCommandRule(rule string) error {
+	allow := rule[0] == '+'
+	body := rule[1:]
+
+	if category, isCategory := strings.CutPrefix(body, "@"); isCategory {
+		bits, ok := parseACLCategory(category)
+		if !ok {
+			return errUnknownACLCommand
+		}
+		if bits == 0 {
+			// +@all/-@all은 앞선 규칙을 모두 덮는다
+			u.commands = make(map[string]bool)
+			u.subcommands = make(map[string]bool)
+			u.commandRules = nil
+		}
+		for name, spec := range commandSpecs {
+			if bits == 0 || spec.categories&bits != 0 {
+				u.setCommand(name, allow)
+			}
+		}
+		u.commandRules = append(u.commandRules, strings.ToLower(rule))
+		return nil
+	}
+
+	name := strings.ToUpper(body)
+	parent, subcommand, hasSubcommand := strings.Cut(name, "|")
+	if _, ok := commandSpecs[parent]; !ok || (hasSubcommand && subcommand == "") {
+		return errUnknownACLCommand
+	}
+	if !hasSubcommand {
+		// 명령어 전체 규칙은 하위 명령어 규칙을 덮는다
+		for key := range u.commands {
+			if strings.HasPrefix(key, parent+"|") {
+				delete(u.commands, key)
+			}
+		}
+		delete(u.subcommands, parent)
+	}
+	u.setCommand(name, allow)
+	u.commandRules = append(u.commandRules, strings.ToLower(rule))
+	return nil
+}
+
+func (u *aclUser) setCommand(name string, allow bool) {
+	u.commands[name] = allow
+	if parent, _, ok := strings.Cut(name, "|"); ok {
+		u.subcommands[parent] = true
+	}
+}
+
+// 명령어를 실행할 수 있는지. 하위 명령어 규칙이 있으면 그것을 따른다.
+func (u *aclUser) canRun(command string, args []protocol.Value) bool {
+	if u.subcommands[command] && len(args) > 1 {
+		if allowed, ok := u.commands[command+"|"+strings.ToUpper(args[1].Str)]; ok {
+			return allowed
+		}
+	}
+	return u.commands[command]
+}
+
+func (u *aclUser) canAccess(key string) bool {
+	if u.allKeys {
+		return true
+	}
+	for _, pattern := range u.keyPatterns {
+		if glob.Match(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (u *aclUser) checkPassword(password string) bool {
+	if u.nopass {
+		return true
+	}
+	hash := []byte(hashPassword(password))
+	for _, stored := range u.passwords {
+		if subtle.ConstantTimeCompare(hash, []byte(stored)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// 규칙 문자열 (ACL LIST, ACL 파일). 이 규칙을 새 사용자에 적용하면 같은 사용자가 된다.
+func (u *aclUser) describe() string {
+	parts := []string{"off"}
+	if u.enabled {
+		parts[0] = "on"
+	}
+	if u.nopass {
+		parts = append(parts, "nopass")
+	}
+	for _, hash := range u.passwords {
+		parts = append(parts, "#"+hash)
+	}
+	for _, pattern := range u.keyPatterns {
+		parts = append(parts, "~"+pattern)
+	}
+	parts = append(parts, u.commandRules...)
+	return strings.Join(parts, " ")
+}
+
+func hashPassword(password string) string {
+	sum := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(sum[:])
+}
+
+func validPasswordHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+	for _, ch := range hash {
+		if !(ch >= '0' && ch <= '9' || ch >= 'a' && ch <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// ========== 서버 ==========
+
+// 현재 사용자 목록. 바꿀 때는 aclMu를 잡고 새 맵으로 바꿔 끼운다.
+func (s *Server) aclUserMap() map[string]*aclUser {
+	return *s.aclUsers.Load()
+}
+
+// 사용자 하나를 바꾼다. 없는 사용자면 새로 만든다. update가 에러를 반환하면 아무것도 바꾸지 않는다.
+func (s *Server) updateACLUser(name string, update func(user *aclUser) error) error {
+	s.aclMu.Lock()
+	defer s.aclMu.Unlock()
+
+	users := s.aclUserMap()
+	user, exist := users[name]
+	if exist {
+		user = user.clone()
+	} else {
+		user = newACLUser(name)
+	}
+	if err := update(user); err != nil {
+		return err
+	}
+
+	next := maps.Clone(users)
+	next[name] = user
+	s.aclUsers.Store(&next)
+	return nil
+}
+
+// 사용자 목록을 통째로 바꾸고, 없어진 사용자로 인증된 연결을 끊는다 (ACL LOAD).
+// 새 목록에 기본 사용자가 없으면 처음 상태의 기본 사용자를 두되, 지금 기본 사용자의 비밀번호는 그대로 둔다.
+// 그러지 않으면 requirepass로 정한 비밀번호가 ACL 파일을 읽으면서 조용히 사라진다.
+func (s *Server) replaceACLUsers(users map[string]*aclUser) {
+	s.aclMu.Lock()
+	if users[defaultUserName] == nil {
+		user := newDefaultUser()
+		if current := s.aclUserMap()[defaultUserName]; current != nil {
+			user.nopass = current.nopass
+			user.passwords = slices.Clone(current.passwords)
+		}
+		users[defaultUserName] = user
+	}
+	s.aclUsers.Store(&users)
+	s.aclMu.Unlock()
+
+	s.disconnectRemovedUsers(users)
+}
+
+// 사용자를 지우고, 지운 사용자로 인증된 연결을 끊는다. 지운 사용자 수를 반환한다 (ACL DELUSER).
+func (s *Server) deleteACLUsers(names []string) int {
+	s.aclMu.Lock()
+	users := maps.Clone(s.aclUserMap())
+	deleted := 0
+	for _, name := range names {
+		if _, exist := users[name]; exist {
+			delete(users, name)
+			deleted++
+		}
+	}
+	if deleted > 0 {
+		s.aclUsers.Store(&users)
+	}
+	s.aclMu.Unlock()
+
+	if deleted > 0 {
+		s.disconnectRemovedUsers(users)
+	}
+	return deleted
+}
+
+func (s *Server) disconnectRemovedUsers(users map[string]*aclUser) {
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+	for _, c := range s.clients {
+		if name := c.username(); name != "" && users[name] == nil {
+			c.conn.Close()
+		}
+	}
+}
+
+// 새 연결이 처음 쓸 사용자. 기본 사용자에 비밀번호가 없으면 곧바로 인증된다
+func (s *Server) initialUser() string {
+	user := s.aclUserMap()[defaultUserName]
+	if user != nil && user.enabled && user.nopass {
+		return defaultUserName
+	}
+	return ""
+}
+
+// 보호 모드에서 이 주소의 연결을 거부하는지.
+// 보호 모드가 켜져 있고, bind를 정하지 않았고, 기본 사용자에 비밀번호가 없으면 루프백 밖의 연결을 받지 않는다.
+func (s *Server) protectedModeDenies(addr net.Addr) bool {
+	if !s.protectedMode.Load() || s.bind != "" {
+		return false
+	}
+	user := s.aclUserMap()[defaultUserName]
+	if user == nil || !user.nopass {
+		return false
+	}
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && !tcp.IP.IsLoopback()
+}
+
+const protectedModeMessage = "Running in protected mode because protected mode is enabled and no password is set for the default user. " +
+	"In this mode connections are only accepted from the loopback interface. " +
+	"Set a password with CONFIG SET requirepass or ACL SETUSER, bind to a specific address, " +
+	"or disable protected mode with CONFIG SET protected-mode no from the same host."
+
+// 연결의 사용자가 명령어와 명령어가 다루는 키에 권한이 있는지 확인한다.
+// 권한이 없으면 에러를 응답하고 ACL LOG에 남긴 뒤 false를 반환한다.
+func (s *Server) checkACL(c *client, command string, args []protocol.Value) bool {
+	name := c.username()
+	user := s.aclUserMap()[name]
+	if user == nil {
+		c.writer.WriteErrorCode("NOAUTH", "Authentication required.")
+		return false
+	}
+
+	spec, known := commandSpecs[command]
+	if !known {
+		// 없는 명령어는 execute가 응답한다
+		return true
+	}
+	object := strings.ToLower(command)
+	if len(args) > 1 {
+		sub := command + "|" + strings.ToUpper(args[1].Str)
+		if subSpec, ok := commandSpecs[sub]; ok || user.subcommands[command] {
+			if ok {
+				spec = subSpec
+			}
+			object = strings.ToLower(sub)
+		}
+	}
+
+	if !user.canRun(command, args) {
+		s.addACLLog(c, "command", object, name)
+		c.writer.WriteErrorCode("NOPERM", fmt.Sprintf("User %s has no permissions to run the '%s' command", name, object))
+		return false
+	}
+	if user.allKeys {
+		return true
+	}
+	for _, key := range spec.keysOf(args) {
+		if !user.canAccess(key) {
+			s.addACLLog(c, "key", key, name)
+			c.writer.WriteErrorCode("NOPERM", "No permissions to access a key")
+			return false
+		}
+	}
+	return true
+}
+
+// AUTH [username] password
+func (s *Server) handleAuth(c *client, args []protocol.Value) {
+	var name, password string
+	switch len(args) {
+	case 2:
+		name, password = defaultUserName, args[1].Str
+		if user := s.aclUserMap()[defaultUserName]; user != nil && user.nopass {
+			c.writer.WriteError("AUTH <password> called without any password configured for the default user. Are you sure your configuration is correct?")
+			return
+		}
+	case 3:
+		name, password = args[1].Str, args[2].Str
+	default:
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	user := s.aclUserMap()[name]
+	if user == nil || !user.enabled || !user.checkPassword(password) {
+		s.addACLLog(c, "auth", "AUTH", name)
+		c.writer.WriteErrorCode("WRONGPASS", "invalid username-password pair or user is disabled.")
+		return
+	}
+	c.setUser(name)
+	c.writer.WriteSimpleString("OK")
+}
+
+// ACL SETUSER username [rule ...] | GETUSER username | DELUSER username [username ...] |
+// LIST | USERS | WHOAMI | CAT [category] | LOG [count|RESET] | LOAD | SAVE
+func (s *Server) handleACL(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "SETUSER":
+		if len(args) < 3 {
+			c.writer.WriteError("wrong number of arguments for 'acl|setuser' command")
+			return
+		}
+		// 인증하지 않은 연결의 사용자 이름이 ""이므로, 이 이름의 사용자가 생기면 NOAUTH를 건너뛰게 된다
+		if args[2].Str == "" {
+			c.writer.WriteError("Usernames can't be empty")
+			return
+		}
+		var failed string
+		err := s.updateACLUser(args[2].Str, func(user *aclUser) error {
+			for _, arg := range args[3:] {
+				if err := user.apply(arg.Str); err != nil {
+					failed = arg.Str
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			c.writer.WriteError(fmt.Sprintf("Error in ACL SETUSER modifier '%s': %v", failed, err))
+			return
+		}
+		c.writer.WriteSimpleString("OK")
+
+	case "GETUSER":
+		if len(args) != 3 {
+			c.writer.WriteError("wrong number of arguments for 'acl|getuser' command")
+			return
+		}
+		user := s.aclUserMap()[args[2].Str]
+		if user == nil {
+			c.writer.WriteNull()
+			return
+		}
+		flags := []string{"off"}
+		if user.enabled {
+			flags[0] = "on"
+		}
+		if user.allKeys {
+			flags = append(flags, "allkeys")
+		}
+		if slices.Equal(user.commandRules, []string{"+@all"}) {
+			flags = append(flags, "allcommands")
+		}
+		if user.nopass {
+			flags = append(flags, "nopass")
+		}
+		keys := make([]string, 0, len(user.keyPatterns))
+		for _, pattern := range user.keyPatterns {
+			keys = append(keys, "~"+pattern)
+		}
+
+		c.writer.WriteArrayLen(8)
+		c.writer.WriteBulkString("flags")
+		c.writer.WriteArray(flags)
+		c.writer.WriteBulkString("passwords")
+		c.writer.WriteArray(user.passwords)
+		c.writer.WriteBulkString("commands")
+		c.writer.WriteBulkString(strings.Join(user.commandRules, " "))
+		c.writer.WriteBulkString("keys")
+		c.writer.WriteBulkString(strings.Join(keys, " "))
+
+	case "DELUSER":
+		if len(args) < 3 {
+			c.writer.WriteError("wrong number of arguments for 'acl|deluser' command")
+			return
+		}
+		for _, arg := range args[2:] {
+			if arg.Str == defaultUserName {
+				c.writer.WriteError("The 'default' user cannot be removed")
+				return
+			}
+		}
+		names := make([]string, 0, len(args)-2)
+		for _, arg := range args[2:] {
+			names = append(names, arg.Str)
+		}
+		c.writer.WriteInteger(s.deleteACLUsers(names))
+
+	case "LIST":
+		users := s.aclUserMap()
+		lines := make([]string, 0, len(users))
+		for _, name := range sortedUserNames(users) {
+			lines = append(lines, "user "+name+" "+users[name].describe())
+		}
+		c.writer.WriteArray(lines)
+
+	case "USERS":
+		c.writer.WriteArray(sortedUserNames(s.aclUserMap()))
+
+	case "WHOAMI":
+		c.writer.WriteBulkString(c.username())
+
+	case "CAT":
+		if len(args) == 2 {
+			names := make([]string, 0, len(aclCategoryNames))
+			for _, entry := range aclCategoryNames {
+				names = append(names, entry.name)
+			}
+			c.writer.WriteArray(names)
+			return
+		}
+		category, ok := parseACLCategory(args[2].Str)
+		if !ok {
+			c.writer.WriteError("Unknown category '" + args[2].Str + "'")
+			return
+		}
+		var commands []string
+		for name, spec := range commandSpecs {
+			if category == 0 || spec.categories&category != 0 {
+				commands = append(commands, strings.ToLower(name))
+			}
+		}
+		sort.Strings(commands)
+		c.writer.WriteArray(commands)
+
+	case "LOG":
+		s.handleACLLog(c, args)
+
+	case "LOAD":
+		if err := s.loadACLFile(); err != nil {
+			c.writer.WriteError(err.Error())
+			return
+		}
+		c.writer.WriteSimpleString("OK")
+
+	case "SAVE":
+		if err := s.saveACLFile(); err != nil {
+			c.writer.WriteError(err.Error())
+			return
+		}
+		c.writer.WriteSimpleString("OK")
+
+	default:
+		c.writer.WriteError("unknown ACL subcommand '" + args[1].Str + "'")
+	}
+}
+
+func sortedUserNames(users map[string]*aclUser) []string {
+	names := make([]string, 0, len(users))
+	for name := range users {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ========== ACL 파일 ==========
+
+// aclfile 설정의 파일에서 사용자를 읽어 지금 사용자를 모두 바꾼다 (시작할 때, ACL LOAD).
+// 한 줄에 "user 이름 규칙..." 하나씩 쓴다. 한 줄이라도 잘못됐으면 아무것도 바꾸지 않는다.
+// 파일에 기본 사용자가 없으면 처음 상태의 기본 사용자를 두고, 비밀번호(requirepass)는 그대로 둔다.
+func (s *Server) loadACLFile() error {
+	if s.aclFile == "" {
+		return errors.New("This instance is not configured to use an ACL file")
+	}
+	data, err := os.ReadFile(s.aclFile)
+	if err != nil {
+		return err
+	}
+
+	users := make(map[string]*aclUser)
+	for number, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args, err := splitConfigArgs(line)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", s.aclFile, number+1, err)
+		}
+		if len(args) < 2 || strings.ToLower(args[0]) != "user" {
+			return fmt.Errorf("%s:%d: line should start with user keyword", s.aclFile, number+1)
+		}
+		name := args[1]
+		if name == "" {
+			return fmt.Errorf("%s:%d: Usernames can't be empty", s.aclFile, number+1)
+		}
+		if _, exist := users[name]; exist {
+			return fmt.Errorf("%s:%d: Duplicate user '%s' found", s.aclFile, number+1, name)
+		}
+		user := newACLUser(name)
+		for _, rule := range args[2:] {
+			if err := user.apply(rule); err != nil {
+				return fmt.Errorf("%s:%d: Error in user declaration '%s': %v", s.aclFile, number+1, rule, err)
+			}
+		}
+		users[name] = user
+	}
+
+	s.replaceACLUsers(users)
+	return nil
+}
+
+// 지금 사용자를 aclfile 설정의 파일에 쓴다 (ACL SAVE). 임시 파일에 쓴 뒤 바꿔치기한다.
+func (s *Server) saveACLFile() error {
+	if s.aclFile == "" {
+		return errors.New("This instance is not configured to use an ACL file")
+	}
+
+	users := s.aclUserMap()
+	var out strings.Builder
+	for _, name := range sortedUserNames(users) {
+		out.WriteString("user " + name + " " + users[name].describe() + "\n")
+	}
+
+	tmp := s.aclFile + ".tmp"
+	if err := os.WriteFile(tmp, []byte(out.String()), 0600); err != nil {
+		return fmt.Errorf("There was an error trying to save the ACLs: %v", err)
+	}
+	if err := os.Rename(tmp, s.aclFile); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("There was an error trying to save the ACLs: %v", err)
+	}
+	return nil
+}
+
+// ========== ACL LOG ==========
+
+// 거부된 명령어, 키 접근, 인증 실패 기록
+type aclLogEntry struct {
+	id    int64
+	count int
+	// "command", "key", "auth"
+	reason string
+	// 거부된 명령어 이름, 키, 또는 "AUTH"
+	object     string
+	username   string
+	clientInfo string
+	created    time.Time
+	updated    time.Time
+}
+
+// 거부 기록을 남긴다. 최근 60초 안에 같은 기록이 있으면 횟수만 올린다.
+func (s *Server) addACLLog(c *client, reason, object, username string) {
+	now := time.Now()
+	info := fmt.Sprintf("id=%d addr=%s laddr=%s user=%s db=%d",
+		c.id, c.conn.RemoteAddr(), c.conn.LocalAddr(), c.username(), c.dbIndex)
+
+	s.aclLogMu.Lock()
+	defer s.aclLogMu.Unlock()
+
+	for i, entry := range s.aclLog {
+		if entry.reason == reason && entry.object == object && entry.username == username &&
+			now.Sub(entry.updated) < aclLogGroupWindow {
+			entry.count++
+			entry.updated = now
+			entry.clientInfo = info
+			// 가장 최근 항목이 맨 앞에 오게 한다
+			copy(s.aclLog[1:i+1], s.aclLog[:i])
+			s.aclLog[0] = entry
+			return
+		}
+	}
+
+	entry := &aclLogEntry{
+		id:         s.aclLogNextID,
+		count:      1,
+		reason:     reason,
+		object:     object,
+		username:   username,
+		clientInfo: info,
+		created:    now,
+		updated:    now,
+	}
+	s.aclLogNextID++
+	s.aclLog = append([]*aclLogEntry{entry}, s.aclLog...)
+	s.trimACLLog()
+}
+
+// acllog-max-len을 넘는 오래된 항목을 버린다. aclLogMu를 잡고 호출한다.
+func (s *Server) trimACLLog() {
+	if limit := int(s.aclLogMaxLen.Load()); len(s.aclLog) > limit {
+		clear(s.aclLog[limit:])
+		s.aclLog = s.aclLog[:limit]
+	}
+}
+
+// ACL LOG [count|RESET]
+func (s *Server) handleACLLog(c *client, args []protocol.Value) {
+	count := 10
+	if len(args) > 2 {
+		if strings.ToUpper(args[2].Str) == "RESET" {
+			s.aclLogMu.Lock()
+			s.aclLog = nil
+			s.aclLogMu.Unlock()
+			c.writer.WriteSimpleString("OK")
+			return
+		}
+		n, err := strconv.Atoi(args[2].Str)
+		if err != nil || n < 0 {
+			c.writer.WriteError("value is out of range, must be positive")
+			return
+		}
+		count = n
+	}
+
+	s.aclLogMu.Lock()
+	entries := make([]aclLogEntry, 0, min(count, len(s.aclLog)))
+	for _, entry := range s.aclLog[:min(count, len(s.aclLog))] {
+		entries = append(entries, *entry)
+	}
+	s.aclLogMu.Unlock()
+
+	now := time.Now()
+	c.writer.WriteArrayLen(len(entries))
+	for _, entry := range entries {
+		c.writer.WriteArrayLen(20)
+		c.writer.WriteBulkString("count")
+		c.writer.WriteInteger(entry.count)
+		c.writer.WriteBulkString("reason")
+		c.writer.WriteBulkString(entry.reason)
+		c.writer.WriteBulkString("context")
+		c.writer.WriteBulkString("toplevel")
+		c.writer.WriteBulkString("object")
+		c.writer.WriteBulkString(entry.object)
+		c.writer.WriteBulkString("username")
+		c.writer.WriteBulkString(entry.username)
+		c.writer.WriteBulkString("age-seconds")
+		c.writer.WriteBulkString(strconv.FormatFloat(now.Sub(entry.created).Seconds(), 'f', 3, 64))
+		c.writer.WriteBulkString("client-info")
+		c.writer.WriteBulkString(entry.clientInfo)
+		c.writer.WriteBulkString("entry-id")
+		c.writer.WriteInteger(int(entry.id))
+		c.writer.WriteBulkString("timestamp-created")
+		c.writer.WriteInteger(int(entry.created.UnixMilli()))
+		c.writer.WriteBulkString("timestamp-last-updated")
+		c.writer.WriteInteger(int(entry.updated.UnixMilli()))
+	}
+}
@@ -0,0 +1,349 @@
+package server
+
+import (
+	"bufio"
+	"inmemory-db/internal/protocol"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func commandArgs(args ...string) []protocol.Value {
+	values := make([]protocol.Value, len(args))
+	for i, arg := range args {
+		values[i] = protocol.Value{Type: '$', Str: arg}
+	}
+	return values
+}
+
+// 응답 하나를 파싱한다. 중첩 배열을 확인할 때 쓴다.
+func readValue(t *testing.T, reader *bufio.Reader) protocol.Value {
+	t.Helper()
+
+	value, err := protocol.NewReader(reader).Read()
+	if err != nil {
+		t.Fatalf("응답 읽기 실패: %v", err)
+	}
+	return value
+}
+
+func TestACLUserRules(t *testing.T) {
+	// given
+	user := newACLUser("alice")
+
+	// when
+	for _, rule := range []string{"on", ">secret", "~app:*", "+@read", "-@dangerous", "+client|setname"} {
+		if err := user.apply(rule); err != nil {
+			t.Fatalf("규칙 %q 적용 실패: %v", rule, err)
+		}
+	}
+
+	// then
+	if !user.enabled || !user.checkPassword("secret") || user.checkPassword("wrong") {
+		t.Fatal("켜짐/비밀번호가 잘못 적용됐습니다")
+	}
+	if !user.canRun("GET", commandArgs("GET", "app:1")) || user.canRun("SET", commandArgs("SET", "app:1", "v")) {
+		t.Fatal("+@read가 잘못 적용됐습니다")
+	}
+	if user.canRun("FLUSHALL", commandArgs("FLUSHALL")) {
+		t.Fatal("-@dangerous가 적용되지 않았습니다")
+	}
+	if !user.canRun("CLIENT", commandArgs("CLIENT", "SETNAME", "x")) || user.canRun("CLIENT", commandArgs("CLIENT", "KILL", "x")) {
+		t.Fatal("하위 명령어 규칙이 잘못 적용됐습니다")
+	}
+	if !user.canAccess("app:1") || user.canAccess("other") {
+		t.Fatal("키 패턴이 잘못 적용됐습니다")
+	}
+
+	// when & then: describe 결과를 새 사용자에 적용하면 같은 권한이 된다
+	copied := newACLUser("alice")
+	for _, rule := range strings.Fields(user.describe()) {
+		if err := copied.apply(rule); err != nil {
+			t.Fatalf("describe 규칙 %q 적용 실패: %v", rule, err)
+		}
+	}
+	if copied.describe() != user.describe() {
+		t.Fatalf("describe가 다릅니다: %q, %q", copied.describe(), user.describe())
+	}
+	if !copied.canRun("CLIENT", commandArgs("CLIENT", "SETNAME", "x")) || copied.canRun("SET", commandArgs("SET", "k", "v")) {
+		t.Fatal("복사한 사용자의 권한이 다릅니다")
+	}
+
+	// when & then: -@all은 하위 명령어 규칙까지 지운다
+	user.apply("-@all")
+	if user.canRun("CLIENT", commandArgs("CLIENT", "SETNAME", "x")) || user.describe() != "on #"+hashPassword("secret")+" ~app:* -@all" {
+		t.Fatalf("-@all 뒤 상태: %q", user.describe())
+	}
+}
+
+func TestACLUserRules_Invalid(t *testing.T) {
+	user := newACLUser("alice")
+	for _, rule := range []string{"+nosuchcommand", "+@nosuchcategory", "#abc", "<notset", "?"} {
+		if err := user.apply(rule); err == nil {
+			t.Fatalf("잘못된 규칙 %q가 적용됐습니다", rule)
+		}
+	}
+}
+
+func TestCommandSpecKeys(t *testing.T) {
+	tests := []struct {
+		args []string
+		keys []string
+	}{
+		{[]string{"GET", "a"}, []string{"a"}},
+		{[]string{"MSET", "a", "1", "b", "2"}, []string{"a", "b"}},
+		{[]string{"BLPOP", "a", "b", "0"}, []string{"a", "b"}},
+		{[]string{"ZUNIONSTORE", "dst", "2", "a", "b", "WEIGHTS", "1", "2"}, []string{"dst", "a", "b"}},
+		{[]string{"XREAD", "COUNT", "1", "STREAMS", "a", "b", "0", "0"}, []string{"a", "b"}},
+		{[]string{"OBJECT", "ENCODING", "a"}, []string{"a"}},
+		{[]string{"PING"}, nil},
+	}
+	for _, test := range tests {
+		keys := commandSpecs[test.args[0]].keysOf(commandArgs(test.args...))
+		if strings.Join(keys, " ") != strings.Join(test.keys, " ") {
+			t.Errorf("%v의 키: %v, 기대값: %v", test.args, keys, test.keys)
+		}
+	}
+}
+
+func TestProtectedModeDenies(t *testing.T) {
+	// given: bind 없이 띄운 서버
+	server := New(":0")
+	remote := &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}
+	local := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5000}
+
+	// then: 비밀번호가 없으면 루프백 밖의 연결만 거부한다
+	if !server.protectedModeDenies(remote) || server.protectedModeDenies(local) {
+		t.Fatal("보호 모드가 잘못 적용됐습니다")
+	}
+
+	// when & then: 비밀번호를 정하면 받는다
+	server.SetConfig("requirepass", "secret")
+	if server.protectedModeDenies(remote) {
+		t.Fatal("비밀번호를 정했는데 거부합니다")
+	}
+
+	// when & then: 보호 모드를 끄면 받는다
+	server.SetConfig("requirepass", "")
+	server.SetConfig("protected-mode", "no")
+	if server.protectedModeDenies(remote) {
+		t.Fatal("보호 모드를 껐는데 거부합니다")
+	}
+}
+
+func TestRequirePassAndAuth(t *testing.T) {
+	// given
+	server, _, result := startServerAt(t, "localhost:6393")
+	defer func() {
+		server.Shutdown(false)
+		waitStopped(t, result)
+	}()
+	server.SetConfig("requirepass", "secret")
+	conn, reader := dialAddr(t, "localhost:6393")
+
+	// when & then: 인증 전에는 AUTH 말고는 실행할 수 없다
+	if response := do(t, conn, reader, "GET", "k"); !strings.HasPrefix(response, "-NOAUTH") {
+		t.Fatalf("인증 전 GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "AUTH", "wrong"); !strings.HasPrefix(response, "-WRONGPASS") {
+		t.Fatalf("잘못된 비밀번호 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "AUTH", "secret"); response != "+OK\r\n" {
+		t.Fatalf("AUTH 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ACL", "WHOAMI"); response != "$7\r\ndefault\r\n" {
+		t.Fatalf("ACL WHOAMI 응답: %q", response)
+	}
+
+	// when & then: 비밀번호를 지우면 새 연결은 곧바로 인증된다
+	do(t, conn, reader, "CONFIG", "SET", "requirepass", "")
+	other, otherReader := dialAddr(t, "localhost:6393")
+	if response := do(t, other, otherReader, "PING"); response != "+PONG\r\n" {
+		t.Fatalf("비밀번호를 지운 뒤 PING 응답: %q", response)
+	}
+	if response := do(t, other, otherReader, "AUTH", "secret"); !strings.HasPrefix(response, "-ERR AUTH <password> called without") {
+		t.Fatalf("비밀번호가 없을 때 AUTH 응답: %q", response)
+	}
+}
+
+func TestACLUserPermissions(t *testing.T) {
+	// given
+	server, _, result := startServerAt(t, "localhost:6394")
+	defer func() {
+		server.Shutdown(false)
+		waitStopped(t, result)
+	}()
+	admin, adminReader := dialAddr(t, "localhost:6394")
+	if response := do(t, admin, adminReader, "ACL", "SETUSER", "alice", "on", ">pw", "~app:*", "+@read", "+@connection"); response != "+OK\r\n" {
+		t.Fatalf("ACL SETUSER 응답: %q", response)
+	}
+	do(t, admin, adminReader, "SET", "app:1", "v")
+	do(t, admin, adminReader, "SET", "other", "v")
+
+	conn, reader := dialAddr(t, "localhost:6394")
+	if response := do(t, conn, reader, "AUTH", "alice", "pw"); response != "+OK\r\n" {
+		t.Fatalf("AUTH 응답: %q", response)
+	}
+
+	// when & then: 허용된 명령어와 키
+	if response := do(t, conn, reader, "GET", "app:1"); response != "$1\r\nv\r\n" {
+		t.Fatalf("GET app:1 응답: %q", response)
+	}
+	// 허용되지 않은 키
+	if response := do(t, conn, reader, "GET", "other"); response != "-NOPERM No permissions to access a key\r\n" {
+		t.Fatalf("GET other 응답: %q", response)
+	}
+	// 허용되지 않은 명령어
+	if response := do(t, conn, reader, "SET", "app:1", "x"); response != "-NOPERM User alice has no permissions to run the 'set' command\r\n" {
+		t.Fatalf("SET 응답: %q", response)
+	}
+	do(t, conn, reader, "SET", "app:1", "x")
+
+	// then: 거부 기록이 최근 것부터 남고, 같은 거부는 하나로 묶인다
+	send(admin, "ACL", "LOG")
+	entries := readValue(t, adminReader)
+	if len(entries.Array) != 2 {
+		t.Fatalf("ACL LOG 항목 수: %d", len(entries.Array))
+	}
+	latest := entries.Array[0].Array
+	if latest[1].Num != 2 || latest[3].Str != "command" || latest[7].Str != "set" || latest[9].Str != "alice" {
+		t.Fatalf("최근 ACL LOG 항목: %+v", latest)
+	}
+	if older := entries.Array[1].Array; older[3].Str != "key" || older[7].Str != "other" {
+		t.Fatalf("이전 ACL LOG 항목: %+v", older)
+	}
+
+	// when & then: GETUSER
+	send(admin, "ACL", "GETUSER", "alice")
+	info := readValue(t, adminReader)
+	if len(info.Array) != 8 || info.Array[7].Str != "~app:*" || info.Array[5].Str != "-@all +@read +@connection" {
+		t.Fatalf("ACL GETUSER 응답: %+v", info)
+	}
+
+	// when & then: 사용자를 지우면 그 사용자의 연결이 끊긴다
+	if response := do(t, admin, adminReader, "ACL", "DELUSER", "alice", "nobody"); response != ":1\r\n" {
+		t.Fatalf("ACL DELUSER 응답: %q", response)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := reader.ReadByte(); err == nil {
+		t.Fatal("지운 사용자의 연결이 끊기지 않았습니다")
+	}
+	if response := do(t, admin, adminReader, "ACL", "DELUSER", "default"); !strings.HasPrefix(response, "-ERR") {
+		t.Fatalf("기본 사용자 DELUSER 응답: %q", response)
+	}
+
+	// when & then: 이름이 빈 사용자는 만들 수 없다 (인증하지 않은 연결이 그 권한을 얻게 된다)
+	if response := do(t, admin, adminReader, "ACL", "SETUSER", "", "on", "nopass", "+@all"); response != "-ERR Usernames can't be empty\r\n" {
+		t.Fatalf("빈 이름 SETUSER 응답: %q", response)
+	}
+}
+
+func TestACLFileLoadAndSave(t *testing.T) {
+	// given: 사용자 둘이 있는 ACL 파일
+	dir := t.TempDir()
+	path := filepath.Join(dir, "users.acl")
+	content := "# 사용자 목록\nuser default on nopass ~* +@all\nuser bob on >pw ~cache:* +get\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server := New("localhost:6395")
+	server.SetConfig("dir", dir)
+	server.SetConfig("debug-addr", "")
+	if err := server.SetConfig("aclfile", path); err != nil {
+		t.Fatalf("aclfile 설정 실패: %v", err)
+	}
+	result := make(chan error, 1)
+	go func() { result <- server.Start() }()
+	defer func() {
+		server.Shutdown(false)
+		waitStopped(t, result)
+	}()
+	for range 100 {
+		if conn, err := net.Dial("tcp", "localhost:6395"); err == nil {
+			conn.Close()
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	conn, reader := dialAddr(t, "localhost:6395")
+
+	// then: 시작할 때 파일의 사용자를 읽는다
+	if response := do(t, conn, reader, "ACL", "USERS"); response != "*2\r\n$3\r\nbob\r\n$7\r\ndefault\r\n" {
+		t.Fatalf("ACL USERS 응답: %q", response)
+	}
+
+	// when: 사용자를 더하고 저장한다
+	do(t, conn, reader, "ACL", "SETUSER", "carol", "on", "nopass", "~*", "+@all")
+	if response := do(t, conn, reader, "ACL", "SAVE"); response != "+OK\r\n" {
+		t.Fatalf("ACL SAVE 응답: %q", response)
+	}
+
+	// then
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "user carol on nopass ~* +@all\n") ||
+		!strings.Contains(string(data), "user bob on #"+hashPassword("pw")+" ~cache:* -@all +get\n") {
+		t.Fatalf("저장된 ACL 파일:\n%s", data)
+	}
+
+	// when & then: 잘못된 파일은 읽지 않고 지금 사용자를 그대로 둔다
+	for _, content := range []string{"user dave on +nosuchcommand\n", "user \"\" on nopass ~* +@all\n"} {
+		os.WriteFile(path, []byte(content), 0600)
+		if response := do(t, conn, reader, "ACL", "LOAD"); !strings.HasPrefix(response, "-ERR") {
+			t.Fatalf("잘못된 파일 %q ACL LOAD 응답: %q", content, response)
+		}
+	}
+	if response := do(t, conn, reader, "ACL", "USERS"); response != "*3\r\n$3\r\nbob\r\n$5\r\ncarol\r\n$7\r\ndefault\r\n" {
+		t.Fatalf("LOAD 실패 뒤 ACL USERS 응답: %q", response)
+	}
+
+	// when & then: 다시 읽으면 파일에 없는 사용자는 사라진다
+	os.WriteFile(path, []byte("user bob on >pw ~cache:* +get\n"), 0600)
+	if response := do(t, conn, reader, "ACL", "LOAD"); response != "+OK\r\n" {
+		t.Fatalf("ACL LOAD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ACL", "USERS"); response != "*2\r\n$3\r\nbob\r\n$7\r\ndefault\r\n" {
+		t.Fatalf("LOAD 뒤 ACL USERS 응답: %q", response)
+	}
+}
+
+func TestACLFileKeepsRequirePass(t *testing.T) {
+	// given: requirepass를 정했고, ACL 파일에는 기본 사용자가 없다
+	path := filepath.Join(t.TempDir(), "users.acl")
+	if err := os.WriteFile(path, []byte("user alice on >pw ~* +@all\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	server, _, result := startServerAt(t, "localhost:6399", "requirepass", "secret", "aclfile", path)
+	defer func() {
+		server.Shutdown(false)
+		waitStopped(t, result)
+	}()
+	conn, reader := dialAddr(t, "localhost:6399")
+
+	// then: 파일을 읽은 뒤에도 기본 사용자에 비밀번호가 있다
+	if response := do(t, conn, reader, "SET", "x", "1"); !strings.HasPrefix(response, "-NOAUTH") {
+		t.Fatalf("인증 전 SET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "AUTH", "secret"); response != "+OK\r\n" {
+		t.Fatalf("AUTH 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "CONFIG", "GET", "requirepass"); response != "*2\r\n$11\r\nrequirepass\r\n$6\r\nsecret\r\n" {
+		t.Fatalf("CONFIG GET requirepass 응답: %q", response)
+	}
+
+	// when: 파일이 기본 사용자를 비밀번호 없이 정한다
+	os.WriteFile(path, []byte("user default on nopass ~* +@all\n"), 0600)
+	if response := do(t, conn, reader, "ACL", "LOAD"); response != "+OK\r\n" {
+		t.Fatalf("ACL LOAD 응답: %q", response)
+	}
+
+	// then: CONFIG GET requirepass도 실제 기본 사용자를 따른다
+	if response := do(t, conn, reader, "CONFIG", "GET", "requirepass"); response != "*2\r\n$11\r\nrequirepass\r\n$0\r\n\r\n" {
+		t.Fatalf("ACL LOAD 뒤 CONFIG GET requirepass 응답: %q", response)
+	}
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"inmemory-db/internal/protocol"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	// 블로킹 대기가 끝난 이유 (context.Cause로 구분한다)
+	errBlockTimeout     = errors.New("timeout")
+	errUnblockedTimeout = errors.New("unblocked via CLIENT UNBLOCK TIMEOUT")
+	errUnblockedError   = errors.New("client unblocked via CLIENT UNBLOCK")
+	errClientClosed     = errors.New("client closed connection")
+)
+
+// BLPOP key [key ...] timeout
+// BRPOP key [key ...] timeout
+func (s *Server) handleBlockingPop(c *client, args []protocol.Value, left bool) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	timeout, err := parseTimeout(args[len(args)-1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	keys := make([]string, 0, len(args)-2)
+	for _, arg := range args[1 : len(args)-1] {
+		keys = append(keys, arg.Str)
+	}
+
+	ctx, done := s.blockContext(c, timeout)
+	key, value, ok, err := c.db.BlockingPop(ctx, keys, left)
+	done()
+
+	switch {
+	case err != nil:
+		c.writer.WriteError(err.Error())
+	case ok:
+		c.writer.WriteArray([]string{key, value})
+	case context.Cause(ctx) == errUnblockedError:
+		c.writer.WriteErrorCode("UNBLOCKED", errUnblockedError.Error())
+	default:
+		c.writer.WriteNullArray()
+	}
+}
+
+// BLMOVE source destination LEFT|RIGHT LEFT|RIGHT timeout
+func (s *Server) handleBLMove(c *client, args []protocol.Value) {
+	if len(args) < 6 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	fromLeft, err := parseDirection(args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	toLeft, err := parseDirection(args[4].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	s.blockingMove(c, args[1].Str, args[2].Str, fromLeft, toLeft, args[5].Str)
+}
+
+// BRPOPLPUSH source destination timeout
+// BLMOVE source destination RIGHT LEFT timeout 와 같다.
+func (s *Server) handleBRPopLPush(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	s.blockingMove(c, args[1].Str, args[2].Str, false, true, args[3].Str)
+}
+
+func (s *Server) blockingMove(c *client, source, destination string, fromLeft, toLeft bool, rawTimeout string) {
+	timeout, err := parseTimeout(rawTimeout)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	ctx, done := s.blockContext(c, timeout)
+	value, ok, err := c.db.BlockingMove(ctx, source, destination, fromLeft, toLeft)
+	done()
+
+	switch {
+	case err != nil:
+		c.writer.WriteError(err.Error())
+	case ok:
+		c.writer.WriteBulkString(value)
+	case context.Cause(ctx) == errUnblockedError:
+		c.writer.WriteErrorCode("UNBLOCKED", errUnblockedError.Error())
+	default:
+		c.writer.WriteNull()
+	}
+}
+
+// CLIENT ID | UNBLOCK client-id [TIMEOUT|ERROR]
+func (s *Server) handleClient(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "ID":
+		c.writer.WriteInteger(int(c.id))
+
+	case "UNBLOCK":
+		if len(args) < 3 {
+			c.writer.WriteError("missing argument")
+			return
+		}
+		id, err := strconv.ParseInt(args[2].Str, 10, 64)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+
+		reason := errUnblockedTimeout
+		if len(args) > 3 {
+			switch strings.ToUpper(args[3].Str) {
+			case "TIMEOUT":
+			case "ERROR":
+				reason = errUnblockedError
+			default:
+				c.writer.WriteError("CLIENT UNBLOCK reason should be TIMEOUT or ERROR")
+				return
+			}
+		}
+
+		if s.unblockClient(id, reason) {
+			c.writer.WriteInteger(1)
+		} else {
+			c.writer.WriteInteger(0)
+		}
+
+	default:
+		c.writer.WriteError("unknown CLIENT subcommand '" + args[1].Str + "'")
+	}
+}
+
+// ID로 클라이언트를 찾아 블로킹 대기를 취소한다.
+// 클라이언트가 블로킹 명령어로 대기 중이었으면 true를 반환한다.
+func (s *Server) unblockClient(id int64, reason error) bool {
+	s.clientsMu.Lock()
+	target, exist := s.clients[id]
+	s.clientsMu.Unlock()
+	if !exist {
+		return false
+	}
+
+	target.blockMu.Lock()
+	defer target.blockMu.Unlock()
+	if target.unblock == nil {
+		return false
+	}
+	target.unblock(reason)
+	target.unblock = nil
+	return true
+}
+
+// 블로킹 명령어가 기다릴 컨텍스트를 만든다. timeout이 0이면 무기한 기다린다.
+// 컨텍스트는 타임아웃, CLIENT UNBLOCK, 클라이언트 연결 종료, 서버 종료 중 하나로 끝난다.
+// 반환된 함수는 대기가 끝난 뒤 반드시 호출해야 한다.
+func (s *Server) blockContext(c *client, timeout time.Duration) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	var timer *time.Timer
+	if timeout > 0 {
+		timer = time.AfterFunc(timeout, func() { cancel(errBlockTimeout) })
+	}
+
+	c.blockMu.Lock()
+	c.unblock = cancel
+	c.blockMu.Unlock()
+	// 종료 중이면 기다리지 않는다. unblock을 등록한 뒤에 확인해야 Shutdown과 엇갈려도 놓치지 않는다
+	if s.closing() {
+		cancel(errServerShutdown)
+	}
+
+	stopWatch := watchDisconnect(c, cancel)
+
+	return ctx, func() {
+		c.blockMu.Lock()
+		c.unblock = nil
+		c.blockMu.Unlock()
+
+		if timer != nil {
+			timer.Stop()
+		}
+		stopWatch()
+		cancel(nil)
+	}
+}
+
+// 대기 중에 클라이언트가 연결을 끊으면 대기를 취소한다.
+// 그러지 않으면 나중에 들어온 요소를 끊긴 연결이 꺼내가서 유실된다.
+// 반환된 함수는 감시를 멈추고 감시 고루틴이 끝날 때까지 기다린다.
+func watchDisconnect(c *client, cancel context.CancelCauseFunc) func() {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// 다음 명령어가 이미 버퍼에 있으면 곧바로 반환된다 (연결이 살아있음)
+		_, err := c.reader.Peek(1)
+		var netErr net.Error
+		if err != nil && !(errors.As(err, &netErr) && netErr.Timeout()) {
+			cancel(errClientClosed)
+		}
+	}()
+
+	return func() {
+		// 읽기 데드라인으로 Peek을 깨운 뒤 원래대로 돌려놓는다
+		c.conn.SetReadDeadline(time.Now())
+		<-done
+		c.conn.SetReadDeadline(time.Time{})
+	}
+}
+
+// 블로킹 명령어의 timeout 인자(초, 소수점 허용)를 파싱한다.
+func parseTimeout(raw string) (time.Duration, error) {
+	seconds, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(seconds) || math.IsInf(seconds, 0) {
+		return 0, errors.New("timeout is not a float or out of range")
+	}
+	if seconds < 0 {
+		return 0, errors.New("timeout is negative")
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// LEFT/RIGHT 인자를 파싱한다. LEFT면 true
+func parseDirection(raw string) (bool, error) {
+	switch strings.ToUpper(raw) {
+	case "LEFT":
+		return true, nil
+	case "RIGHT":
+		return false, nil
+	default:
+		return false, errors.New("syntax error")
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBLPopWakesOnPush(t *testing.T) {
+	// given: 빈 리스트에서 BLPOP 대기
+	conn, reader := dial(t)
+	send(conn, "BLPOP", "bl-queue", "bl-other", "5")
+	time.Sleep(100 * time.Millisecond)
+
+	// when: 다른 연결에서 RPUSH
+	pushConn, pushReader := dial(t)
+	do(t, pushConn, pushReader, "RPUSH", "bl-other", "job")
+
+	// then: [key, value] 배열 응답
+	response := readReply(t, reader)
+	if response != "*2\r\n$8\r\nbl-other\r\n$3\r\njob\r\n" {
+		t.Fatalf("BLPOP 응답: %q", response)
+	}
+}
+
+func TestBLPopTimeout(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when: 0.1초 타임아웃
+	start := time.Now()
+	response := do(t, conn, reader, "BRPOP", "bl-empty", "0.1")
+
+	// then: null 배열
+	if response != "*-1\r\n" {
+		t.Fatalf("BRPOP 응답: %q", response)
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Fatalf("타임아웃 전에 응답했습니다: %v", elapsed)
+	}
+}
+
+func TestBLPopInvalidTimeout(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "BLPOP", "bl-key", "-1"); response != "-ERR timeout is negative\r\n" {
+		t.Fatalf("응답: %q", response)
+	}
+	if response := do(t, conn, reader, "BLPOP", "bl-key", "abc"); response != "-ERR timeout is not a float or out of range\r\n" {
+		t.Fatalf("응답: %q", response)
+	}
+}
+
+func TestBLMoveCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	send(conn, "BLMOVE", "bl-src", "bl-dst", "LEFT", "RIGHT", "5")
+	time.Sleep(100 * time.Millisecond)
+
+	// when
+	pushConn, pushReader := dial(t)
+	do(t, pushConn, pushReader, "RPUSH", "bl-src", "a", "b")
+
+	// then: 이동한 값을 bulk string으로 응답
+	if response := readReply(t, reader); response != "$1\r\na\r\n" {
+		t.Fatalf("BLMOVE 응답: %q", response)
+	}
+	dst := do(t, pushConn, pushReader, "LRANGE", "bl-dst", "0", "-1")
+	if dst != "*1\r\n$1\r\na\r\n" {
+		t.Fatalf("destination: %q", dst)
+	}
+}
+
+func TestClientUnblock(t *testing.T) {
+	// given: 무기한 대기 중인 클라이언트
+	blockedConn, blockedReader := dial(t)
+	idResponse := do(t, blockedConn, blockedReader, "CLIENT", "ID")
+	id := strings.TrimSpace(idResponse[1:])
+	send(blockedConn, "BLPOP", "bl-forever", "0")
+	time.Sleep(100 * time.Millisecond)
+
+	conn, reader := dial(t)
+
+	// when: TIMEOUT으로 해제
+	response := do(t, conn, reader, "CLIENT", "UNBLOCK", id)
+
+	// then: 1을 반환하고, 대기하던 클라이언트는 타임아웃처럼 null을 받는다
+	if response != ":1\r\n" {
+		t.Fatalf("CLIENT UNBLOCK 응답: %q", response)
+	}
+	if blocked := readReply(t, blockedReader); blocked != "*-1\r\n" {
+		t.Fatalf("BLPOP 응답: %q", blocked)
+	}
+
+	// 대기 중이 아닌 클라이언트는 0
+	if response := do(t, conn, reader, "CLIENT", "UNBLOCK", id); response != ":0\r\n" {
+		t.Fatalf("CLIENT UNBLOCK 응답: %q", response)
+	}
+}
+
+func TestClientUnblockWithError(t *testing.T) {
+	// given
+	blockedConn, blockedReader := dial(t)
+	id := strings.TrimSpace(do(t, blockedConn, blockedReader, "CLIENT", "ID")[1:])
+	send(blockedConn, "BLPOP", "bl-forever", "0")
+	time.Sleep(100 * time.Millisecond)
+
+	conn, reader := dial(t)
+
+	// when
+	do(t, conn, reader, "CLIENT", "UNBLOCK", id, "ERROR")
+
+	// then
+	expected := "-UNBLOCKED client unblocked via CLIENT UNBLOCK\r\n"
+	if blocked := readReply(t, blockedReader); blocked != expected {
+		t.Fatalf("BLPOP 응답: %q", blocked)
+	}
+}
+
+func TestBLPopDisconnectedClientDoesNotConsume(t *testing.T) {
+	// given: 대기 중인 클라이언트가 연결을 끊는다
+	blockedConn, _ := dial(t)
+	send(blockedConn, "BLPOP", "bl-lost", "0")
+	time.Sleep(100 * time.Millisecond)
+	blockedConn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	// when
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "bl-lost", "job")
+
+	// then: 끊긴 연결이 요소를 가져가지 않는다
+	if response := do(t, conn, reader, "LPOP", "bl-lost"); response != "$3\r\njob\r\n" {
+		t.Fatalf("LPOP 응답: %q", response)
+	}
+}
@@ -0,0 +1,64 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/pubsub"
+	"inmemory-db/internal/storage"
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// 연결 하나의 상태
+type client struct {
+	id     int64
+	conn   net.Conn
+	reader *bufio.Reader
+	writer *protocol.Writer
+
+	// SELECT로 고른 데이터베이스와 그 번호
+	db      *storage.Store
+	dbIndex int
+
+	// 명령어 응답과 Pub/Sub 메시지 전달 고루틴이 같은 연결에 쓰기 때문에
+	// writer 사용은 mu로 직렬화한다.
+	mu sync.Mutex
+
+	// Pub/Sub 구독 상태. 첫 SUBSCRIBE/PSUBSCRIBE 때 생성된다.
+	sub *pubsub.Subscriber
+
+	// 연결이 끝나면 닫힌다. 연결에 딸린 고루틴들의 종료 신호
+	done chan struct{}
+
+	// 블로킹 명령어로 대기 중일 때 대기를 취소하는 함수 (CLIENT UNBLOCK).
+	// 대기 중이 아니면 nil이다. mu와 별개의 락으로 보호한다.
+	blockMu sync.Mutex
+	unblock context.CancelCauseFunc
+
+	// 인증된 ACL 사용자 이름 (""이면 아직 인증하지 않음). ACL DELUSER가 다른 연결에서 읽는다
+	user atomic.Pointer[string]
+}
+
+func newClient(id int64, conn net.Conn, db *storage.Store) *client {
+	return &client{
+		id:     id,
+		conn:   conn,
+		reader: bufio.NewReader(conn),
+		writer: protocol.NewWriter(conn),
+		db:     db,
+		done:   make(chan struct{}),
+	}
+}
+
+func (c *client) username() string {
+	if name := c.user.Load(); name != nil {
+		return *name
+	}
+	return ""
+}
+
+func (c *client) setUser(name string) {
+	c.user.Store(&name)
+}
@@ -0,0 +1,329 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"strconv"
+	"strings"
+)
+
+// 명령어 분류 (ACL의 +@category / -@category)
+type aclCategory uint32
+
+const (
+	aclKeyspace aclCategory = 1 << iota
+	aclRead
+	aclWrite
+	aclString
+	aclList
+	aclHash
+	aclSet
+	aclSortedSet
+	aclStream
+	aclPubSub
+	aclAdmin
+	aclDangerous
+	aclConnection
+	aclBlocking
+)
+
+// 분류 이름 (ACL CAT 순서). "all"은 모든 명령어다
+var aclCategoryNames = []struct {
+	name     string
+	category aclCategory
+}{
+	{"keyspace", aclKeyspace},
+	{"read", aclRead},
+	{"write", aclWrite},
+	{"string", aclString},
+	{"list", aclList},
+	{"hash", aclHash},
+	{"set", aclSet},
+	{"sortedset", aclSortedSet},
+	{"stream", aclStream},
+	{"pubsub", aclPubSub},
+	{"admin", aclAdmin},
+	{"dangerous", aclDangerous},
+	{"connection", aclConnection},
+	{"blocking", aclBlocking},
+}
+
+// 이름으로 분류를 찾는다. "all"이면 ok=true, category=0.
+func parseACLCategory(name string) (category aclCategory, ok bool) {
+	name = strings.ToLower(name)
+	if name == "all" {
+		return 0, true
+	}
+	for _, entry := range aclCategoryNames {
+		if entry.name == name {
+			return entry.category, true
+		}
+	}
+	return 0, false
+}
+
+// 명령어의 분류와 키 인자 위치. ACL이 명령어와 키 권한을 확인할 때 쓴다.
+type commandSpec struct {
+	categories aclCategory
+	// 키 인자의 위치 (명령어 이름이 0번). firstKey가 0이면 키가 없다.
+	// lastKey가 음수면 끝에서부터 센다 (-1이 마지막 인자)
+	firstKey, lastKey, keyStep int
+	// 위치로 나타낼 수 없는 키 (XREAD의 STREAMS 뒤 등)
+	keys func(args []protocol.Value) []string
+}
+
+func noKeys(categories aclCategory) commandSpec {
+	return commandSpec{categories: categories}
+}
+
+func oneKey(categories aclCategory) commandSpec {
+	return commandSpec{categories: categories, firstKey: 1, lastKey: 1, keyStep: 1}
+}
+
+func keyRange(categories aclCategory, first, last, step int) commandSpec {
+	return commandSpec{categories: categories, firstKey: first, lastKey: last, keyStep: step}
+}
+
+func keysBy(categories aclCategory, keys func(args []protocol.Value) []string) commandSpec {
+	return commandSpec{categories: categories, keys: keys}
+}
+
+// 명령어 인자 중 키를 고른다.
+func (spec commandSpec) keysOf(args []protocol.Value) []string {
+	if spec.keys != nil {
+		return spec.keys(args)
+	}
+	if spec.firstKey == 0 || spec.firstKey >= len(args) {
+		return nil
+	}
+
+	last := spec.lastKey
+	if last < 0 {
+		last += len(args)
+	}
+	last = min(last, len(args)-1)
+
+	keys := make([]string, 0, last-spec.firstKey+1)
+	for i := spec.firstKey; i <= last; i += spec.keyStep {
+		keys = append(keys, args[i].Str)
+	}
+	return keys
+}
+
+// OBJECT ENCODING key, XGROUP CREATE key ... 처럼 하위 명령어 뒤에 키가 오는 경우
+func subcommandKey(args []protocol.Value) []string {
+	if len(args) < 3 {
+		return nil
+	}
+	return []string{args[2].Str}
+}
+
+// MEMORY USAGE key
+func memoryKeys(args []protocol.Value) []string {
+	if len(args) < 3 || strings.ToUpper(args[1].Str) != "USAGE" {
+		return nil
+	}
+	return []string{args[2].Str}
+}
+
+// XREAD/XREADGROUP ... STREAMS key [key ...] id [id ...]
+func streamsKeys(args []protocol.Value) []string {
+	for i, arg := range args {
+		if strings.ToUpper(arg.Str) != "STREAMS" {
+			continue
+		}
+		rest := args[i+1:]
+		keys := make([]string, 0, len(rest)/2)
+		for _, key := range rest[:len(rest)/2] {
+			keys = append(keys, key.Str)
+		}
+		return keys
+	}
+	return nil
+}
+
+// numkeys 인자 뒤에 키가 numkeys개 오는 명령어. destination이 있으면 1번 인자도 키다.
+// ZUNIONSTORE destination numkeys key [key ...], SINTERCARD numkeys key [key ...]
+func numKeysAt(index int, destination bool) func(args []protocol.Value) []string {
+	return func(args []protocol.Value) []string {
+		var keys []string
+		if destination && len(args) > 1 {
+			keys = append(keys, args[1].Str)
+		}
+		if index >= len(args) {
+			return keys
+		}
+		count, err := strconv.Atoi(args[index].Str)
+		if err != nil || count < 0 {
+			return keys
+		}
+		for _, key := range args[index+1 : min(index+1+count, len(args))] {
+			keys = append(keys, key.Str)
+		}
+		return keys
+	}
+}
+
+// 명령어 표. "ACL|WHOAMI"처럼 하위 명령어마다 분류가 다르면 따로 둔다.
+var commandSpecs = map[string]commandSpec{
+	"PING":     noKeys(aclConnection),
+	"ECHO":     noKeys(aclConnection),
+	"SELECT":   noKeys(aclConnection),
+	"CLIENT":   noKeys(aclConnection),
+	"AUTH":     noKeys(aclConnection),
+	"SHUTDOWN": noKeys(aclAdmin | aclDangerous),
+	"SAVE":     noKeys(aclAdmin | aclDangerous),
+	"CONFIG":   noKeys(aclAdmin | aclDangerous),
+	"DEBUG":    noKeys(aclAdmin | aclDangerous),
+	"INFO":     noKeys(aclDangerous),
+	"MEMORY":   keysBy(aclRead, memoryKeys),
+
+	"ACL":        noKeys(aclAdmin | aclDangerous),
+	"ACL|WHOAMI": noKeys(aclConnection),
+	"ACL|CAT":    noKeys(aclConnection),
+
+	// 키 공간
+	"DEL":         keyRange(aclKeyspace|aclWrite, 1, -1, 1),
+	"UNLINK":      keyRange(aclKeyspace|aclWrite, 1, -1, 1),
+	"EXISTS":      keyRange(aclKeyspace|aclRead, 1, -1, 1),
+	"TOUCH":       keyRange(aclKeyspace|aclRead, 1, -1, 1),
+	"TYPE":        oneKey(aclKeyspace | aclRead),
+	"RENAME":      keyRange(aclKeyspace|aclWrite, 1, 2, 1),
+	"RENAMENX":    keyRange(aclKeyspace|aclWrite, 1, 2, 1),
+	"COPY":        keyRange(aclKeyspace|aclWrite, 1, 2, 1),
+	"MOVE":        oneKey(aclKeyspace | aclWrite),
+	"EXPIRE":      oneKey(aclKeyspace | aclWrite),
+	"PEXPIRE":     oneKey(aclKeyspace | aclWrite),
+	"EXPIREAT":    oneKey(aclKeyspace | aclWrite),
+	"PEXPIREAT":   oneKey(aclKeyspace | aclWrite),
+	"PERSIST":     oneKey(aclKeyspace | aclWrite),
+	"TTL":         oneKey(aclKeyspace | aclRead),
+	"PTTL":        oneKey(aclKeyspace | aclRead),
+	"EXPIRETIME":  oneKey(aclKeyspace | aclRead),
+	"PEXPIRETIME": oneKey(aclKeyspace | aclRead),
+	"OBJECT":      keysBy(aclKeyspace|aclRead, subcommandKey),
+	"DBSIZE":      noKeys(aclKeyspace | aclRead),
+	"RANDOMKEY":   noKeys(aclKeyspace | aclRead),
+	"SCAN":        noKeys(aclKeyspace | aclRead),
+	"KEYS":        noKeys(aclKeyspace | aclRead | aclDangerous),
+	"SWAPDB":      noKeys(aclKeyspace | aclWrite | aclDangerous),
+	"FLUSHDB":     noKeys(aclKeyspace | aclWrite | aclDangerous),
+	"FLUSHALL":    noKeys(aclKeyspace | aclWrite | aclDangerous),
+
+	// 문자열
+	"GET":         oneKey(aclString | aclRead),
+	"SET":         oneKey(aclString | aclWrite),
+	"SETNX":       oneKey(aclString | aclWrite),
+	"GETSET":      oneKey(aclString | aclWrite),
+	"GETDEL":      oneKey(aclString | aclWrite),
+	"GETEX":       oneKey(aclString | aclWrite),
+	"INCR":        oneKey(aclString | aclWrite),
+	"DECR":        oneKey(aclString | aclWrite),
+	"INCRBY":      oneKey(aclString | aclWrite),
+	"DECRBY":      oneKey(aclString | aclWrite),
+	"INCRBYFLOAT": oneKey(aclString | aclWrite),
+	"APPEND":      oneKey(aclString | aclWrite),
+	"SETRANGE":    oneKey(aclString | aclWrite),
+	"STRLEN":      oneKey(aclString | aclRead),
+	"GETRANGE":    oneKey(aclString | aclRead),
+	"MGET":        keyRange(aclString|aclRead, 1, -1, 1),
+	"MSET":        keyRange(aclString|aclWrite, 1, -1, 2),
+	"MSETNX":      keyRange(aclString|aclWrite, 1, -1, 2),
+
+	// 리스트
+	"LPUSH":      oneKey(aclList | aclWrite),
+	"RPUSH":      oneKey(aclList | aclWrite),
+	"LPUSHX":     oneKey(aclList | aclWrite),
+	"RPUSHX":     oneKey(aclList | aclWrite),
+	"LPOP":       oneKey(aclList | aclWrite),
+	"RPOP":       oneKey(aclList | aclWrite),
+	"LSET":       oneKey(aclList | aclWrite),
+	"LINSERT":    oneKey(aclList | aclWrite),
+	"LREM":       oneKey(aclList | aclWrite),
+	"LTRIM":      oneKey(aclList | aclWrite),
+	"LMOVE":      keyRange(aclList|aclWrite, 1, 2, 1),
+	"RPOPLPUSH":  keyRange(aclList|aclWrite, 1, 2, 1),
+	"LLEN":       oneKey(aclList | aclRead),
+	"LINDEX":     oneKey(aclList | aclRead),
+	"LRANGE":     oneKey(aclList | aclRead),
+	"LPOS":       oneKey(aclList | aclRead),
+	"BLPOP":      keyRange(aclList|aclWrite|aclBlocking, 1, -2, 1),
+	"BRPOP":      keyRange(aclList|aclWrite|aclBlocking, 1, -2, 1),
+	"BLMOVE":     keyRange(aclList|aclWrite|aclBlocking, 1, 2, 1),
+	"BRPOPLPUSH": keyRange(aclList|aclWrite|aclBlocking, 1, 2, 1),
+
+	// 해시
+	"HSET":         oneKey(aclHash | aclWrite),
+	"HSETNX":       oneKey(aclHash | aclWrite),
+	"HDEL":         oneKey(aclHash | aclWrite),
+	"HINCRBY":      oneKey(aclHash | aclWrite),
+	"HINCRBYFLOAT": oneKey(aclHash | aclWrite),
+	"HGET":         oneKey(aclHash | aclRead),
+	"HMGET":        oneKey(aclHash | aclRead),
+	"HEXISTS":      oneKey(aclHash | aclRead),
+	"HLEN":         oneKey(aclHash | aclRead),
+	"HSTRLEN":      oneKey(aclHash | aclRead),
+	"HKEYS":        oneKey(aclHash | aclRead),
+	"HVALS":        oneKey(aclHash | aclRead),
+	"HGETALL":      oneKey(aclHash | aclRead),
+	"HRANDFIELD":   oneKey(aclHash | aclRead),
+	"HSCAN":        oneKey(aclHash | aclRead),
+
+	// 집합
+	"SADD":        oneKey(aclSet | aclWrite),
+	"SREM":        oneKey(aclSet | aclWrite),
+	"SPOP":        oneKey(aclSet | aclWrite),
+	"SMOVE":       keyRange(aclSet|aclWrite, 1, 2, 1),
+	"SINTERSTORE": keyRange(aclSet|aclWrite, 1, -1, 1),
+	"SUNIONSTORE": keyRange(aclSet|aclWrite, 1, -1, 1),
+	"SDIFFSTORE":  keyRange(aclSet|aclWrite, 1, -1, 1),
+	"SISMEMBER":   oneKey(aclSet | aclRead),
+	"SMISMEMBER":  oneKey(aclSet | aclRead),
+	"SCARD":       oneKey(aclSet | aclRead),
+	"SMEMBERS":    oneKey(aclSet | aclRead),
+	"SRANDMEMBER": oneKey(aclSet | aclRead),
+	"SSCAN":       oneKey(aclSet | aclRead),
+	"SINTER":      keyRange(aclSet|aclRead, 1, -1, 1),
+	"SUNION":      keyRange(aclSet|aclRead, 1, -1, 1),
+	"SDIFF":       keyRange(aclSet|aclRead, 1, -1, 1),
+	"SINTERCARD":  keysBy(aclSet|aclRead, numKeysAt(1, false)),
+
+	// 정렬된 집합
+	"ZADD":        oneKey(aclSortedSet | aclWrite),
+	"ZINCRBY":     oneKey(aclSortedSet | aclWrite),
+	"ZREM":        oneKey(aclSortedSet | aclWrite),
+	"ZPOPMIN":     oneKey(aclSortedSet | aclWrite),
+	"ZPOPMAX":     oneKey(aclSortedSet | aclWrite),
+	"ZUNIONSTORE": keysBy(aclSortedSet|aclWrite, numKeysAt(2, true)),
+	"ZINTERSTORE": keysBy(aclSortedSet|aclWrite, numKeysAt(2, true)),
+	"ZCARD":       oneKey(aclSortedSet | aclRead),
+	"ZSCORE":      oneKey(aclSortedSet | aclRead),
+	"ZRANK":       oneKey(aclSortedSet | aclRead),
+	"ZREVRANK":    oneKey(aclSortedSet | aclRead),
+	"ZRANGE":      oneKey(aclSortedSet | aclRead),
+	"ZCOUNT":      oneKey(aclSortedSet | aclRead),
+	"ZSCAN":       oneKey(aclSortedSet | aclRead),
+	"BZPOPMIN":    keyRange(aclSortedSet|aclWrite|aclBlocking, 1, -2, 1),
+	"BZPOPMAX":    keyRange(aclSortedSet|aclWrite|aclBlocking, 1, -2, 1),
+
+	// 스트림
+	"XADD":       oneKey(aclStream | aclWrite),
+	"XACK":       oneKey(aclStream | aclWrite),
+	"XCLAIM":     oneKey(aclStream | aclWrite),
+	"XAUTOCLAIM": oneKey(aclStream | aclWrite),
+	"XGROUP":     keysBy(aclStream|aclWrite, subcommandKey),
+	"XLEN":       oneKey(aclStream | aclRead),
+	"XRANGE":     oneKey(aclStream | aclRead),
+	"XREVRANGE":  oneKey(aclStream | aclRead),
+	"XPENDING":   oneKey(aclStream | aclRead),
+	"XINFO":      keysBy(aclStream|aclRead, subcommandKey),
+	"XREAD":      keysBy(aclStream|aclRead|aclBlocking, streamsKeys),
+	"XREADGROUP": keysBy(aclStream|aclWrite|aclBlocking, streamsKeys),
+
+	// Pub/Sub
+	"SUBSCRIBE":    noKeys(aclPubSub),
+	"UNSUBSCRIBE":  noKeys(aclPubSub),
+	"PSUBSCRIBE":   noKeys(aclPubSub),
+	"PUNSUBSCRIBE": noKeys(aclPubSub),
+	"PUBLISH":      noKeys(aclPubSub),
+	"PUBSUB":       noKeys(aclPubSub),
+}
@@ -0,0 +1,497 @@
+package server
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"inmemory-db/internal/glob"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/pubsub"
+	"inmemory-db/internal/storage"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 조회/변경할 수 있는 설정 항목
+type configParam struct {
+	get func(s *Server) string
+	set func(s *Server, value string) error
+	// 기본값. New가 이 값으로 설정하고, CONFIG REWRITE는 설정 파일에 없는 항목 중 기본값과 다른 것만 덧붙인다
+	defaultValue string
+	// 서버를 시작한 뒤에는 바꿀 수 없는 항목 (설정 파일과 명령줄 옵션으로만 정한다)
+	immutable bool
+	// 명령줄 옵션 설명
+	usage string
+}
+
+var configParams = map[string]configParam{
+	"bind": {
+		get: func(s *Server) string {
+			return s.bind
+		},
+		set: func(s *Server, value string) error {
+			s.bind = value
+			return nil
+		},
+		immutable: true,
+		usage:     "수신할 주소 (비우면 모든 인터페이스)",
+	},
+	"port": {
+		get: func(s *Server) string {
+			return strconv.Itoa(s.port)
+		},
+		set: func(s *Server, value string) error {
+			port, err := strconv.Atoi(value)
+			if err != nil || port < 0 || port > 65535 {
+				return errors.New("argument must be between 0 and 65535 inclusive")
+			}
+			s.port = port
+			return nil
+		},
+		defaultValue: strconv.Itoa(defaultPort),
+		immutable:    true,
+		usage:        "수신할 TCP 포트",
+	},
+	"debug-addr": {
+		get: func(s *Server) string {
+			return s.debugAddr
+		},
+		set: func(s *Server, value string) error {
+			s.debugAddr = value
+			return nil
+		},
+		defaultValue: defaultDebugAddr,
+		immutable:    true,
+		usage:        "pprof 디버그 서버 주소 (비우면 띄우지 않는다)",
+	},
+	"dir": {
+		get: func(s *Server) string {
+			s.configMu.RLock()
+			defer s.configMu.RUnlock()
+			return s.dir
+		},
+		set: func(s *Server, value string) error {
+			if info, err := os.Stat(value); err != nil || !info.IsDir() {
+				return errors.New("No such file or directory")
+			}
+			s.configMu.Lock()
+			defer s.configMu.Unlock()
+			s.dir = value
+			return nil
+		},
+		defaultValue: ".",
+		usage:        "스냅샷 파일을 둘 디렉터리",
+	},
+	"dbfilename": {
+		get: func(s *Server) string {
+			s.configMu.RLock()
+			defer s.configMu.RUnlock()
+			return s.dbfilename
+		},
+		set: func(s *Server, value string) error {
+			if value == "" || filepath.Base(value) != value {
+				return errors.New("dbfilename can't be a path, just a filename")
+			}
+			s.configMu.Lock()
+			defer s.configMu.Unlock()
+			s.dbfilename = value
+			return nil
+		},
+		defaultValue: defaultDBFilename,
+		usage:        "스냅샷 파일 이름",
+	},
+	"timeout": {
+		get: func(s *Server) string {
+			return strconv.FormatInt(s.timeout.Load(), 10)
+		},
+		set: func(s *Server, value string) error {
+			seconds, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || seconds < 0 || seconds > math.MaxInt32 {
+				return errors.New("argument must be between 0 and 2147483647 inclusive")
+			}
+			s.timeout.Store(seconds)
+			return nil
+		},
+		defaultValue: "0",
+		usage:        "이 시간(초) 동안 명령어가 없는 연결을 끊는다 (0이면 끊지 않는다)",
+	},
+	"tcp-keepalive": {
+		get: func(s *Server) string {
+			return strconv.FormatInt(s.tcpKeepalive.Load(), 10)
+		},
+		set: func(s *Server, value string) error {
+			seconds, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || seconds < 0 || seconds > math.MaxInt32 {
+				return errors.New("argument must be between 0 and 2147483647 inclusive")
+			}
+			s.tcpKeepalive.Store(seconds)
+			return nil
+		},
+		defaultValue: "300",
+		usage:        "새 연결의 TCP keepalive 주기(초, 0이면 끄기)",
+	},
+	"shutdown-timeout": {
+		get: func(s *Server) string {
+			return strconv.FormatInt(s.shutdownTimeout.Load(), 10)
+		},
+		set: func(s *Server, value string) error {
+			seconds, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || seconds < 0 || seconds > math.MaxInt32 {
+				return errors.New("argument must be between 0 and 2147483647 inclusive")
+			}
+			s.shutdownTimeout.Store(seconds)
+			return nil
+		},
+		defaultValue: "10",
+		usage:        "종료할 때 실행 중인 명령어가 끝나길 기다리는 시간(초). 지나면 남은 연결을 끊는다",
+	},
+	"requirepass": {
+		get: func(s *Server) string {
+			s.aclMu.Lock()
+			defer s.aclMu.Unlock()
+			// ACL SETUSER나 ACL 파일로 기본 사용자의 비밀번호가 바뀌었으면 더는 requirepass가 아니다
+			user := s.aclUserMap()[defaultUserName]
+			if s.requirepass == "" || user == nil || user.nopass || !user.checkPassword(s.requirepass) {
+				return ""
+			}
+			return s.requirepass
+		},
+		set: func(s *Server, value string) error {
+			// 기본 사용자의 비밀번호를 바꾼다. 비우면 비밀번호 없이 접속할 수 있다
+			return s.updateACLUser(defaultUserName, func(user *aclUser) error {
+				if value == "" {
+					user.apply("nopass")
+				} else {
+					user.apply("resetpass")
+					user.apply(">" + value)
+				}
+				s.requirepass = value
+				return nil
+			})
+		},
+		usage: "기본 사용자의 비밀번호 (비우면 비밀번호 없이 접속한다)",
+	},
+	"protected-mode": {
+		get: func(s *Server) string {
+			return formatYesNo(s.protectedMode.Load())
+		},
+		set: func(s *Server, value string) error {
+			enabled, err := parseYesNo(value)
+			if err != nil {
+				return err
+			}
+			s.protectedMode.Store(enabled)
+			return nil
+		},
+		defaultValue: "yes",
+		usage:        "기본 사용자에 비밀번호가 없고 bind를 정하지 않았으면 루프백 밖의 연결을 거부한다",
+	},
+	"enable-debug-command": {
+		get: func(s *Server) string {
+			return s.debugCommand
+		},
+		set: func(s *Server, value string) error {
+			switch value = strings.ToLower(value); value {
+			case "yes", "no", "local":
+				s.debugCommand = value
+				return nil
+			}
+			return errors.New("argument must be 'yes', 'no' or 'local'")
+		},
+		defaultValue: "no",
+		immutable:    true,
+		usage:        "DEBUG 명령어(서버 시계 조작)를 허용할지. local이면 루프백 연결에서만 허용한다",
+	},
+	"aclfile": {
+		get: func(s *Server) string {
+			return s.aclFile
+		},
+		set: func(s *Server, value string) error {
+			s.aclFile = value
+			return nil
+		},
+		immutable: true,
+		usage:     "ACL 사용자 파일. 시작할 때 읽고, ACL LOAD/SAVE가 읽고 쓴다",
+	},
+	"acllog-max-len": {
+		get: func(s *Server) string {
+			return strconv.FormatInt(s.aclLogMaxLen.Load(), 10)
+		},
+		set: func(s *Server, value string) error {
+			length, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || length < 0 || length > math.MaxInt32 {
+				return errors.New("argument must be between 0 and 2147483647 inclusive")
+			}
+			s.aclLogMaxLen.Store(length)
+			s.aclLogMu.Lock()
+			s.trimACLLog()
+			s.aclLogMu.Unlock()
+			return nil
+		},
+		defaultValue: "128",
+		usage:        "ACL LOG에 남길 최대 항목 수",
+	},
+	"notify-keyspace-events": {
+		get: func(s *Server) string {
+			return pubsub.FormatKeyspaceEvents(s.notifier.Flags())
+		},
+		set: func(s *Server, value string) error {
+			flags, err := pubsub.ParseKeyspaceEvents(value)
+			if err != nil {
+				return err
+			}
+			s.notifier.SetFlags(flags)
+			return nil
+		},
+		usage: "발행할 키스페이스 이벤트 종류 (예: KEA)",
+	},
+	"maxmemory": {
+		get: func(s *Server) string {
+			return strconv.FormatInt(s.maxmemory.Load(), 10)
+		},
+		set: func(s *Server, value string) error {
+			bytes, err := parseMemory(value)
+			if err != nil {
+				return err
+			}
+			s.maxmemory.Store(bytes)
+			return nil
+		},
+		defaultValue: "0",
+		usage:        "메모리 한도 (예: 100mb, 0이면 무제한)",
+	},
+	"maxmemory-policy": {
+		get: func(s *Server) string {
+			return storage.EvictionPolicy(s.maxmemoryPolicy.Load()).String()
+		},
+		set: func(s *Server, value string) error {
+			policy, ok := storage.ParseEvictionPolicy(strings.ToLower(value))
+			if !ok {
+				return errors.New("argument(s) must be one of the following: volatile-lru, allkeys-lru, volatile-lfu, allkeys-lfu, volatile-random, allkeys-random, volatile-ttl, noeviction")
+			}
+			s.maxmemoryPolicy.Store(int32(policy))
+			return nil
+		},
+		defaultValue: storage.NoEviction.String(),
+		usage:        "메모리 한도를 넘었을 때 축출 정책",
+	},
+	"hz": {
+		get: func(s *Server) string {
+			return strconv.Itoa(s.dbs[0].ExpiryHz())
+		},
+		set: func(s *Server, value string) error {
+			hz, err := strconv.Atoi(value)
+			if err != nil || hz < 0 {
+				return errors.New("argument couldn't be parsed into an integer")
+			}
+			// 범위를 벗어난 값은 가까운 경계값으로 맞춘다 (Redis와 같다)
+			for _, db := range s.dbs {
+				db.SetExpiryHz(hz)
+			}
+			return nil
+		},
+		defaultValue: strconv.Itoa(storage.DefaultExpiryHz),
+		usage:        "백그라운드 만료 루프의 초당 실행 횟수",
+	},
+	"maxmemory-samples": {
+		get: func(s *Server) string {
+			return strconv.Itoa(int(s.maxmemorySamples.Load()))
+		},
+		set: func(s *Server, value string) error {
+			samples, err := strconv.Atoi(value)
+			if err != nil || samples < 1 || samples > 64 {
+				return errors.New("argument must be between 1 and 64 inclusive")
+			}
+			s.maxmemorySamples.Store(int32(samples))
+			return nil
+		},
+		defaultValue: strconv.Itoa(defaultMaxmemorySamples),
+		usage:        "축출할 키를 고를 때 데이터베이스마다 뽑는 키 수",
+	},
+}
+
+// 설정 항목 하나를 바꾼다. 서버를 시작한 뒤에는 시작할 때만 정할 수 있는 항목(bind, port 등)을 바꿀 수 없다.
+func (s *Server) SetConfig(name, value string) error {
+	param, exist := configParams[strings.ToLower(name)]
+	if !exist {
+		return fmt.Errorf("unknown config parameter '%s'", name)
+	}
+	if param.immutable && s.started.Load() {
+		return errors.New("can't set immutable config")
+	}
+	return param.set(s, value)
+}
+
+// 이름과 값의 쌍을 차례로 적용한다. 하나라도 실패하면 앞서 적용한 항목을 이전 값으로 되돌리고
+// 실패한 항목의 이름과 에러를 반환한다 (CONFIG SET은 모두 적용되거나 하나도 적용되지 않는다).
+func (s *Server) setConfigs(pairs []protocol.Value) (string, error) {
+	s.configSetMu.Lock()
+	defer s.configSetMu.Unlock()
+
+	previous := make([]string, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		old, _ := s.GetConfig(pairs[i].Str)
+		if err := s.SetConfig(pairs[i].Str, pairs[i+1].Str); err != nil {
+			for j := len(previous) - 1; j >= 0; j-- {
+				s.SetConfig(pairs[j*2].Str, previous[j])
+			}
+			return pairs[i].Str, err
+		}
+		previous = append(previous, old)
+	}
+	return "", nil
+}
+
+// 설정 항목의 현재 값
+func (s *Server) GetConfig(name string) (string, bool) {
+	param, exist := configParams[strings.ToLower(name)]
+	if !exist {
+		return "", false
+	}
+	return param.get(s), true
+}
+
+// 설정 항목마다 같은 이름의 명령줄 옵션(-port 6380 등)을 fs에 등록한다.
+// 반환된 함수는 명령줄에서 준 값을 준 순서대로 서버에 적용한다.
+// 설정 파일을 읽은 뒤에 호출하면 파일의 값을 명령줄 값으로 덮어쓴다.
+func RegisterConfigFlags(fs *flag.FlagSet) func(s *Server) error {
+	var names, values []string
+	for _, name := range configNames() {
+		fs.Func(name, configParams[name].usage, func(value string) error {
+			names = append(names, name)
+			values = append(values, value)
+			return nil
+		})
+	}
+
+	return func(s *Server) error {
+		for i, name := range names {
+			if err := s.SetConfig(name, values[i]); err != nil {
+				return fmt.Errorf("-%s %q: %w", name, values[i], err)
+			}
+		}
+		return nil
+	}
+}
+
+// yes/no 설정값
+func parseYesNo(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes":
+		return true, nil
+	case "no":
+		return false, nil
+	default:
+		return false, errors.New("argument must be 'yes' or 'no'")
+	}
+}
+
+func formatYesNo(value bool) string {
+	if value {
+		return "yes"
+	}
+	return "no"
+}
+
+// 모든 설정 항목 이름 (정렬)
+func configNames() []string {
+	names := make([]string, 0, len(configParams))
+	for name := range configParams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 모든 설정 항목을 기본값으로 정한다 (New).
+func (s *Server) applyDefaultConfig() {
+	for _, name := range configNames() {
+		if err := configParams[name].set(s, configParams[name].defaultValue); err != nil {
+			panic("잘못된 설정 기본값 " + name + ": " + err.Error())
+		}
+	}
+}
+
+// CONFIG GET pattern [pattern ...] | SET parameter value [parameter value ...] | REWRITE | RESETSTAT
+func (s *Server) handleConfig(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "GET":
+		if len(args) < 3 {
+			c.writer.WriteError("missing argument")
+			return
+		}
+
+		// 여러 패턴에 중복으로 매칭돼도 한 번만 응답한다
+		matched := make(map[string]bool)
+		for _, arg := range args[2:] {
+			pattern := strings.ToLower(arg.Str)
+			for name := range configParams {
+				if glob.Match(pattern, name) {
+					matched[name] = true
+				}
+			}
+		}
+
+		names := make([]string, 0, len(matched))
+		for name := range matched {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		// [name1, value1, name2, value2, ...]
+		result := make([]string, 0, len(names)*2)
+		for _, name := range names {
+			result = append(result, name, configParams[name].get(s))
+		}
+		c.writer.WriteArray(result)
+
+	case "SET":
+		if len(args) < 4 || len(args)%2 != 0 {
+			c.writer.WriteError("wrong number of arguments for 'config|set' command")
+			return
+		}
+
+		// 하나라도 없거나 두 번 나온 항목이면 아무것도 바꾸지 않는다
+		seen := make(map[string]bool)
+		for i := 2; i < len(args); i += 2 {
+			name := strings.ToLower(args[i].Str)
+			if _, exist := configParams[name]; !exist {
+				c.writer.WriteError("Unknown option or number of arguments for CONFIG SET - '" + args[i].Str + "'")
+				return
+			}
+			if seen[name] {
+				c.writer.WriteError("CONFIG SET failed (possibly related to argument '" + args[i].Str + "') - duplicate parameter")
+				return
+			}
+			seen[name] = true
+		}
+		if name, err := s.setConfigs(args[2:]); err != nil {
+			c.writer.WriteError("CONFIG SET failed (possibly related to argument '" + name + "') - " + err.Error())
+			return
+		}
+		c.writer.WriteSimpleString("OK")
+
+	case "REWRITE":
+		if err := s.rewriteConfig(); err != nil {
+			c.writer.WriteError(err.Error())
+			return
+		}
+		c.writer.WriteSimpleString("OK")
+
+	case "RESETSTAT":
+		s.resetStats()
+		c.writer.WriteSimpleString("OK")
+
+	default:
+		c.writer.WriteError("unknown CONFIG subcommand '" + args[1].Str + "'")
+	}
+}
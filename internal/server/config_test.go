@@ -0,0 +1,248 @@
+package server
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigSetAndGet(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	defer do(t, conn, reader, "CONFIG", "SET", "notify-keyspace-events", "")
+
+	// when
+	response := do(t, conn, reader, "CONFIG", "SET", "notify-keyspace-events", "KEA")
+
+	// then: 모든 클래스가 켜지면 "A"로 줄여서 표시된다
+	if response != "+OK\r\n" {
+		t.Fatalf("CONFIG SET 응답: %q", response)
+	}
+	get := do(t, conn, reader, "CONFIG", "GET", "notify-*")
+	if get != "*2\r\n$22\r\nnotify-keyspace-events\r\n$3\r\nAKE\r\n" {
+		t.Fatalf("CONFIG GET 응답: %q", get)
+	}
+}
+
+func TestConfigSet_InvalidValue(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when
+	response := do(t, conn, reader, "CONFIG", "SET", "notify-keyspace-events", "KQ")
+
+	// then
+	expected := "-ERR CONFIG SET failed (possibly related to argument 'notify-keyspace-events') - Invalid event class character. Use 'Ag$lshzxeKEtmn'.\r\n"
+	if response != expected {
+		t.Fatalf("응답: %q", response)
+	}
+}
+
+func TestConfigSet_MultipleIsAtomic(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	before := do(t, conn, reader, "CONFIG", "GET", "maxmemory-samples")
+
+	// when: 뒤의 항목이 잘못됐다
+	response := do(t, conn, reader, "CONFIG", "SET", "maxmemory-samples", "7", "notify-keyspace-events", "KQ")
+
+	// then: 앞의 항목도 적용되지 않는다
+	if !strings.HasPrefix(response, "-ERR CONFIG SET failed (possibly related to argument 'notify-keyspace-events')") {
+		t.Fatalf("응답: %q", response)
+	}
+	if after := do(t, conn, reader, "CONFIG", "GET", "maxmemory-samples"); after != before {
+		t.Fatalf("되돌리지 않았습니다: %q -> %q", before, after)
+	}
+
+	// when & then: 같은 항목을 두 번 주면 아무것도 바꾸지 않는다
+	response = do(t, conn, reader, "CONFIG", "SET", "maxmemory-samples", "7", "MAXMEMORY-SAMPLES", "8")
+	if !strings.HasSuffix(response, "duplicate parameter\r\n") {
+		t.Fatalf("중복 항목 응답: %q", response)
+	}
+	if after := do(t, conn, reader, "CONFIG", "GET", "maxmemory-samples"); after != before {
+		t.Fatalf("중복 항목인데 바뀌었습니다: %q -> %q", before, after)
+	}
+}
+
+func TestKeyspaceNotificationOverConnection(t *testing.T) {
+	// given: keyevent 알림 활성화 후 구독
+	conn, reader := dial(t)
+	do(t, conn, reader, "CONFIG", "SET", "notify-keyspace-events", "KEg$")
+	defer do(t, conn, reader, "CONFIG", "SET", "notify-keyspace-events", "")
+
+	subConn, subReader := dial(t)
+	do(t, subConn, subReader, "SUBSCRIBE", "__keyspace@0__:ks-key", "__keyevent@0__:del")
+	readReply(t, subReader) // 두 번째 채널의 subscribe 응답
+
+	// when
+	do(t, conn, reader, "SET", "ks-key", "v")
+	do(t, conn, reader, "DEL", "ks-key")
+
+	// then: SET → keyspace 채널, DEL → keyspace/keyevent 채널
+	set := readReply(t, subReader)
+	if set != "*3\r\n$7\r\nmessage\r\n$21\r\n__keyspace@0__:ks-key\r\n$3\r\nset\r\n" {
+		t.Fatalf("SET 알림: %q", set)
+	}
+	del := readReply(t, subReader)
+	if del != "*3\r\n$7\r\nmessage\r\n$21\r\n__keyspace@0__:ks-key\r\n$3\r\ndel\r\n" {
+		t.Fatalf("DEL keyspace 알림: %q", del)
+	}
+	delEvent := readReply(t, subReader)
+	if delEvent != "*3\r\n$7\r\nmessage\r\n$18\r\n__keyevent@0__:del\r\n$6\r\nks-key\r\n" {
+		t.Fatalf("DEL keyevent 알림: %q", delEvent)
+	}
+}
+
+func TestConfigImmutableAndRewriteWithoutFile(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then: 시작한 뒤에는 port를 바꿀 수 없다
+	if response := do(t, conn, reader, "CONFIG", "SET", "port", "7000"); response != "-ERR CONFIG SET failed (possibly related to argument 'port') - can't set immutable config\r\n" {
+		t.Fatalf("CONFIG SET port 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "CONFIG", "GET", "port", "dbfile*"); response != "*4\r\n$10\r\ndbfilename\r\n$8\r\ndump.rdb\r\n$4\r\nport\r\n$4\r\n6379\r\n" {
+		t.Fatalf("CONFIG GET 응답: %q", response)
+	}
+	// 하나라도 모르는 항목이면 아무것도 바꾸지 않는다
+	if response := do(t, conn, reader, "CONFIG", "SET", "timeout", "5", "nope", "1"); response != "-ERR Unknown option or number of arguments for CONFIG SET - 'nope'\r\n" {
+		t.Fatalf("CONFIG SET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "CONFIG", "GET", "timeout"); response != "*2\r\n$7\r\ntimeout\r\n$1\r\n0\r\n" {
+		t.Fatalf("CONFIG GET timeout 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "CONFIG", "REWRITE"); response != "-ERR The server is running without a config file\r\n" {
+		t.Fatalf("CONFIG REWRITE 응답: %q", response)
+	}
+}
+
+func TestConfigResetStat(t *testing.T) {
+	// given: 만료로 지운 키가 있다
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "resetstat-key", "v", "PX", "1")
+	time.Sleep(10 * time.Millisecond)
+	do(t, conn, reader, "DEL", "resetstat-key")
+
+	// when
+	if response := do(t, conn, reader, "CONFIG", "RESETSTAT"); response != "+OK\r\n" {
+		t.Fatalf("CONFIG RESETSTAT 응답: %q", response)
+	}
+
+	// then
+	stats := do(t, conn, reader, "INFO", "stats")
+	if expired := infoField(t, stats, "expired_keys"); expired != "0" {
+		t.Fatalf("expired_keys: %s, expected: 0", expired)
+	}
+	if evicted := infoField(t, stats, "evicted_keys"); evicted != "0" {
+		t.Fatalf("evicted_keys: %s, expected: 0", evicted)
+	}
+}
+
+func TestIdleTimeoutClosesConnection(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	defer func() {
+		admin, adminReader := dial(t)
+		do(t, admin, adminReader, "CONFIG", "SET", "timeout", "0")
+	}()
+	do(t, conn, reader, "CONFIG", "SET", "timeout", "1")
+
+	// when: 다음 명령어 없이 타임아웃보다 오래 기다린다
+	idle, idleReader := dial(t)
+	do(t, idle, idleReader, "PING")
+	time.Sleep(1500 * time.Millisecond)
+
+	// then: 서버가 연결을 끊었다
+	idle.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := idleReader.ReadByte(); err == nil {
+		t.Fatal("유휴 연결이 끊기지 않았습니다")
+	}
+}
+
+func TestSplitConfigArgs(t *testing.T) {
+	// given
+	cases := map[string][]string{
+		`port 6380`:                 {"port", "6380"},
+		`  dir   "/tmp/my data"  `:  {"dir", "/tmp/my data"},
+		`notify-keyspace-events ""`: {"notify-keyspace-events", ""},
+		`bind 'a\'b' "c\"d\n"`:      {"bind", "a'b", "c\"d\n"},
+	}
+
+	for line, expected := range cases {
+		// when
+		args, err := splitConfigArgs(line)
+
+		// then
+		if err != nil || strings.Join(args, "|") != strings.Join(expected, "|") {
+			t.Fatalf("%q: %q, %v, expected: %q", line, args, err, expected)
+		}
+	}
+	if _, err := splitConfigArgs(`dir "/tmp`); err == nil {
+		t.Fatal("닫히지 않은 따옴표가 에러가 아닙니다")
+	}
+}
+
+func TestLoadConfigFileAndRewrite(t *testing.T) {
+	// given: 주석, 따옴표 값, 중복 항목이 있는 설정 파일
+	dir := t.TempDir()
+	path := filepath.Join(dir, "server.conf")
+	content := "# 테스트 설정\nport 7001\nmaxmemory 1mb\nunknown-directive yes\ndir \"" + dir + "\"\nmaxmemory 2mb\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	server := New(":6379")
+
+	// when: 파일을 읽고 명령줄 옵션으로 덮어쓴 뒤 런타임에 값을 바꾸고 다시 쓴다
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	applyFlags := RegisterConfigFlags(fs)
+	if err := fs.Parse([]string{"-port", "7002", "-dbfilename", "data.rdb"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.LoadConfigFile(path); err == nil {
+		t.Fatal("모르는 항목이 있는 설정 파일을 읽었습니다")
+	}
+	content = strings.Replace(content, "unknown-directive yes\n", "", 1)
+	os.WriteFile(path, []byte(content), 0644)
+	if err := server.LoadConfigFile(path); err != nil {
+		t.Fatalf("LoadConfigFile 에러: %v", err)
+	}
+	if err := applyFlags(server); err != nil {
+		t.Fatalf("명령줄 옵션 적용 에러: %v", err)
+	}
+	server.SetConfig("timeout", "30")
+
+	// then: 뒤에 나온 값과 명령줄 옵션이 이긴다
+	if port, _ := server.GetConfig("port"); port != "7002" {
+		t.Fatalf("port: %s, expected: 7002", port)
+	}
+	if maxmemory, _ := server.GetConfig("maxmemory"); maxmemory != "2097152" {
+		t.Fatalf("maxmemory: %s, expected: 2097152", maxmemory)
+	}
+	if snapshot := server.snapshotPath(); snapshot != filepath.Join(dir, "data.rdb") {
+		t.Fatalf("스냅샷 경로: %s", snapshot)
+	}
+
+	// when
+	if err := server.rewriteConfig(); err != nil {
+		t.Fatalf("rewriteConfig 에러: %v", err)
+	}
+
+	// then: 주석은 남고, 중복은 하나로 합쳐지고, 바뀐 항목은 끝에 붙는다
+	rewritten, _ := os.ReadFile(path)
+	expected := "# 테스트 설정\nport 7002\nmaxmemory 2097152\ndir " + dir + "\ndbfilename data.rdb\ntimeout 30\n"
+	if string(rewritten) != expected {
+		t.Fatalf("고쳐 쓴 설정 파일:\n%s\nexpected:\n%s", rewritten, expected)
+	}
+
+	// when & then: 고쳐 쓴 파일을 다시 읽어도 같은 설정이다
+	reloaded := New(":6379")
+	if err := reloaded.LoadConfigFile(path); err != nil {
+		t.Fatalf("다시 읽기 에러: %v", err)
+	}
+	if timeout, _ := reloaded.GetConfig("timeout"); timeout != "30" {
+		t.Fatalf("timeout: %s, expected: 30", timeout)
+	}
+}
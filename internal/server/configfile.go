@@ -0,0 +1,185 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// 설정 파일을 읽어 항목을 차례로 적용하고, CONFIG REWRITE가 덮어쓸 파일로 기억한다.
+// 형식은 Redis 설정 파일과 같다. 한 줄에 "이름 값" 하나씩 쓰고, #으로 시작하는 줄과 빈 줄은 무시한다.
+// 값에 공백이 있으면 큰따옴표나 작은따옴표로 감싼다.
+func (s *Server) LoadConfigFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	for number, line := range strings.Split(string(data), "\n") {
+		name, value, ok, err := parseConfigLine(line)
+		if err != nil {
+			return fmt.Errorf("%s:%d: %w", path, number+1, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := s.SetConfig(name, value); err != nil {
+			return fmt.Errorf("%s:%d: %s: %w", path, number+1, name, err)
+		}
+	}
+
+	s.configMu.Lock()
+	s.configFile = path
+	s.configMu.Unlock()
+	return nil
+}
+
+// 설정 파일 한 줄을 이름(소문자)과 값으로 나눈다. 주석이나 빈 줄이면 ok=false.
+func parseConfigLine(line string) (name, value string, ok bool, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false, nil
+	}
+
+	args, err := splitConfigArgs(line)
+	if err != nil {
+		return "", "", false, err
+	}
+	if len(args) < 2 {
+		return "", "", false, fmt.Errorf("'%s' 항목에 값이 없습니다", args[0])
+	}
+	return strings.ToLower(args[0]), strings.Join(args[1:], " "), true, nil
+}
+
+// 줄을 공백으로 나눈다. 따옴표로 감싼 부분은 공백을 포함한 하나의 인자이고,
+// 큰따옴표 안에서는 \n, \t, \", \\ 같은 이스케이프를 쓸 수 있다 (Redis sdssplitargs).
+func splitConfigArgs(line string) ([]string, error) {
+	var args []string
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+
+		var arg strings.Builder
+		switch quote := line[i]; quote {
+		case '"', '\'':
+			i++
+			closed := false
+			for i < len(line) {
+				ch := line[i]
+				if ch == quote {
+					closed = true
+					i++
+					break
+				}
+				if ch == '\\' && i+1 < len(line) {
+					if quote == '"' {
+						arg.WriteByte(unescapeConfigByte(line[i+1]))
+						i += 2
+						continue
+					}
+					// 작은따옴표 안에서는 \' 만 이스케이프다
+					if line[i+1] == '\'' {
+						arg.WriteByte('\'')
+						i += 2
+						continue
+					}
+				}
+				arg.WriteByte(ch)
+				i++
+			}
+			if !closed {
+				return nil, errors.New("따옴표가 닫히지 않았습니다")
+			}
+			if i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				return nil, errors.New("닫는 따옴표 뒤에는 공백이 와야 합니다")
+			}
+
+		default:
+			for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+				arg.WriteByte(line[i])
+				i++
+			}
+		}
+		args = append(args, arg.String())
+	}
+	return args, nil
+}
+
+func unescapeConfigByte(ch byte) byte {
+	switch ch {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	default:
+		return ch
+	}
+}
+
+// 설정 파일에 쓸 값. 비었거나 공백, 따옴표 등이 있으면 큰따옴표로 감싼다.
+func quoteConfigValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " \t\r\n\"'\\#") {
+		return value
+	}
+	return strconv.Quote(value)
+}
+
+// 현재 설정으로 설정 파일을 고쳐 쓴다 (CONFIG REWRITE).
+// 주석과 모르는 줄은 그대로 두고, 항목 줄은 현재 값으로 바꾸며 같은 항목이 여러 번 나오면 처음 것만 남긴다.
+// 파일에 없던 항목은 기본값과 다를 때만 끝에 덧붙인다. 임시 파일에 쓴 뒤 바꿔치기한다.
+func (s *Server) rewriteConfig() error {
+	s.configMu.RLock()
+	path := s.configFile
+	s.configMu.RUnlock()
+	if path == "" {
+		return errors.New("The server is running without a config file")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("Rewriting config file: %v", err)
+	}
+
+	var out bytes.Buffer
+	written := make(map[string]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Text()
+		name, _, ok, _ := parseConfigLine(line)
+		if _, known := configParams[name]; !ok || !known {
+			out.WriteString(line + "\n")
+			continue
+		}
+		if written[name] {
+			continue
+		}
+		written[name] = true
+		out.WriteString(name + " " + quoteConfigValue(configParams[name].get(s)) + "\n")
+	}
+
+	for _, name := range configNames() {
+		value := configParams[name].get(s)
+		if written[name] || value == configParams[name].defaultValue {
+			continue
+		}
+		out.WriteString(name + " " + quoteConfigValue(value) + "\n")
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, out.Bytes(), 0644); err != nil {
+		return fmt.Errorf("Rewriting config file: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("Rewriting config file: %v", err)
+	}
+	return nil
+}
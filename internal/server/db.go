@@ -0,0 +1,108 @@
+package server
+
+import (
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"strconv"
+	"strings"
+)
+
+// SELECT index
+// 이 연결이 사용할 데이터베이스를 바꾼다
+func (s *Server) handleSelect(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	index, err := s.parseDBIndex(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	c.db, c.dbIndex = s.dbs[index], index
+	c.writer.WriteSimpleString("OK")
+}
+
+// MOVE key db
+// 키를 다른 데이터베이스로 옮겼으면 1, 키가 없거나 대상에 이미 있으면 0
+func (s *Server) handleMove(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	index, err := s.parseDBIndex(args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	moved, err := storage.Move(c.db, s.dbs[index], args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	c.writer.WriteInteger(boolToInt(moved))
+}
+
+// SWAPDB index1 index2
+// 두 데이터베이스의 내용을 맞바꾼다. 해당 번호를 고른 연결은 바로 바뀐 내용을 본다
+func (s *Server) handleSwapDB(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	first, err := s.parseDBIndex(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	second, err := s.parseDBIndex(args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	storage.Swap(s.dbs[first], s.dbs[second])
+	c.writer.WriteSimpleString("OK")
+}
+
+// FLUSHDB [ASYNC | SYNC] / FLUSHALL [ASYNC | SYNC]
+// all이면 모든 데이터베이스를, 아니면 현재 데이터베이스를 비운다
+func (s *Server) handleFlush(c *client, args []protocol.Value, all bool) {
+	async := false
+	switch {
+	case len(args) == 1:
+	case len(args) == 2 && strings.ToUpper(args[1].Str) == "ASYNC":
+		async = true
+	case len(args) == 2 && strings.ToUpper(args[1].Str) == "SYNC":
+	default:
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	if all {
+		for _, db := range s.dbs {
+			db.Flush(async)
+		}
+	} else {
+		c.db.Flush(async)
+	}
+	c.writer.WriteSimpleString("OK")
+}
+
+// 데이터베이스 번호를 파싱한다.
+func (s *Server) parseDBIndex(raw string) (int, error) {
+	index, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, errors.New("value is not an integer or out of range")
+	}
+	if index < 0 || index >= len(s.dbs) {
+		return 0, errors.New("DB index is out of range")
+	}
+	return index, nil
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestSelectAndMove(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SELECT", "5")
+	do(t, conn, reader, "FLUSHDB")
+	do(t, conn, reader, "SELECT", "6")
+	do(t, conn, reader, "FLUSHDB")
+
+	// when & then
+	if response := do(t, conn, reader, "SELECT", "16"); response != "-ERR DB index is out of range\r\n" {
+		t.Fatalf("범위 밖 SELECT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SELECT", "5"); response != "+OK\r\n" {
+		t.Fatalf("SELECT 응답: %q", response)
+	}
+	do(t, conn, reader, "SET", "db-key", "five")
+	if response := do(t, conn, reader, "MOVE", "db-key", "6"); response != ":1\r\n" {
+		t.Fatalf("MOVE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXISTS", "db-key"); response != ":0\r\n" {
+		t.Fatalf("MOVE 후 원래 DB EXISTS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MOVE", "db-key", "5"); response != "-ERR source and destination objects are the same\r\n" {
+		t.Fatalf("같은 DB MOVE 응답: %q", response)
+	}
+	do(t, conn, reader, "SELECT", "6")
+	if response := do(t, conn, reader, "GET", "db-key"); response != "$4\r\nfive\r\n" {
+		t.Fatalf("MOVE 후 GET 응답: %q", response)
+	}
+}
+
+func TestSwapDBAndFlushDB(t *testing.T) {
+	// given: 7번과 8번에 서로 다른 키
+	conn, reader := dial(t)
+	other, otherReader := dial(t)
+	do(t, conn, reader, "SELECT", "7")
+	do(t, conn, reader, "FLUSHDB")
+	do(t, conn, reader, "SET", "swap-key", "seven")
+	do(t, other, otherReader, "SELECT", "8")
+	do(t, other, otherReader, "FLUSHDB", "ASYNC")
+
+	// when
+	response := do(t, conn, reader, "SWAPDB", "7", "8")
+
+	// then: 8번을 고른 다른 연결이 바로 바뀐 내용을 본다
+	if response != "+OK\r\n" {
+		t.Fatalf("SWAPDB 응답: %q", response)
+	}
+	if response := do(t, other, otherReader, "GET", "swap-key"); response != "$5\r\nseven\r\n" {
+		t.Fatalf("SWAPDB 후 GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DBSIZE"); response != ":0\r\n" {
+		t.Fatalf("SWAPDB 후 DBSIZE 응답: %q", response)
+	}
+	if response := do(t, other, otherReader, "FLUSHDB", "LATER"); response != "-ERR syntax error\r\n" {
+		t.Fatalf("잘못된 FLUSHDB 응답: %q", response)
+	}
+	if response := do(t, other, otherReader, "FLUSHDB"); response != "+OK\r\n" {
+		t.Fatalf("FLUSHDB 응답: %q", response)
+	}
+	if response := do(t, other, otherReader, "DBSIZE"); response != ":0\r\n" {
+		t.Fatalf("FLUSHDB 후 DBSIZE 응답: %q", response)
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DEBUG SET-CLOCK unix-time-milliseconds|SYSTEM | ADVANCE-CLOCK milliseconds
+// 만료 시각을 계산하는 서버 시계를 멈추거나 옮긴다. 통합 테스트에서 실제로 기다리지 않고
+// TTL이 지나게 할 때 쓴다. SYSTEM을 주면 다시 시스템 시각을 따른다.
+// 모든 데이터베이스의 만료 시각을 바꾸므로 enable-debug-command 설정으로 허용했을 때만 실행한다.
+func (s *Server) handleDebug(c *client, args []protocol.Value) {
+	if !s.debugAllowed(c) {
+		c.writer.WriteError(debugNotAllowedMessage)
+		return
+	}
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "SET-CLOCK":
+		if len(args) != 3 {
+			c.writer.WriteError("wrong number of arguments for 'debug|set-clock' command")
+			return
+		}
+		if strings.ToUpper(args[2].Str) == "SYSTEM" {
+			s.clock.Reset()
+			c.writer.WriteSimpleString("OK")
+			return
+		}
+		ms, err := strconv.ParseInt(args[2].Str, 10, 64)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		s.clock.Set(time.UnixMilli(ms))
+		c.writer.WriteSimpleString("OK")
+
+	case "ADVANCE-CLOCK":
+		if len(args) != 3 {
+			c.writer.WriteError("wrong number of arguments for 'debug|advance-clock' command")
+			return
+		}
+		ms, err := strconv.ParseInt(args[2].Str, 10, 64)
+		if err != nil || ms > math.MaxInt64/int64(time.Millisecond) || ms < math.MinInt64/int64(time.Millisecond) {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		// 옮긴 뒤의 시각(유닉스 밀리초)
+		c.writer.WriteInteger(int(s.clock.Advance(time.Duration(ms) * time.Millisecond).UnixMilli()))
+
+	default:
+		c.writer.WriteError("unknown DEBUG subcommand '" + args[1].Str + "'")
+	}
+}
+
+const debugNotAllowedMessage = "DEBUG command not allowed. If the enable-debug-command option is set to \"local\", " +
+	"you can run it from a local connection, otherwise you need to set this option in the configuration file, " +
+	"and then restart the server."
+
+func (s *Server) debugAllowed(c *client) bool {
+	switch s.debugCommand {
+	case "yes":
+		return true
+	case "local":
+		tcp, ok := c.conn.RemoteAddr().(*net.TCPAddr)
+		return ok && tcp.IP.IsLoopback()
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDebugClockCommands(t *testing.T) {
+	// given: 서버 시계를 고정한다
+	conn, reader := dial(t)
+	defer do(t, conn, reader, "DEBUG", "SET-CLOCK", "SYSTEM")
+	if response := do(t, conn, reader, "DEBUG", "SET-CLOCK", "1700000000000"); response != "+OK\r\n" {
+		t.Fatalf("DEBUG SET-CLOCK 응답: %q", response)
+	}
+	do(t, conn, reader, "SET", "clock-key", "v", "EX", "100")
+
+	// when & then: 만료 시각은 고정한 시계 기준이다
+	if response := do(t, conn, reader, "PEXPIRETIME", "clock-key"); response != ":1700000100000\r\n" {
+		t.Fatalf("PEXPIRETIME 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DEBUG", "ADVANCE-CLOCK", "99000"); response != ":1700000099000\r\n" {
+		t.Fatalf("DEBUG ADVANCE-CLOCK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TTL", "clock-key"); response != ":1\r\n" {
+		t.Fatalf("TTL 응답: %q", response)
+	}
+
+	// when & then: 기다리지 않고 만료 시각을 넘긴다
+	do(t, conn, reader, "DEBUG", "ADVANCE-CLOCK", "1001")
+	if response := do(t, conn, reader, "GET", "clock-key"); response != "$-1\r\n" {
+		t.Fatalf("만료 후 GET 응답: %q", response)
+	}
+}
+
+func TestDebugClockErrors(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "DEBUG", "SET-CLOCK", "soon"); response != "-ERR value is not an integer or out of range\r\n" {
+		t.Fatalf("DEBUG SET-CLOCK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DEBUG", "ADVANCE-CLOCK"); response != "-ERR wrong number of arguments for 'debug|advance-clock' command\r\n" {
+		t.Fatalf("DEBUG ADVANCE-CLOCK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DEBUG", "SLEEP", "1"); response != "-ERR unknown DEBUG subcommand 'SLEEP'\r\n" {
+		t.Fatalf("DEBUG SLEEP 응답: %q", response)
+	}
+}
+
+func TestDebugCommandDisabledByDefault(t *testing.T) {
+	// given: enable-debug-command를 정하지 않은 서버
+	server, _, result := startServerAt(t, "localhost:6396")
+	defer func() {
+		server.Shutdown(false)
+		waitStopped(t, result)
+	}()
+	conn, reader := dialAddr(t, "localhost:6396")
+
+	// when & then: 시계를 옮길 수 없고, 실행 중에 켤 수도 없다
+	if response := do(t, conn, reader, "DEBUG", "SET-CLOCK", "1700000000000"); !strings.HasPrefix(response, "-ERR DEBUG command not allowed.") {
+		t.Fatalf("DEBUG SET-CLOCK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "CONFIG", "SET", "enable-debug-command", "yes"); !strings.HasPrefix(response, "-ERR") {
+		t.Fatalf("CONFIG SET enable-debug-command 응답: %q", response)
+	}
+	if now := server.clock.Now(); time.Since(now).Abs() > time.Minute {
+		t.Fatalf("서버 시계가 바뀌었습니다: %v", now)
+	}
+}
+
+func TestDebugCommandLocal(t *testing.T) {
+	// given: 루프백 연결에서만 허용한다
+	server, _, result := startServerAt(t, "localhost:6397", "enable-debug-command", "local")
+	defer func() {
+		server.Shutdown(false)
+		waitStopped(t, result)
+	}()
+	conn, reader := dialAddr(t, "localhost:6397")
+
+	// when & then
+	if response := do(t, conn, reader, "DEBUG", "ADVANCE-CLOCK", "1000"); !strings.HasPrefix(response, ":") {
+		t.Fatalf("루프백 연결의 DEBUG ADVANCE-CLOCK 응답: %q", response)
+	}
+}
@@ -0,0 +1,97 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EXPIRE key seconds [NX | XX | GT | LT]
+// PEXPIRE key milliseconds [NX | XX | GT | LT]
+// EXPIREAT key unix-time-seconds [NX | XX | GT | LT]
+// PEXPIREAT key unix-time-milliseconds [NX | XX | GT | LT]
+// unit은 인자의 단위, absolute이면 인자가 유닉스 시각이다.
+func (s *Server) handleExpire(c *client, args []protocol.Value, unit time.Duration, absolute bool) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	command := strings.ToLower(args[0].Str)
+	n, err := strconv.ParseInt(args[2].Str, 10, 64)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	var condition storage.ExpireCondition
+	for _, arg := range args[3:] {
+		switch strings.ToUpper(arg.Str) {
+		case "NX":
+			condition |= storage.ExpireNX
+		case "XX":
+			condition |= storage.ExpireXX
+		case "GT":
+			condition |= storage.ExpireGT
+		case "LT":
+			condition |= storage.ExpireLT
+		default:
+			c.writer.WriteError("Unsupported option " + arg.Str)
+			return
+		}
+	}
+	if condition&storage.ExpireNX != 0 && condition != storage.ExpireNX {
+		c.writer.WriteError("NX and XX, GT or LT options at the same time are not compatible")
+		return
+	}
+	if condition&storage.ExpireGT != 0 && condition&storage.ExpireLT != 0 {
+		c.writer.WriteError("GT and LT options at the same time are not compatible")
+		return
+	}
+
+	if n > math.MaxInt64/int64(unit) || n < math.MinInt64/int64(unit) {
+		c.writer.WriteError("invalid expire time in '" + command + "' command")
+		return
+	}
+	var at time.Time
+	if absolute {
+		at = time.Unix(0, 0).Add(time.Duration(n) * unit)
+	} else {
+		at = c.db.Now().Add(time.Duration(n) * unit)
+	}
+
+	c.writer.WriteInteger(c.db.ExpireAt(args[1].Str, at, condition))
+}
+
+// TTL key / PTTL key
+// 남은 수명. TTL이 없으면 -1, 키가 없으면 -2
+func (s *Server) handleTTL(c *client, args []protocol.Value, milliseconds bool) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	if milliseconds {
+		c.writer.WriteInteger(int(c.db.PTTL(args[1].Str)))
+	} else {
+		c.writer.WriteInteger(c.db.TTL(args[1].Str))
+	}
+}
+
+// EXPIRETIME key / PEXPIRETIME key
+// 만료되는 유닉스 시각. TTL이 없으면 -1, 키가 없으면 -2
+func (s *Server) handleExpireTime(c *client, args []protocol.Value, milliseconds bool) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	at := c.db.PExpireTime(args[1].Str)
+	if at >= 0 && !milliseconds {
+		at = (at + 500) / 1000
+	}
+	c.writer.WriteInteger(int(at))
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMillisecondExpireCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "e-ms", "v")
+
+	// when & then
+	if response := do(t, conn, reader, "PEXPIRE", "e-ms", "1500"); response != ":1\r\n" {
+		t.Fatalf("PEXPIRE 응답: %q", response)
+	}
+	response := do(t, conn, reader, "PTTL", "e-ms")
+	pttl, _ := strconv.Atoi(strings.TrimSpace(response[1:]))
+	if pttl <= 1000 || pttl > 1500 {
+		t.Fatalf("PTTL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TTL", "e-ms"); response != ":1\r\n" && response != ":2\r\n" {
+		t.Fatalf("TTL 응답: %q", response)
+	}
+
+	at := time.Now().Add(time.Minute).UnixMilli()
+	if response := do(t, conn, reader, "PEXPIREAT", "e-ms", strconv.FormatInt(at, 10)); response != ":1\r\n" {
+		t.Fatalf("PEXPIREAT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "PEXPIRETIME", "e-ms"); response != ":"+strconv.FormatInt(at, 10)+"\r\n" {
+		t.Fatalf("PEXPIRETIME 응답: %q", response)
+	}
+
+	seconds := time.Now().Add(time.Hour).Unix()
+	if response := do(t, conn, reader, "EXPIREAT", "e-ms", strconv.FormatInt(seconds, 10)); response != ":1\r\n" {
+		t.Fatalf("EXPIREAT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRETIME", "e-ms"); response != ":"+strconv.FormatInt(seconds, 10)+"\r\n" {
+		t.Fatalf("EXPIRETIME 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRETIME", "e-missing"); response != ":-2\r\n" {
+		t.Fatalf("존재하지 않는 키 EXPIRETIME 응답: %q", response)
+	}
+}
+
+func TestExpireConditionFlags(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "e-flag", "v")
+
+	// when & then
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "100", "XX"); response != ":0\r\n" {
+		t.Fatalf("EXPIRE XX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "100", "NX"); response != ":1\r\n" {
+		t.Fatalf("EXPIRE NX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "50", "GT"); response != ":0\r\n" {
+		t.Fatalf("EXPIRE GT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "50", "lt"); response != ":1\r\n" {
+		t.Fatalf("EXPIRE LT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "50", "NX", "XX"); response != "-ERR NX and XX, GT or LT options at the same time are not compatible\r\n" {
+		t.Fatalf("EXPIRE NX XX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "50", "GT", "LT"); response != "-ERR GT and LT options at the same time are not compatible\r\n" {
+		t.Fatalf("EXPIRE GT LT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "EXPIRE", "e-flag", "abc"); response != "-ERR value is not an integer or out of range\r\n" {
+		t.Fatalf("EXPIRE 잘못된 값 응답: %q", response)
+	}
+}
+
+func TestExpireNegativeDeletesKey(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "e-neg", "v")
+
+	// when
+	response := do(t, conn, reader, "EXPIRE", "e-neg", "-1")
+
+	// then
+	if response != ":1\r\n" {
+		t.Fatalf("EXPIRE 음수 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GET", "e-neg"); response != "$-1\r\n" {
+		t.Fatalf("음수 EXPIRE 후 GET 응답: %q", response)
+	}
+}
+
+func TestActiveExpiryHzAndStats(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	defer do(t, conn, reader, "CONFIG", "SET", "hz", "10")
+	if response := do(t, conn, reader, "CONFIG", "SET", "hz", "100"); response != "+OK\r\n" {
+		t.Fatalf("CONFIG SET hz 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "CONFIG", "GET", "hz"); response != "*2\r\n$2\r\nhz\r\n$3\r\n100\r\n" {
+		t.Fatalf("CONFIG GET hz 응답: %q", response)
+	}
+	expiredBefore, _ := strconv.Atoi(infoField(t, do(t, conn, reader, "INFO", "stats"), "expired_keys"))
+
+	// when: 조회하지 않는 키가 만료된다
+	do(t, conn, reader, "SET", "hz-key", "v", "PX", "50")
+	time.Sleep(300 * time.Millisecond)
+
+	// then: 백그라운드 만료로 지워져 expired_keys가 늘어난다
+	stats := do(t, conn, reader, "INFO", "stats")
+	if expired, _ := strconv.Atoi(infoField(t, stats, "expired_keys")); expired <= expiredBefore {
+		t.Fatalf("expired_keys: %d, before: %d", expired, expiredBefore)
+	}
+	infoField(t, stats, "expired_stale_perc")
+	infoField(t, stats, "expired_time_cap_reached_count")
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// HSET key field value [field value ...]
+func (s *Server) handleHSet(c *client, args []protocol.Value) {
+	if len(args) < 4 || len(args)%2 != 0 {
+		c.writer.WriteError("wrong number of arguments for 'hset' command")
+		return
+	}
+
+	fieldValues := make([]string, 0, len(args)-2)
+	for _, arg := range args[2:] {
+		fieldValues = append(fieldValues, arg.Str)
+	}
+
+	added, err := c.db.HSet(args[1].Str, fieldValues...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(added)
+	}
+}
+
+// HSETNX key field value
+func (s *Server) handleHSetNX(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	ok, err := c.db.HSetNX(args[1].Str, args[2].Str, args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(boolToInt(ok))
+	}
+}
+
+// HGET key field
+func (s *Server) handleHGet(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	value, exist, err := c.db.HGet(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !exist {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteBulkString(value)
+	}
+}
+
+// HMGET key field [field ...]
+// 없는 필드는 null로 응답한다.
+func (s *Server) handleHMGet(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	values, exists, err := c.db.HMGet(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	c.writer.WriteArrayLen(len(values))
+	for i, value := range values {
+		if exists[i] {
+			c.writer.WriteBulkString(value)
+		} else {
+			c.writer.WriteNull()
+		}
+	}
+}
+
+// HDEL key field [field ...]
+func (s *Server) handleHDel(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	removed, err := c.db.HDel(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(removed)
+	}
+}
+
+// HEXISTS key field
+func (s *Server) handleHExists(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	exist, err := c.db.HExists(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(boolToInt(exist))
+	}
+}
+
+// HLEN key
+func (s *Server) handleHLen(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	length, err := c.db.HLen(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// HSTRLEN key field
+func (s *Server) handleHStrLen(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	length, err := c.db.HStrLen(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// HKEYS key / HVALS key / HGETALL key
+func (s *Server) handleHCollect(c *client, args []protocol.Value, collect func(key string) ([]string, error)) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	result, err := collect(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteArray(result)
+	}
+}
+
+// HINCRBY key field increment
+func (s *Server) handleHIncrBy(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	delta, err := strconv.ParseInt(args[3].Str, 10, 64)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	result, err := c.db.HIncrBy(args[1].Str, args[2].Str, delta)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(int(result))
+	}
+}
+
+// HINCRBYFLOAT key field increment
+func (s *Server) handleHIncrByFloat(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	delta, err := strconv.ParseFloat(args[3].Str, 64)
+	if err != nil || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		c.writer.WriteError("value is not a valid float")
+		return
+	}
+
+	result, err := c.db.HIncrByFloat(args[1].Str, args[2].Str, delta)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteBulkString(result)
+	}
+}
+
+// HRANDFIELD key [count [WITHVALUES]]
+// count가 없으면 필드 하나(없으면 null), 있으면 배열로 응답한다.
+func (s *Server) handleHRandField(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	if len(args) == 2 {
+		fields, _, err := c.db.HRandField(args[1].Str, 1)
+		if err != nil {
+			c.writer.WriteError(err.Error())
+		} else if len(fields) == 0 {
+			c.writer.WriteNull()
+		} else {
+			c.writer.WriteBulkString(fields[0])
+		}
+		return
+	}
+
+	count, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	withValues := false
+	if len(args) > 3 {
+		if len(args) > 4 || strings.ToUpper(args[3].Str) != "WITHVALUES" {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		withValues = true
+	}
+
+	fields, values, err := c.db.HRandField(args[1].Str, count)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	if !withValues {
+		c.writer.WriteArray(fields)
+		return
+	}
+	result := make([]string, 0, len(fields)*2)
+	for i, field := range fields {
+		result = append(result, field, values[i])
+	}
+	c.writer.WriteArray(result)
+}
+
+// HSCAN key cursor [MATCH pattern] [COUNT count] [NOVALUES]
+func (s *Server) handleHScan(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	cursor, err := parseScanCursor(args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	options, err := parseScanOptions(args[3:], true, false)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	next, result, err := c.db.HScan(args[1].Str, cursor, options.pattern, options.count)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	if options.noValues {
+		fields := make([]string, 0, len(result)/2)
+		for i := 0; i < len(result); i += 2 {
+			fields = append(fields, result[i])
+		}
+		result = fields
+	}
+	writeScanReply(c.writer, next, result)
+}
+
+// 인자들의 문자열 값
+func argStrings(args []protocol.Value) []string {
+	result := make([]string, 0, len(args))
+	for _, arg := range args {
+		result = append(result, arg.Str)
+	}
+	return result
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
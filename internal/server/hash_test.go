@@ -0,0 +1,121 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHashCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "HSET", "h-user", "name", "gopher", "lang", "go"); response != ":2\r\n" {
+		t.Fatalf("HSET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HGET", "h-user", "name"); response != "$6\r\ngopher\r\n" {
+		t.Fatalf("HGET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HMGET", "h-user", "lang", "missing"); response != "*2\r\n$2\r\ngo\r\n$-1\r\n" {
+		t.Fatalf("HMGET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HEXISTS", "h-user", "name"); response != ":1\r\n" {
+		t.Fatalf("HEXISTS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HSTRLEN", "h-user", "name"); response != ":6\r\n" {
+		t.Fatalf("HSTRLEN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HSETNX", "h-user", "name", "other"); response != ":0\r\n" {
+		t.Fatalf("HSETNX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HDEL", "h-user", "name", "missing"); response != ":1\r\n" {
+		t.Fatalf("HDEL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HGETALL", "h-user"); response != "*2\r\n$4\r\nlang\r\n$2\r\ngo\r\n" {
+		t.Fatalf("HGETALL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HLEN", "h-user"); response != ":1\r\n" {
+		t.Fatalf("HLEN 응답: %q", response)
+	}
+}
+
+func TestHSet_WrongNumberOfArguments(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when
+	response := do(t, conn, reader, "HSET", "h-odd", "field")
+
+	// then
+	if response != "-ERR wrong number of arguments for 'hset' command\r\n" {
+		t.Fatalf("응답: %q", response)
+	}
+}
+
+func TestHash_WrongType(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "h-list", "a")
+
+	// when
+	response := do(t, conn, reader, "HGET", "h-list", "a")
+
+	// then
+	if response != "-ERR WRONGTYPE Operation against a key holding the wrong kind of value\r\n" {
+		t.Fatalf("응답: %q", response)
+	}
+}
+
+func TestHIncrByCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "HSET", "h-counter", "text", "abc")
+
+	// when & then
+	if response := do(t, conn, reader, "HINCRBY", "h-counter", "n", "5"); response != ":5\r\n" {
+		t.Fatalf("HINCRBY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HINCRBY", "h-counter", "text", "1"); response != "-ERR hash value is not an integer\r\n" {
+		t.Fatalf("정수가 아닌 값 HINCRBY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HINCRBYFLOAT", "h-counter", "n", "0.5"); response != "$3\r\n5.5\r\n" {
+		t.Fatalf("HINCRBYFLOAT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HINCRBYFLOAT", "h-counter", "n", "abc"); response != "-ERR value is not a valid float\r\n" {
+		t.Fatalf("잘못된 increment HINCRBYFLOAT 응답: %q", response)
+	}
+}
+
+func TestHRandFieldCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "HSET", "h-rand", "a", "1")
+
+	// when & then
+	if response := do(t, conn, reader, "HRANDFIELD", "h-rand"); response != "$1\r\na\r\n" {
+		t.Fatalf("HRANDFIELD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HRANDFIELD", "h-rand", "-2", "WITHVALUES"); response != "*4\r\n$1\r\na\r\n$1\r\n1\r\n$1\r\na\r\n$1\r\n1\r\n" {
+		t.Fatalf("HRANDFIELD WITHVALUES 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HRANDFIELD", "h-missing"); response != "$-1\r\n" {
+		t.Fatalf("없는 키 HRANDFIELD 응답: %q", response)
+	}
+}
+
+func TestHScanCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "HSET", "h-scan", "a", "1", "b", "2")
+
+	// when
+	response := do(t, conn, reader, "HSCAN", "h-scan", "0", "MATCH", "a", "COUNT", "100", "NOVALUES")
+
+	// then: 작은 해시는 한 번에 끝나서 커서 0과 일치하는 필드만 반환
+	if response != "*2\r\n$1\r\n0\r\n*1\r\n$1\r\na\r\n" {
+		t.Fatalf("HSCAN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "HSCAN", "h-scan", "abc"); !strings.HasPrefix(response, "-ERR invalid cursor") {
+		t.Fatalf("잘못된 커서 응답: %q", response)
+	}
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var startOnce sync.Once
+
+// 테스트 서버를 :6379에 한 번만 띄운다.
+// 다른 테스트가 이미 서버를 띄웠다면 Start는 실패하고 기존 서버를 그대로 사용한다.
+func startTestServer() {
+	startOnce.Do(func() {
+		server := New(":6379")
+		server.SetConfig("enable-debug-command", "yes")
+		go server.Start()
+		time.Sleep(time.Second)
+	})
+}
+
+// 테스트 서버에 연결한다.
+func dial(t *testing.T) (net.Conn, *bufio.Reader) {
+	t.Helper()
+	startTestServer()
+
+	conn, err := net.Dial("tcp", "localhost:6379")
+	if err != nil {
+		t.Fatalf("연결 실패: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, bufio.NewReader(conn)
+}
+
+// RESP 배열 형식으로 명령어를 보낸다.
+func send(conn net.Conn, args ...string) {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("*%d\r\n", len(args)))
+	for _, arg := range args {
+		sb.WriteString(fmt.Sprintf("$%d\r\n%s\r\n", len(arg), arg))
+	}
+	conn.Write([]byte(sb.String()))
+}
+
+// 응답 하나를 통째로 읽어 원문 그대로 반환한다.
+// Bulk String과 Array는 본문까지 재귀적으로 읽는다.
+func readReply(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("응답 읽기 실패: %v", err)
+	}
+
+	switch line[0] {
+	case '$':
+		length, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		if length < 0 {
+			return line
+		}
+		body := make([]byte, length+2) // 본문 + \r\n
+		io.ReadFull(reader, body)
+		return line + string(body)
+
+	case '*':
+		count, _ := strconv.Atoi(strings.TrimSpace(line[1:]))
+		result := line
+		for i := 0; i < count; i++ {
+			result += readReply(t, reader)
+		}
+		return result
+
+	default:
+		return line
+	}
+}
+
+// 명령어를 보내고 응답을 읽는다.
+func do(t *testing.T, conn net.Conn, reader *bufio.Reader, args ...string) string {
+	t.Helper()
+	send(conn, args...)
+	return readReply(t, reader)
+}
+
+// 공유 테스트 서버와 별개로 종료해도 되는 서버를 addr에 띄운다.
+// 스냅샷은 임시 디렉터리에 쓰고, Start의 반환값은 채널로 받는다.
+// config는 시작하기 전에 적용할 설정 이름과 값의 쌍이다.
+func startServerAt(t *testing.T, addr string, config ...string) (*Server, string, <-chan error) {
+	t.Helper()
+
+	server := New(addr)
+	dir := t.TempDir()
+	server.SetConfig("dir", dir)
+	server.SetConfig("debug-addr", "")
+	for i := 0; i+1 < len(config); i += 2 {
+		if err := server.SetConfig(config[i], config[i+1]); err != nil {
+			t.Fatalf("설정 %s 실패: %v", config[i], err)
+		}
+	}
+
+	result := make(chan error, 1)
+	go func() { result <- server.Start() }()
+	for range 100 {
+		if conn, err := net.Dial("tcp", addr); err == nil {
+			conn.Close()
+			return server, dir, result
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s 서버가 뜨지 않았습니다", addr)
+	return nil, "", nil
+}
+
+func dialAddr(t *testing.T, addr string) (net.Conn, *bufio.Reader) {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("연결 실패: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, bufio.NewReader(conn)
+}
+
+// Start가 반환되길 기다린다.
+func waitStopped(t *testing.T, result <-chan error) error {
+	t.Helper()
+
+	select {
+	case err := <-result:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start가 반환되지 않았습니다")
+		return nil
+	}
+}
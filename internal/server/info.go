@@ -0,0 +1,66 @@
+package server
+
+import (
+	"fmt"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"strings"
+)
+
+// INFO [section ...]
+// 서버 상태를 "name:value" 줄로 묶어 bulk string으로 돌려준다. 섹션을 주지 않으면 모두
+func (s *Server) handleInfo(c *client, args []protocol.Value) {
+	sections := map[string]bool{}
+	for _, arg := range args[1:] {
+		sections[strings.ToLower(arg.Str)] = true
+	}
+	all := len(sections) == 0 || sections["all"] || sections["everything"] || sections["default"]
+
+	var builder strings.Builder
+	if all || sections["memory"] {
+		builder.WriteString("# Memory\r\n")
+		fmt.Fprintf(&builder, "used_memory:%d\r\n", s.usedMemory())
+		fmt.Fprintf(&builder, "maxmemory:%d\r\n", s.maxmemory.Load())
+		fmt.Fprintf(&builder, "maxmemory_policy:%s\r\n", storage.EvictionPolicy(s.maxmemoryPolicy.Load()))
+		builder.WriteString("\r\n")
+	}
+	if all || sections["stats"] {
+		builder.WriteString("# Stats\r\n")
+		var expired, timeCapReached int64
+		var stale float64
+		volatile := 0
+		for _, db := range s.dbs {
+			stats := db.ExpiryStats()
+			expired += stats.Expired
+			timeCapReached += stats.TimeCapReached
+			// 데이터베이스마다의 추정치를 만료 시간이 있는 키 수로 가중 평균한다
+			stale += stats.StalePercent * float64(stats.Volatile)
+			volatile += stats.Volatile
+		}
+		if volatile > 0 {
+			stale /= float64(volatile)
+		}
+		fmt.Fprintf(&builder, "expired_keys:%d\r\n", expired)
+		fmt.Fprintf(&builder, "expired_stale_perc:%.2f\r\n", stale)
+		fmt.Fprintf(&builder, "expired_time_cap_reached_count:%d\r\n", timeCapReached)
+		fmt.Fprintf(&builder, "evicted_keys:%d\r\n", s.evictedKeys.Load())
+		builder.WriteString("\r\n")
+	}
+	if all || sections["keyspace"] {
+		builder.WriteString("# Keyspace\r\n")
+		for index, db := range s.dbs {
+			if size := db.DBSize(); size > 0 {
+				fmt.Fprintf(&builder, "db%d:keys=%d,expires=%d\r\n", index, size, db.ExpiryStats().Volatile)
+			}
+		}
+	}
+	c.writer.WriteBulkString(builder.String())
+}
+
+// INFO stats의 누적 통계를 0으로 되돌린다 (CONFIG RESETSTAT).
+func (s *Server) resetStats() {
+	s.evictedKeys.Store(0)
+	for _, db := range s.dbs {
+		db.ResetExpiryStats()
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"strings"
+)
+
+// DEL key [key ...] / UNLINK key [key ...]
+// 삭제된 키의 개수. UNLINK는 큰 값을 백그라운드에서 해제한다
+func (s *Server) handleDel(c *client, args []protocol.Value, lazy bool) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	keys := argStrings(args[1:])
+	if lazy {
+		c.writer.WriteInteger(c.db.Unlink(keys...))
+	} else {
+		c.writer.WriteInteger(c.db.Del(keys...))
+	}
+}
+
+// EXISTS key [key ...] / TOUCH key [key ...]
+// 존재하는 키의 개수. 같은 키를 여러 번 주면 여러 번 센다
+func (s *Server) handleExists(c *client, args []protocol.Value, touch bool) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	keys := argStrings(args[1:])
+	if touch {
+		c.writer.WriteInteger(c.db.Touch(keys...))
+	} else {
+		c.writer.WriteInteger(c.db.Exists(keys...))
+	}
+}
+
+// TYPE key
+func (s *Server) handleType(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	c.writer.WriteSimpleString(c.db.Type(args[1].Str))
+}
+
+// RENAME key newkey
+func (s *Server) handleRename(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	if err := c.db.Rename(args[1].Str, args[2].Str); err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	c.writer.WriteSimpleString("OK")
+}
+
+// RENAMENX key newkey
+// 이름을 바꿨으면 1, newkey가 이미 있으면 0
+func (s *Server) handleRenameNX(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	renamed, err := c.db.RenameNX(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	c.writer.WriteInteger(boolToInt(renamed))
+}
+
+// COPY source destination [REPLACE]
+// 복사했으면 1, source가 없거나 destination이 이미 있으면 0
+func (s *Server) handleCopy(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	replace := false
+	for _, arg := range args[3:] {
+		if strings.ToUpper(arg.Str) != "REPLACE" {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		replace = true
+	}
+
+	copied, err := c.db.Copy(args[1].Str, args[2].Str, replace)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	c.writer.WriteInteger(boolToInt(copied))
+}
+
+// DBSIZE
+func (s *Server) handleDBSize(c *client) {
+	c.writer.WriteInteger(c.db.DBSize())
+}
+
+// RANDOMKEY
+// 무작위 키 하나. 키가 없으면 null
+func (s *Server) handleRandomKey(c *client) {
+	key, exist := c.db.RandomKey()
+	writeOptionalString(c, key, exist, nil)
+}
+
+// SCAN cursor [MATCH pattern] [COUNT count] [TYPE type]
+func (s *Server) handleScan(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	cursor, err := parseScanCursor(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	options, err := parseScanOptions(args[2:], false, true)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	next, keys := c.db.Scan(cursor, options.pattern, options.count, options.typeName)
+	writeScanReply(c.writer, next, keys)
+}
+
+// KEYS pattern
+// 키 공간 전체를 훑으므로 개발용 작은 데이터셋에서만 쓴다
+func (s *Server) handleKeys(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	pattern := args[1].Str
+	if pattern == "*" {
+		pattern = ""
+	}
+	c.writer.WriteArray(c.db.Keys(pattern))
+}
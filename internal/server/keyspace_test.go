@@ -0,0 +1,96 @@
+package server
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKeyspaceCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "DEL", "k-a", "k-b", "k-c", "k-list")
+	do(t, conn, reader, "SET", "k-a", "1")
+	do(t, conn, reader, "RPUSH", "k-list", "x")
+
+	// when & then
+	if response := do(t, conn, reader, "EXISTS", "k-a", "k-a", "k-missing"); response != ":2\r\n" {
+		t.Fatalf("EXISTS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TYPE", "k-list"); response != "+list\r\n" {
+		t.Fatalf("TYPE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TYPE", "k-missing"); response != "+none\r\n" {
+		t.Fatalf("없는 키 TYPE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "RENAME", "k-a", "k-b"); response != "+OK\r\n" {
+		t.Fatalf("RENAME 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "RENAME", "k-missing", "k-b"); response != "-ERR no such key\r\n" {
+		t.Fatalf("없는 키 RENAME 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "RENAMENX", "k-b", "k-list"); response != ":0\r\n" {
+		t.Fatalf("RENAMENX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "COPY", "k-b", "k-c"); response != ":1\r\n" {
+		t.Fatalf("COPY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "COPY", "k-b", "k-c"); response != ":0\r\n" {
+		t.Fatalf("기존 키 COPY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "COPY", "k-b", "k-c", "REPLACE"); response != ":1\r\n" {
+		t.Fatalf("COPY REPLACE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TOUCH", "k-b", "k-c"); response != ":2\r\n" {
+		t.Fatalf("TOUCH 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "RANDOMKEY"); response[0] != '$' || response == "$-1\r\n" {
+		t.Fatalf("RANDOMKEY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DBSIZE"); response[0] != ':' || response == ":0\r\n" {
+		t.Fatalf("DBSIZE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DEL", "k-b", "k-missing"); response != ":1\r\n" {
+		t.Fatalf("DEL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "UNLINK", "k-c", "k-list"); response != ":2\r\n" {
+		t.Fatalf("UNLINK 응답: %q", response)
+	}
+}
+
+func TestScanCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "scan-a", "1")
+	do(t, conn, reader, "SET", "scan-b", "2")
+	do(t, conn, reader, "RPUSH", "scan-list", "x")
+
+	// when: 커서가 0이 될 때까지 순회
+	found := 0
+	cursor := "0"
+	for {
+		send(conn, "SCAN", cursor, "MATCH", "scan-*", "COUNT", "100", "TYPE", "string")
+		header, _ := reader.ReadString('\n') // *2
+		if header != "*2\r\n" {
+			t.Fatalf("SCAN 응답 헤더: %q", header)
+		}
+		reader.ReadString('\n') // $n
+		next, _ := reader.ReadString('\n')
+		cursor = strings.TrimSpace(next)
+		elements := readReply(t, reader)
+		found += strings.Count(elements, "scan-")
+		if cursor == "0" {
+			break
+		}
+	}
+
+	// then
+	if found != 2 {
+		t.Fatalf("SCAN TYPE string으로 찾은 키 수: %d, expected: 2", found)
+	}
+	if response := do(t, conn, reader, "SCAN", "0", "TYPE", "nope"); response != "-ERR unknown type name 'nope'\r\n" {
+		t.Fatalf("SCAN 잘못된 TYPE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "KEYS", "scan-l*"); response != "*1\r\n$9\r\nscan-list\r\n" {
+		t.Fatalf("KEYS 응답: %q", response)
+	}
+}
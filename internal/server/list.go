@@ -0,0 +1,322 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"strconv"
+	"strings"
+)
+
+// LPOP key [count]
+// RPOP key [count]
+// count가 없으면 bulk string 하나, 있으면 배열로 응답한다.
+func (s *Server) handlePop(c *client, args []protocol.Value, left bool) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	if len(args) == 2 {
+		var value string
+		var result bool
+		var err error
+		if left {
+			value, result, err = c.db.LPop(args[1].Str)
+		} else {
+			value, result, err = c.db.RPop(args[1].Str)
+		}
+
+		if err != nil {
+			c.writer.WriteError(err.Error())
+		} else if !result {
+			c.writer.WriteNull()
+		} else {
+			c.writer.WriteBulkString(value)
+		}
+		return
+	}
+
+	count, err := strconv.Atoi(args[2].Str)
+	if err != nil || count < 0 {
+		c.writer.WriteError("value is out of range, must be positive")
+		return
+	}
+
+	var values []string
+	var exist bool
+	if left {
+		values, exist, err = c.db.LPopCount(args[1].Str, count)
+	} else {
+		values, exist, err = c.db.RPopCount(args[1].Str, count)
+	}
+
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !exist {
+		c.writer.WriteNullArray()
+	} else {
+		c.writer.WriteArray(values)
+	}
+}
+
+// LPUSHX key element [element ...]
+// RPUSHX key element [element ...]
+func (s *Server) handlePushX(c *client, args []protocol.Value, left bool) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	values := make([]string, 0, len(args)-2)
+	for _, v := range args[2:] {
+		values = append(values, v.Str)
+	}
+
+	var length int
+	var err error
+	if left {
+		length, err = c.db.LPushX(args[1].Str, values...)
+	} else {
+		length, err = c.db.RPushX(args[1].Str, values...)
+	}
+
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// LLEN key
+func (s *Server) handleLLen(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	length, err := c.db.LLen(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// LINDEX key index
+func (s *Server) handleLIndex(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	index, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	value, ok, err := c.db.LIndex(args[1].Str, index)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !ok {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteBulkString(value)
+	}
+}
+
+// LSET key index element
+func (s *Server) handleLSet(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	index, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	if err := c.db.LSet(args[1].Str, index, args[3].Str); err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteSimpleString("OK")
+	}
+}
+
+// LINSERT key BEFORE|AFTER pivot element
+func (s *Server) handleLInsert(c *client, args []protocol.Value) {
+	if len(args) < 5 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	var before bool
+	switch strings.ToUpper(args[2].Str) {
+	case "BEFORE":
+		before = true
+	case "AFTER":
+		before = false
+	default:
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	length, err := c.db.LInsert(args[1].Str, before, args[3].Str, args[4].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// LREM key count element
+func (s *Server) handleLRem(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	count, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	removed, err := c.db.LRem(args[1].Str, count, args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(removed)
+	}
+}
+
+// LTRIM key start stop
+func (s *Server) handleLTrim(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	start, err1 := strconv.Atoi(args[2].Str)
+	stop, err2 := strconv.Atoi(args[3].Str)
+	if err1 != nil || err2 != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	if err := c.db.LTrim(args[1].Str, start, stop); err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteSimpleString("OK")
+	}
+}
+
+// LPOS key element [RANK rank] [COUNT num-matches] [MAXLEN len]
+// COUNT가 없으면 정수 하나(없으면 null), 있으면 정수 배열로 응답한다.
+func (s *Server) handleLPos(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	rank, count, maxlen := 1, 1, 0
+	withCount := false
+
+	for i := 3; i < len(args); i += 2 {
+		if i+1 >= len(args) {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		n, err := strconv.Atoi(args[i+1].Str)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+
+		switch strings.ToUpper(args[i].Str) {
+		case "RANK":
+			if n == 0 {
+				c.writer.WriteError("RANK can't be zero: use 1 to start from the first match, 2 from the second ... or use negative to start from the end of the list")
+				return
+			}
+			rank = n
+		case "COUNT":
+			if n < 0 {
+				c.writer.WriteError("COUNT can't be negative")
+				return
+			}
+			count = n
+			withCount = true
+		case "MAXLEN":
+			if n < 0 {
+				c.writer.WriteError("MAXLEN can't be negative")
+				return
+			}
+			maxlen = n
+		default:
+			c.writer.WriteError("syntax error")
+			return
+		}
+	}
+
+	positions, err := c.db.LPos(args[1].Str, args[2].Str, rank, count, maxlen)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	if withCount {
+		c.writer.WriteArrayLen(len(positions))
+		for _, p := range positions {
+			c.writer.WriteInteger(p)
+		}
+	} else if len(positions) == 0 {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteInteger(positions[0])
+	}
+}
+
+// LMOVE source destination LEFT|RIGHT LEFT|RIGHT
+func (s *Server) handleLMove(c *client, args []protocol.Value) {
+	if len(args) < 5 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	fromLeft, err := parseDirection(args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	toLeft, err := parseDirection(args[4].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	s.move(c, args[1].Str, args[2].Str, fromLeft, toLeft)
+}
+
+// RPOPLPUSH source destination
+// LMOVE source destination RIGHT LEFT 와 같다.
+func (s *Server) handleRPopLPush(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	s.move(c, args[1].Str, args[2].Str, false, true)
+}
+
+func (s *Server) move(c *client, source, destination string, fromLeft, toLeft bool) {
+	value, ok, err := c.db.LMove(source, destination, fromLeft, toLeft)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !ok {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteBulkString(value)
+	}
+}
@@ -0,0 +1,127 @@
+package server
+
+import "testing"
+
+func TestLPopWithCount(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "lc-list", "a", "b", "c")
+
+	// when & then: count가 있으면 배열로 응답
+	if response := do(t, conn, reader, "LPOP", "lc-list", "2"); response != "*2\r\n$1\r\na\r\n$1\r\nb\r\n" {
+		t.Fatalf("LPOP count 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "RPOP", "lc-missing", "2"); response != "*-1\r\n" {
+		t.Fatalf("없는 키 RPOP count 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LPOP", "lc-list", "-1"); response != "-ERR value is out of range, must be positive\r\n" {
+		t.Fatalf("음수 count 응답: %q", response)
+	}
+}
+
+func TestListIndexCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "li-list", "a", "b", "c")
+
+	// when & then
+	if response := do(t, conn, reader, "LLEN", "li-list"); response != ":3\r\n" {
+		t.Fatalf("LLEN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LINDEX", "li-list", "-1"); response != "$1\r\nc\r\n" {
+		t.Fatalf("LINDEX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LINDEX", "li-list", "10"); response != "$-1\r\n" {
+		t.Fatalf("범위 밖 LINDEX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LSET", "li-list", "1", "B"); response != "+OK\r\n" {
+		t.Fatalf("LSET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LSET", "li-list", "10", "x"); response != "-ERR index out of range\r\n" {
+		t.Fatalf("범위 밖 LSET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LSET", "li-missing", "0", "x"); response != "-ERR no such key\r\n" {
+		t.Fatalf("없는 키 LSET 응답: %q", response)
+	}
+}
+
+func TestLInsertAndLRemCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "lr-list", "a", "c", "a")
+
+	// when & then
+	if response := do(t, conn, reader, "LINSERT", "lr-list", "BEFORE", "c", "b"); response != ":4\r\n" {
+		t.Fatalf("LINSERT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LINSERT", "lr-list", "AFTER", "zz", "b"); response != ":-1\r\n" {
+		t.Fatalf("pivot 없는 LINSERT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LREM", "lr-list", "0", "a"); response != ":2\r\n" {
+		t.Fatalf("LREM 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LRANGE", "lr-list", "0", "-1"); response != "*2\r\n$1\r\nb\r\n$1\r\nc\r\n" {
+		t.Fatalf("LRANGE 응답: %q", response)
+	}
+}
+
+func TestLTrimCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "lt-list", "a", "b", "c", "d")
+
+	// when
+	response := do(t, conn, reader, "LTRIM", "lt-list", "0", "1")
+
+	// then
+	if response != "+OK\r\n" {
+		t.Fatalf("LTRIM 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LRANGE", "lt-list", "0", "-1"); response != "*2\r\n$1\r\na\r\n$1\r\nb\r\n" {
+		t.Fatalf("LRANGE 응답: %q", response)
+	}
+}
+
+func TestLPosCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "lp-pos", "a", "b", "a", "a")
+
+	// when & then
+	if response := do(t, conn, reader, "LPOS", "lp-pos", "a", "RANK", "2"); response != ":2\r\n" {
+		t.Fatalf("LPOS RANK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LPOS", "lp-pos", "a", "COUNT", "0"); response != "*3\r\n:0\r\n:2\r\n:3\r\n" {
+		t.Fatalf("LPOS COUNT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LPOS", "lp-pos", "z"); response != "$-1\r\n" {
+		t.Fatalf("일치 없는 LPOS 응답: %q", response)
+	}
+	expected := "-ERR RANK can't be zero: use 1 to start from the first match, 2 from the second ... or use negative to start from the end of the list\r\n"
+	if response := do(t, conn, reader, "LPOS", "lp-pos", "a", "RANK", "0"); response != expected {
+		t.Fatalf("RANK 0 응답: %q", response)
+	}
+}
+
+func TestLMoveAndPushXCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "RPUSH", "lm-src", "a", "b")
+
+	// when & then
+	if response := do(t, conn, reader, "RPOPLPUSH", "lm-src", "lm-dst"); response != "$1\r\nb\r\n" {
+		t.Fatalf("RPOPLPUSH 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LMOVE", "lm-src", "lm-dst", "LEFT", "RIGHT"); response != "$1\r\na\r\n" {
+		t.Fatalf("LMOVE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LMOVE", "lm-src", "lm-dst", "LEFT", "RIGHT"); response != "$-1\r\n" {
+		t.Fatalf("빈 source LMOVE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "LPUSHX", "lm-src", "x"); response != ":0\r\n" {
+		t.Fatalf("없는 키 LPUSHX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "RPUSHX", "lm-dst", "c"); response != ":3\r\n" {
+		t.Fatalf("RPUSHX 응답: %q", response)
+	}
+}
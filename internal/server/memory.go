@@ -0,0 +1,213 @@
+package server
+
+import (
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// maxmemory-samples 기본값. 축출할 키를 고를 때 데이터베이스마다 뽑는 키 수
+const defaultMaxmemorySamples = 5
+
+// 메모리를 늘릴 수 있는 명령어. maxmemory를 넘었는데 축출로 공간을 만들지 못하면 거부한다
+var denyOOMCommands = map[string]bool{
+	"SET": true, "SETNX": true, "MSET": true, "MSETNX": true, "GETSET": true,
+	"APPEND": true, "SETRANGE": true, "INCR": true, "DECR": true, "INCRBY": true,
+	"DECRBY": true, "INCRBYFLOAT": true,
+	"LPUSH": true, "RPUSH": true, "LPUSHX": true, "RPUSHX": true, "LINSERT": true,
+	"LSET": true, "LMOVE": true, "RPOPLPUSH": true, "BLMOVE": true, "BRPOPLPUSH": true,
+	"HSET": true, "HSETNX": true, "HINCRBY": true, "HINCRBYFLOAT": true,
+	"SADD": true, "SMOVE": true, "SINTERSTORE": true, "SUNIONSTORE": true, "SDIFFSTORE": true,
+	"ZADD": true, "ZINCRBY": true, "ZUNIONSTORE": true, "ZINTERSTORE": true,
+	"XADD": true, "XGROUP": true,
+	"COPY": true,
+}
+
+// maxmemory를 넘었으면 정책에 따라 키를 축출한다.
+// 한도 아래로 내려갔거나 한도가 없으면 true, 더 지울 키가 없으면 false.
+func (s *Server) freeMemory() bool {
+	limit := s.maxmemory.Load()
+	if limit <= 0 {
+		return true
+	}
+
+	policy := storage.EvictionPolicy(s.maxmemoryPolicy.Load())
+	samples := int(s.maxmemorySamples.Load())
+	for s.usedMemory() > limit {
+		if !storage.Evict(s.dbs, policy, samples) {
+			return false
+		}
+		s.evictedKeys.Add(1)
+	}
+	return true
+}
+
+// 모든 데이터베이스가 쓰는 메모리 추정치의 합(바이트)
+func (s *Server) usedMemory() int64 {
+	var used int64
+	for _, db := range s.dbs {
+		used += db.UsedMemory()
+	}
+	return used
+}
+
+// 메모리 크기 단위 (Redis 설정 파일과 같다)
+var memoryUnits = map[string]int64{
+	"":   1,
+	"b":  1,
+	"k":  1000,
+	"kb": 1024,
+	"m":  1000 * 1000,
+	"mb": 1024 * 1024,
+	"g":  1000 * 1000 * 1000,
+	"gb": 1024 * 1024 * 1024,
+}
+
+// "100mb" 같은 메모리 크기를 바이트 수로 바꾼다.
+func parseMemory(raw string) (int64, error) {
+	raw = strings.ToLower(raw)
+	digits := strings.TrimRight(raw, "abcdefghijklmnopqrstuvwxyz")
+	unit, exist := memoryUnits[raw[len(digits):]]
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if !exist || err != nil || n < 0 {
+		return 0, errors.New("argument must be a memory value")
+	}
+	return n * unit, nil
+}
+
+// MEMORY USAGE key [SAMPLES count] | STATS
+func (s *Server) handleMemory(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "USAGE":
+		if len(args) != 3 && len(args) != 5 {
+			c.writer.WriteError("syntax error")
+			return
+		}
+
+		samples := storage.DefaultMemorySamples
+		if len(args) == 5 {
+			if strings.ToUpper(args[3].Str) != "SAMPLES" {
+				c.writer.WriteError("syntax error")
+				return
+			}
+			n, err := strconv.Atoi(args[4].Str)
+			if err != nil || n < 0 {
+				c.writer.WriteError("value is out of range, must be positive")
+				return
+			}
+			samples = n
+		}
+
+		usage, exist := c.db.MemoryUsage(args[2].Str, samples)
+		if !exist {
+			c.writer.WriteNull()
+			return
+		}
+		c.writer.WriteInteger(int(usage))
+
+	case "STATS":
+		s.writeMemoryStats(c)
+
+	default:
+		c.writer.WriteError("unknown MEMORY subcommand '" + args[1].Str + "'")
+	}
+}
+
+// MEMORY STATS 응답. 데이터베이스마다 쌓아 둔 합계만 읽으므로 키 수와 상관없이 빠르다.
+// [이름1, 값1, 이름2, 값2, ...] 형태이고, 키가 있는 데이터베이스는 db.<번호> 항목으로 따로 보여 준다.
+func (s *Server) writeMemoryStats(c *client) {
+	var runtimeStats runtime.MemStats
+	runtime.ReadMemStats(&runtimeStats)
+
+	stats := make([]storage.MemoryStats, len(s.dbs))
+	var keys int
+	var dataset, overhead int64
+	nonEmpty := 0
+	for i, db := range s.dbs {
+		stats[i] = db.MemoryStats()
+		keys += stats[i].Keys
+		dataset += stats[i].Dataset
+		overhead += stats[i].MainOverhead + stats[i].ExpiresOverhead
+		if stats[i].Keys > 0 {
+			nonEmpty++
+		}
+	}
+
+	var bytesPerKey int64
+	if keys > 0 {
+		bytesPerKey = (dataset + overhead) / int64(keys)
+	}
+	var percentage float64
+	if dataset+overhead > 0 {
+		percentage = float64(dataset) * 100 / float64(dataset+overhead)
+	}
+
+	c.writer.WriteArrayLen((7 + nonEmpty) * 2)
+	c.writer.WriteBulkString("total.allocated")
+	c.writer.WriteInteger(int(runtimeStats.HeapAlloc))
+	c.writer.WriteBulkString("used.memory")
+	c.writer.WriteInteger(int(dataset + overhead))
+	c.writer.WriteBulkString("overhead.total")
+	c.writer.WriteInteger(int(overhead))
+	c.writer.WriteBulkString("keys.count")
+	c.writer.WriteInteger(keys)
+	c.writer.WriteBulkString("keys.bytes-per-key")
+	c.writer.WriteInteger(int(bytesPerKey))
+	c.writer.WriteBulkString("dataset.bytes")
+	c.writer.WriteInteger(int(dataset))
+	c.writer.WriteBulkString("dataset.percentage")
+	c.writer.WriteBulkString(strconv.FormatFloat(percentage, 'f', -1, 64))
+	for i, stat := range stats {
+		if stat.Keys == 0 {
+			continue
+		}
+		c.writer.WriteBulkString("db." + strconv.Itoa(i))
+		c.writer.WriteArrayLen(4)
+		c.writer.WriteBulkString("overhead.hashtable.main")
+		c.writer.WriteInteger(int(stat.MainOverhead))
+		c.writer.WriteBulkString("overhead.hashtable.expires")
+		c.writer.WriteInteger(int(stat.ExpiresOverhead))
+	}
+}
+
+// OBJECT ENCODING | IDLETIME | FREQ | REFCOUNT key
+// 키의 접근 정보는 바꾸지 않는다. 키가 없으면 nil
+func (s *Server) handleObject(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	subcommand := strings.ToUpper(args[1].Str)
+	switch subcommand {
+	case "ENCODING", "IDLETIME", "FREQ", "REFCOUNT":
+	default:
+		c.writer.WriteError("unknown OBJECT subcommand '" + args[1].Str + "'")
+		return
+	}
+
+	info, exist := c.db.Object(args[2].Str)
+	if !exist {
+		c.writer.WriteNull()
+		return
+	}
+
+	switch subcommand {
+	case "ENCODING":
+		c.writer.WriteBulkString(info.Encoding)
+	case "IDLETIME":
+		c.writer.WriteInteger(int(info.Idle.Seconds()))
+	case "FREQ":
+		c.writer.WriteInteger(info.Freq)
+	case "REFCOUNT":
+		c.writer.WriteInteger(info.RefCount)
+	}
+}
@@ -0,0 +1,116 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// INFO 응답에서 name 필드 값을 찾는다.
+func infoField(t *testing.T, info, name string) string {
+	t.Helper()
+	for _, line := range strings.Split(info, "\r\n") {
+		if value, found := strings.CutPrefix(line, name+":"); found {
+			return value
+		}
+	}
+	t.Fatalf("INFO에 %s 필드가 없습니다: %q", name, info)
+	return ""
+}
+
+func TestMaxmemoryNoEviction(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "oom-key", "v")
+	defer do(t, conn, reader, "CONFIG", "SET", "maxmemory", "0")
+	if response := do(t, conn, reader, "CONFIG", "SET", "maxmemory", "1"); response != "+OK\r\n" {
+		t.Fatalf("CONFIG SET maxmemory 응답: %q", response)
+	}
+
+	// when & then: 쓰기는 거부되고 읽기와 삭제는 된다
+	if response := do(t, conn, reader, "SET", "oom-other", "v"); response != "-OOM command not allowed when used memory > 'maxmemory'.\r\n" {
+		t.Fatalf("maxmemory 초과 SET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GET", "oom-key"); response != "$1\r\nv\r\n" {
+		t.Fatalf("maxmemory 초과 GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DEL", "oom-key"); response != ":1\r\n" {
+		t.Fatalf("maxmemory 초과 DEL 응답: %q", response)
+	}
+}
+
+func TestMaxmemoryEviction(t *testing.T) {
+	// given: 지금 사용량보다 조금 큰 한도
+	conn, reader := dial(t)
+	info := do(t, conn, reader, "INFO", "memory")
+	used, _ := strconv.Atoi(infoField(t, info, "used_memory"))
+	evictedBefore, _ := strconv.Atoi(infoField(t, do(t, conn, reader, "INFO", "stats"), "evicted_keys"))
+
+	defer do(t, conn, reader, "CONFIG", "SET", "maxmemory-policy", "noeviction")
+	defer do(t, conn, reader, "CONFIG", "SET", "maxmemory", "0")
+	do(t, conn, reader, "CONFIG", "SET", "maxmemory-policy", "allkeys-lru")
+	do(t, conn, reader, "CONFIG", "SET", "maxmemory", strconv.Itoa(used+4096))
+
+	// when: 한도를 넘을 만큼 쓴다
+	for i := range 100 {
+		if response := do(t, conn, reader, "SET", "evict-"+strconv.Itoa(i), strings.Repeat("x", 100)); response != "+OK\r\n" {
+			t.Fatalf("allkeys-lru SET 응답: %q", response)
+		}
+	}
+
+	// then
+	stats := do(t, conn, reader, "INFO", "stats")
+	if evicted, _ := strconv.Atoi(infoField(t, stats, "evicted_keys")); evicted <= evictedBefore {
+		t.Fatalf("evicted_keys: %d, before: %d", evicted, evictedBefore)
+	}
+	memory := do(t, conn, reader, "INFO", "memory")
+	if policy := infoField(t, memory, "maxmemory_policy"); policy != "allkeys-lru" {
+		t.Fatalf("maxmemory_policy: %s", policy)
+	}
+	if response := do(t, conn, reader, "CONFIG", "SET", "maxmemory-policy", "nope"); !strings.HasPrefix(response, "-ERR") {
+		t.Fatalf("잘못된 maxmemory-policy 응답: %q", response)
+	}
+}
+
+func TestObjectAndMemoryCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "object-int", "42")
+	do(t, conn, reader, "SET", "object-str", "hello")
+	do(t, conn, reader, "RPUSH", "object-list", "a", "b")
+
+	// when & then
+	if response := do(t, conn, reader, "OBJECT", "ENCODING", "object-int"); response != "$3\r\nint\r\n" {
+		t.Fatalf("OBJECT ENCODING int 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "ENCODING", "object-str"); response != "$6\r\nembstr\r\n" {
+		t.Fatalf("OBJECT ENCODING embstr 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "ENCODING", "object-list"); response != "$8\r\nlistpack\r\n" {
+		t.Fatalf("OBJECT ENCODING listpack 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "IDLETIME", "object-str"); response != ":0\r\n" {
+		t.Fatalf("OBJECT IDLETIME 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "REFCOUNT", "object-str"); response != ":1\r\n" {
+		t.Fatalf("OBJECT REFCOUNT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "FREQ", "object-str"); !strings.HasPrefix(response, ":") {
+		t.Fatalf("OBJECT FREQ 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "ENCODING", "object-missing"); response != "$-1\r\n" {
+		t.Fatalf("없는 키 OBJECT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "OBJECT", "NOPE", "object-str"); response != "-ERR unknown OBJECT subcommand 'NOPE'\r\n" {
+		t.Fatalf("잘못된 OBJECT 하위 명령어 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MEMORY", "USAGE", "object-list", "SAMPLES", "0"); !strings.HasPrefix(response, ":") || response == ":0\r\n" {
+		t.Fatalf("MEMORY USAGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MEMORY", "USAGE", "object-missing"); response != "$-1\r\n" {
+		t.Fatalf("없는 키 MEMORY USAGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MEMORY", "STATS"); !strings.Contains(response, "dataset.bytes") || !strings.Contains(response, "keys.count") {
+		t.Fatalf("MEMORY STATS 응답: %q", response)
+	}
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"runtime/pprof"
+	"runtime/trace"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 디버그 서버(debug-addr)의 pprof 핸들러.
+// net/http/pprof는 가져오기만 해도 http.DefaultServeMux에 핸들러를 등록하므로, 이 패키지를 가져다 쓰는
+// 프로그램의 HTTP 서버에 /debug/pprof가 노출되지 않도록 runtime/pprof로 같은 경로를 직접 제공한다.
+// go tool pprof http://addr/debug/pprof/profile 처럼 그대로 쓸 수 있다.
+func debugHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/pprof/", pprofProfileHandler)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprofCmdlineHandler)
+	mux.HandleFunc("GET /debug/pprof/profile", pprofCPUHandler)
+	mux.HandleFunc("GET /debug/pprof/trace", pprofTraceHandler)
+	return mux
+}
+
+// /debug/pprof/: 프로필 목록. /debug/pprof/heap?debug=1 처럼 이름을 주면 그 프로필
+func pprofProfileHandler(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/debug/pprof/")
+	if name == "" {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		for _, profile := range pprof.Profiles() {
+			fmt.Fprintf(w, "%s\t%d\t/debug/pprof/%s?debug=1\n", profile.Name(), profile.Count(), profile.Name())
+		}
+		fmt.Fprintln(w, "profile\t-\t/debug/pprof/profile?seconds=30")
+		fmt.Fprintln(w, "trace\t-\t/debug/pprof/trace?seconds=1")
+		return
+	}
+
+	profile := pprof.Lookup(name)
+	if profile == nil {
+		http.Error(w, "unknown profile", http.StatusNotFound)
+		return
+	}
+	debug, _ := strconv.Atoi(r.FormValue("debug"))
+	if debug > 0 {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+	}
+	profile.WriteTo(w, debug)
+}
+
+func pprofCmdlineHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprint(w, strings.Join(os.Args, "\x00"))
+}
+
+// seconds초 동안 CPU 프로필을 모은다 (기본 30초)
+func pprofCPUHandler(w http.ResponseWriter, r *http.Request) {
+	duration := profileDuration(r, 30*time.Second)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="profile"`)
+	if err := pprof.StartCPUProfile(w); err != nil {
+		http.Error(w, "Could not enable CPU profiling: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleepOrDone(r, duration)
+	pprof.StopCPUProfile()
+}
+
+// seconds초 동안 실행 추적을 모은다 (기본 1초)
+func pprofTraceHandler(w http.ResponseWriter, r *http.Request) {
+	duration := profileDuration(r, time.Second)
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="trace"`)
+	if err := trace.Start(w); err != nil {
+		http.Error(w, "Could not enable tracing: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	sleepOrDone(r, duration)
+	trace.Stop()
+}
+
+func profileDuration(r *http.Request, fallback time.Duration) time.Duration {
+	seconds, err := strconv.ParseFloat(r.FormValue("seconds"), 64)
+	if err != nil || seconds <= 0 {
+		return fallback
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// d만큼 기다린다. 요청이 먼저 끊기면 바로 반환한다.
+func sleepOrDone(r *http.Request, d time.Duration) {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-r.Context().Done():
+	}
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDebugHandler(t *testing.T) {
+	// given
+	server := httptest.NewServer(debugHandler())
+	defer server.Close()
+
+	// when & then: 프로필 목록과 이름으로 찾은 프로필을 제공한다
+	for path, expected := range map[string]string{
+		"/debug/pprof/":                  "goroutine",
+		"/debug/pprof/goroutine?debug=1": "goroutine profile:",
+	} {
+		response, err := http.Get(server.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body strings.Builder
+		buf := make([]byte, 4096)
+		n, _ := response.Body.Read(buf)
+		body.Write(buf[:n])
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK || !strings.Contains(body.String(), expected) {
+			t.Fatalf("%s 응답: %d %q", path, response.StatusCode, body.String())
+		}
+	}
+	if response, _ := http.Get(server.URL + "/debug/pprof/nosuch"); response.StatusCode != http.StatusNotFound {
+		t.Fatalf("없는 프로필 응답: %d", response.StatusCode)
+	}
+
+	// then: 기본 ServeMux에는 등록하지 않는다
+	request := httptest.NewRequest("GET", "/debug/pprof/", nil)
+	if _, pattern := http.DefaultServeMux.Handler(request); pattern != "" {
+		t.Fatalf("http.DefaultServeMux에 %q가 등록되어 있습니다", pattern)
+	}
+}
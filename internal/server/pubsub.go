@@ -0,0 +1,227 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/pubsub"
+	"strings"
+)
+
+// 구독 모드에서 허용되는 명령어
+var subscribeModeCommands = map[string]bool{
+	"SUBSCRIBE":    true,
+	"UNSUBSCRIBE":  true,
+	"PSUBSCRIBE":   true,
+	"PUNSUBSCRIBE": true,
+	"PING":         true,
+}
+
+// 연결이 구독 모드(채널 또는 패턴을 하나 이상 구독 중)인지 확인한다.
+func (s *Server) inSubscribeMode(c *client) bool {
+	return c.sub != nil && s.pubsub.Count(c.sub) > 0
+}
+
+// SUBSCRIBE channel [channel ...]
+func (s *Server) handleSubscribe(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	s.startSubscriber(c)
+	for _, arg := range args[1:] {
+		count := s.pubsub.Subscribe(c.sub, arg.Str)
+		writeSubscription(c.writer, "subscribe", arg.Str, count)
+	}
+}
+
+// PSUBSCRIBE pattern [pattern ...]
+func (s *Server) handlePSubscribe(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	s.startSubscriber(c)
+	for _, arg := range args[1:] {
+		count := s.pubsub.PSubscribe(c.sub, arg.Str)
+		writeSubscription(c.writer, "psubscribe", arg.Str, count)
+	}
+}
+
+// UNSUBSCRIBE [channel ...]
+// 인자가 없으면 구독 중인 모든 채널을 해제한다.
+func (s *Server) handleUnsubscribe(c *client, args []protocol.Value) {
+	var channels []string
+	if len(args) > 1 {
+		for _, arg := range args[1:] {
+			channels = append(channels, arg.Str)
+		}
+	} else if c.sub != nil {
+		channels = s.pubsub.SubscribedChannels(c.sub)
+	}
+
+	if len(channels) == 0 {
+		writeEmptySubscription(c.writer, "unsubscribe", s.subscriptionCount(c))
+		return
+	}
+
+	for _, channel := range channels {
+		count := 0
+		if c.sub != nil {
+			count = s.pubsub.Unsubscribe(c.sub, channel)
+		}
+		writeSubscription(c.writer, "unsubscribe", channel, count)
+	}
+}
+
+// PUNSUBSCRIBE [pattern ...]
+// 인자가 없으면 구독 중인 모든 패턴을 해제한다.
+func (s *Server) handlePUnsubscribe(c *client, args []protocol.Value) {
+	var patterns []string
+	if len(args) > 1 {
+		for _, arg := range args[1:] {
+			patterns = append(patterns, arg.Str)
+		}
+	} else if c.sub != nil {
+		patterns = s.pubsub.SubscribedPatterns(c.sub)
+	}
+
+	if len(patterns) == 0 {
+		writeEmptySubscription(c.writer, "punsubscribe", s.subscriptionCount(c))
+		return
+	}
+
+	for _, pattern := range patterns {
+		count := 0
+		if c.sub != nil {
+			count = s.pubsub.PUnsubscribe(c.sub, pattern)
+		}
+		writeSubscription(c.writer, "punsubscribe", pattern, count)
+	}
+}
+
+// PUBLISH channel message
+// 메시지를 받은 구독자 수를 반환한다.
+func (s *Server) handlePublish(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	receivers := s.pubsub.Publish(args[1].Str, args[2].Str)
+	c.writer.WriteInteger(receivers)
+}
+
+// PUBSUB CHANNELS [pattern] | NUMSUB [channel ...] | NUMPAT
+func (s *Server) handlePubSub(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "CHANNELS":
+		pattern := ""
+		if len(args) > 2 {
+			pattern = args[2].Str
+		}
+		c.writer.WriteArray(s.pubsub.Channels(pattern))
+
+	case "NUMSUB":
+		channels := make([]string, 0, len(args)-2)
+		for _, arg := range args[2:] {
+			channels = append(channels, arg.Str)
+		}
+		counts := s.pubsub.NumSub(channels...)
+
+		// [channel1, count1, channel2, count2, ...]
+		c.writer.WriteArrayLen(len(channels) * 2)
+		for i, channel := range channels {
+			c.writer.WriteBulkString(channel)
+			c.writer.WriteInteger(counts[i])
+		}
+
+	case "NUMPAT":
+		c.writer.WriteInteger(s.pubsub.NumPat())
+
+	default:
+		c.writer.WriteError("unknown PUBSUB subcommand '" + args[1].Str + "'")
+	}
+}
+
+// 구독 모드의 PING은 일반 응답 대신 ["pong", message] 배열로 응답한다.
+func (s *Server) handleSubscribedPing(c *client, args []protocol.Value) {
+	message := ""
+	if len(args) > 1 {
+		message = args[1].Str
+	}
+	c.writer.WriteArrayLen(2)
+	c.writer.WriteBulkString("pong")
+	c.writer.WriteBulkString(message)
+}
+
+// 연결의 구독자를 만들고, 메시지를 연결로 전달하는 고루틴을 시작한다.
+// 이미 구독자가 있으면 아무것도 하지 않는다.
+func (s *Server) startSubscriber(c *client) {
+	if c.sub != nil {
+		return
+	}
+	c.sub = pubsub.NewSubscriber(pubsub.DefaultBufferSize)
+
+	go func() {
+		for {
+			select {
+			case <-c.done:
+				return
+			case msg := <-c.sub.Messages():
+				c.mu.Lock()
+				writeMessage(c.writer, msg)
+				c.mu.Unlock()
+			}
+		}
+	}()
+
+	// 전달 고루틴이 느린 소켓 쓰기에 막혀있을 수 있으므로 overflow 감시는 따로 한다.
+	// 연결을 닫으면 막혀있던 쓰기와 읽기 루프가 모두 에러로 빠져나온다.
+	go func() {
+		select {
+		case <-c.done:
+		case <-c.sub.Overflow():
+			s.logger.Printf("느린 구독자의 연결을 종료합니다: %s", c.conn.RemoteAddr())
+			c.conn.Close()
+		}
+	}()
+}
+
+func (s *Server) subscriptionCount(c *client) int {
+	if c.sub == nil {
+		return 0
+	}
+	return s.pubsub.Count(c.sub)
+}
+
+// 구독/해제 확인 응답: [kind, channel, count]
+func writeSubscription(writer *protocol.Writer, kind, channel string, count int) {
+	writer.WriteArrayLen(3)
+	writer.WriteBulkString(kind)
+	writer.WriteBulkString(channel)
+	writer.WriteInteger(count)
+}
+
+// 구독이 하나도 없는 상태에서 해제를 요청하면 channel 자리에 null을 쓴다.
+func writeEmptySubscription(writer *protocol.Writer, kind string, count int) {
+	writer.WriteArrayLen(3)
+	writer.WriteBulkString(kind)
+	writer.WriteNull()
+	writer.WriteInteger(count)
+}
+
+// 채널 메시지: ["message", channel, payload]
+// 패턴 메시지: ["pmessage", pattern, channel, payload]
+func writeMessage(writer *protocol.Writer, msg pubsub.Message) {
+	if msg.Pattern == "" {
+		writer.WriteArray([]string{"message", msg.Channel, msg.Payload})
+	} else {
+		writer.WriteArray([]string{"pmessage", msg.Pattern, msg.Channel, msg.Payload})
+	}
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSubscribeAndPublishCommand(t *testing.T) {
+	// given: 구독자 연결
+	subConn, subReader := dial(t)
+	response := do(t, subConn, subReader, "SUBSCRIBE", "ps-news")
+	if response != "*3\r\n$9\r\nsubscribe\r\n$7\r\nps-news\r\n:1\r\n" {
+		t.Fatalf("SUBSCRIBE 응답: %q", response)
+	}
+
+	// when: 다른 연결에서 PUBLISH
+	pubConn, pubReader := dial(t)
+	receivers := do(t, pubConn, pubReader, "PUBLISH", "ps-news", "hello")
+
+	// then: 수신자 1명, 구독자는 message를 받는다
+	if receivers != ":1\r\n" {
+		t.Fatalf("PUBLISH 응답: %q", receivers)
+	}
+	message := readReply(t, subReader)
+	if message != "*3\r\n$7\r\nmessage\r\n$7\r\nps-news\r\n$5\r\nhello\r\n" {
+		t.Fatalf("메시지: %q", message)
+	}
+}
+
+func TestPSubscribeCommand(t *testing.T) {
+	// given
+	subConn, subReader := dial(t)
+	response := do(t, subConn, subReader, "PSUBSCRIBE", "ps-sport.*")
+	if response != "*3\r\n$10\r\npsubscribe\r\n$10\r\nps-sport.*\r\n:1\r\n" {
+		t.Fatalf("PSUBSCRIBE 응답: %q", response)
+	}
+
+	// when
+	pubConn, pubReader := dial(t)
+	do(t, pubConn, pubReader, "PUBLISH", "ps-sport.soccer", "goal")
+
+	// then: pmessage에 패턴과 실제 채널이 함께 온다
+	message := readReply(t, subReader)
+	expected := "*4\r\n$8\r\npmessage\r\n$10\r\nps-sport.*\r\n$15\r\nps-sport.soccer\r\n$4\r\ngoal\r\n"
+	if message != expected {
+		t.Fatalf("메시지: %q", message)
+	}
+}
+
+func TestSubscribeModeRejectsOtherCommands(t *testing.T) {
+	// given: 구독 모드 진입
+	conn, reader := dial(t)
+	do(t, conn, reader, "SUBSCRIBE", "ps-mode")
+
+	// when: 일반 명령어 실행
+	response := do(t, conn, reader, "GET", "some-key")
+
+	// then
+	expected := "-ERR Can't execute 'get': only (P)SUBSCRIBE / (P)UNSUBSCRIBE / PING are allowed in this context\r\n"
+	if response != expected {
+		t.Fatalf("응답: %q", response)
+	}
+
+	// PING은 ["pong", ""] 배열로 응답한다
+	ping := do(t, conn, reader, "PING")
+	if ping != "*2\r\n$4\r\npong\r\n$0\r\n\r\n" {
+		t.Fatalf("PING 응답: %q", ping)
+	}
+}
+
+func TestUnsubscribeLeavesSubscribeMode(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SUBSCRIBE", "ps-a", "ps-b")
+	readReply(t, reader) // 두 번째 채널의 subscribe 응답
+
+	// when: 인자 없이 UNSUBSCRIBE → 모든 채널 해제
+	first := do(t, conn, reader, "UNSUBSCRIBE")
+	second := readReply(t, reader)
+
+	// then: 채널 이름순으로 해제되고 남은 구독 수가 줄어든다
+	if first != "*3\r\n$11\r\nunsubscribe\r\n$4\r\nps-a\r\n:1\r\n" {
+		t.Fatalf("첫 번째 UNSUBSCRIBE 응답: %q", first)
+	}
+	if second != "*3\r\n$11\r\nunsubscribe\r\n$4\r\nps-b\r\n:0\r\n" {
+		t.Fatalf("두 번째 UNSUBSCRIBE 응답: %q", second)
+	}
+
+	// 구독 모드를 벗어나 일반 명령어가 동작한다
+	if ping := do(t, conn, reader, "PING"); ping != "+PONG\r\n" {
+		t.Fatalf("PING 응답: %q", ping)
+	}
+}
+
+func TestPubSubIntrospection(t *testing.T) {
+	// given
+	subConn, subReader := dial(t)
+	do(t, subConn, subReader, "SUBSCRIBE", "ps-intro")
+	do(t, subConn, subReader, "PSUBSCRIBE", "ps-intro-*")
+
+	conn, reader := dial(t)
+
+	// when & then: CHANNELS
+	channels := do(t, conn, reader, "PUBSUB", "CHANNELS", "ps-intro*")
+	if channels != "*1\r\n$8\r\nps-intro\r\n" {
+		t.Fatalf("PUBSUB CHANNELS 응답: %q", channels)
+	}
+
+	// NUMSUB: [channel, count, ...]
+	numsub := do(t, conn, reader, "PUBSUB", "NUMSUB", "ps-intro", "ps-none")
+	if numsub != "*4\r\n$8\r\nps-intro\r\n:1\r\n$7\r\nps-none\r\n:0\r\n" {
+		t.Fatalf("PUBSUB NUMSUB 응답: %q", numsub)
+	}
+
+	// NUMPAT: 다른 테스트의 패턴 구독이 남아있을 수 있으므로 1 이상인지만 확인
+	numpat := do(t, conn, reader, "PUBSUB", "NUMPAT")
+	if count, _ := strconv.Atoi(strings.TrimSpace(numpat[1:])); numpat[0] != ':' || count < 1 {
+		t.Fatalf("PUBSUB NUMPAT 응답: %q", numpat)
+	}
+}
+
+func TestDisconnectRemovesSubscriptions(t *testing.T) {
+	// given
+	subConn, subReader := dial(t)
+	do(t, subConn, subReader, "SUBSCRIBE", "ps-gone")
+
+	// when: 구독자 연결 종료
+	subConn.Close()
+	time.Sleep(100 * time.Millisecond)
+
+	// then: 수신자가 없다
+	conn, reader := dial(t)
+	if response := do(t, conn, reader, "PUBLISH", "ps-gone", "x"); response != ":0\r\n" {
+		t.Fatalf("PUBLISH 응답: %q", response)
+	}
+}
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"strconv"
+	"strings"
+)
+
+// SCAN 계열 명령어(HSCAN 등)의 옵션
+type scanOptions struct {
+	pattern  string // MATCH. 비어있으면 모두
+	count    int    // COUNT. 한 번에 훑을 요소 수의 힌트
+	noValues bool   // NOVALUES. HSCAN에서 값 없이 필드만 반환
+	typeName string // TYPE. SCAN에서 이 타입의 키만 반환. 비어있으면 모두
+}
+
+// 커서 인자를 파싱한다.
+func parseScanCursor(raw string) (uint64, error) {
+	cursor, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, errors.New("invalid cursor")
+	}
+	return cursor, nil
+}
+
+// [MATCH pattern] [COUNT count] 옵션을 파싱한다.
+// allowNoValues면 NOVALUES를, allowType이면 TYPE type도 받는다.
+func parseScanOptions(args []protocol.Value, allowNoValues, allowType bool) (scanOptions, error) {
+	options := scanOptions{count: 10}
+
+	for i := 0; i < len(args); i++ {
+		switch strings.ToUpper(args[i].Str) {
+		case "MATCH":
+			if i+1 >= len(args) {
+				return options, errors.New("syntax error")
+			}
+			options.pattern = args[i+1].Str
+			// "*"는 모두와 일치하므로 매칭을 건너뛴다
+			if options.pattern == "*" {
+				options.pattern = ""
+			}
+			i++
+		case "COUNT":
+			if i+1 >= len(args) {
+				return options, errors.New("syntax error")
+			}
+			count, err := strconv.Atoi(args[i+1].Str)
+			if err != nil {
+				return options, errors.New("value is not an integer or out of range")
+			}
+			if count < 1 {
+				return options, errors.New("syntax error")
+			}
+			options.count = count
+			i++
+		case "NOVALUES":
+			if !allowNoValues {
+				return options, errors.New("syntax error")
+			}
+			options.noValues = true
+		case "TYPE":
+			if !allowType || i+1 >= len(args) {
+				return options, errors.New("syntax error")
+			}
+			typeName := strings.ToLower(args[i+1].Str)
+			if _, ok := storage.ParseEntryType(typeName); !ok {
+				return options, errors.New("unknown type name '" + args[i+1].Str + "'")
+			}
+			options.typeName = typeName
+			i++
+		default:
+			return options, errors.New("syntax error")
+		}
+	}
+	return options, nil
+}
+
+// [cursor, [element ...]] 형태로 SCAN 응답을 쓴다.
+func writeScanReply(writer *protocol.Writer, cursor uint64, elements []string) {
+	writer.WriteArrayLen(2)
+	writer.WriteBulkString(strconv.FormatUint(cursor, 10))
+	writer.WriteArray(elements)
+}
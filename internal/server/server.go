@@ -1,73 +1,270 @@
 package server
 
 import (
-	"bufio"
+	"context"
+	"fmt"
 	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/pubsub"
 	"inmemory-db/internal/storage"
 	"log"
 	"net"
 	"net/http"
-	_ "net/http/pprof"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 서버가 가지는 데이터베이스 수 (Redis databases 기본값)
+const defaultDatabases = 16
+
+// 설정 기본값
+const (
+	defaultPort       = 6379
+	defaultDBFilename = "dump.rdb"
+	defaultDebugAddr  = "127.0.0.1:6060"
 )
 
 // TCP 서버
 type Server struct {
 	listener net.Listener
-	addr     string
-	store    *storage.Store
+	// 번호가 매겨진 데이터베이스들. 연결은 SELECT로 하나를 고른다 (기본 0번)
+	dbs      []*storage.Store
+	pubsub   *pubsub.Hub
+	notifier *pubsub.KeyspaceNotifier
+
+	// 연결된 클라이언트 목록 (CLIENT UNBLOCK 등에서 ID로 찾는다)
+	clientsMu    sync.Mutex
+	clients      map[int64]*client
+	nextClientID atomic.Int64
+
+	// 메모리 한도(바이트, 0이면 무제한)와 축출 정책. CONFIG SET으로 바뀐다
+	maxmemory        atomic.Int64
+	maxmemoryPolicy  atomic.Int32
+	maxmemorySamples atomic.Int32
+	// 메모리 한도 때문에 축출된 키 수 (INFO stats의 evicted_keys)
+	evictedKeys atomic.Int64
+
+	// 모든 데이터베이스가 함께 쓰는 시계. 시스템 시각을 따르다가 DEBUG SET-CLOCK/ADVANCE-CLOCK으로 멈추고 옮긴다
+	clock *storage.ManualClock
+
+	// 시작할 때만 정할 수 있는 설정 (config.go)
+	bind      string
+	port      int
+	debugAddr string
+	// DEBUG 명령어를 허용할지: "yes", "no", "local"(루프백 연결만)
+	debugCommand string
+	// 시작한 뒤에는 bind, port 등을 바꿀 수 없다
+	started atomic.Bool
+
+	// 여러 항목을 바꾸는 CONFIG SET끼리 섞이지 않게 한다 (실패하면 되돌리는 동안 다른 CONFIG SET이 끼어들지 않게)
+	configSetMu sync.Mutex
+
+	// 스냅샷 파일 위치와 설정 파일 경로. CONFIG SET/REWRITE가 다른 연결에서 바꾸므로 configMu로 보호한다
+	configMu   sync.RWMutex
+	dir        string
+	dbfilename string
+	configFile string
+
+	// 명령어 없이 이 시간(초)이 지난 연결을 끊는다 (0이면 끊지 않음). 새 연결의 TCP keepalive 주기(초)
+	timeout      atomic.Int64
+	tcpKeepalive atomic.Int64
+
+	// 종료 처리 (shutdown.go). Shutdown이 quit을 닫으면 Serve가 연결을 정리하고 반환한다
+	quit            chan struct{}
+	shutdownOnce    sync.Once
+	saveOnShutdown  atomic.Bool
+	shutdownTimeout atomic.Int64
+	// 처리 중인 연결 수
+	connWG sync.WaitGroup
+	// 기다리지 않고 남은 연결을 끊으라는 신호 (ForceShutdown)
+	force     chan struct{}
+	forceOnce sync.Once
+	// pprof 디버그 서버 (debug-addr가 비었으면 nil)
+	debugServer *http.Server
+
+	// 서버 로그 출력
+	logger *log.Logger
+	// 스냅샷 파일을 읽고 쓰지 않는다 (DisableSnapshots)
+	snapshotsDisabled bool
+
+	// ACL 사용자 (acl.go). 명령어마다 읽으므로 맵을 통째로 바꿔 끼우고, 바꾸는 쪽만 aclMu로 직렬화한다
+	aclMu       sync.Mutex
+	aclUsers    atomic.Pointer[map[string]*aclUser]
+	requirepass string
+	// 사용자를 읽고 쓸 파일 (시작할 때만 정할 수 있다)
+	aclFile string
+	// 비밀번호가 없으면 루프백 밖의 연결을 거부한다
+	protectedMode atomic.Bool
+	// 거부된 요청 기록 (ACL LOG). 최근 것이 앞에 온다
+	aclLogMu     sync.Mutex
+	aclLog       []*aclLogEntry
+	aclLogNextID int64
+	aclLogMaxLen atomic.Int64
 }
 
+// addr("host:port")에서 수신하는 서버를 기본 설정으로 만든다.
+// 다른 설정은 LoadConfigFile, SetConfig로 시작하기 전에 바꾼다.
 func New(addr string) *Server {
-	return &Server{
-		addr:  addr,
-		store: storage.New(),
+	hub := pubsub.NewHub()
+	server := &Server{
+		dbs:      make([]*storage.Store, defaultDatabases),
+		pubsub:   hub,
+		notifier: pubsub.NewKeyspaceNotifier(hub),
+		clients:  make(map[int64]*client),
+		clock:    &storage.ManualClock{},
+		quit:     make(chan struct{}),
+		force:    make(chan struct{}),
+		logger:   log.Default(),
+	}
+
+	// 데이터 변경 이벤트를 __keyspace@<db>__ / __keyevent@<db>__ 채널로 발행
+	for i := range server.dbs {
+		db := storage.New(storage.WithClock(server.clock))
+		db.SetNotifier(func(class int, event, key string) {
+			server.notifier.Notify(i, class, event, key)
+		})
+		server.dbs[i] = db
+	}
+
+	server.aclUsers.Store(&map[string]*aclUser{defaultUserName: newDefaultUser()})
+	server.applyDefaultConfig()
+	if host, port, err := net.SplitHostPort(addr); err == nil {
+		server.bind = host
+		server.SetConfig("port", port)
 	}
+	return server
 }
 
 // Start는 서버를 시작하고 연결을 수신합니다.
-// 이 함수는 블로킹됩니다 (무한 루프).
+// Shutdown이 호출될 때까지 블로킹되고, 연결을 정리하고 마지막 스냅샷을 쓴 뒤 반환합니다.
 func (s *Server) Start() error {
-	listener, err := net.Listen("tcp", s.addr)
+	if err := s.Listen(context.Background()); err != nil {
+		return err
+	}
+	return s.Serve()
+}
+
+// Listen은 스냅샷을 읽고, 주소를 열고, 백그라운드 작업을 시작한다. 연결은 Serve가 받는다.
+// 스냅샷이 깨져 읽지 못하면 에러를 반환한다. ctx는 주소를 여는 동안에만 쓰인다.
+func (s *Server) Listen(ctx context.Context) error {
+	if s.aclFile != "" {
+		if err := s.loadACLFile(); err != nil {
+			return err
+		}
+	}
+
+	// 스냅샷을 읽지 못하면 시작하지 않는다. 빈 키 공간으로 떠 있다가 종료할 때 스냅샷을 덮어쓰면 데이터를 잃는다
+	if !s.snapshotsDisabled {
+		if err := storage.LoadAll(s.snapshotPath(), s.dbs); err != nil {
+			return fmt.Errorf("loading snapshot %s: %w", s.snapshotPath(), err)
+		}
+		s.logger.Println("RDB 파일 로딩 완료")
+	}
+
+	addr := net.JoinHostPort(s.bind, strconv.Itoa(s.port))
+	var config net.ListenConfig
+	listener, err := config.Listen(ctx, "tcp", addr)
 
 	if err != nil {
 		return err
 	}
 
-	// 서버 리스너에 저장
+	// 서버 리스너에 저장. 포트를 0으로 줬으면 실제로 열린 포트를 설정에 반영한다
 	s.listener = listener
-	defer s.listener.Close() // 서버 종료 전 리소스 정리
-	log.Printf("현재 서버가 [%s] 에서 리스닝중입니다.", s.addr)
-
-	if err := s.store.Load("dump.rdb"); err != nil {
-		log.Printf("RDB 로딩 실패: %v", err)
-	} else {
-		log.Println("RDB 파일 로딩 완료")
+	if tcp, ok := listener.Addr().(*net.TCPAddr); ok {
+		s.port = tcp.Port
 	}
+	s.started.Store(true)
+	s.logger.Printf("현재 서버가 [%s] 에서 리스닝중입니다.", listener.Addr())
 
 	// pprof 디버그 서버 시작
+	if s.debugAddr != "" {
+		s.debugServer = &http.Server{Addr: s.debugAddr, Handler: debugHandler()}
+		go func() {
+			s.logger.Printf("pprof 서버 시작: http://%s/debug/pprof/", s.debugAddr)
+			s.debugServer.ListenAndServe()
+		}()
+	}
+
+	for _, db := range s.dbs {
+		db.StartExpiry()
+	}
+
+	// Shutdown이 호출되면 리스너를 닫아 Accept를 깨운다
 	go func() {
-		log.Println("pprof 서버 시작: http://localhost:6060/debug/pprof/")
-		http.ListenAndServe(":6060", nil)
+		<-s.quit
+		listener.Close()
 	}()
+	return nil
+}
 
-	s.store.StartExpiry()
-	defer s.store.StopExpiry()
-
-	// 무한루프
+// Serve는 Listen으로 연 주소에서 연결을 받는다.
+// Shutdown이 호출될 때까지 블로킹되고, 연결을 정리하고 마지막 스냅샷을 쓴 뒤 반환한다.
+func (s *Server) Serve() error {
 	for {
 		// Accept() 호출 -> 연결대기(블로킹)
 		conn, err := s.listener.Accept()
 
 		if err != nil {
-			log.Print("연결 중 오류: ", err)
+			if s.closing() {
+				break
+			}
+			s.logger.Print("연결 중 오류: ", err)
 			continue
-		} else {
-			go s.handleConnection(conn)
 		}
+
+		if keepalive := s.tcpKeepalive.Load(); keepalive > 0 {
+			if tcp, ok := conn.(*net.TCPConn); ok {
+				tcp.SetKeepAlive(true)
+				tcp.SetKeepAlivePeriod(time.Duration(keepalive) * time.Second)
+			}
+		}
+		s.connWG.Add(1)
+		go func() {
+			defer s.connWG.Done()
+			s.handleConnection(conn)
+		}()
 	}
 
+	return s.finishShutdown()
+}
+
+// 수신 중인 주소. Listen 전에는 nil이다.
+func (s *Server) Addr() net.Addr {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Addr()
+}
+
+// index번 데이터베이스. 연결을 거치지 않고 데이터를 직접 읽고 쓸 때 쓴다.
+func (s *Server) DB(index int) *storage.Store {
+	return s.dbs[index]
+}
+
+// 데이터베이스 수
+func (s *Server) Databases() int {
+	return len(s.dbs)
+}
+
+// 서버 로그를 logger로 보낸다 (기본값은 log 패키지의 표준 로거). 시작하기 전에 호출한다.
+func (s *Server) SetLogger(logger *log.Logger) {
+	s.logger = logger
+}
+
+// 스냅샷을 쓰지 않는다. 시작할 때 읽지 않고, 종료할 때와 SAVE로 쓰지 않는다. 시작하기 전에 호출한다.
+func (s *Server) DisableSnapshots() {
+	s.snapshotsDisabled = true
+}
+
+// 스냅샷 파일 경로 (dir/dbfilename)
+func (s *Server) snapshotPath() string {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return filepath.Join(s.dir, s.dbfilename)
 }
 
 // 단일 클라이언트 연결을 처리합니다.
@@ -75,168 +272,569 @@ func (s *Server) handleConnection(conn net.Conn) {
 	// 연결 종료 예약
 	defer conn.Close()
 
-	bufReader := bufio.NewReader(conn)
-	reader := protocol.NewReader(bufReader)
-	writer := protocol.NewWriter(conn)
+	c := newClient(s.nextClientID.Add(1), conn, s.dbs[0])
+	reader := protocol.NewReader(c.reader)
+	if s.protectedModeDenies(conn.RemoteAddr()) {
+		c.writer.WriteErrorCode("DENIED", protectedModeMessage)
+		return
+	}
+	c.setUser(s.initialUser())
+
+	s.clientsMu.Lock()
+	s.clients[c.id] = c
+	s.clientsMu.Unlock()
+
+	defer func() {
+		s.clientsMu.Lock()
+		delete(s.clients, c.id)
+		s.clientsMu.Unlock()
+
+		close(c.done)
+		if c.sub != nil {
+			s.pubsub.UnsubscribeAll(c.sub)
+		}
+	}()
+
+	for !s.closing() {
+		// 유휴 연결 끊기. 구독 중인 연결은 메시지만 받으므로 제외한다 (Redis와 같다)
+		idle := time.Duration(s.timeout.Load()) * time.Second
+		if idle > 0 && !s.inSubscribeMode(c) {
+			conn.SetReadDeadline(time.Now().Add(idle))
+		} else {
+			idle = 0
+		}
+		// 데드라인을 정하는 사이에 Shutdown이 읽기를 깨웠을 수 있다
+		if s.closing() {
+			return
+		}
 
-	for {
 		value, err := reader.Read()
 		// EOF면 클라이언트가 연결을 끊은 것이니 루프 탈출 필요
 		if err != nil {
 			return
 		}
+		if idle > 0 {
+			// 블로킹 명령어는 데드라인 없이 기다려야 한다
+			conn.SetReadDeadline(time.Time{})
+		}
+		if len(value.Array) == 0 {
+			continue
+		}
+
+		c.mu.Lock()
+		s.execute(c, value)
+		c.mu.Unlock()
+	}
+}
+
+// 명령어 하나를 실행하고 응답을 쓴다.
+func (s *Server) execute(c *client, value protocol.Value) {
+	writer := c.writer
+	command := strings.ToUpper(value.Array[0].Str)
+
+	// AUTH는 인증 전에도 쓸 수 있다. 나머지는 사용자 권한을 확인한다
+	if command == "AUTH" {
+		s.handleAuth(c, value.Array)
+		return
+	}
+	if !s.checkACL(c, command, value.Array) {
+		return
+	}
+
+	// 구독 모드에서는 구독 관련 명령어와 PING만 허용된다
+	if s.inSubscribeMode(c) {
+		if !subscribeModeCommands[command] {
+			writer.WriteError("Can't execute '" + strings.ToLower(command) + "': only (P)SUBSCRIBE / (P)UNSUBSCRIBE / PING are allowed in this context")
+			return
+		}
+		if command == "PING" {
+			s.handleSubscribedPing(c, value.Array)
+			return
+		}
+	}
 
-		command := strings.ToUpper(value.Array[0].Str)
+	if denyOOMCommands[command] && !s.freeMemory() {
+		writer.WriteErrorCode("OOM", "command not allowed when used memory > 'maxmemory'.")
+		return
+	}
 
-		switch command {
+	switch command {
 
-		case "PING":
-			writer.WriteSimpleString("PONG")
+	case "PING":
+		writer.WriteSimpleString("PONG")
 
-		case "ECHO":
-			if len(value.Array) > 1 {
-				writer.WriteBulkString(value.Array[1].Str)
-			} else {
-				writer.WriteError("missing argument")
-			}
+	case "ECHO":
+		if len(value.Array) > 1 {
+			writer.WriteBulkString(value.Array[1].Str)
+		} else {
+			writer.WriteError("missing argument")
+		}
 
-		case "SET":
-			key := value.Array[1].Str
-			value := value.Array[2].Str
-			s.store.Set(key, value)
+	case "SET":
+		s.handleSet(c, value.Array)
 
-			writer.WriteSimpleString("OK")
+	case "GET":
+		s.handleGet(c, value.Array)
 
-		case "GET":
-			key := value.Array[1].Str
-			result, exist := s.store.Get(key)
-			if exist {
-				writer.WriteBulkString(result)
-			} else {
-				writer.WriteNull()
+	case "LPUSH":
+		if len(value.Array) < 3 {
+			writer.WriteError("missing argument")
+		} else {
+			values := make([]string, 0, len(value.Array)-2)
+			for _, v := range value.Array[2:] {
+				values = append(values, v.Str)
 			}
-
-		case "LPUSH":
-			if len(value.Array) < 3 {
-				writer.WriteError("missing argument")
+			length, err := c.db.LPush(value.Array[1].Str, values...)
+			if err != nil {
+				writer.WriteError(err.Error())
 			} else {
-				values := make([]string, 0, len(value.Array)-2)
-				for _, v := range value.Array[2:] {
-					values = append(values, v.Str)
-				}
-				length, err := s.store.LPush(value.Array[1].Str, values...)
-				if err != nil {
-					writer.WriteError(err.Error())
-				} else {
-					writer.WriteInteger(length)
-				}
+				writer.WriteInteger(length)
 			}
+		}
 
-		case "RPUSH":
-			if len(value.Array) < 3 {
-				writer.WriteError("missing argument")
-			} else {
-				values := make([]string, 0, len(value.Array)-2)
-				for _, v := range value.Array[2:] {
-					values = append(values, v.Str)
-				}
-				length, err := s.store.RPush(value.Array[1].Str, values...)
-				if err != nil {
-					writer.WriteError(err.Error())
-				} else {
-					writer.WriteInteger(length)
-				}
+	case "RPUSH":
+		if len(value.Array) < 3 {
+			writer.WriteError("missing argument")
+		} else {
+			values := make([]string, 0, len(value.Array)-2)
+			for _, v := range value.Array[2:] {
+				values = append(values, v.Str)
 			}
-
-		case "LPOP":
-			if len(value.Array) < 2 {
-				writer.WriteError("missing argument")
+			length, err := c.db.RPush(value.Array[1].Str, values...)
+			if err != nil {
+				writer.WriteError(err.Error())
 			} else {
-				value, result, err := s.store.LPop(value.Array[1].Str)
-				if err != nil {
-					writer.WriteError(err.Error())
-				} else {
-					if !result {
-						writer.WriteNull()
-					} else {
-						writer.WriteBulkString(value)
-					}
-				}
+				writer.WriteInteger(length)
 			}
+		}
 
-		case "RPOP":
-			if len(value.Array) < 2 {
-				writer.WriteError("missing argument")
-			} else {
-				value, result, err := s.store.RPop(value.Array[1].Str)
-				if err != nil {
-					writer.WriteError(err.Error())
-				} else {
-					if !result {
-						writer.WriteNull()
-					} else {
-						writer.WriteBulkString(value)
-					}
-				}
-			}
+	case "LPOP":
+		s.handlePop(c, value.Array, true)
 
-		case "LRANGE":
-			if len(value.Array) < 4 {
-				writer.WriteError("missing argument")
-			} else {
-				start, _ := strconv.Atoi(value.Array[2].Str)
-				stop, _ := strconv.Atoi(value.Array[3].Str)
-				result, err := s.store.LRange(value.Array[1].Str, start, stop)
-				if err != nil {
-					writer.WriteError(err.Error())
-				} else {
-					writer.WriteArray(result)
-				}
-			}
+	case "RPOP":
+		s.handlePop(c, value.Array, false)
 
-		case "EXPIRE":
-			if len(value.Array) < 3 {
-				writer.WriteError("missing argument")
-			} else {
-				seconds, _ := strconv.Atoi(value.Array[2].Str)
-				result := s.store.Expire(value.Array[1].Str, seconds)
-				writer.WriteInteger(result)
-			}
+	case "LPUSHX":
+		s.handlePushX(c, value.Array, true)
 
-		case "TTL":
-			if len(value.Array) < 2 {
-				writer.WriteError("missing argument")
-			} else {
-				result := s.store.TTL(value.Array[1].Str)
-				writer.WriteInteger(result)
-			}
+	case "RPUSHX":
+		s.handlePushX(c, value.Array, false)
 
-		case "DEL":
-			if len(value.Array) < 2 {
-				writer.WriteError("missing argument")
-			} else {
-				result := s.store.Del(value.Array[1].Str)
-				writer.WriteInteger(result)
-			}
+	case "LLEN":
+		s.handleLLen(c, value.Array)
 
-		case "PERSIST":
-			if len(value.Array) < 2 {
-				writer.WriteError("missing argument")
-			} else {
-				result := s.store.Persist(value.Array[1].Str)
-				writer.WriteInteger(result)
-			}
+	case "LINDEX":
+		s.handleLIndex(c, value.Array)
+
+	case "LSET":
+		s.handleLSet(c, value.Array)
+
+	case "LINSERT":
+		s.handleLInsert(c, value.Array)
+
+	case "LREM":
+		s.handleLRem(c, value.Array)
+
+	case "LTRIM":
+		s.handleLTrim(c, value.Array)
 
-		case "SAVE":
-			err := s.store.Save("dump.rdb")
+	case "LPOS":
+		s.handleLPos(c, value.Array)
+
+	case "LMOVE":
+		s.handleLMove(c, value.Array)
+
+	case "RPOPLPUSH":
+		s.handleRPopLPush(c, value.Array)
+
+	case "LRANGE":
+		if len(value.Array) < 4 {
+			writer.WriteError("missing argument")
+		} else {
+			start, _ := strconv.Atoi(value.Array[2].Str)
+			stop, _ := strconv.Atoi(value.Array[3].Str)
+			result, err := c.db.LRange(value.Array[1].Str, start, stop)
 			if err != nil {
 				writer.WriteError(err.Error())
 			} else {
-				writer.WriteSimpleString("OK")
+				writer.WriteArray(result)
 			}
+		}
+
+	case "EXPIRE":
+		s.handleExpire(c, value.Array, time.Second, false)
+
+	case "PEXPIRE":
+		s.handleExpire(c, value.Array, time.Millisecond, false)
+
+	case "EXPIREAT":
+		s.handleExpire(c, value.Array, time.Second, true)
+
+	case "PEXPIREAT":
+		s.handleExpire(c, value.Array, time.Millisecond, true)
+
+	case "TTL":
+		s.handleTTL(c, value.Array, false)
+
+	case "PTTL":
+		s.handleTTL(c, value.Array, true)
+
+	case "EXPIRETIME":
+		s.handleExpireTime(c, value.Array, false)
+
+	case "PEXPIRETIME":
+		s.handleExpireTime(c, value.Array, true)
+
+	case "DEL":
+		s.handleDel(c, value.Array, false)
 
-		default:
-			writer.WriteError("unknown command")
+	case "UNLINK":
+		s.handleDel(c, value.Array, true)
+
+	case "EXISTS":
+		s.handleExists(c, value.Array, false)
+
+	case "TOUCH":
+		s.handleExists(c, value.Array, true)
+
+	case "TYPE":
+		s.handleType(c, value.Array)
+
+	case "RENAME":
+		s.handleRename(c, value.Array)
+
+	case "RENAMENX":
+		s.handleRenameNX(c, value.Array)
+
+	case "COPY":
+		s.handleCopy(c, value.Array)
+
+	case "DBSIZE":
+		s.handleDBSize(c)
+
+	case "RANDOMKEY":
+		s.handleRandomKey(c)
+
+	case "SCAN":
+		s.handleScan(c, value.Array)
+
+	case "KEYS":
+		s.handleKeys(c, value.Array)
+
+	case "SELECT":
+		s.handleSelect(c, value.Array)
+
+	case "MOVE":
+		s.handleMove(c, value.Array)
+
+	case "SWAPDB":
+		s.handleSwapDB(c, value.Array)
+
+	case "FLUSHDB":
+		s.handleFlush(c, value.Array, false)
+
+	case "FLUSHALL":
+		s.handleFlush(c, value.Array, true)
+
+	case "PERSIST":
+		if len(value.Array) < 2 {
+			writer.WriteError("missing argument")
+		} else {
+			result := c.db.Persist(value.Array[1].Str)
+			writer.WriteInteger(result)
+		}
+
+	case "SAVE":
+		err := s.save()
+		if err != nil {
+			writer.WriteError(err.Error())
+		} else {
+			writer.WriteSimpleString("OK")
 		}
+
+	case "SHUTDOWN":
+		s.handleShutdown(c, value.Array)
+
+	case "SUBSCRIBE":
+		s.handleSubscribe(c, value.Array)
+
+	case "UNSUBSCRIBE":
+		s.handleUnsubscribe(c, value.Array)
+
+	case "PSUBSCRIBE":
+		s.handlePSubscribe(c, value.Array)
+
+	case "PUNSUBSCRIBE":
+		s.handlePUnsubscribe(c, value.Array)
+
+	case "PUBLISH":
+		s.handlePublish(c, value.Array)
+
+	case "PUBSUB":
+		s.handlePubSub(c, value.Array)
+
+	case "CONFIG":
+		s.handleConfig(c, value.Array)
+
+	case "INFO":
+		s.handleInfo(c, value.Array)
+
+	case "MEMORY":
+		s.handleMemory(c, value.Array)
+
+	case "DEBUG":
+		s.handleDebug(c, value.Array)
+
+	case "OBJECT":
+		s.handleObject(c, value.Array)
+
+	case "BLPOP":
+		s.handleBlockingPop(c, value.Array, true)
+
+	case "BRPOP":
+		s.handleBlockingPop(c, value.Array, false)
+
+	case "BLMOVE":
+		s.handleBLMove(c, value.Array)
+
+	case "BRPOPLPUSH":
+		s.handleBRPopLPush(c, value.Array)
+
+	case "CLIENT":
+		s.handleClient(c, value.Array)
+
+	case "ACL":
+		s.handleACL(c, value.Array)
+
+	case "HSET":
+		s.handleHSet(c, value.Array)
+
+	case "HSETNX":
+		s.handleHSetNX(c, value.Array)
+
+	case "HGET":
+		s.handleHGet(c, value.Array)
+
+	case "HMGET":
+		s.handleHMGet(c, value.Array)
+
+	case "HDEL":
+		s.handleHDel(c, value.Array)
+
+	case "HEXISTS":
+		s.handleHExists(c, value.Array)
+
+	case "HLEN":
+		s.handleHLen(c, value.Array)
+
+	case "HSTRLEN":
+		s.handleHStrLen(c, value.Array)
+
+	case "HKEYS":
+		s.handleHCollect(c, value.Array, c.db.HKeys)
+
+	case "HVALS":
+		s.handleHCollect(c, value.Array, c.db.HVals)
+
+	case "HGETALL":
+		s.handleHCollect(c, value.Array, c.db.HGetAll)
+
+	case "HINCRBY":
+		s.handleHIncrBy(c, value.Array)
+
+	case "HINCRBYFLOAT":
+		s.handleHIncrByFloat(c, value.Array)
+
+	case "HRANDFIELD":
+		s.handleHRandField(c, value.Array)
+
+	case "HSCAN":
+		s.handleHScan(c, value.Array)
+
+	case "SADD":
+		s.handleSAdd(c, value.Array)
+
+	case "SREM":
+		s.handleSRem(c, value.Array)
+
+	case "SISMEMBER":
+		s.handleSIsMember(c, value.Array)
+
+	case "SMISMEMBER":
+		s.handleSMIsMember(c, value.Array)
+
+	case "SCARD":
+		s.handleSCard(c, value.Array)
+
+	case "SMEMBERS":
+		s.handleSMembers(c, value.Array)
+
+	case "SPOP":
+		s.handleSPop(c, value.Array)
+
+	case "SRANDMEMBER":
+		s.handleSRandMember(c, value.Array)
+
+	case "SMOVE":
+		s.handleSMove(c, value.Array)
+
+	case "SINTER":
+		s.handleSetAlgebra(c, value.Array, c.db.SInter)
+
+	case "SUNION":
+		s.handleSetAlgebra(c, value.Array, c.db.SUnion)
+
+	case "SDIFF":
+		s.handleSetAlgebra(c, value.Array, c.db.SDiff)
+
+	case "SINTERSTORE":
+		s.handleSetAlgebraStore(c, value.Array, c.db.SInterStore)
+
+	case "SUNIONSTORE":
+		s.handleSetAlgebraStore(c, value.Array, c.db.SUnionStore)
+
+	case "SDIFFSTORE":
+		s.handleSetAlgebraStore(c, value.Array, c.db.SDiffStore)
+
+	case "SINTERCARD":
+		s.handleSInterCard(c, value.Array)
+
+	case "SSCAN":
+		s.handleSScan(c, value.Array)
+
+	case "ZADD":
+		s.handleZAdd(c, value.Array)
+
+	case "ZINCRBY":
+		s.handleZIncrBy(c, value.Array)
+
+	case "ZREM":
+		s.handleZRem(c, value.Array)
+
+	case "ZCARD":
+		s.handleZCard(c, value.Array)
+
+	case "ZSCORE":
+		s.handleZScore(c, value.Array)
+
+	case "ZRANK":
+		s.handleZRank(c, value.Array, false)
+
+	case "ZREVRANK":
+		s.handleZRank(c, value.Array, true)
+
+	case "ZRANGE":
+		s.handleZRange(c, value.Array)
+
+	case "ZCOUNT":
+		s.handleZCount(c, value.Array)
+
+	case "ZPOPMIN":
+		s.handleZPop(c, value.Array, false)
+
+	case "ZPOPMAX":
+		s.handleZPop(c, value.Array, true)
+
+	case "BZPOPMIN":
+		s.handleBZPop(c, value.Array, false)
+
+	case "BZPOPMAX":
+		s.handleBZPop(c, value.Array, true)
+
+	case "ZUNIONSTORE":
+		s.handleZSetAlgebraStore(c, value.Array, false)
+
+	case "ZINTERSTORE":
+		s.handleZSetAlgebraStore(c, value.Array, true)
+
+	case "ZSCAN":
+		s.handleZScan(c, value.Array)
+
+	case "XADD":
+		s.handleXAdd(c, value.Array)
+
+	case "XLEN":
+		s.handleXLen(c, value.Array)
+
+	case "XRANGE":
+		s.handleXRange(c, value.Array, false)
+
+	case "XREVRANGE":
+		s.handleXRange(c, value.Array, true)
+
+	case "XREAD":
+		s.handleXRead(c, value.Array)
+
+	case "XREADGROUP":
+		s.handleXReadGroup(c, value.Array)
+
+	case "XGROUP":
+		s.handleXGroup(c, value.Array)
+
+	case "XACK":
+		s.handleXAck(c, value.Array)
+
+	case "XPENDING":
+		s.handleXPending(c, value.Array)
+
+	case "XCLAIM":
+		s.handleXClaim(c, value.Array)
+
+	case "XAUTOCLAIM":
+		s.handleXAutoClaim(c, value.Array)
+
+	case "XINFO":
+		s.handleXInfo(c, value.Array)
+
+	case "INCR":
+		s.handleIncr(c, value.Array, 1)
+
+	case "DECR":
+		s.handleIncr(c, value.Array, -1)
+
+	case "INCRBY":
+		s.handleIncrBy(c, value.Array, false)
+
+	case "DECRBY":
+		s.handleIncrBy(c, value.Array, true)
+
+	case "INCRBYFLOAT":
+		s.handleIncrByFloat(c, value.Array)
+
+	case "APPEND":
+		s.handleAppend(c, value.Array)
+
+	case "STRLEN":
+		s.handleStrLen(c, value.Array)
+
+	case "GETRANGE":
+		s.handleGetRange(c, value.Array)
+
+	case "SETRANGE":
+		s.handleSetRange(c, value.Array)
+
+	case "MGET":
+		s.handleMGet(c, value.Array)
+
+	case "MSET":
+		s.handleMSet(c, value.Array)
+
+	case "MSETNX":
+		s.handleMSetNX(c, value.Array)
+
+	case "SETNX":
+		s.handleSetNX(c, value.Array)
+
+	case "GETSET":
+		s.handleGetSet(c, value.Array)
+
+	case "GETDEL":
+		s.handleGetDel(c, value.Array)
+
+	case "GETEX":
+		s.handleGetEx(c, value.Array)
+
+	default:
+		writer.WriteError("unknown command")
 	}
 }
@@ -0,0 +1,287 @@
+package server
+
+import (
+	"inmemory-db/internal/protocol"
+	"strconv"
+	"strings"
+)
+
+// SADD key member [member ...]
+func (s *Server) handleSAdd(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	added, err := c.db.SAdd(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(added)
+	}
+}
+
+// SREM key member [member ...]
+func (s *Server) handleSRem(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	removed, err := c.db.SRem(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(removed)
+	}
+}
+
+// SISMEMBER key member
+func (s *Server) handleSIsMember(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	exist, err := c.db.SIsMember(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(boolToInt(exist))
+	}
+}
+
+// SMISMEMBER key member [member ...]
+func (s *Server) handleSMIsMember(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	result, err := c.db.SMIsMember(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	c.writer.WriteArrayLen(len(result))
+	for _, exist := range result {
+		c.writer.WriteInteger(boolToInt(exist))
+	}
+}
+
+// SCARD key
+func (s *Server) handleSCard(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	count, err := c.db.SCard(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// SMEMBERS key
+func (s *Server) handleSMembers(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	members, err := c.db.SMembers(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteArray(members)
+	}
+}
+
+// SPOP key [count]
+// count가 없으면 요소 하나(없으면 null), 있으면 배열로 응답한다.
+func (s *Server) handleSPop(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	if len(args) == 2 {
+		members, err := c.db.SPop(args[1].Str, 1)
+		if err != nil {
+			c.writer.WriteError(err.Error())
+		} else if len(members) == 0 {
+			c.writer.WriteNull()
+		} else {
+			c.writer.WriteBulkString(members[0])
+		}
+		return
+	}
+
+	count, err := strconv.Atoi(args[2].Str)
+	if err != nil || count < 0 {
+		c.writer.WriteError("value is out of range, must be positive")
+		return
+	}
+
+	members, err := c.db.SPop(args[1].Str, count)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteArray(members)
+	}
+}
+
+// SRANDMEMBER key [count]
+// count가 없으면 요소 하나(없으면 null), 있으면 배열로 응답한다.
+func (s *Server) handleSRandMember(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	if len(args) == 2 {
+		members, err := c.db.SRandMember(args[1].Str, 1)
+		if err != nil {
+			c.writer.WriteError(err.Error())
+		} else if len(members) == 0 {
+			c.writer.WriteNull()
+		} else {
+			c.writer.WriteBulkString(members[0])
+		}
+		return
+	}
+
+	count, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	members, err := c.db.SRandMember(args[1].Str, count)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteArray(members)
+	}
+}
+
+// SMOVE source destination member
+func (s *Server) handleSMove(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	moved, err := c.db.SMove(args[1].Str, args[2].Str, args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(boolToInt(moved))
+	}
+}
+
+// SINTER key [key ...] / SUNION key [key ...] / SDIFF key [key ...]
+func (s *Server) handleSetAlgebra(c *client, args []protocol.Value, op func(keys ...string) ([]string, error)) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	members, err := op(argStrings(args[1:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteArray(members)
+	}
+}
+
+// SINTERSTORE destination key [key ...] / SUNIONSTORE ... / SDIFFSTORE ...
+func (s *Server) handleSetAlgebraStore(c *client, args []protocol.Value, op func(destination string, keys ...string) (int, error)) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	count, err := op(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// SINTERCARD numkeys key [key ...] [LIMIT limit]
+func (s *Server) handleSInterCard(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	numKeys, err := strconv.Atoi(args[1].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+	if numKeys <= 0 {
+		c.writer.WriteError("numkeys should be greater than 0")
+		return
+	}
+	if numKeys > len(args)-2 {
+		c.writer.WriteError("Number of keys can't be greater than number of args")
+		return
+	}
+
+	limit := 0
+	rest := args[2+numKeys:]
+	if len(rest) > 0 {
+		if len(rest) != 2 || strings.ToUpper(rest[0].Str) != "LIMIT" {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		limit, err = strconv.Atoi(rest[1].Str)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		if limit < 0 {
+			c.writer.WriteError("LIMIT can't be negative")
+			return
+		}
+	}
+
+	count, err := c.db.SInterCard(limit, argStrings(args[2:2+numKeys])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// SSCAN key cursor [MATCH pattern] [COUNT count]
+func (s *Server) handleSScan(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	cursor, err := parseScanCursor(args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	options, err := parseScanOptions(args[3:], false, false)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	next, members, err := c.db.SScan(args[1].Str, cursor, options.pattern, options.count)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		writeScanReply(c.writer, next, members)
+	}
+}
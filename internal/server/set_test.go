@@ -0,0 +1,84 @@
+package server
+
+import "testing"
+
+func TestSetCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "SADD", "s-tags", "go", "redis", "go"); response != ":2\r\n" {
+		t.Fatalf("SADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SISMEMBER", "s-tags", "go"); response != ":1\r\n" {
+		t.Fatalf("SISMEMBER 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SMISMEMBER", "s-tags", "redis", "java"); response != "*2\r\n:1\r\n:0\r\n" {
+		t.Fatalf("SMISMEMBER 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SCARD", "s-tags"); response != ":2\r\n" {
+		t.Fatalf("SCARD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SREM", "s-tags", "go", "java"); response != ":1\r\n" {
+		t.Fatalf("SREM 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SMEMBERS", "s-tags"); response != "*1\r\n$5\r\nredis\r\n" {
+		t.Fatalf("SMEMBERS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SMOVE", "s-tags", "s-other", "redis"); response != ":1\r\n" {
+		t.Fatalf("SMOVE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SPOP", "s-other"); response != "$5\r\nredis\r\n" {
+		t.Fatalf("SPOP 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SPOP", "s-other"); response != "$-1\r\n" {
+		t.Fatalf("빈 집합 SPOP 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SRANDMEMBER", "s-other", "3"); response != "*0\r\n" {
+		t.Fatalf("없는 키 SRANDMEMBER 응답: %q", response)
+	}
+}
+
+func TestSetAlgebraCommands(t *testing.T) {
+	// given: 정수만 있는 집합이라 정렬된 순서로 응답한다
+	conn, reader := dial(t)
+	do(t, conn, reader, "SADD", "s-a", "1", "2", "3")
+	do(t, conn, reader, "SADD", "s-b", "2", "3", "4")
+
+	// when & then
+	if response := do(t, conn, reader, "SINTER", "s-a", "s-b"); response != "*2\r\n$1\r\n2\r\n$1\r\n3\r\n" {
+		t.Fatalf("SINTER 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SDIFF", "s-a", "s-b"); response != "*1\r\n$1\r\n1\r\n" {
+		t.Fatalf("SDIFF 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SUNIONSTORE", "s-union", "s-a", "s-b"); response != ":4\r\n" {
+		t.Fatalf("SUNIONSTORE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SMEMBERS", "s-union"); response != "*4\r\n$1\r\n1\r\n$1\r\n2\r\n$1\r\n3\r\n$1\r\n4\r\n" {
+		t.Fatalf("SMEMBERS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SINTERCARD", "2", "s-a", "s-b", "LIMIT", "1"); response != ":1\r\n" {
+		t.Fatalf("SINTERCARD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SINTERCARD", "3", "s-a", "s-b"); response != "-ERR Number of keys can't be greater than number of args\r\n" {
+		t.Fatalf("잘못된 numkeys SINTERCARD 응답: %q", response)
+	}
+}
+
+func TestSScanCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SADD", "s-scan", "1", "2", "10")
+
+	// when
+	response := do(t, conn, reader, "SSCAN", "s-scan", "0", "MATCH", "1*")
+
+	// then
+	if response != "*2\r\n$1\r\n0\r\n*2\r\n$1\r\n1\r\n$2\r\n10\r\n" {
+		t.Fatalf("SSCAN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SSCAN", "s-scan", "0", "NOVALUES"); response != "-ERR syntax error\r\n" {
+		t.Fatalf("NOVALUES SSCAN 응답: %q", response)
+	}
+}
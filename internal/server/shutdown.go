@@ -0,0 +1,131 @@
+package server
+
+import (
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"strings"
+	"time"
+)
+
+// 서버 종료로 블로킹 대기가 끝났음을 나타낸다
+var errServerShutdown = errors.New("server is shutting down")
+
+// SHUTDOWN [NOSAVE|SAVE]
+// 서버를 종료한다. 기본값과 SAVE는 마지막 스냅샷을 쓰고, NOSAVE는 쓰지 않는다.
+// 성공하면 응답 없이 연결이 닫힌다.
+func (s *Server) handleShutdown(c *client, args []protocol.Value) {
+	save := true
+	switch {
+	case len(args) == 1:
+	case len(args) == 2 && strings.ToUpper(args[1].Str) == "NOSAVE":
+		save = false
+	case len(args) == 2 && strings.ToUpper(args[1].Str) == "SAVE":
+	default:
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	s.logger.Printf("클라이언트 %d 의 SHUTDOWN 요청으로 서버를 종료합니다.", c.id)
+	s.Shutdown(save)
+}
+
+// Shutdown은 서버 종료를 시작하고 바로 반환한다. 여러 번 호출해도 처음 한 번만 적용된다.
+// 새 연결을 받지 않고, 대기 중인 연결은 깨워서 닫으며, 블로킹 명령어의 대기를 취소한다.
+// 실행 중인 명령어는 shutdown-timeout까지 기다린 뒤 남은 연결을 끊는다.
+// save가 true면 연결을 모두 정리한 뒤 마지막 스냅샷을 쓴다. 정리가 끝나면 Serve가 반환한다.
+func (s *Server) Shutdown(save bool) {
+	s.shutdownOnce.Do(func() {
+		s.saveOnShutdown.Store(save)
+		close(s.quit)
+
+		s.clientsMu.Lock()
+		clients := make([]*client, 0, len(s.clients))
+		for _, c := range s.clients {
+			clients = append(clients, c)
+		}
+		s.clientsMu.Unlock()
+
+		for _, c := range clients {
+			// 명령어를 기다리며 읽기에서 멈춘 연결을 깨운다. 명령어를 실행 중인 연결은 끝난 뒤 루프에서 빠져나온다
+			c.conn.SetReadDeadline(time.Now())
+			s.unblockClient(c.id, errServerShutdown)
+		}
+	})
+}
+
+// 종료가 시작됐는지
+func (s *Server) closing() bool {
+	select {
+	case <-s.quit:
+		return true
+	default:
+		return false
+	}
+}
+
+// 리스너가 닫힌 뒤 연결이 모두 끝나길 기다리고, 백그라운드 작업을 멈추고, 마지막 스냅샷을 쓴다.
+func (s *Server) finishShutdown() error {
+	timeout := time.Duration(s.shutdownTimeout.Load()) * time.Second
+	if !s.waitConnections(timeout) {
+		s.logger.Print("끝나지 않은 연결을 끊습니다.")
+		s.clientsMu.Lock()
+		for _, c := range s.clients {
+			c.conn.Close()
+		}
+		s.clientsMu.Unlock()
+		s.connWG.Wait()
+	}
+
+	for _, db := range s.dbs {
+		db.StopExpiry()
+	}
+	if s.debugServer != nil {
+		s.debugServer.Close()
+	}
+
+	if s.saveOnShutdown.Load() && !s.snapshotsDisabled {
+		if err := s.save(); err != nil {
+			s.logger.Printf("종료 전 RDB 저장 실패: %v", err)
+			return err
+		}
+		s.logger.Println("종료 전 RDB 저장 완료")
+	}
+	s.logger.Println("서버를 종료했습니다.")
+	return nil
+}
+
+// ForceShutdown은 종료를 시작하고, 실행 중인 명령어를 기다리지 않고 남은 연결을 바로 끊는다.
+// 종료가 이미 시작됐으면 shutdown-timeout을 기다리던 중이라도 곧바로 끊는다.
+func (s *Server) ForceShutdown(save bool) {
+	s.Shutdown(save)
+	s.forceOnce.Do(func() { close(s.force) })
+}
+
+// 연결이 모두 끝나길 timeout까지 기다린다. 시간 안에 끝났거나 ForceShutdown가 호출되기 전에 끝났으면 true.
+func (s *Server) waitConnections(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		s.connWG.Wait()
+		close(done)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	case <-s.force:
+		return false
+	}
+}
+
+// 스냅샷 파일을 쓴다 (SAVE, 종료 시).
+func (s *Server) save() error {
+	if s.snapshotsDisabled {
+		return errors.New("snapshots are disabled")
+	}
+	return storage.SaveAll(s.snapshotPath(), s.dbs)
+}
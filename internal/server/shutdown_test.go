@@ -0,0 +1,157 @@
+package server
+
+import (
+	"inmemory-db/internal/storage"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShutdownCommandSavesAndClosesConnections(t *testing.T) {
+	// given: 데이터가 있고, 다른 연결은 명령어를 기다리거나 블로킹 명령어로 대기 중이다
+	_, dir, result := startServerAt(t, "localhost:6390")
+	conn, reader := dialAddr(t, "localhost:6390")
+	do(t, conn, reader, "SET", "shutdown-key", "v")
+	idle, idleReader := dialAddr(t, "localhost:6390")
+	do(t, idle, idleReader, "PING")
+	blocked, blockedReader := dialAddr(t, "localhost:6390")
+	send(blocked, "BLPOP", "shutdown-list", "0")
+	time.Sleep(50 * time.Millisecond)
+
+	// when
+	send(conn, "SHUTDOWN")
+
+	// then: Start가 반환되고 스냅샷이 남는다
+	if err := waitStopped(t, result); err != nil {
+		t.Fatalf("Start 에러: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, defaultDBFilename)); err != nil {
+		t.Fatalf("스냅샷 파일이 없습니다: %v", err)
+	}
+	// 응답 없이 연결이 닫힌다
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := reader.ReadByte(); err == nil {
+		t.Fatal("SHUTDOWN을 보낸 연결이 닫히지 않았습니다")
+	}
+	idle.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := idleReader.ReadByte(); err == nil {
+		t.Fatal("대기 중인 연결이 닫히지 않았습니다")
+	}
+	// 블로킹 대기는 빈 응답으로 끝나고 연결이 닫힌다
+	blocked.SetReadDeadline(time.Now().Add(time.Second))
+	if response := readReply(t, blockedReader); response != "*-1\r\n" {
+		t.Fatalf("BLPOP 응답: %q", response)
+	}
+	if _, err := blockedReader.ReadByte(); err == nil {
+		t.Fatal("블로킹 중이던 연결이 닫히지 않았습니다")
+	}
+	if _, err := net.Dial("tcp", "localhost:6390"); err == nil {
+		t.Fatal("종료한 뒤에도 새 연결을 받습니다")
+	}
+
+	// when & then: 같은 디렉터리로 다시 띄우면 데이터가 복구된다
+	restarted := New("localhost:6390")
+	restarted.SetConfig("dir", dir)
+	restarted.SetConfig("debug-addr", "")
+	restartResult := make(chan error, 1)
+	go func() { restartResult <- restarted.Start() }()
+	defer func() {
+		restarted.Shutdown(false)
+		waitStopped(t, restartResult)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	again, againReader := dialAddr(t, "localhost:6390")
+	if response := do(t, again, againReader, "GET", "shutdown-key"); response != "$1\r\nv\r\n" {
+		t.Fatalf("재시작 후 GET 응답: %q", response)
+	}
+}
+
+func TestShutdownNoSave(t *testing.T) {
+	// given
+	_, dir, result := startServerAt(t, "localhost:6391")
+	conn, reader := dialAddr(t, "localhost:6391")
+	do(t, conn, reader, "SET", "nosave-key", "v")
+
+	// when & then: 모르는 인자는 종료하지 않는다
+	if response := do(t, conn, reader, "SHUTDOWN", "LATER"); response != "-ERR syntax error\r\n" {
+		t.Fatalf("SHUTDOWN LATER 응답: %q", response)
+	}
+
+	// when
+	send(conn, "SHUTDOWN", "NOSAVE")
+
+	// then
+	if err := waitStopped(t, result); err != nil {
+		t.Fatalf("Start 에러: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, defaultDBFilename)); !os.IsNotExist(err) {
+		t.Fatalf("NOSAVE인데 스냅샷 파일이 있습니다: %v", err)
+	}
+}
+
+func TestShutdownTimeoutClosesBusyConnections(t *testing.T) {
+	// given: 명령어를 실행 중인 연결이 shutdown-timeout보다 오래 걸린다
+	server, _, result := startServerAt(t, "localhost:6392")
+	server.SetConfig("shutdown-timeout", "0")
+	conn, reader := dialAddr(t, "localhost:6392")
+	do(t, conn, reader, "PING")
+
+	var busy *client
+	server.clientsMu.Lock()
+	for _, c := range server.clients {
+		busy = c
+	}
+	server.clientsMu.Unlock()
+	busy.mu.Lock()
+	send(conn, "PING")
+	time.Sleep(50 * time.Millisecond)
+
+	// when
+	server.Shutdown(false)
+	time.Sleep(100 * time.Millisecond)
+	busy.mu.Unlock()
+
+	// then: 명령어가 끝나기 전에 연결이 강제로 닫혀 응답을 받지 못하고, Start가 반환된다
+	if err := waitStopped(t, result); err != nil {
+		t.Fatalf("Start 에러: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	if _, err := reader.ReadByte(); err == nil {
+		t.Fatal("실행 중이던 연결이 닫히지 않았습니다")
+	}
+}
+
+func TestStartRefusesCorruptSnapshot(t *testing.T) {
+	// given: 체크섬이 맞지 않는 스냅샷
+	dir := t.TempDir()
+	path := filepath.Join(dir, defaultDBFilename)
+	db := storage.New()
+	db.Set("a", "1")
+	db.Set("b", "2")
+	if err := db.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	data[len(data)/2] ^= 0xff
+	os.WriteFile(path, data, 0644)
+
+	// when
+	server := New("localhost:6398")
+	server.SetConfig("dir", dir)
+	server.SetConfig("debug-addr", "")
+	err := server.Start()
+
+	// then: 시작하지 않고, 스냅샷을 덮어쓰지도 않는다
+	if err == nil {
+		server.Shutdown(true)
+		t.Fatal("깨진 스냅샷으로 시작했습니다")
+	}
+	if _, err := net.Dial("tcp", "localhost:6398"); err == nil {
+		t.Fatal("시작에 실패했는데 연결을 받습니다")
+	}
+	if current, _ := os.ReadFile(path); string(current) != string(data) {
+		t.Fatal("깨진 스냅샷이 바뀌었습니다")
+	}
+}
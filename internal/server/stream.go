@@ -0,0 +1,910 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XADD key [NOMKSTREAM] [MAXLEN|MINID [=|~] threshold [LIMIT count]] *|id field value [field value ...]
+func (s *Server) handleXAdd(c *client, args []protocol.Value) {
+	if len(args) < 5 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	var options storage.XAddOptions
+	i := 2
+	for ; i < len(args); i++ {
+		option := strings.ToUpper(args[i].Str)
+		if option == "NOMKSTREAM" {
+			options.NoMkStream = true
+			continue
+		}
+		if option != "MAXLEN" && option != "MINID" {
+			break
+		}
+
+		trim, next, err := parseStreamTrim(args, i)
+		if err != nil {
+			writeStreamError(c.writer, err)
+			return
+		}
+		options.Trim = trim
+		i = next - 1
+	}
+
+	rest := args[i:]
+	if len(rest) < 3 || len(rest)%2 != 1 {
+		c.writer.WriteError("wrong number of arguments for 'xadd' command")
+		return
+	}
+
+	id, err := parseXAddID(rest[0].Str)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	newID, ok, err := c.db.XAdd(args[1].Str, options, id, argStrings(rest[1:]))
+	if err != nil {
+		writeStreamError(c.writer, err)
+	} else if !ok {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteBulkString(newID.String())
+	}
+}
+
+// XLEN key
+func (s *Server) handleXLen(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	length, err := c.db.XLen(args[1].Str)
+	if err != nil {
+		writeStreamError(c.writer, err)
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// XRANGE key start end [COUNT count] / XREVRANGE key end start [COUNT count]
+func (s *Server) handleXRange(c *client, args []protocol.Value, reverse bool) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	startArg, endArg := args[2].Str, args[3].Str
+	if reverse {
+		startArg, endArg = endArg, startArg
+	}
+	start, end, err := parseStreamInterval(startArg, endArg)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	count := -1
+	if len(args) > 4 {
+		if len(args) != 6 || strings.ToUpper(args[4].Str) != "COUNT" {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		count, err = strconv.Atoi(args[5].Str)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		count = max(count, 0)
+	}
+
+	entries, err := c.db.XRange(args[1].Str, start, end, reverse, count)
+	if err != nil {
+		writeStreamError(c.writer, err)
+	} else {
+		writeStreamEntries(c.writer, entries)
+	}
+}
+
+// XREAD [COUNT count] [BLOCK milliseconds] STREAMS key [key ...] id [id ...]
+func (s *Server) handleXRead(c *client, args []protocol.Value) {
+	request, err := parseXRead(args, 1, false)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	if !request.block {
+		results, err := c.db.XRead(request.streams, request.count)
+		if err != nil {
+			writeStreamError(c.writer, err)
+		} else {
+			writeStreamReadResults(c.writer, results)
+		}
+		return
+	}
+
+	ctx, done := s.blockContext(c, request.timeout)
+	results, err := c.db.BlockingXRead(ctx, request.streams, request.count)
+	done()
+	s.writeBlockingStreamReply(c, ctx, results, err)
+}
+
+// XREADGROUP GROUP group consumer [COUNT count] [BLOCK milliseconds] [NOACK] STREAMS key [key ...] id [id ...]
+func (s *Server) handleXReadGroup(c *client, args []protocol.Value) {
+	if len(args) < 4 || strings.ToUpper(args[1].Str) != "GROUP" {
+		c.writer.WriteError("syntax error")
+		return
+	}
+	group, consumer := args[2].Str, args[3].Str
+
+	request, err := parseXRead(args, 4, true)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	// 이력(PEL)을 읽을 때는 기다리지 않는다
+	block := request.block
+	for _, stream := range request.streams {
+		block = block && stream.New
+	}
+
+	if !block {
+		results, err := c.db.XReadGroup(group, consumer, request.streams, request.count, request.noAck)
+		if err != nil {
+			writeStreamError(c.writer, err)
+		} else {
+			writeStreamReadResults(c.writer, results)
+		}
+		return
+	}
+
+	ctx, done := s.blockContext(c, request.timeout)
+	results, err := c.db.BlockingXReadGroup(ctx, group, consumer, request.streams, request.count, request.noAck)
+	done()
+	s.writeBlockingStreamReply(c, ctx, results, err)
+}
+
+// XGROUP CREATE key group id|$ [MKSTREAM] [ENTRIESREAD entries-read] | DESTROY key group
+func (s *Server) handleXGroup(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "CREATE":
+		if len(args) < 5 {
+			c.writer.WriteError("wrong number of arguments for 'xgroup|create' command")
+			return
+		}
+
+		mkStream := false
+		entriesRead := int64(-1)
+		for i := 5; i < len(args); i++ {
+			switch strings.ToUpper(args[i].Str) {
+			case "MKSTREAM":
+				mkStream = true
+			case "ENTRIESREAD":
+				if i+1 >= len(args) {
+					c.writer.WriteError("syntax error")
+					return
+				}
+				n, err := strconv.ParseInt(args[i+1].Str, 10, 64)
+				if err != nil || n < 0 {
+					c.writer.WriteError("value for ENTRIESREAD must be positive or 0")
+					return
+				}
+				entriesRead = n
+				i++
+			default:
+				c.writer.WriteError("syntax error")
+				return
+			}
+		}
+
+		var id storage.StreamID
+		last := args[4].Str == "$"
+		if !last {
+			var err error
+			id, err = storage.ParseStreamID(args[4].Str, 0)
+			if err != nil {
+				writeStreamError(c.writer, err)
+				return
+			}
+		}
+
+		if err := c.db.XGroupCreate(args[2].Str, args[3].Str, id, last, mkStream, entriesRead); err != nil {
+			writeStreamError(c.writer, err)
+		} else {
+			c.writer.WriteSimpleString("OK")
+		}
+
+	case "DESTROY":
+		if len(args) != 4 {
+			c.writer.WriteError("wrong number of arguments for 'xgroup|destroy' command")
+			return
+		}
+
+		destroyed, err := c.db.XGroupDestroy(args[2].Str, args[3].Str)
+		if err != nil {
+			writeStreamError(c.writer, err)
+		} else {
+			c.writer.WriteInteger(boolToInt(destroyed))
+		}
+
+	default:
+		c.writer.WriteError("unknown XGROUP subcommand '" + args[1].Str + "'")
+	}
+}
+
+// XACK key group id [id ...]
+func (s *Server) handleXAck(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	ids, err := parseStreamIDs(args[3:])
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	acked, err := c.db.XAck(args[1].Str, args[2].Str, ids...)
+	if err != nil {
+		writeStreamError(c.writer, err)
+	} else {
+		c.writer.WriteInteger(acked)
+	}
+}
+
+// XPENDING key group [[IDLE min-idle-time] start end count [consumer]]
+func (s *Server) handleXPending(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+	key, group := args[1].Str, args[2].Str
+
+	// 요약 형식: [개수, 가장 작은 ID, 가장 큰 ID, [[소비자, 개수], ...]]
+	if len(args) == 3 {
+		summary, err := c.db.XPendingSummary(key, group)
+		if err != nil {
+			writeStreamError(c.writer, err)
+			return
+		}
+
+		c.writer.WriteArrayLen(4)
+		c.writer.WriteInteger(summary.Count)
+		if summary.Count == 0 {
+			c.writer.WriteNull()
+			c.writer.WriteNull()
+			c.writer.WriteNullArray()
+			return
+		}
+		c.writer.WriteBulkString(summary.Min.String())
+		c.writer.WriteBulkString(summary.Max.String())
+		c.writer.WriteArrayLen(len(summary.Consumers))
+		for _, consumer := range summary.Consumers {
+			c.writer.WriteArray([]string{consumer.Name, strconv.Itoa(consumer.Count)})
+		}
+		return
+	}
+
+	// 확장 형식: [[ID, 소비자, 경과 시간(ms), 전달 횟수], ...]
+	rest := args[3:]
+	var options storage.PendingOptions
+	if strings.ToUpper(rest[0].Str) == "IDLE" {
+		if len(rest) < 2 {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		ms, err := strconv.ParseInt(rest[1].Str, 10, 64)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		options.MinIdle = time.Duration(ms) * time.Millisecond
+		rest = rest[2:]
+	}
+	if len(rest) < 3 || len(rest) > 4 {
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	var err error
+	options.Start, options.End, err = parseStreamInterval(rest[0].Str, rest[1].Str)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+	options.Count, err = strconv.Atoi(rest[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+	if len(rest) == 4 {
+		options.Consumer = rest[3].Str
+	}
+
+	pending, err := c.db.XPending(key, group, options)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	c.writer.WriteArrayLen(len(pending))
+	for _, p := range pending {
+		c.writer.WriteArrayLen(4)
+		c.writer.WriteBulkString(p.ID.String())
+		c.writer.WriteBulkString(p.Consumer)
+		c.writer.WriteInteger(int(p.Idle.Milliseconds()))
+		c.writer.WriteInteger(p.DeliveryCount)
+	}
+}
+
+// XCLAIM key group consumer min-idle-time id [id ...] [IDLE ms] [TIME unix-time-milliseconds]
+// [RETRYCOUNT count] [FORCE] [JUSTID] [LASTID lastid]
+func (s *Server) handleXClaim(c *client, args []protocol.Value) {
+	if len(args) < 6 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	minIdle, err := parseMinIdle(args[4].Str)
+	if err != nil {
+		c.writer.WriteError("Invalid min-idle-time argument for XCLAIM")
+		return
+	}
+
+	// 옵션이 나오기 전까지는 모두 ID다
+	var ids []storage.StreamID
+	i := 5
+	for ; i < len(args); i++ {
+		id, err := storage.ParseStreamID(args[i].Str, 0)
+		if err != nil {
+			break
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		c.writer.WriteError(storage.ErrInvalidStreamID.Error())
+		return
+	}
+
+	var options storage.XClaimOptions
+	for ; i < len(args); i++ {
+		option := strings.ToUpper(args[i].Str)
+		switch option {
+		case "FORCE":
+			options.Force = true
+			continue
+		case "JUSTID":
+			options.JustID = true
+			continue
+		case "IDLE", "TIME", "RETRYCOUNT", "LASTID":
+		default:
+			c.writer.WriteError("Unrecognized XCLAIM option '" + args[i].Str + "'")
+			return
+		}
+
+		if i+1 >= len(args) {
+			c.writer.WriteError("syntax error")
+			return
+		}
+		value := args[i+1].Str
+		i++
+
+		if option == "LASTID" {
+			id, err := storage.ParseStreamID(value, 0)
+			if err != nil {
+				writeStreamError(c.writer, err)
+				return
+			}
+			options.LastID = &id
+			continue
+		}
+
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		switch option {
+		case "IDLE":
+			deliveryTime := c.db.Now().Add(-time.Duration(n) * time.Millisecond)
+			options.DeliveryTime = &deliveryTime
+		case "TIME":
+			deliveryTime := time.UnixMilli(n)
+			options.DeliveryTime = &deliveryTime
+		case "RETRYCOUNT":
+			retryCount := int(n)
+			options.RetryCount = &retryCount
+		}
+	}
+
+	entries, err := c.db.XClaim(args[1].Str, args[2].Str, args[3].Str, minIdle, ids, options)
+	if err != nil {
+		writeStreamError(c.writer, err)
+	} else if options.JustID {
+		writeStreamIDs(c.writer, entries)
+	} else {
+		writeStreamEntries(c.writer, entries)
+	}
+}
+
+// XAUTOCLAIM key group consumer min-idle-time start [COUNT count] [JUSTID]
+// [다음 커서, 넘긴 엔트리, 삭제된 ID] 형태로 응답한다.
+func (s *Server) handleXAutoClaim(c *client, args []protocol.Value) {
+	if len(args) < 6 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	minIdle, err := parseMinIdle(args[4].Str)
+	if err != nil {
+		c.writer.WriteError("Invalid min-idle-time argument for XAUTOCLAIM")
+		return
+	}
+	start, _, err := parseStreamInterval(args[5].Str, "+")
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	count, justID := 100, false
+	for i := 6; i < len(args); i++ {
+		switch strings.ToUpper(args[i].Str) {
+		case "JUSTID":
+			justID = true
+		case "COUNT":
+			if i+1 >= len(args) {
+				c.writer.WriteError("syntax error")
+				return
+			}
+			count, err = strconv.Atoi(args[i+1].Str)
+			if err != nil || count <= 0 {
+				c.writer.WriteError("COUNT must be > 0")
+				return
+			}
+			i++
+		default:
+			c.writer.WriteError("syntax error")
+			return
+		}
+	}
+
+	next, claimed, deleted, err := c.db.XAutoClaim(args[1].Str, args[2].Str, args[3].Str, minIdle, start, count, justID)
+	if err != nil {
+		writeStreamError(c.writer, err)
+		return
+	}
+
+	c.writer.WriteArrayLen(3)
+	c.writer.WriteBulkString(next.String())
+	if justID {
+		writeStreamIDs(c.writer, claimed)
+	} else {
+		writeStreamEntries(c.writer, claimed)
+	}
+	deletedIDs := make([]string, 0, len(deleted))
+	for _, id := range deleted {
+		deletedIDs = append(deletedIDs, id.String())
+	}
+	c.writer.WriteArray(deletedIDs)
+}
+
+// XINFO STREAM key | GROUPS key | CONSUMERS key group
+// 각 항목은 [이름1, 값1, 이름2, 값2, ...] 형태로 응답한다.
+func (s *Server) handleXInfo(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	switch strings.ToUpper(args[1].Str) {
+	case "STREAM":
+		info, err := c.db.XInfoStream(args[2].Str)
+		if err != nil {
+			writeStreamError(c.writer, err)
+			return
+		}
+
+		recordedFirstID := storage.StreamID{}
+		if info.FirstEntry != nil {
+			recordedFirstID = info.FirstEntry.ID
+		}
+
+		c.writer.WriteArrayLen(16)
+		c.writer.WriteBulkString("length")
+		c.writer.WriteInteger(info.Length)
+		c.writer.WriteBulkString("last-generated-id")
+		c.writer.WriteBulkString(info.LastID.String())
+		c.writer.WriteBulkString("max-deleted-entry-id")
+		c.writer.WriteBulkString(info.MaxDeletedID.String())
+		c.writer.WriteBulkString("entries-added")
+		c.writer.WriteInteger(int(info.EntriesAdded))
+		c.writer.WriteBulkString("recorded-first-entry-id")
+		c.writer.WriteBulkString(recordedFirstID.String())
+		c.writer.WriteBulkString("groups")
+		c.writer.WriteInteger(info.Groups)
+		c.writer.WriteBulkString("first-entry")
+		writeOptionalStreamEntry(c.writer, info.FirstEntry)
+		c.writer.WriteBulkString("last-entry")
+		writeOptionalStreamEntry(c.writer, info.LastEntry)
+
+	case "GROUPS":
+		groups, err := c.db.XInfoGroups(args[2].Str)
+		if err != nil {
+			writeStreamError(c.writer, err)
+			return
+		}
+
+		c.writer.WriteArrayLen(len(groups))
+		for _, group := range groups {
+			c.writer.WriteArrayLen(12)
+			c.writer.WriteBulkString("name")
+			c.writer.WriteBulkString(group.Name)
+			c.writer.WriteBulkString("consumers")
+			c.writer.WriteInteger(group.Consumers)
+			c.writer.WriteBulkString("pending")
+			c.writer.WriteInteger(group.Pending)
+			c.writer.WriteBulkString("last-delivered-id")
+			c.writer.WriteBulkString(group.LastDeliveredID.String())
+			c.writer.WriteBulkString("entries-read")
+			writeOptionalInteger(c.writer, group.EntriesRead)
+			c.writer.WriteBulkString("lag")
+			writeOptionalInteger(c.writer, group.Lag)
+		}
+
+	case "CONSUMERS":
+		if len(args) < 4 {
+			c.writer.WriteError("missing argument")
+			return
+		}
+
+		consumers, err := c.db.XInfoConsumers(args[2].Str, args[3].Str)
+		if err != nil {
+			writeStreamError(c.writer, err)
+			return
+		}
+
+		c.writer.WriteArrayLen(len(consumers))
+		for _, consumer := range consumers {
+			c.writer.WriteArrayLen(8)
+			c.writer.WriteBulkString("name")
+			c.writer.WriteBulkString(consumer.Name)
+			c.writer.WriteBulkString("pending")
+			c.writer.WriteInteger(consumer.Pending)
+			c.writer.WriteBulkString("idle")
+			c.writer.WriteInteger(int(consumer.Idle.Milliseconds()))
+			c.writer.WriteBulkString("inactive")
+			if consumer.Inactive < 0 {
+				c.writer.WriteInteger(-1)
+			} else {
+				c.writer.WriteInteger(int(consumer.Inactive.Milliseconds()))
+			}
+		}
+
+	default:
+		c.writer.WriteError("unknown XINFO subcommand '" + args[1].Str + "'")
+	}
+}
+
+// ========== 파싱 ==========
+
+// XREAD/XREADGROUP의 공통 인자
+type xreadRequest struct {
+	streams []storage.XReadStream
+	count   int
+	block   bool
+	timeout time.Duration
+	noAck   bool
+}
+
+// args[start:]부터 [COUNT count] [BLOCK ms] [NOACK] STREAMS key... id... 를 파싱한다.
+// NOACK와 ">"는 group일 때만, "$"는 group이 아닐 때만 허용한다.
+func parseXRead(args []protocol.Value, start int, group bool) (xreadRequest, error) {
+	request := xreadRequest{count: -1}
+
+	i := start
+	for ; i < len(args); i++ {
+		option := strings.ToUpper(args[i].Str)
+		if option == "STREAMS" {
+			break
+		}
+		if option == "NOACK" && group {
+			request.noAck = true
+			continue
+		}
+		if (option != "COUNT" && option != "BLOCK") || i+1 >= len(args) {
+			return request, errors.New("syntax error")
+		}
+
+		n, err := strconv.ParseInt(args[i+1].Str, 10, 64)
+		if err != nil {
+			return request, errors.New("value is not an integer or out of range")
+		}
+		if option == "COUNT" {
+			// 0 이하면 제한 없음
+			if n > 0 {
+				request.count = int(n)
+			}
+		} else {
+			if n < 0 {
+				return request, errors.New("timeout is negative")
+			}
+			request.block = true
+			request.timeout = time.Duration(n) * time.Millisecond
+		}
+		i++
+	}
+
+	rest := args[min(i+1, len(args)):]
+	if i >= len(args) || len(rest) == 0 {
+		return request, errors.New("syntax error")
+	}
+	if len(rest)%2 != 0 {
+		command := "xread"
+		if group {
+			command = "xreadgroup"
+		}
+		return request, errors.New("Unbalanced '" + command + "' list of streams: for each stream key an ID or '$' must be specified.")
+	}
+
+	n := len(rest) / 2
+	for j := 0; j < n; j++ {
+		stream := storage.XReadStream{Key: rest[j].Str}
+		raw := rest[n+j].Str
+		switch {
+		case raw == "$" && !group:
+			stream.Last = true
+		case raw == "$":
+			return request, errors.New("The $ ID is meaningful only in the context of XREAD")
+		case raw == ">" && group:
+			stream.New = true
+		case raw == ">":
+			return request, errors.New("The > ID can be specified only when calling XREADGROUP using the GROUP <group> <consumer> option.")
+		default:
+			id, err := storage.ParseStreamID(raw, 0)
+			if err != nil {
+				return request, err
+			}
+			stream.ID = id
+		}
+		request.streams = append(request.streams, stream)
+	}
+	return request, nil
+}
+
+// MAXLEN|MINID [=|~] threshold [LIMIT count]를 args[i]부터 파싱한다.
+// 파싱한 옵션과 다음 인자의 위치를 반환한다.
+func parseStreamTrim(args []protocol.Value, i int) (*storage.StreamTrim, int, error) {
+	trim := &storage.StreamTrim{ByMinID: strings.ToUpper(args[i].Str) == "MINID"}
+	i++
+
+	approximate := false
+	if i < len(args) && (args[i].Str == "~" || args[i].Str == "=") {
+		approximate = args[i].Str == "~"
+		i++
+	}
+	if i >= len(args) {
+		return nil, i, errors.New("syntax error")
+	}
+
+	if trim.ByMinID {
+		id, err := storage.ParseStreamID(args[i].Str, 0)
+		if err != nil {
+			return nil, i, err
+		}
+		trim.MinID = id
+	} else {
+		n, err := strconv.Atoi(args[i].Str)
+		if err != nil {
+			return nil, i, errors.New("value is not an integer or out of range")
+		}
+		if n < 0 {
+			return nil, i, errors.New("The MAXLEN argument must be >= 0.")
+		}
+		trim.MaxLen = n
+	}
+	i++
+
+	if i+1 < len(args) && strings.ToUpper(args[i].Str) == "LIMIT" {
+		if !approximate {
+			return nil, i, errors.New("syntax error, LIMIT cannot be used without the special ~ option")
+		}
+		n, err := strconv.Atoi(args[i+1].Str)
+		if err != nil || n < 0 {
+			return nil, i, errors.New("The LIMIT argument must be >= 0.")
+		}
+		trim.Limit = n
+		i += 2
+	}
+	return trim, i, nil
+}
+
+// XADD의 ID 인자. "*", "ms-*", "ms-seq", "ms" 형식이다.
+func parseXAddID(raw string) (storage.XAddID, error) {
+	if raw == "*" {
+		return storage.XAddID{AutoMs: true}, nil
+	}
+	if msPart, found := strings.CutSuffix(raw, "-*"); found {
+		ms, err := strconv.ParseUint(msPart, 10, 64)
+		if err != nil {
+			return storage.XAddID{}, storage.ErrInvalidStreamID
+		}
+		return storage.XAddID{ID: storage.StreamID{Ms: ms}, AutoSeq: true}, nil
+	}
+
+	id, err := storage.ParseStreamID(raw, 0)
+	if err != nil {
+		return storage.XAddID{}, err
+	}
+	return storage.XAddID{ID: id}, nil
+}
+
+// XRANGE의 start end를 파싱한다. "-"와 "+"는 가장 작은/큰 ID이고,
+// 시퀀스를 생략하면 start는 0, end는 가장 큰 시퀀스다. "("로 시작하면 경계를 포함하지 않는다.
+func parseStreamInterval(startArg, endArg string) (storage.StreamID, storage.StreamID, error) {
+	start, err := parseStreamBound(startArg, false)
+	if err != nil {
+		return start, start, err
+	}
+	end, err := parseStreamBound(endArg, true)
+	return start, end, err
+}
+
+func parseStreamBound(raw string, isEnd bool) (storage.StreamID, error) {
+	switch raw {
+	case "-":
+		return storage.StreamID{}, nil
+	case "+":
+		return storage.MaxStreamID, nil
+	}
+
+	exclusive := strings.HasPrefix(raw, "(")
+	if exclusive {
+		raw = raw[1:]
+	}
+
+	var missingSeq uint64
+	if isEnd {
+		missingSeq = storage.MaxStreamID.Seq
+	}
+	id, err := storage.ParseStreamID(raw, missingSeq)
+	if err != nil || !exclusive {
+		return id, err
+	}
+
+	if isEnd {
+		prev, ok := id.Prev()
+		if !ok {
+			return id, errors.New("invalid end ID for the interval")
+		}
+		return prev, nil
+	}
+	next, ok := id.Next()
+	if !ok {
+		return id, errors.New("invalid start ID for the interval")
+	}
+	return next, nil
+}
+
+func parseStreamIDs(args []protocol.Value) ([]storage.StreamID, error) {
+	ids := make([]storage.StreamID, 0, len(args))
+	for _, arg := range args {
+		id, err := storage.ParseStreamID(arg.Str, 0)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// 밀리초 단위의 min-idle-time 인자
+func parseMinIdle(raw string) (time.Duration, error) {
+	ms, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(max(ms, 0)) * time.Millisecond, nil
+}
+
+// ========== 응답 ==========
+
+// 블로킹 XREAD/XREADGROUP이 끝난 뒤 응답한다.
+func (s *Server) writeBlockingStreamReply(c *client, ctx context.Context, results []storage.StreamReadResult, err error) {
+	switch {
+	case err != nil:
+		writeStreamError(c.writer, err)
+	case len(results) > 0:
+		writeStreamReadResults(c.writer, results)
+	case context.Cause(ctx) == errUnblockedError:
+		c.writer.WriteErrorCode("UNBLOCKED", errUnblockedError.Error())
+	default:
+		c.writer.WriteNullArray()
+	}
+}
+
+// [[key, [[id, [field, value, ...]], ...]], ...]. 결과가 없으면 null 배열
+func writeStreamReadResults(writer *protocol.Writer, results []storage.StreamReadResult) {
+	if len(results) == 0 {
+		writer.WriteNullArray()
+		return
+	}
+
+	writer.WriteArrayLen(len(results))
+	for _, result := range results {
+		writer.WriteArrayLen(2)
+		writer.WriteBulkString(result.Key)
+		writeStreamEntries(writer, result.Entries)
+	}
+}
+
+// [[id, [field, value, ...]], ...]. 스트림에서 삭제된 엔트리는 필드 자리가 null 배열이다.
+func writeStreamEntries(writer *protocol.Writer, entries []storage.StreamEntry) {
+	writer.WriteArrayLen(len(entries))
+	for _, entry := range entries {
+		writeStreamEntry(writer, entry)
+	}
+}
+
+func writeStreamEntry(writer *protocol.Writer, entry storage.StreamEntry) {
+	writer.WriteArrayLen(2)
+	writer.WriteBulkString(entry.ID.String())
+	if entry.Fields == nil {
+		writer.WriteNullArray()
+	} else {
+		writer.WriteArray(entry.Fields)
+	}
+}
+
+func writeOptionalStreamEntry(writer *protocol.Writer, entry *storage.StreamEntry) {
+	if entry == nil {
+		writer.WriteNull()
+	} else {
+		writeStreamEntry(writer, *entry)
+	}
+}
+
+// JUSTID 응답: 엔트리의 ID만 쓴다.
+func writeStreamIDs(writer *protocol.Writer, entries []storage.StreamEntry) {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID.String())
+	}
+	writer.WriteArray(ids)
+}
+
+// 음수면 null을 쓴다 (XINFO GROUPS의 entries-read, lag).
+func writeOptionalInteger(writer *protocol.Writer, n int64) {
+	if n < 0 {
+		writer.WriteNull()
+	} else {
+		writer.WriteInteger(int(n))
+	}
+}
+
+// 스트림 명령어의 에러를 응답한다. BUSYGROUP, NOGROUP은 클라이언트가 에러 코드로 구분하므로
+// "-ERR" 대신 메시지 앞의 코드를 그대로 쓴다.
+func writeStreamError(writer *protocol.Writer, err error) {
+	var noGroup *storage.NoGroupError
+	if errors.Is(err, storage.ErrBusyGroup) || errors.As(err, &noGroup) {
+		code, message, _ := strings.Cut(err.Error(), " ")
+		writer.WriteErrorCode(code, message)
+		return
+	}
+	writer.WriteError(err.Error())
+}
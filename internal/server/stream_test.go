@@ -0,0 +1,179 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStreamCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "XADD", "x-log", "1-1", "name", "a"); response != "$3\r\n1-1\r\n" {
+		t.Fatalf("XADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-log", "1-*", "name", "b"); response != "$3\r\n1-2\r\n" {
+		t.Fatalf("XADD ms-* 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-log", "2", "name", "c"); response != "$3\r\n2-0\r\n" {
+		t.Fatalf("XADD ms 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-log", "1-5", "name", "d"); response != "-ERR The ID specified in XADD is equal or smaller than the target stream top item\r\n" {
+		t.Fatalf("작은 ID XADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-log", "3-0", "name", "v", "extra"); response != "-ERR wrong number of arguments for 'xadd' command\r\n" {
+		t.Fatalf("짝이 안 맞는 XADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-missing", "NOMKSTREAM", "*", "name", "a"); response != "$-1\r\n" {
+		t.Fatalf("NOMKSTREAM XADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XLEN", "x-log"); response != ":3\r\n" {
+		t.Fatalf("XLEN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XRANGE", "x-log", "(1-1", "+", "COUNT", "1"); response != "*1\r\n*2\r\n$3\r\n1-2\r\n*2\r\n$4\r\nname\r\n$1\r\nb\r\n" {
+		t.Fatalf("XRANGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREVRANGE", "x-log", "+", "1", "COUNT", "1"); response != "*1\r\n*2\r\n$3\r\n2-0\r\n*2\r\n$4\r\nname\r\n$1\r\nc\r\n" {
+		t.Fatalf("XREVRANGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-log", "MAXLEN", "=", "1", "*", "name", "e"); !strings.HasPrefix(response, "$") {
+		t.Fatalf("MAXLEN XADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XLEN", "x-log"); response != ":1\r\n" {
+		t.Fatalf("MAXLEN 후 XLEN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XADD", "x-log", "MAXLEN", "1", "LIMIT", "5", "*", "name", "f"); response != "-ERR syntax error, LIMIT cannot be used without the special ~ option\r\n" {
+		t.Fatalf("LIMIT XADD 응답: %q", response)
+	}
+}
+
+func TestXReadCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "XADD", "x-read-a", "1-0", "n", "1")
+	do(t, conn, reader, "XADD", "x-read-a", "2-0", "n", "2")
+
+	// when & then
+	if response := do(t, conn, reader, "XREAD", "COUNT", "1", "STREAMS", "x-read-a", "x-read-b", "0", "0"); response != "*1\r\n*2\r\n$8\r\nx-read-a\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\nn\r\n$1\r\n1\r\n" {
+		t.Fatalf("XREAD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREAD", "STREAMS", "x-read-a", "$"); response != "*-1\r\n" {
+		t.Fatalf("XREAD $ 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREAD", "STREAMS", "x-read-a", "x-read-b", "0"); !strings.HasPrefix(response, "-ERR Unbalanced 'xread' list of streams") {
+		t.Fatalf("짝이 안 맞는 XREAD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREAD", "STREAMS", "x-read-a", ">"); !strings.HasPrefix(response, "-ERR The > ID can be specified only") {
+		t.Fatalf("XREAD > 응답: %q", response)
+	}
+}
+
+func TestXReadBlockWakesOnXAdd(t *testing.T) {
+	// given: 새 엔트리를 기다린다
+	conn, reader := dial(t)
+	send(conn, "XREAD", "BLOCK", "5000", "STREAMS", "x-block", "$")
+	time.Sleep(100 * time.Millisecond)
+
+	// when
+	addConn, addReader := dial(t)
+	do(t, addConn, addReader, "XADD", "x-block", "7-0", "n", "v")
+
+	// then
+	response := readReply(t, reader)
+	if response != "*1\r\n*2\r\n$7\r\nx-block\r\n*1\r\n*2\r\n$3\r\n7-0\r\n*2\r\n$1\r\nn\r\n$1\r\nv\r\n" {
+		t.Fatalf("XREAD BLOCK 응답: %q", response)
+	}
+
+	// when & then: 타임아웃이면 null 배열
+	if response := do(t, conn, reader, "XREAD", "BLOCK", "50", "STREAMS", "x-block", "$"); response != "*-1\r\n" {
+		t.Fatalf("XREAD BLOCK 타임아웃 응답: %q", response)
+	}
+}
+
+func TestConsumerGroupCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "XADD", "x-group", "1-0", "n", "1")
+	do(t, conn, reader, "XADD", "x-group", "2-0", "n", "2")
+
+	// when & then
+	if response := do(t, conn, reader, "XGROUP", "CREATE", "x-group", "g", "0"); response != "+OK\r\n" {
+		t.Fatalf("XGROUP CREATE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XGROUP", "CREATE", "x-group", "g", "$"); response != "-BUSYGROUP Consumer Group name already exists\r\n" {
+		t.Fatalf("중복 XGROUP CREATE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XGROUP", "CREATE", "x-group-missing", "g", "$"); !strings.HasPrefix(response, "-ERR The XGROUP subcommand requires the key to exist") {
+		t.Fatalf("키 없는 XGROUP CREATE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREADGROUP", "GROUP", "g", "alice", "COUNT", "1", "STREAMS", "x-group", ">"); response != "*1\r\n*2\r\n$7\r\nx-group\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\nn\r\n$1\r\n1\r\n" {
+		t.Fatalf("XREADGROUP 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREADGROUP", "GROUP", "g", "bob", "STREAMS", "x-group", ">"); !strings.Contains(response, "2-0") {
+		t.Fatalf("XREADGROUP bob 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XPENDING", "x-group", "g"); response != "*4\r\n:2\r\n$3\r\n1-0\r\n$3\r\n2-0\r\n*2\r\n*2\r\n$5\r\nalice\r\n$1\r\n1\r\n*2\r\n$3\r\nbob\r\n$1\r\n1\r\n" {
+		t.Fatalf("XPENDING 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREADGROUP", "GROUP", "g", "alice", "STREAMS", "x-group", "0"); response != "*1\r\n*2\r\n$7\r\nx-group\r\n*1\r\n*2\r\n$3\r\n1-0\r\n*2\r\n$1\r\nn\r\n$1\r\n1\r\n" {
+		t.Fatalf("이력 XREADGROUP 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XACK", "x-group", "g", "1-0", "1-0"); response != ":1\r\n" {
+		t.Fatalf("XACK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XCLAIM", "x-group", "g", "alice", "0", "2-0", "JUSTID"); response != "*1\r\n$3\r\n2-0\r\n" {
+		t.Fatalf("XCLAIM 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XAUTOCLAIM", "x-group", "g", "bob", "0", "0", "COUNT", "10", "JUSTID"); response != "*3\r\n$3\r\n0-0\r\n*1\r\n$3\r\n2-0\r\n*0\r\n" {
+		t.Fatalf("XAUTOCLAIM 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XREADGROUP", "GROUP", "missing", "alice", "STREAMS", "x-group", ">"); response != "-NOGROUP No such key 'x-group' or consumer group 'missing'\r\n" {
+		t.Fatalf("NOGROUP 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XGROUP", "DESTROY", "x-group", "g"); response != ":1\r\n" {
+		t.Fatalf("XGROUP DESTROY 응답: %q", response)
+	}
+}
+
+func TestXPendingExtended(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "XADD", "x-pel", "1-0", "n", "1")
+	do(t, conn, reader, "XGROUP", "CREATE", "x-pel", "g", "0")
+	do(t, conn, reader, "XREADGROUP", "GROUP", "g", "alice", "STREAMS", "x-pel", ">")
+
+	// when
+	response := do(t, conn, reader, "XPENDING", "x-pel", "g", "-", "+", "10", "alice")
+
+	// then: [[ID, 소비자, 경과 시간, 전달 횟수]]
+	if !strings.HasPrefix(response, "*1\r\n*4\r\n$3\r\n1-0\r\n$5\r\nalice\r\n:") || !strings.HasSuffix(response, ":1\r\n") {
+		t.Fatalf("XPENDING 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XPENDING", "x-pel", "g", "IDLE", "3600000", "-", "+", "10"); response != "*0\r\n" {
+		t.Fatalf("XPENDING IDLE 응답: %q", response)
+	}
+}
+
+func TestXInfoCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "XADD", "x-info", "1-0", "n", "1")
+	do(t, conn, reader, "XGROUP", "CREATE", "x-info", "g", "$")
+
+	// when & then
+	response := do(t, conn, reader, "XINFO", "STREAM", "x-info")
+	if !strings.HasPrefix(response, "*16\r\n$6\r\nlength\r\n:1\r\n$17\r\nlast-generated-id\r\n$3\r\n1-0\r\n") || !strings.Contains(response, "$6\r\ngroups\r\n:1\r\n") {
+		t.Fatalf("XINFO STREAM 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XINFO", "GROUPS", "x-info"); response != "*1\r\n*12\r\n$4\r\nname\r\n$1\r\ng\r\n$9\r\nconsumers\r\n:0\r\n$7\r\npending\r\n:0\r\n$17\r\nlast-delivered-id\r\n$3\r\n1-0\r\n$12\r\nentries-read\r\n:1\r\n$3\r\nlag\r\n:0\r\n" {
+		t.Fatalf("XINFO GROUPS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XINFO", "CONSUMERS", "x-info", "g"); response != "*0\r\n" {
+		t.Fatalf("XINFO CONSUMERS 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "XINFO", "STREAM", "x-info-missing"); response != "-ERR no such key\r\n" {
+		t.Fatalf("키 없는 XINFO STREAM 응답: %q", response)
+	}
+}
@@ -0,0 +1,361 @@
+package server
+
+import (
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SET key value [NX | XX] [GET] [EX seconds | PX milliseconds | EXAT unix-time-seconds | PXAT unix-time-milliseconds | KEEPTTL]
+// GET이면 이전 값(없으면 null), 아니면 OK를 응답한다. 조건 때문에 저장하지 않았으면 null
+func (s *Server) handleSet(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	var options storage.SetOptions
+	hasExpire := false
+	for i := 3; i < len(args); i++ {
+		switch option := strings.ToUpper(args[i].Str); option {
+		case "NX":
+			options.NX = true
+		case "XX":
+			options.XX = true
+		case "GET":
+			options.Get = true
+		case "KEEPTTL":
+			options.KeepTTL = true
+		case "EX", "PX", "EXAT", "PXAT":
+			if hasExpire || i+1 >= len(args) {
+				c.writer.WriteError("syntax error")
+				return
+			}
+			at, err := parseExpireOption(option, args[i+1].Str, "set", c.db.Now())
+			if err != nil {
+				c.writer.WriteError(err.Error())
+				return
+			}
+			options.ExpireAt = &at
+			hasExpire = true
+			i++
+		default:
+			c.writer.WriteError("syntax error")
+			return
+		}
+	}
+	if (options.NX && options.XX) || (options.KeepTTL && hasExpire) {
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	old, exist, set, err := c.db.SetWithOptions(args[1].Str, args[2].Str, options)
+	switch {
+	case err != nil:
+		c.writer.WriteError(err.Error())
+	case options.Get:
+		writeOptionalString(c, old, exist, nil)
+	case !set:
+		c.writer.WriteNull()
+	default:
+		c.writer.WriteSimpleString("OK")
+	}
+}
+
+// INCR key / DECR key
+func (s *Server) handleIncr(c *client, args []protocol.Value, delta int64) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	s.incrBy(c, args[1].Str, delta)
+}
+
+// INCRBY key increment / DECRBY key decrement
+func (s *Server) handleIncrBy(c *client, args []protocol.Value, decrement bool) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	delta, err := strconv.ParseInt(args[2].Str, 10, 64)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+	if decrement {
+		if delta == math.MinInt64 {
+			c.writer.WriteError("decrement would overflow")
+			return
+		}
+		delta = -delta
+	}
+
+	s.incrBy(c, args[1].Str, delta)
+}
+
+func (s *Server) incrBy(c *client, key string, delta int64) {
+	result, err := c.db.IncrBy(key, delta)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(int(result))
+	}
+}
+
+// INCRBYFLOAT key increment
+func (s *Server) handleIncrByFloat(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	delta, err := strconv.ParseFloat(args[2].Str, 64)
+	if err != nil || math.IsNaN(delta) || math.IsInf(delta, 0) {
+		c.writer.WriteError("value is not a valid float")
+		return
+	}
+
+	result, err := c.db.IncrByFloat(args[1].Str, delta)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteBulkString(result)
+	}
+}
+
+// APPEND key value
+func (s *Server) handleAppend(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	length, err := c.db.Append(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// STRLEN key
+func (s *Server) handleStrLen(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	length, err := c.db.StrLen(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// GETRANGE key start end
+func (s *Server) handleGetRange(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	start, err1 := strconv.Atoi(args[2].Str)
+	end, err2 := strconv.Atoi(args[3].Str)
+	if err1 != nil || err2 != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+
+	value, err := c.db.GetRange(args[1].Str, start, end)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteBulkString(value)
+	}
+}
+
+// SETRANGE key offset value
+func (s *Server) handleSetRange(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	offset, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+	if offset < 0 {
+		c.writer.WriteError("offset is out of range")
+		return
+	}
+
+	length, err := c.db.SetRange(args[1].Str, offset, args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(length)
+	}
+}
+
+// MGET key [key ...]
+// 없거나 문자열이 아닌 키는 null로 응답한다.
+func (s *Server) handleMGet(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	values, exists := c.db.MGet(argStrings(args[1:])...)
+	c.writer.WriteArrayLen(len(values))
+	for i, value := range values {
+		if exists[i] {
+			c.writer.WriteBulkString(value)
+		} else {
+			c.writer.WriteNull()
+		}
+	}
+}
+
+// MSET key value [key value ...]
+func (s *Server) handleMSet(c *client, args []protocol.Value) {
+	if len(args) < 3 || len(args)%2 != 1 {
+		c.writer.WriteError("wrong number of arguments for 'mset' command")
+		return
+	}
+
+	c.db.MSet(argStrings(args[1:])...)
+	c.writer.WriteSimpleString("OK")
+}
+
+// MSETNX key value [key value ...]
+// 키가 하나라도 있으면 아무것도 저장하지 않는다.
+func (s *Server) handleMSetNX(c *client, args []protocol.Value) {
+	if len(args) < 3 || len(args)%2 != 1 {
+		c.writer.WriteError("wrong number of arguments for 'msetnx' command")
+		return
+	}
+
+	c.writer.WriteInteger(boolToInt(c.db.MSetNX(argStrings(args[1:])...)))
+}
+
+// SETNX key value
+func (s *Server) handleSetNX(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	c.writer.WriteInteger(boolToInt(c.db.SetNX(args[1].Str, args[2].Str)))
+}
+
+// GETSET key value
+func (s *Server) handleGetSet(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	old, exist, err := c.db.GetSet(args[1].Str, args[2].Str)
+	writeOptionalString(c, old, exist, err)
+}
+
+// GET key
+func (s *Server) handleGet(c *client, args []protocol.Value) {
+	if len(args) != 2 {
+		c.writer.WriteError("wrong number of arguments for 'get' command")
+		return
+	}
+
+	value, exist := c.db.Get(args[1].Str)
+	writeOptionalString(c, value, exist, nil)
+}
+
+// GETDEL key
+func (s *Server) handleGetDel(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	value, exist, err := c.db.GetDel(args[1].Str)
+	writeOptionalString(c, value, exist, err)
+}
+
+// GETEX key [EX seconds | PX milliseconds | EXAT unix-time-seconds | PXAT unix-time-milliseconds | PERSIST]
+func (s *Server) handleGetEx(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	var options storage.GetExOptions
+	rest := args[2:]
+	switch {
+	case len(rest) == 0:
+	case len(rest) == 1 && strings.ToUpper(rest[0].Str) == "PERSIST":
+		options.Persist = true
+	case len(rest) == 2:
+		at, err := parseExpireOption(rest[0].Str, rest[1].Str, "getex", c.db.Now())
+		if err != nil {
+			c.writer.WriteError(err.Error())
+			return
+		}
+		options.ExpireAt = &at
+	default:
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	value, exist, err := c.db.GetEx(args[1].Str, options)
+	writeOptionalString(c, value, exist, err)
+}
+
+// EX/PX/EXAT/PXAT 옵션을 절대 만료 시각으로 바꾼다. EX/PX는 now부터 센다.
+func parseExpireOption(option, raw, command string, now time.Time) (time.Time, error) {
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return time.Time{}, errors.New("value is not an integer or out of range")
+	}
+	invalid := errors.New("invalid expire time in '" + command + "' command")
+	if n <= 0 {
+		return time.Time{}, invalid
+	}
+
+	switch strings.ToUpper(option) {
+	case "EX":
+		if n > math.MaxInt64/int64(time.Second) {
+			return time.Time{}, invalid
+		}
+		return now.Add(time.Duration(n) * time.Second), nil
+	case "PX":
+		if n > math.MaxInt64/int64(time.Millisecond) {
+			return time.Time{}, invalid
+		}
+		return now.Add(time.Duration(n) * time.Millisecond), nil
+	case "EXAT":
+		return time.Unix(n, 0), nil
+	case "PXAT":
+		return time.UnixMilli(n), nil
+	}
+	return time.Time{}, errors.New("syntax error")
+}
+
+// 있으면 bulk string, 없으면 null로 응답한다.
+func writeOptionalString(c *client, value string, exist bool, err error) {
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !exist {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteBulkString(value)
+	}
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"testing"
+)
+
+func TestCounterCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "s-text", "abc")
+
+	// when & then
+	if response := do(t, conn, reader, "INCR", "s-counter"); response != ":1\r\n" {
+		t.Fatalf("INCR 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "INCRBY", "s-counter", "10"); response != ":11\r\n" {
+		t.Fatalf("INCRBY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DECR", "s-counter"); response != ":10\r\n" {
+		t.Fatalf("DECR 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DECRBY", "s-counter", "3"); response != ":7\r\n" {
+		t.Fatalf("DECRBY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "DECRBY", "s-counter", "-9223372036854775808"); response != "-ERR decrement would overflow\r\n" {
+		t.Fatalf("DECRBY 최솟값 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "INCR", "s-text"); response != "-ERR value is not an integer or out of range\r\n" {
+		t.Fatalf("문자열 INCR 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "INCRBYFLOAT", "s-counter", "0.5"); response != "$3\r\n7.5\r\n" {
+		t.Fatalf("INCRBYFLOAT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "INCRBYFLOAT", "s-counter", "abc"); response != "-ERR value is not a valid float\r\n" {
+		t.Fatalf("잘못된 INCRBYFLOAT 응답: %q", response)
+	}
+}
+
+func TestStringRangeCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "APPEND", "s-greet", "Hello"); response != ":5\r\n" {
+		t.Fatalf("APPEND 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "APPEND", "s-greet", " World"); response != ":11\r\n" {
+		t.Fatalf("APPEND 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "STRLEN", "s-greet"); response != ":11\r\n" {
+		t.Fatalf("STRLEN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETRANGE", "s-greet", "-5", "-1"); response != "$5\r\nWorld\r\n" {
+		t.Fatalf("GETRANGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SETRANGE", "s-greet", "6", "Redis"); response != ":11\r\n" {
+		t.Fatalf("SETRANGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GET", "s-greet"); response != "$11\r\nHello Redis\r\n" {
+		t.Fatalf("GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SETRANGE", "s-greet", "-1", "x"); response != "-ERR offset is out of range\r\n" {
+		t.Fatalf("음수 SETRANGE 응답: %q", response)
+	}
+}
+
+func TestMultiKeyStringCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "MSET", "s-a", "1", "s-b", "2"); response != "+OK\r\n" {
+		t.Fatalf("MSET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GET"); response != "-ERR wrong number of arguments for 'get' command\r\n" {
+		t.Fatalf("인자 없는 GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MSET", "s-a", "1", "s-b"); response != "-ERR wrong number of arguments for 'mset' command\r\n" {
+		t.Fatalf("짝이 안 맞는 MSET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MGET", "s-a", "s-missing", "s-b"); response != "*3\r\n$1\r\n1\r\n$-1\r\n$1\r\n2\r\n" {
+		t.Fatalf("MGET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "MSETNX", "s-a", "x", "s-c", "3"); response != ":0\r\n" {
+		t.Fatalf("MSETNX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SETNX", "s-c", "3"); response != ":1\r\n" {
+		t.Fatalf("SETNX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETSET", "s-c", "4"); response != "$1\r\n3\r\n" {
+		t.Fatalf("GETSET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETDEL", "s-c"); response != "$1\r\n4\r\n" {
+		t.Fatalf("GETDEL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETDEL", "s-c"); response != "$-1\r\n" {
+		t.Fatalf("없는 키 GETDEL 응답: %q", response)
+	}
+}
+
+func TestGetExCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "SET", "s-ex", "v")
+
+	// when & then
+	if response := do(t, conn, reader, "GETEX", "s-ex", "EX", "100"); response != "$1\r\nv\r\n" {
+		t.Fatalf("GETEX EX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TTL", "s-ex"); response == ":-1\r\n" || response == ":-2\r\n" {
+		t.Fatalf("GETEX 후 TTL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETEX", "s-ex", "PERSIST"); response != "$1\r\nv\r\n" {
+		t.Fatalf("GETEX PERSIST 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TTL", "s-ex"); response != ":-1\r\n" {
+		t.Fatalf("PERSIST 후 TTL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETEX", "s-ex", "EX", "0"); response != "-ERR invalid expire time in 'getex' command\r\n" {
+		t.Fatalf("잘못된 GETEX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GETEX", "s-ex", "EX", "10", "PERSIST"); response != "-ERR syntax error\r\n" {
+		t.Fatalf("옵션이 겹치는 GETEX 응답: %q", response)
+	}
+}
+
+func TestSetOptions(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "SET", "s-opt", "v1", "NX", "EX", "100"); response != "+OK\r\n" {
+		t.Fatalf("SET NX EX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v2", "NX"); response != "$-1\r\n" {
+		t.Fatalf("SET NX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v2", "XX", "KEEPTTL", "GET"); response != "$2\r\nv1\r\n" {
+		t.Fatalf("SET XX KEEPTTL GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "TTL", "s-opt"); response == ":-1\r\n" || response == ":-2\r\n" {
+		t.Fatalf("KEEPTTL 후 TTL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v3", "PX", "100000"); response != "+OK\r\n" {
+		t.Fatalf("SET PX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt-missing", "v", "GET"); response != "$-1\r\n" {
+		t.Fatalf("없는 키 SET GET 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v", "NX", "XX"); response != "-ERR syntax error\r\n" {
+		t.Fatalf("NX XX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v", "EX", "10", "KEEPTTL"); response != "-ERR syntax error\r\n" {
+		t.Fatalf("EX KEEPTTL 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v", "EX", "-1"); response != "-ERR invalid expire time in 'set' command\r\n" {
+		t.Fatalf("음수 EX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "SET", "s-opt", "v", "EXAT", "1"); response != "+OK\r\n" {
+		t.Fatalf("과거 EXAT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "GET", "s-opt"); response != "$-1\r\n" {
+		t.Fatalf("과거 EXAT 후 GET 응답: %q", response)
+	}
+}
@@ -0,0 +1,562 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"inmemory-db/internal/protocol"
+	"inmemory-db/internal/storage"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ZADD key [NX|XX] [GT|LT] [CH] [INCR] score member [score member ...]
+func (s *Server) handleZAdd(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	var options storage.ZAddOptions
+	incr := false
+	i := 2
+	for ; i < len(args); i++ {
+		switch strings.ToUpper(args[i].Str) {
+		case "NX":
+			options.NX = true
+		case "XX":
+			options.XX = true
+		case "GT":
+			options.GT = true
+		case "LT":
+			options.LT = true
+		case "CH":
+			options.CH = true
+		case "INCR":
+			incr = true
+		default:
+			goto pairs
+		}
+	}
+
+pairs:
+	rest := args[i:]
+	if len(rest) == 0 || len(rest)%2 != 0 {
+		c.writer.WriteError("syntax error")
+		return
+	}
+	if options.NX && options.XX {
+		c.writer.WriteError("XX and NX options at the same time are not compatible")
+		return
+	}
+	if (options.GT && options.LT) || (options.NX && (options.GT || options.LT)) {
+		c.writer.WriteError("GT, LT, and/or NX options at the same time are not compatible")
+		return
+	}
+	if incr && len(rest) != 2 {
+		c.writer.WriteError("INCR option supports a single increment-element pair")
+		return
+	}
+
+	members := make([]storage.ZMember, 0, len(rest)/2)
+	for j := 0; j < len(rest); j += 2 {
+		score, err := parseScore(rest[j].Str)
+		if err != nil {
+			c.writer.WriteError(err.Error())
+			return
+		}
+		members = append(members, storage.ZMember{Member: rest[j+1].Str, Score: score})
+	}
+
+	if incr {
+		score, ok, err := c.db.ZAddIncr(args[1].Str, options, members[0].Member, members[0].Score)
+		if err != nil {
+			c.writer.WriteError(err.Error())
+		} else if !ok {
+			c.writer.WriteNull()
+		} else {
+			c.writer.WriteBulkString(formatScore(score))
+		}
+		return
+	}
+
+	count, err := c.db.ZAdd(args[1].Str, options, members...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// ZINCRBY key increment member
+func (s *Server) handleZIncrBy(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	increment, err := parseScore(args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	score, err := c.db.ZIncrBy(args[1].Str, args[3].Str, increment)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteBulkString(formatScore(score))
+	}
+}
+
+// ZREM key member [member ...]
+func (s *Server) handleZRem(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	removed, err := c.db.ZRem(args[1].Str, argStrings(args[2:])...)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(removed)
+	}
+}
+
+// ZCARD key
+func (s *Server) handleZCard(c *client, args []protocol.Value) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	count, err := c.db.ZCard(args[1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// ZSCORE key member
+func (s *Server) handleZScore(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	score, exist, err := c.db.ZScore(args[1].Str, args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !exist {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteBulkString(formatScore(score))
+	}
+}
+
+// ZRANK key member / ZREVRANK key member
+func (s *Server) handleZRank(c *client, args []protocol.Value, reverse bool) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	rank, exist, err := c.db.ZRank(args[1].Str, args[2].Str, reverse)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else if !exist {
+		c.writer.WriteNull()
+	} else {
+		c.writer.WriteInteger(rank)
+	}
+}
+
+// ZRANGE key start stop [BYSCORE|BYLEX] [REV] [LIMIT offset count] [WITHSCORES]
+// REV와 BYSCORE/BYLEX를 함께 쓰면 start가 최댓값, stop이 최솟값이다.
+func (s *Server) handleZRange(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	byScore, byLex, reverse, withScores, limited := false, false, false, false, false
+	offset, count := 0, -1
+	for i := 4; i < len(args); i++ {
+		switch strings.ToUpper(args[i].Str) {
+		case "BYSCORE":
+			byScore = true
+		case "BYLEX":
+			byLex = true
+		case "REV":
+			reverse = true
+		case "WITHSCORES":
+			withScores = true
+		case "LIMIT":
+			if i+2 >= len(args) {
+				c.writer.WriteError("syntax error")
+				return
+			}
+			var err1, err2 error
+			offset, err1 = strconv.Atoi(args[i+1].Str)
+			count, err2 = strconv.Atoi(args[i+2].Str)
+			if err1 != nil || err2 != nil {
+				c.writer.WriteError("value is not an integer or out of range")
+				return
+			}
+			limited = true
+			i += 2
+		default:
+			c.writer.WriteError("syntax error")
+			return
+		}
+	}
+
+	if byScore && byLex {
+		c.writer.WriteError("syntax error")
+		return
+	}
+	if limited && !byScore && !byLex {
+		c.writer.WriteError("syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX")
+		return
+	}
+	if withScores && byLex {
+		c.writer.WriteError("syntax error, WITHSCORES not supported in combination with BYLEX")
+		return
+	}
+
+	minArg, maxArg := args[2].Str, args[3].Str
+	if reverse {
+		minArg, maxArg = maxArg, minArg
+	}
+	// 음수 offset은 빈 결과 (Redis 동작)
+	if offset < 0 {
+		count = 0
+	}
+
+	var members []storage.ZMember
+	var err error
+	switch {
+	case byScore:
+		r, parseErr := parseScoreRange(minArg, maxArg)
+		if parseErr != nil {
+			c.writer.WriteError(parseErr.Error())
+			return
+		}
+		members, err = c.db.ZRangeByScore(args[1].Str, r, reverse, max(offset, 0), count)
+
+	case byLex:
+		r, parseErr := parseLexRange(minArg, maxArg)
+		if parseErr != nil {
+			c.writer.WriteError(parseErr.Error())
+			return
+		}
+		members, err = c.db.ZRangeByLex(args[1].Str, r, reverse, max(offset, 0), count)
+
+	default:
+		start, err1 := strconv.Atoi(args[2].Str)
+		stop, err2 := strconv.Atoi(args[3].Str)
+		if err1 != nil || err2 != nil {
+			c.writer.WriteError("value is not an integer or out of range")
+			return
+		}
+		members, err = c.db.ZRangeByRank(args[1].Str, start, stop, reverse)
+	}
+
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	writeZMembers(c.writer, members, withScores)
+}
+
+// ZCOUNT key min max
+func (s *Server) handleZCount(c *client, args []protocol.Value) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	r, err := parseScoreRange(args[2].Str, args[3].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	count, err := c.db.ZCount(args[1].Str, r)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// ZPOPMIN key [count] / ZPOPMAX key [count]
+// [member1, score1, member2, score2, ...] 형태로 응답한다.
+func (s *Server) handleZPop(c *client, args []protocol.Value, max bool) {
+	if len(args) < 2 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	count := 1
+	if len(args) > 2 {
+		n, err := strconv.Atoi(args[2].Str)
+		if err != nil || n < 0 {
+			c.writer.WriteError("value is out of range, must be positive")
+			return
+		}
+		count = n
+	}
+
+	var members []storage.ZMember
+	var err error
+	if max {
+		members, err = c.db.ZPopMax(args[1].Str, count)
+	} else {
+		members, err = c.db.ZPopMin(args[1].Str, count)
+	}
+
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		writeZMembers(c.writer, members, true)
+	}
+}
+
+// BZPOPMIN key [key ...] timeout / BZPOPMAX key [key ...] timeout
+// [key, member, score] 형태로 응답한다.
+func (s *Server) handleBZPop(c *client, args []protocol.Value, max bool) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	timeout, err := parseTimeout(args[len(args)-1].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	ctx, done := s.blockContext(c, timeout)
+	key, member, ok, err := c.db.BlockingZPop(ctx, argStrings(args[1:len(args)-1]), max)
+	done()
+
+	switch {
+	case err != nil:
+		c.writer.WriteError(err.Error())
+	case ok:
+		c.writer.WriteArray([]string{key, member.Member, formatScore(member.Score)})
+	case context.Cause(ctx) == errUnblockedError:
+		c.writer.WriteErrorCode("UNBLOCKED", errUnblockedError.Error())
+	default:
+		c.writer.WriteNullArray()
+	}
+}
+
+// ZUNIONSTORE destination numkeys key [key ...] [WEIGHTS weight [weight ...]] [AGGREGATE SUM|MIN|MAX]
+// ZINTERSTORE도 형식이 같다.
+func (s *Server) handleZSetAlgebraStore(c *client, args []protocol.Value, inter bool) {
+	if len(args) < 4 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	name := "zunionstore"
+	if inter {
+		name = "zinterstore"
+	}
+
+	numKeys, err := strconv.Atoi(args[2].Str)
+	if err != nil {
+		c.writer.WriteError("value is not an integer or out of range")
+		return
+	}
+	if numKeys <= 0 {
+		c.writer.WriteError("at least 1 input key is needed for '" + name + "' command")
+		return
+	}
+	if numKeys > len(args)-3 {
+		c.writer.WriteError("syntax error")
+		return
+	}
+
+	keys := argStrings(args[3 : 3+numKeys])
+	var weights []float64
+	aggregate := storage.ZAggregateSum
+
+	rest := args[3+numKeys:]
+	for i := 0; i < len(rest); i++ {
+		switch strings.ToUpper(rest[i].Str) {
+		case "WEIGHTS":
+			if i+numKeys >= len(rest) {
+				c.writer.WriteError("syntax error")
+				return
+			}
+			weights = make([]float64, numKeys)
+			for j := 0; j < numKeys; j++ {
+				weight, err := strconv.ParseFloat(rest[i+1+j].Str, 64)
+				if err != nil || math.IsNaN(weight) {
+					c.writer.WriteError("weight value is not a float")
+					return
+				}
+				weights[j] = weight
+			}
+			i += numKeys
+		case "AGGREGATE":
+			if i+1 >= len(rest) {
+				c.writer.WriteError("syntax error")
+				return
+			}
+			switch strings.ToUpper(rest[i+1].Str) {
+			case "SUM":
+				aggregate = storage.ZAggregateSum
+			case "MIN":
+				aggregate = storage.ZAggregateMin
+			case "MAX":
+				aggregate = storage.ZAggregateMax
+			default:
+				c.writer.WriteError("syntax error")
+				return
+			}
+			i++
+		default:
+			c.writer.WriteError("syntax error")
+			return
+		}
+	}
+
+	var count int
+	if inter {
+		count, err = c.db.ZInterStore(args[1].Str, keys, weights, aggregate)
+	} else {
+		count, err = c.db.ZUnionStore(args[1].Str, keys, weights, aggregate)
+	}
+
+	if err != nil {
+		c.writer.WriteError(err.Error())
+	} else {
+		c.writer.WriteInteger(count)
+	}
+}
+
+// ZSCAN key cursor [MATCH pattern] [COUNT count]
+func (s *Server) handleZScan(c *client, args []protocol.Value) {
+	if len(args) < 3 {
+		c.writer.WriteError("missing argument")
+		return
+	}
+
+	cursor, err := parseScanCursor(args[2].Str)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+	options, err := parseScanOptions(args[3:], false, false)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	next, members, err := c.db.ZScan(args[1].Str, cursor, options.pattern, options.count)
+	if err != nil {
+		c.writer.WriteError(err.Error())
+		return
+	}
+
+	elements := make([]string, 0, len(members)*2)
+	for _, m := range members {
+		elements = append(elements, m.Member, formatScore(m.Score))
+	}
+	writeScanReply(c.writer, next, elements)
+}
+
+// 원소 목록을 쓴다. withScores면 [member1, score1, member2, score2, ...] 형태다.
+func writeZMembers(writer *protocol.Writer, members []storage.ZMember, withScores bool) {
+	result := make([]string, 0, len(members)*2)
+	for _, m := range members {
+		result = append(result, m.Member)
+		if withScores {
+			result = append(result, formatScore(m.Score))
+		}
+	}
+	writer.WriteArray(result)
+}
+
+// 점수 인자를 파싱한다. inf, -inf는 허용하고 NaN은 허용하지 않는다.
+func parseScore(raw string) (float64, error) {
+	score, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(score) {
+		return 0, errors.New("value is not a valid float")
+	}
+	return score, nil
+}
+
+// ZRANGE BYSCORE, ZCOUNT의 min max 인자를 파싱한다. "("로 시작하면 경계값을 포함하지 않는다.
+func parseScoreRange(minArg, maxArg string) (storage.ScoreRange, error) {
+	var r storage.ScoreRange
+	var err1, err2 error
+	r.Min, r.MinExclusive, err1 = parseScoreBound(minArg)
+	r.Max, r.MaxExclusive, err2 = parseScoreBound(maxArg)
+	if err1 != nil || err2 != nil {
+		return r, errors.New("min or max is not a float")
+	}
+	return r, nil
+}
+
+func parseScoreBound(raw string) (float64, bool, error) {
+	exclusive := strings.HasPrefix(raw, "(")
+	if exclusive {
+		raw = raw[1:]
+	}
+	value, err := strconv.ParseFloat(raw, 64)
+	if err != nil || math.IsNaN(value) {
+		return 0, false, errors.New("not a float")
+	}
+	return value, exclusive, nil
+}
+
+// ZRANGE BYLEX의 min max 인자를 파싱한다. "[a", "(a", "-", "+" 형식이다.
+func parseLexRange(minArg, maxArg string) (storage.LexRange, error) {
+	var r storage.LexRange
+	var ok1, ok2 bool
+	r.Min, ok1 = parseLexBound(minArg)
+	r.Max, ok2 = parseLexBound(maxArg)
+	if !ok1 || !ok2 {
+		return r, errors.New("min or max not valid string range item")
+	}
+	return r, nil
+}
+
+func parseLexBound(raw string) (storage.LexBound, bool) {
+	switch {
+	case raw == "-":
+		return storage.LexBound{Infinite: -1}, true
+	case raw == "+":
+		return storage.LexBound{Infinite: 1}, true
+	case strings.HasPrefix(raw, "["):
+		return storage.LexBound{Value: raw[1:]}, true
+	case strings.HasPrefix(raw, "("):
+		return storage.LexBound{Value: raw[1:], Exclusive: true}, true
+	default:
+		return storage.LexBound{}, false
+	}
+}
+
+// 점수를 응답용 문자열로 바꾼다.
+// Redis처럼 정수는 소수점 없이, 아주 크거나 작은 값만 지수 표기로 쓴다.
+func formatScore(score float64) string {
+	switch {
+	case math.IsInf(score, 1):
+		return "inf"
+	case math.IsInf(score, -1):
+		return "-inf"
+	}
+
+	abs := math.Abs(score)
+	if score == 0 || (abs >= 1e-4 && abs < 1e17) {
+		return strconv.FormatFloat(score, 'f', -1, 64)
+	}
+	return strconv.FormatFloat(score, 'g', -1, 64)
+}
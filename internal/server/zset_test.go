@@ -0,0 +1,195 @@
+package server
+
+import (
+	"testing"
+	"time"
+)
+
+func TestZSetCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	// when & then
+	if response := do(t, conn, reader, "ZADD", "z-rank", "1", "a", "2", "b", "3", "c"); response != ":3\r\n" {
+		t.Fatalf("ZADD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZADD", "z-rank", "XX", "CH", "5", "a", "1", "d"); response != ":1\r\n" {
+		t.Fatalf("ZADD XX CH 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZADD", "z-rank", "INCR", "0.5", "b"); response != "$3\r\n2.5\r\n" {
+		t.Fatalf("ZADD INCR 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZADD", "z-rank", "NX", "INCR", "1", "b"); response != "$-1\r\n" {
+		t.Fatalf("ZADD NX INCR 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZINCRBY", "z-rank", "-1", "c"); response != "$1\r\n2\r\n" {
+		t.Fatalf("ZINCRBY 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZSCORE", "z-rank", "a"); response != "$1\r\n5\r\n" {
+		t.Fatalf("ZSCORE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZRANK", "z-rank", "a"); response != ":2\r\n" {
+		t.Fatalf("ZRANK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZREVRANK", "z-rank", "a"); response != ":0\r\n" {
+		t.Fatalf("ZREVRANK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZRANK", "z-rank", "missing"); response != "$-1\r\n" {
+		t.Fatalf("없는 원소 ZRANK 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZCARD", "z-rank"); response != ":3\r\n" {
+		t.Fatalf("ZCARD 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZCOUNT", "z-rank", "(2", "+inf"); response != ":2\r\n" {
+		t.Fatalf("ZCOUNT 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZREM", "z-rank", "a", "missing"); response != ":1\r\n" {
+		t.Fatalf("ZREM 응답: %q", response)
+	}
+}
+
+func TestZAddErrors(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+
+	cases := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"ZADD", "z-err", "NX", "XX", "1", "a"}, "-ERR XX and NX options at the same time are not compatible\r\n"},
+		{[]string{"ZADD", "z-err", "GT", "LT", "1", "a"}, "-ERR GT, LT, and/or NX options at the same time are not compatible\r\n"},
+		{[]string{"ZADD", "z-err", "INCR", "1", "a", "2", "b"}, "-ERR INCR option supports a single increment-element pair\r\n"},
+		{[]string{"ZADD", "z-err", "abc", "a"}, "-ERR value is not a valid float\r\n"},
+		{[]string{"ZADD", "z-err", "1", "a", "2"}, "-ERR syntax error\r\n"},
+	}
+
+	for _, c := range cases {
+		// when
+		response := do(t, conn, reader, c.args...)
+
+		// then
+		if response != c.expected {
+			t.Fatalf("%v 응답: %q", c.args, response)
+		}
+	}
+}
+
+func TestZRangeCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "ZADD", "z-range", "1", "a", "2", "b", "3", "c", "4", "d")
+	do(t, conn, reader, "ZADD", "z-lex", "0", "a", "0", "b", "0", "c")
+
+	cases := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"0", "1", "WITHSCORES"}, "*4\r\n$1\r\na\r\n$1\r\n1\r\n$1\r\nb\r\n$1\r\n2\r\n"},
+		{[]string{"0", "0", "REV"}, "*1\r\n$1\r\nd\r\n"},
+		{[]string{"(1", "3", "BYSCORE"}, "*2\r\n$1\r\nb\r\n$1\r\nc\r\n"},
+		{[]string{"+inf", "-inf", "BYSCORE", "REV", "LIMIT", "1", "2"}, "*2\r\n$1\r\nc\r\n$1\r\nb\r\n"},
+		{[]string{"0", "-1", "LIMIT", "0", "1"}, "-ERR syntax error, LIMIT is only supported in combination with either BYSCORE or BYLEX\r\n"},
+		{[]string{"abc", "1", "BYSCORE"}, "-ERR min or max is not a float\r\n"},
+	}
+
+	for _, c := range cases {
+		// when
+		response := do(t, conn, reader, append([]string{"ZRANGE", "z-range"}, c.args...)...)
+
+		// then
+		if response != c.expected {
+			t.Fatalf("ZRANGE %v 응답: %q", c.args, response)
+		}
+	}
+
+	// when & then: BYLEX
+	if response := do(t, conn, reader, "ZRANGE", "z-lex", "(a", "+", "BYLEX"); response != "*2\r\n$1\r\nb\r\n$1\r\nc\r\n" {
+		t.Fatalf("ZRANGE BYLEX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZRANGE", "z-lex", "[b", "-", "BYLEX", "REV"); response != "*2\r\n$1\r\nb\r\n$1\r\na\r\n" {
+		t.Fatalf("ZRANGE BYLEX REV 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZRANGE", "z-lex", "a", "+", "BYLEX"); response != "-ERR min or max not valid string range item\r\n" {
+		t.Fatalf("잘못된 BYLEX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZRANGE", "z-lex", "-", "+", "BYLEX", "WITHSCORES"); response != "-ERR syntax error, WITHSCORES not supported in combination with BYLEX\r\n" {
+		t.Fatalf("BYLEX WITHSCORES 응답: %q", response)
+	}
+}
+
+func TestZPopCommands(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "ZADD", "z-pop", "1", "a", "2", "b", "3", "c")
+
+	// when & then
+	if response := do(t, conn, reader, "ZPOPMIN", "z-pop"); response != "*2\r\n$1\r\na\r\n$1\r\n1\r\n" {
+		t.Fatalf("ZPOPMIN 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZPOPMAX", "z-pop", "5"); response != "*4\r\n$1\r\nc\r\n$1\r\n3\r\n$1\r\nb\r\n$1\r\n2\r\n" {
+		t.Fatalf("ZPOPMAX 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZPOPMIN", "z-pop"); response != "*0\r\n" {
+		t.Fatalf("빈 집합 ZPOPMIN 응답: %q", response)
+	}
+}
+
+func TestBZPopMinWakesOnZAdd(t *testing.T) {
+	// given: 빈 정렬된 집합에서 BZPOPMIN 대기
+	conn, reader := dial(t)
+	send(conn, "BZPOPMIN", "bz-queue", "5")
+	time.Sleep(100 * time.Millisecond)
+
+	// when
+	addConn, addReader := dial(t)
+	do(t, addConn, addReader, "ZADD", "bz-queue", "2", "b", "1.5", "a")
+
+	// then: [key, member, score] 배열 응답
+	response := readReply(t, reader)
+	if response != "*3\r\n$8\r\nbz-queue\r\n$1\r\na\r\n$3\r\n1.5\r\n" {
+		t.Fatalf("BZPOPMIN 응답: %q", response)
+	}
+
+	// when & then: 타임아웃이면 null 배열
+	if response := do(t, conn, reader, "BZPOPMAX", "bz-empty", "0.05"); response != "*-1\r\n" {
+		t.Fatalf("BZPOPMAX 응답: %q", response)
+	}
+}
+
+func TestZUnionAndInterStore(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "ZADD", "z-u1", "1", "a", "2", "b")
+	do(t, conn, reader, "ZADD", "z-u2", "3", "b", "4", "c")
+
+	// when & then
+	if response := do(t, conn, reader, "ZUNIONSTORE", "z-union", "2", "z-u1", "z-u2", "WEIGHTS", "2", "1"); response != ":3\r\n" {
+		t.Fatalf("ZUNIONSTORE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZRANGE", "z-union", "0", "-1", "WITHSCORES"); response != "*6\r\n$1\r\na\r\n$1\r\n2\r\n$1\r\nc\r\n$1\r\n4\r\n$1\r\nb\r\n$1\r\n7\r\n" {
+		t.Fatalf("ZRANGE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZINTERSTORE", "z-inter", "2", "z-u1", "z-u2", "AGGREGATE", "MIN"); response != ":1\r\n" {
+		t.Fatalf("ZINTERSTORE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZSCORE", "z-inter", "b"); response != "$1\r\n2\r\n" {
+		t.Fatalf("ZSCORE 응답: %q", response)
+	}
+	if response := do(t, conn, reader, "ZUNIONSTORE", "z-union", "0", "z-u1"); response != "-ERR at least 1 input key is needed for 'zunionstore' command\r\n" {
+		t.Fatalf("numkeys 0 응답: %q", response)
+	}
+}
+
+func TestZScanCommand(t *testing.T) {
+	// given
+	conn, reader := dial(t)
+	do(t, conn, reader, "ZADD", "z-scan", "1", "one", "2", "two")
+
+	// when
+	response := do(t, conn, reader, "ZSCAN", "z-scan", "0", "MATCH", "o*")
+
+	// then
+	if response != "*2\r\n$1\r\n0\r\n*2\r\n$3\r\none\r\n$1\r\n1\r\n" {
+		t.Fatalf("ZSCAN 응답: %q", response)
+	}
+}
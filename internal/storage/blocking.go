@@ -0,0 +1,177 @@
+package storage
+
+import (
+	"context"
+	"slices"
+)
+
+// waiter는 블로킹 명령어(BLPOP 등)로 키에 데이터가 들어오기를 기다리는 클라이언트다.
+// 같은 키를 기다리는 waiter들은 FIFO 순서로 처리된다.
+type waiter struct {
+	keys []string
+
+	// serve가 건드리는 모든 키 (keys와 BLMOVE의 destination).
+	// serve를 호출하기 전에 이 키들의 샤드 락을 모두 잡는다.
+	locks []string
+
+	// 키에 데이터가 들어왔을 때 locks의 샤드 락을 잡은 상태에서 호출된다.
+	// 요청을 처리했으면 true를 반환하고, 처리할 수 없으면(예: 빈 리스트) false를 반환한다.
+	serve func(key string, entry *Entry) bool
+
+	// serve가 성공하면 닫힌다.
+	ready chan struct{}
+
+	// 대기열에 등록되어 있으면 true. blockedMu로 보호한다
+	registered bool
+}
+
+// 키들에 대해 waiter를 등록한다. blockedMu를 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) addWaiter(w *waiter) {
+	for _, key := range w.keys {
+		s.blocked[key] = append(s.blocked[key], w)
+	}
+	w.registered = true
+	s.waiting.Add(1)
+}
+
+// 모든 키의 대기열에서 waiter를 제거한다. blockedMu를 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) removeWaiter(w *waiter) {
+	for _, key := range w.keys {
+		queue := s.blocked[key]
+		for i, other := range queue {
+			if other == w {
+				queue = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+		if len(queue) == 0 {
+			delete(s.blocked, key)
+		} else {
+			s.blocked[key] = queue
+		}
+	}
+	w.registered = false
+	s.waiting.Add(-1)
+}
+
+// 키에 데이터가 추가되었음을 기록한다. 기다리는 클라이언트는 명령어가 락을 푼 뒤
+// (unlock에서) 먼저 온 순서대로 깨운다. 락을 잡은 채로 다른 샤드의 락을 잡지 않기 위해서다.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) serveBlocked(key string) {
+	if s.waiting.Load() == 0 {
+		return
+	}
+
+	s.blockedMu.Lock()
+	defer s.blockedMu.Unlock()
+
+	if len(s.blocked[key]) > 0 && !slices.Contains(s.ready, key) {
+		s.ready = append(s.ready, key)
+		s.hasReady.Store(true)
+	}
+}
+
+// serveBlocked로 기록된 키들에서 기다리는 클라이언트를 깨운다.
+// 처리 도중 다른 키에 데이터가 들어오면(BLMOVE) 그 키도 이어서 처리한다.
+// 샤드 락을 잡지 않은 상태에서 호출해야 한다 (내부용).
+func (s *Store) serveReady() {
+	for s.hasReady.Load() {
+		s.blockedMu.Lock()
+		keys := s.ready
+		s.ready = nil
+		s.hasReady.Store(false)
+		s.blockedMu.Unlock()
+
+		for _, key := range keys {
+			s.serveKey(key)
+		}
+	}
+}
+
+// 키에서 기다리는 waiter를 먼저 온 순서대로 처리한다.
+// 처리하지 못한 waiter(예: 더 뒤의 ID를 기다리는 XREAD)는 건너뛰고 다음 waiter를 시도한다.
+func (s *Store) serveKey(key string) {
+	tried := make(map[*waiter]bool)
+	for {
+		s.blockedMu.Lock()
+		var w *waiter
+		for _, candidate := range s.blocked[key] {
+			if !tried[candidate] {
+				w = candidate
+				break
+			}
+		}
+		s.blockedMu.Unlock()
+		if w == nil {
+			return
+		}
+		tried[w] = true
+
+		// 다른 키로 먼저 처리됐을 수 있으므로 락을 잡은 뒤 다시 확인한다
+		locks := s.lock(w.locks...)
+		s.blockedMu.Lock()
+		registered := w.registered
+		s.blockedMu.Unlock()
+
+		entry, exist := s.data.Get(key)
+		if registered && exist && w.serve(key, entry) {
+			s.blockedMu.Lock()
+			s.removeWaiter(w)
+			s.blockedMu.Unlock()
+			close(w.ready)
+		}
+		locks.unlock()
+
+		// 키가 비었으면 남은 waiter도 처리할 수 없다
+		if !exist {
+			return
+		}
+	}
+}
+
+// waiter를 등록하고 serve가 성공하거나 ctx가 끝날 때까지 기다린다.
+// w.locks의 샤드 락(locks)을 잡은 상태로 호출하며, 반환할 때도 락을 잡은 상태다.
+// serve가 성공했으면 true, ctx가 끝났으면 false를 반환한다.
+func (s *Store) waitLocked(ctx context.Context, w *waiter, locks shardLocks) bool {
+	if w.locks == nil {
+		w.locks = w.keys
+	}
+	w.ready = make(chan struct{})
+	s.blockedMu.Lock()
+	s.addWaiter(w)
+	s.blockedMu.Unlock()
+	s.unlock(locks)
+
+	select {
+	case <-w.ready:
+		locks.lock()
+		return true
+	case <-ctx.Done():
+	}
+
+	locks.lock()
+	s.blockedMu.Lock()
+	defer s.blockedMu.Unlock()
+	// 락을 다시 잡는 사이에 serve가 끝났을 수 있다
+	select {
+	case <-w.ready:
+		return true
+	default:
+		s.removeWaiter(w)
+		return false
+	}
+}
+
+// 블로킹 명령어로 대기 중인 클라이언트 수
+func (s *Store) BlockedClients() int {
+	s.blockedMu.Lock()
+	defer s.blockedMu.Unlock()
+
+	seen := make(map[*waiter]struct{})
+	for _, queue := range s.blocked {
+		for _, w := range queue {
+			seen[w] = struct{}{}
+		}
+	}
+	return len(seen)
+}
@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// 대기 중인 클라이언트 수가 n이 될 때까지 기다린다.
+func waitForBlocked(t *testing.T, store *Store, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for store.BlockedClients() != n {
+		if time.Now().After(deadline) {
+			t.Fatalf("대기 중인 클라이언트 수: %d, expected: %d", store.BlockedClients(), n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestBlockingPop_Immediate(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("b", "x")
+
+	// when: 첫 번째 키는 비어있고 두 번째 키에 요소가 있다
+	key, value, ok, err := store.BlockingPop(context.Background(), []string{"a", "b"}, true)
+
+	// then
+	if err != nil || !ok {
+		t.Fatalf("ok: %v, err: %v", ok, err)
+	}
+	if key != "b" || value != "x" {
+		t.Fatalf("key: %s, value: %s", key, value)
+	}
+}
+
+func TestBlockingPop_WaitsForPush(t *testing.T) {
+	// given
+	store := New()
+	type result struct {
+		key, value string
+		ok         bool
+	}
+	results := make(chan result)
+
+	go func() {
+		key, value, ok, _ := store.BlockingPop(context.Background(), []string{"queue"}, true)
+		results <- result{key, value, ok}
+	}()
+	waitForBlocked(t, store, 1)
+
+	// when
+	length, _ := store.RPush("queue", "job")
+
+	// then: PUSH는 요소를 넣은 직후의 길이를 반환하고, 대기자가 요소를 가져간다
+	if length != 1 {
+		t.Fatalf("RPush 길이: %d, expected: 1", length)
+	}
+	r := <-results
+	if !r.ok || r.key != "queue" || r.value != "job" {
+		t.Fatalf("결과: %+v", r)
+	}
+	if values, _ := store.LRange("queue", 0, -1); len(values) != 0 {
+		t.Fatalf("리스트가 비어있지 않습니다: %v", values)
+	}
+}
+
+func TestBlockingPop_FIFO(t *testing.T) {
+	// given: 두 클라이언트가 순서대로 대기
+	store := New()
+	first := make(chan string)
+	second := make(chan string)
+
+	go func() {
+		_, value, _, _ := store.BlockingPop(context.Background(), []string{"queue"}, true)
+		first <- value
+	}()
+	waitForBlocked(t, store, 1)
+	go func() {
+		_, value, _, _ := store.BlockingPop(context.Background(), []string{"queue"}, true)
+		second <- value
+	}()
+	waitForBlocked(t, store, 2)
+
+	// when
+	store.RPush("queue", "a", "b")
+
+	// then: 먼저 기다린 클라이언트가 먼저 받는다
+	if v := <-first; v != "a" {
+		t.Fatalf("첫 번째 대기자: %s, expected: a", v)
+	}
+	if v := <-second; v != "b" {
+		t.Fatalf("두 번째 대기자: %s, expected: b", v)
+	}
+}
+
+func TestBlockingPop_Timeout(t *testing.T) {
+	// given
+	store := New()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// when
+	_, _, ok, err := store.BlockingPop(ctx, []string{"empty"}, true)
+
+	// then: 대기열에서도 제거된다
+	if ok || err != nil {
+		t.Fatalf("ok: %v, err: %v", ok, err)
+	}
+	if store.BlockedClients() != 0 {
+		t.Fatalf("대기 중인 클라이언트 수: %d, expected: 0", store.BlockedClients())
+	}
+}
+
+func TestBlockingPop_WrongType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("str", "value")
+
+	// when
+	_, _, _, err := store.BlockingPop(context.Background(), []string{"str"}, true)
+
+	// then
+	if err != ErrWrongType {
+		t.Fatalf("에러: %v, expected: ErrWrongType", err)
+	}
+}
+
+func TestBlockingMove_WaitsForPush(t *testing.T) {
+	// given
+	store := New()
+	results := make(chan string)
+
+	go func() {
+		value, _, _ := store.BlockingMove(context.Background(), "src", "dst", false, true)
+		results <- value
+	}()
+	waitForBlocked(t, store, 1)
+
+	// when
+	store.RPush("src", "a", "b")
+
+	// then: src 오른쪽 끝(b)이 dst 왼쪽으로 이동한다
+	if v := <-results; v != "b" {
+		t.Fatalf("이동한 값: %s, expected: b", v)
+	}
+	src, _ := store.LRange("src", 0, -1)
+	dst, _ := store.LRange("dst", 0, -1)
+	if len(src) != 1 || src[0] != "a" || len(dst) != 1 || dst[0] != "b" {
+		t.Fatalf("src: %v, dst: %v", src, dst)
+	}
+}
+
+func TestBlockingMove_ChainsToWaiterOnDestination(t *testing.T) {
+	// given: dst를 기다리는 BLPOP과 src를 기다리는 BLMOVE
+	store := New()
+	popped := make(chan string)
+	moved := make(chan string)
+
+	go func() {
+		_, value, _, _ := store.BlockingPop(context.Background(), []string{"dst"}, true)
+		popped <- value
+	}()
+	waitForBlocked(t, store, 1)
+	go func() {
+		value, _, _ := store.BlockingMove(context.Background(), "src", "dst", true, true)
+		moved <- value
+	}()
+	waitForBlocked(t, store, 2)
+
+	// when
+	store.RPush("src", "job")
+
+	// then: src → dst로 이동한 요소를 BLPOP 대기자가 받는다
+	if v := <-moved; v != "job" {
+		t.Fatalf("이동한 값: %s", v)
+	}
+	if v := <-popped; v != "job" {
+		t.Fatalf("꺼낸 값: %s", v)
+	}
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Clock은 저장소가 만료 시각을 계산하고 비교할 때 쓰는 현재 시각이다.
+// 테스트에서는 ManualClock을 넣어 실제로 기다리지 않고 시간을 옮길 수 있다.
+// LRU/LFU 접근 정보와 만료 루프의 시간 제한처럼 실제로 흐른 시간이 필요한 곳은 시스템 시각을 그대로 쓴다.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// 시스템 시각을 그대로 쓰는 시계 (기본값)
+var SystemClock Clock = systemClock{}
+
+// ManualClock은 Set이나 Advance로만 움직이는 시계다.
+// 제로 값은 시스템 시각을 따르다가, 처음 Set이나 Advance를 호출한 시각에 멈춘다 (DEBUG SET-CLOCK 등).
+// 모든 샤드가 만료를 확인할 때마다 읽으므로 락 없이 원자적으로 읽고 쓴다. 여러 고루틴에서 함께 써도 안전하다.
+type ManualClock struct {
+	// 멈춘 시각. nil이면 시스템 시각을 따른다
+	now atomic.Pointer[time.Time]
+}
+
+// start 시각에 멈춘 시계를 만든다.
+func NewManualClock(start time.Time) *ManualClock {
+	c := &ManualClock{}
+	c.now.Store(&start)
+	return c
+}
+
+func (c *ManualClock) Now() time.Time {
+	if now := c.now.Load(); now != nil {
+		return *now
+	}
+	return time.Now()
+}
+
+// 시계를 at으로 옮긴다.
+func (c *ManualClock) Set(at time.Time) {
+	c.now.Store(&at)
+}
+
+// 시계를 d만큼 옮기고 옮긴 시각을 반환한다. 음수면 과거로 돌린다.
+func (c *ManualClock) Advance(d time.Duration) time.Time {
+	for {
+		current := c.now.Load()
+		at := time.Now()
+		if current != nil {
+			at = *current
+		}
+		at = at.Add(d)
+		if c.now.CompareAndSwap(current, &at) {
+			return at
+		}
+	}
+}
+
+// 다시 시스템 시각을 따르게 한다.
+func (c *ManualClock) Reset() {
+	c.now.Store(nil)
+}
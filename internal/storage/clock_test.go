@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestManualClock(t *testing.T) {
+	// given
+	start := time.UnixMilli(1_700_000_000_000)
+	clock := NewManualClock(start)
+
+	// when & then: 옮기기 전에는 멈춰 있다
+	if now := clock.Now(); !now.Equal(start) {
+		t.Fatalf("Now: %v, expected: %v", now, start)
+	}
+	if at := clock.Advance(1500 * time.Millisecond); !at.Equal(start.Add(1500 * time.Millisecond)) {
+		t.Fatalf("Advance: %v", at)
+	}
+	clock.Set(start)
+	if now := clock.Now(); !now.Equal(start) {
+		t.Fatalf("Set 후 Now: %v, expected: %v", now, start)
+	}
+}
+
+func TestManualClockZeroValueFollowsSystem(t *testing.T) {
+	// given
+	var clock ManualClock
+
+	// when & then: 제로 값은 시스템 시각을 따르고, Reset하면 다시 따른다
+	if drift := time.Since(clock.Now()); drift < 0 || drift > time.Second {
+		t.Fatalf("시스템 시각과 차이: %v", drift)
+	}
+	clock.Advance(time.Hour)
+	if drift := time.Until(clock.Now()); drift < 59*time.Minute {
+		t.Fatalf("Advance 후 차이: %v", drift)
+	}
+	clock.Reset()
+	if drift := time.Since(clock.Now()); drift < 0 || drift > time.Second {
+		t.Fatalf("Reset 후 시스템 시각과 차이: %v", drift)
+	}
+}
+
+func TestStoreUsesInjectedClock(t *testing.T) {
+	// given
+	clock := NewManualClock(time.UnixMilli(1_700_000_000_000))
+	store := New(WithClock(clock))
+	store.Set("session", "abc")
+	store.Expire("session", 10)
+
+	// when & then: 만료 시각은 주입한 시계 기준이다
+	if at := store.PExpireTime("session"); at != 1_700_000_010_000 {
+		t.Fatalf("PExpireTime: %d, expected: 1700000010000", at)
+	}
+	clock.Advance(9 * time.Second)
+	if ttl := store.PTTL("session"); ttl != 1000 {
+		t.Fatalf("PTTL: %d, expected: 1000", ttl)
+	}
+	clock.Advance(time.Second + time.Millisecond)
+	if _, exist := store.Get("session"); exist {
+		t.Fatal("만료 시각이 지난 키가 조회되었습니다")
+	}
+}
+
+func TestSnapshotTTLWithClock(t *testing.T) {
+	// given: TTL이 남은 키를 저장한다
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
+	store.Set("short", "v")
+	store.Expire("short", 10)
+	store.Set("long", "v")
+	store.Expire("long", 100)
+	path := filepath.Join(t.TempDir(), "clock.rdb")
+	if err := store.Save(path); err != nil {
+		t.Fatalf("Save 에러: %v", err)
+	}
+
+	// when: 50초 뒤 시각의 저장소에 불러온다
+	loaded := New(WithClock(NewManualClock(clock.Now().Add(50 * time.Second))))
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load 에러: %v", err)
+	}
+
+	// then: 그 사이 만료된 키는 불러오지 않고, 남은 키의 TTL은 줄어 있다
+	if n := loaded.Exists("short", "long"); n != 1 {
+		t.Fatalf("Exists: %d, expected: 1", n)
+	}
+	if ttl := loaded.TTL("long"); ttl != 50 {
+		t.Fatalf("TTL: %d, expected: 50", ttl)
+	}
+}
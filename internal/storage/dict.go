@@ -0,0 +1,176 @@
+package storage
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"math/rand/v2"
+)
+
+// 테이블의 최소 버킷 수
+const dictMinBuckets = 4
+
+// Dict는 문자열 키를 쓰는 체이닝 해시 테이블이다.
+// Go map과 달리 버킷 구조가 드러나 있어서, 테이블 크기가 바뀌어도
+// 요소를 빠뜨리지 않는 커서 기반 순회(SCAN)와 무작위 조회를 할 수 있다.
+type Dict[V any] struct {
+	buckets []*dictEntry[V]
+	size    int
+	seed    maphash.Seed
+}
+
+type dictEntry[V any] struct {
+	key   string
+	value V
+	next  *dictEntry[V]
+}
+
+func NewDict[V any]() *Dict[V] {
+	return &Dict[V]{
+		buckets: make([]*dictEntry[V], dictMinBuckets),
+		seed:    maphash.MakeSeed(),
+	}
+}
+
+// 요소 개수
+func (d *Dict[V]) Len() int {
+	return d.size
+}
+
+func (d *Dict[V]) Get(key string) (V, bool) {
+	for e := d.buckets[d.bucketOf(key)]; e != nil; e = e.next {
+		if e.key == key {
+			return e.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// 값을 넣거나 바꾼다. 새로 추가된 키면 true
+func (d *Dict[V]) Set(key string, value V) bool {
+	index := d.bucketOf(key)
+	for e := d.buckets[index]; e != nil; e = e.next {
+		if e.key == key {
+			e.value = value
+			return false
+		}
+	}
+
+	d.buckets[index] = &dictEntry[V]{key: key, value: value, next: d.buckets[index]}
+	d.size++
+	if d.size > len(d.buckets) {
+		d.resize(len(d.buckets) * 2)
+	}
+	return true
+}
+
+// 키를 삭제한다. 삭제했으면 true
+func (d *Dict[V]) Delete(key string) bool {
+	index := d.bucketOf(key)
+	for prev, e := (*dictEntry[V])(nil), d.buckets[index]; e != nil; prev, e = e, e.next {
+		if e.key != key {
+			continue
+		}
+		if prev == nil {
+			d.buckets[index] = e.next
+		} else {
+			prev.next = e.next
+		}
+		d.size--
+		if len(d.buckets) > dictMinBuckets && d.size < len(d.buckets)/8 {
+			d.resize(len(d.buckets) / 2)
+		}
+		return true
+	}
+	return false
+}
+
+// 모든 요소를 fn에 넘긴다. fn이 false를 반환하면 멈춘다.
+// 순회 중에 Dict를 변경하면 안 된다.
+func (d *Dict[V]) Range(fn func(key string, value V) bool) {
+	for _, e := range d.buckets {
+		for ; e != nil; e = e.next {
+			if !fn(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// cursor가 가리키는 버킷 하나의 요소들을 fn에 넘기고 다음 커서를 반환한다.
+// 0을 반환하면 순회가 끝난 것이다.
+//
+// 커서는 버킷 번호의 비트를 뒤집은 순서(reverse binary)로 증가한다.
+// 그래서 호출 사이에 테이블이 커지거나 작아져도, 순회 내내 존재한 요소는 최소 한 번 반환된다
+// (Redis dictScan과 같은 방식). 대신 같은 요소가 두 번 반환될 수는 있다.
+func (d *Dict[V]) Scan(cursor uint64, fn func(key string, value V)) uint64 {
+	mask := uint64(len(d.buckets) - 1)
+	for e := d.buckets[cursor&mask]; e != nil; e = e.next {
+		fn(e.key, e.value)
+	}
+
+	cursor |= ^mask
+	cursor = bits.Reverse64(cursor)
+	cursor++
+	return bits.Reverse64(cursor)
+}
+
+// 무작위 요소 하나를 반환한다. 비어있으면 ok=false
+// 무작위 버킷을 고른 뒤 체인 안에서 다시 고르므로 완전히 균등하지는 않다.
+func (d *Dict[V]) Random() (key string, value V, ok bool) {
+	if d.size == 0 {
+		return "", value, false
+	}
+
+	var e *dictEntry[V]
+	for e == nil {
+		e = d.buckets[rand.IntN(len(d.buckets))]
+	}
+
+	length := 0
+	for chain := e; chain != nil; chain = chain.next {
+		length++
+	}
+	for i := rand.IntN(length); i > 0; i-- {
+		e = e.next
+	}
+	return e.key, e.value, true
+}
+
+// ========== 헬퍼 메서드 ==========
+
+func (d *Dict[V]) bucketOf(key string) uint64 {
+	return maphash.String(d.seed, key) & uint64(len(d.buckets)-1)
+}
+
+// 버킷 수를 바꾸고 모든 요소를 다시 배치한다. size는 2의 거듭제곱이어야 한다.
+func (d *Dict[V]) resize(size int) {
+	old := d.buckets
+	d.buckets = make([]*dictEntry[V], size)
+	for _, e := range old {
+		for e != nil {
+			next := e.next
+			index := d.bucketOf(e.key)
+			e.next = d.buckets[index]
+			d.buckets[index] = e
+			e = next
+		}
+	}
+}
+
+// cursor부터 버킷을 차례로 훑어 count개 이상의 요소를 모으거나 순회가 끝날 때까지 fn에 넘긴다.
+// 다음 커서를 반환한다 (HSCAN/SSCAN/ZSCAN/SCAN의 COUNT 동작).
+func scanDict[V any](d *Dict[V], cursor uint64, count int, fn func(key string, value V)) uint64 {
+	visited := 0
+	// 빈 버킷이 이어져도 너무 오래 붙잡혀 있지 않도록 훑을 버킷 수에 상한을 둔다
+	for steps := count * 10; steps > 0; steps-- {
+		cursor = d.Scan(cursor, func(key string, value V) {
+			visited++
+			fn(key, value)
+		})
+		if cursor == 0 || visited >= count {
+			break
+		}
+	}
+	return cursor
+}
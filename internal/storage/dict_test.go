@@ -0,0 +1,132 @@
+package storage
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDict_SetGetDelete(t *testing.T) {
+	// given
+	dict := NewDict[int]()
+
+	// when
+	added := dict.Set("a", 1)
+	replaced := dict.Set("a", 2)
+
+	// then
+	if !added || replaced {
+		t.Fatalf("Set 반환값 added: %v, replaced: %v", added, replaced)
+	}
+	if value, ok := dict.Get("a"); !ok || value != 2 {
+		t.Fatalf("Get: %d, %v", value, ok)
+	}
+	if !dict.Delete("a") || dict.Delete("a") {
+		t.Fatal("Delete 반환값이 다릅니다")
+	}
+	if dict.Len() != 0 {
+		t.Fatalf("Len: %d, expected: 0", dict.Len())
+	}
+}
+
+func TestDict_GrowAndShrink(t *testing.T) {
+	// given
+	dict := NewDict[int]()
+
+	// when: 많이 넣었다가 대부분 지운다
+	for i := 0; i < 1000; i++ {
+		dict.Set(strconv.Itoa(i), i)
+	}
+	grown := len(dict.buckets)
+	for i := 0; i < 990; i++ {
+		dict.Delete(strconv.Itoa(i))
+	}
+
+	// then
+	if grown < 1000 {
+		t.Fatalf("버킷 수: %d, 1000개를 넣으면 늘어나야 합니다", grown)
+	}
+	if len(dict.buckets) >= grown {
+		t.Fatalf("버킷 수: %d, 지운 뒤에는 줄어들어야 합니다", len(dict.buckets))
+	}
+	for i := 990; i < 1000; i++ {
+		if value, ok := dict.Get(strconv.Itoa(i)); !ok || value != i {
+			t.Fatalf("Get(%d): %d, %v", i, value, ok)
+		}
+	}
+}
+
+func TestDict_ScanVisitsAll(t *testing.T) {
+	// given
+	dict := NewDict[int]()
+	for i := 0; i < 100; i++ {
+		dict.Set(strconv.Itoa(i), i)
+	}
+
+	// when
+	seen := make(map[string]bool)
+	cursor := uint64(0)
+	for {
+		cursor = dict.Scan(cursor, func(key string, value int) {
+			seen[key] = true
+		})
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// then
+	if len(seen) != 100 {
+		t.Fatalf("순회한 키 수: %d, expected: 100", len(seen))
+	}
+}
+
+func TestDict_ScanDuringResize(t *testing.T) {
+	// given
+	dict := NewDict[int]()
+	for i := 0; i < 100; i++ {
+		dict.Set(strconv.Itoa(i), i)
+	}
+
+	// when: 순회 도중에 테이블이 커지도록 키를 더 넣는다
+	seen := make(map[string]bool)
+	cursor, steps := uint64(0), 0
+	for {
+		cursor = dict.Scan(cursor, func(key string, value int) {
+			seen[key] = true
+		})
+		steps++
+		if steps == 10 {
+			for i := 100; i < 1000; i++ {
+				dict.Set(strconv.Itoa(i), i)
+			}
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// then: 처음부터 있던 키는 빠짐없이 반환된다
+	for i := 0; i < 100; i++ {
+		if !seen[strconv.Itoa(i)] {
+			t.Fatalf("키 %d를 순회하지 못했습니다", i)
+		}
+	}
+}
+
+func TestDict_Random(t *testing.T) {
+	// given
+	dict := NewDict[int]()
+	if _, _, ok := dict.Random(); ok {
+		t.Fatal("빈 Dict에서 Random 성공")
+	}
+	dict.Set("a", 1)
+	dict.Set("b", 2)
+
+	// when & then
+	for i := 0; i < 20; i++ {
+		key, value, ok := dict.Random()
+		if !ok || (key == "a" && value != 1) || (key == "b" && value != 2) {
+			t.Fatalf("Random: %s, %d, %v", key, value, ok)
+		}
+	}
+}
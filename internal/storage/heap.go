@@ -5,50 +5,129 @@ import "time"
 type HeapItem struct {
 	Key      string
 	ExpireAt time.Time
+
+	// 힙 배열 안의 위치. 삭제와 갱신 때 항목을 바로 찾는 데 쓴다
+	index int
 }
 
+// MinHeap은 키마다 항목을 하나만 두는 만료 시각 최소 힙이다.
+// 키로 항목을 찾을 수 있어서 만료 시간이 바뀌거나 키가 지워지면 항목을 고치거나 빼고,
+// 그래서 힙의 크기는 만료 시간이 있는 키 수를 넘지 않는다.
 type MinHeap struct {
 	items []*HeapItem
+	index map[string]*HeapItem
 }
 
 func NewMinHeap() *MinHeap {
-	return &MinHeap{}
+	return &MinHeap{index: make(map[string]*HeapItem)}
 }
 
-// 새 항목을 추가하고 Bubble Up으로 힙 속성을 복원한다. O(log n)
+// 항목을 추가한다. 같은 키의 항목이 이미 있으면 만료 시각만 바꾼다. O(log n)
 func (h *MinHeap) Push(item *HeapItem) {
-	h.items = append(h.items, item)
+	if old, exist := h.index[item.Key]; exist {
+		h.update(old, item.ExpireAt)
+		return
+	}
 
-	curIdx := len(h.items) - 1
-	parentIdx := (curIdx - 1) / 2
+	item.index = len(h.items)
+	h.items = append(h.items, item)
+	h.index[item.Key] = item
+	h.up(item.index)
+}
 
-	for curIdx != 0 && h.items[curIdx].ExpireAt.Before(h.items[parentIdx].ExpireAt) {
-		h.items[parentIdx], h.items[curIdx] = h.items[curIdx], h.items[parentIdx]
-		curIdx = parentIdx
-		parentIdx = (curIdx - 1) / 2
+// 키의 만료 시각을 at으로 맞춘다. 항목이 없을 때만 새로 할당한다. O(log n)
+func (h *MinHeap) Set(key string, at time.Time) {
+	if old, exist := h.index[key]; exist {
+		h.update(old, at)
+		return
+	}
+	h.Push(&HeapItem{Key: key, ExpireAt: at})
+}
 
+// 키의 항목을 뺀다. 항목이 있었으면 true. O(log n)
+func (h *MinHeap) Remove(key string) bool {
+	item, exist := h.index[key]
+	if !exist {
+		return false
 	}
+	h.removeAt(item.index)
+	return true
 }
 
-// 최솟값(가장 먼저 만료되는 항목)을 제거하고 반환한다.
-// Bubble Down으로 힙 속성을 복원한다. O(log n)
+// 최솟값(가장 먼저 만료되는 항목)을 제거하고 반환한다. O(log n)
 // 힙이 비어있으면 nil을 반환한다.
 func (h *MinHeap) Pop() *HeapItem {
 	if len(h.items) == 0 {
 		return nil
 	}
+	return h.removeAt(0)
+}
 
-	min := h.items[0]
-	last := len(h.items) - 1
+// 최솟값을 제거하지 않고 조회한다. O(1)
+// 힙이 비어있으면 nil을 반환한다.
+func (h *MinHeap) Peek() *HeapItem {
+	if len(h.items) == 0 {
+		return nil
+	}
+	return h.items[0]
+}
+
+// 힙의 크기를 반환한다.
+func (h *MinHeap) Len() int {
+	return len(h.items)
+}
+
+// ========== 헬퍼 메서드 ==========
+
+func (h *MinHeap) update(item *HeapItem, at time.Time) {
+	if item.ExpireAt.Equal(at) {
+		return
+	}
+	earlier := at.Before(item.ExpireAt)
+	item.ExpireAt = at
+	if earlier {
+		h.up(item.index)
+	} else {
+		h.down(item.index)
+	}
+}
 
-	h.items[0] = h.items[last]
+// i번째 항목을 마지막 항목과 바꿔 빼낸 뒤 힙 속성을 복원한다.
+func (h *MinHeap) removeAt(i int) *HeapItem {
+	item := h.items[i]
+	last := len(h.items) - 1
+	if i != last {
+		h.swap(i, last)
+	}
+	h.items[last] = nil
 	h.items = h.items[:last]
+	delete(h.index, item.Key)
 
-	curIdx := 0
+	if i != last {
+		h.down(i)
+		h.up(i)
+	}
+	return item
+}
+
+// Bubble Up
+func (h *MinHeap) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !h.items[i].ExpireAt.Before(h.items[parent].ExpireAt) {
+			break
+		}
+		h.swap(i, parent)
+		i = parent
+	}
+}
+
+// Bubble Down
+func (h *MinHeap) down(i int) {
 	for {
-		left := 2*curIdx + 1
-		right := 2*curIdx + 2
-		smallest := curIdx
+		left := 2*i + 1
+		right := 2*i + 2
+		smallest := i
 
 		if left < len(h.items) && h.items[left].ExpireAt.Before(h.items[smallest].ExpireAt) {
 			smallest = left
@@ -56,28 +135,17 @@ func (h *MinHeap) Pop() *HeapItem {
 		if right < len(h.items) && h.items[right].ExpireAt.Before(h.items[smallest].ExpireAt) {
 			smallest = right
 		}
-
-		if smallest == curIdx {
-			break
+		if smallest == i {
+			return
 		}
 
-		h.items[curIdx], h.items[smallest] = h.items[smallest], h.items[curIdx]
-		curIdx = smallest
+		h.swap(i, smallest)
+		i = smallest
 	}
-
-	return min
 }
 
-// 최솟값을 제거하지 않고 조회한다. O(1)
-// 힙이 비어있으면 nil을 반환한다.
-func (h *MinHeap) Peek() *HeapItem {
-	if len(h.items) == 0 {
-		return nil
-	}
-	return h.items[0]
-}
-
-// 힙의 크기를 반환한다.
-func (h *MinHeap) Len() int {
-	return len(h.items)
+func (h *MinHeap) swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
 }
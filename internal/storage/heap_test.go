@@ -169,3 +169,50 @@ func TestHeapSingleElement(t *testing.T) {
 		t.Fatalf("Pop 후 크기: %d, expected: 0", heap.Len())
 	}
 }
+
+func TestHeapPushSameKeyUpdates(t *testing.T) {
+	// given
+	heap := NewMinHeap()
+	now := time.Now()
+	heap.Push(&HeapItem{Key: "a", ExpireAt: now.Add(10 * time.Second)})
+	heap.Push(&HeapItem{Key: "b", ExpireAt: now.Add(20 * time.Second)})
+
+	// when: 같은 키를 다시 넣으면 항목을 늘리지 않고 만료 시각만 바꾼다
+	heap.Push(&HeapItem{Key: "b", ExpireAt: now.Add(5 * time.Second)})
+	heap.Set("a", now.Add(30*time.Second))
+
+	// then
+	if heap.Len() != 2 {
+		t.Fatalf("크기: %d, expected: 2", heap.Len())
+	}
+	for _, expected := range []string{"b", "a"} {
+		if item := heap.Pop(); item.Key != expected {
+			t.Fatalf("Pop 순서가 다릅니다. actual: %s, expected: %s", item.Key, expected)
+		}
+	}
+}
+
+func TestHeapRemove(t *testing.T) {
+	// given
+	heap := NewMinHeap()
+	now := time.Now()
+	for i, key := range []string{"a", "b", "c", "d", "e", "f"} {
+		heap.Push(&HeapItem{Key: key, ExpireAt: now.Add(time.Duration(i+1) * time.Second)})
+	}
+
+	// when: 루트, 중간, 마지막 항목과 없는 키를 뺀다
+	removed := []bool{heap.Remove("a"), heap.Remove("c"), heap.Remove("f"), heap.Remove("none")}
+
+	// then
+	if removed[0] != true || removed[1] != true || removed[2] != true || removed[3] != false {
+		t.Fatalf("Remove 결과: %v", removed)
+	}
+	for _, expected := range []string{"b", "d", "e"} {
+		if item := heap.Pop(); item == nil || item.Key != expected {
+			t.Fatalf("Pop 결과: %v, expected: %s", item, expected)
+		}
+	}
+	if heap.Len() != 0 {
+		t.Fatalf("크기: %d, expected: 0", heap.Len())
+	}
+}
@@ -1,90 +1,128 @@
 package storage
 
-type Node struct {
-	Value string
-	Prev  *Node
-	Next  *Node
-}
+import (
+	"encoding/binary"
+	"slices"
+)
+
+// 노드 하나(packed block)에 담을 수 있는 최대 바이트 수.
+// Redis의 list-max-listpack-size -2 와 같은 8KB. 작은 리스트는 노드 하나에 모두 들어간다.
+const listNodeMaxBytes = 8 * 1024
 
+// 리스트는 quicklist로 저장한다.
+// 요소들을 연속된 바이트 블록(listNode)에 모아 담고, 블록들을 양방향 연결 리스트로 잇는다.
+// 요소마다 노드와 포인터를 두는 것보다 메모리를 훨씬 적게 쓰고, GC가 훑을 포인터도 거의 없다.
 type List struct {
-	Head   *Node
-	Tail   *Node
+	head   *listNode
+	tail   *listNode
 	Length int
+
+	// 모든 노드 블록의 바이트 수와 노드 수. 메모리 추정을 노드를 훑지 않고 O(1)에 하려고 함께 관리한다
+	bytes int
+	nodes int
+}
+
+// listNode는 여러 요소를 하나의 바이트 블록에 담는다.
+//
+// 요소 하나의 인코딩: [uvarint 데이터 길이][데이터][backlen]
+// backlen은 (길이 헤더 + 데이터)의 바이트 수를 뒤에서부터 읽을 수 있게 거꾸로 쓴 uvarint로,
+// 블록의 끝에서 앞으로 탐색할 때 쓴다 (RPOP, 뒤에서부터의 LPOS 등).
+type listNode struct {
+	entries []byte
+	count   int
+	prev    *listNode
+	next    *listNode
 }
 
 func NewList() *List {
 	return &List{}
 }
 
-// 앞쪽 삽입 - O(1)
+// 앞쪽 삽입 - O(노드 크기)
 func (l *List) LPush(value string) {
-	newNode := &Node{Value: value}
+	entry := encodeListEntry(value)
 
-	if l.Head != nil {
-		newNode.Next = l.Head
-		l.Head.Prev = newNode
-	} else {
-		l.Tail = newNode
+	if l.head == nil || !l.head.fits(len(entry)) {
+		node := &listNode{next: l.head}
+		if l.head != nil {
+			l.head.prev = node
+		} else {
+			l.tail = node
+		}
+		l.head = node
+		l.nodes++
 	}
 
-	l.Head = newNode
+	l.head.entries = append(entry, l.head.entries...)
+	l.head.count++
 	l.Length++
+	l.bytes += len(entry)
 }
 
 // 뒤쪽 삽입 — O(1)
 func (l *List) RPush(value string) {
-	newNode := &Node{Value: value}
+	entry := encodeListEntry(value)
 
-	if l.Tail != nil {
-		newNode.Prev = l.Tail
-		l.Tail.Next = newNode
-	} else {
-		l.Head = newNode
+	if l.tail == nil || !l.tail.fits(len(entry)) {
+		node := &listNode{prev: l.tail}
+		if l.tail != nil {
+			l.tail.next = node
+		} else {
+			l.head = node
+		}
+		l.tail = node
+		l.nodes++
 	}
 
-	l.Tail = newNode
+	l.tail.entries = append(l.tail.entries, entry...)
+	l.tail.count++
 	l.Length++
+	l.bytes += len(entry)
 }
 
 // 앞쪽 삭제 — O(1). 빈 리스트면 ("", false) 반환
 func (l *List) LPop() (string, bool) {
-	if l.Head == nil {
+	if l.head == nil {
 		return "", false
 	}
 
-	value := l.Head.Value
-	l.Head = l.Head.Next
+	node := l.head
+	value, next := readListEntry(node.entries, 0)
+	result := string(value)
 
-	if l.Head != nil {
-		l.Head.Prev = nil
-	} else {
-		l.Tail = nil
+	l.setEntries(node, node.entries[next:])
+	node.count--
+	if node.count == 0 {
+		l.unlink(node)
 	}
 
 	l.Length--
-	return value, true
+	return result, true
 }
 
 // 뒤쪽 삭제 — O(1). 빈 리스트면 ("", false) 반환
 func (l *List) RPop() (string, bool) {
-	if l.Tail == nil {
+	if l.tail == nil {
 		return "", false
 	}
 
-	value := l.Tail.Value
-	l.Tail = l.Tail.Prev
+	node := l.tail
+	offset := prevListEntry(node.entries, len(node.entries))
+	value, _ := readListEntry(node.entries, offset)
+	result := string(value)
 
-	if l.Tail != nil {
-		l.Tail.Next = nil
-	} else {
-		l.Head = nil
+	l.setEntries(node, node.entries[:offset])
+	node.count--
+	if node.count == 0 {
+		l.unlink(node)
 	}
 
 	l.Length--
-	return value, true
+	return result, true
 }
 
 // 범위 조회. 음수 인덱스 지원 (-1 = 마지막, -2 = 마지막에서 두 번째)
+// 시작 위치까지는 노드 단위로 건너뛰고, 그 뒤로는 블록을 차례로 읽는다.
 func (l *List) Range(start, stop int) []string {
 	if start < 0 {
 		start = l.Length + start
@@ -104,16 +142,471 @@ func (l *List) Range(start, stop int) []string {
 		return make([]string, 0)
 	}
 
-	current := l.Head
-	for i := 0; i < start; i++ {
-		current = current.Next
-	}
+	node, i := l.locate(start)
+	offset := node.offsetAt(i)
 
 	result := make([]string, 0, stop-start+1)
-	for i := start; i <= stop; i++ {
-		result = append(result, current.Value)
-		current = current.Next
+	for len(result) < stop-start+1 {
+		if offset >= len(node.entries) {
+			node, offset = node.next, 0
+			continue
+		}
+		value, next := readListEntry(node.entries, offset)
+		result = append(result, string(value))
+		offset = next
+	}
+
+	return result
+}
+
+// index 위치의 값을 조회한다. 음수 인덱스 지원. 범위를 벗어나면 ("", false)
+func (l *List) Index(index int) (string, bool) {
+	node, i := l.locate(index)
+	if node == nil {
+		return "", false
+	}
+	value, _ := readListEntry(node.entries, node.offsetAt(i))
+	return string(value), true
+}
+
+// index 위치의 값을 바꾼다. 범위를 벗어나면 false
+func (l *List) Set(index int, value string) bool {
+	node, i := l.locate(index)
+	if node == nil {
+		return false
+	}
+
+	offset := node.offsetAt(i)
+	_, next := readListEntry(node.entries, offset)
+	l.splice(node, offset, next, encodeListEntry(value))
+	l.split(node)
+	return true
+}
+
+// pivot 값을 가진 첫 번째 요소의 앞(before=true) 또는 뒤에 value를 넣는다.
+// 넣은 뒤의 길이를 반환하고, pivot을 찾지 못하면 -1을 반환한다.
+func (l *List) Insert(pivot, value string, before bool) int {
+	for node := l.head; node != nil; node = node.next {
+		for offset := 0; offset < len(node.entries); {
+			current, next := readListEntry(node.entries, offset)
+			if string(current) != pivot {
+				offset = next
+				continue
+			}
+
+			at := next
+			if before {
+				at = offset
+			}
+			l.splice(node, at, at, encodeListEntry(value))
+			node.count++
+			l.Length++
+			l.split(node)
+			return l.Length
+		}
+	}
+	return -1
+}
+
+// value와 같은 요소를 삭제하고 삭제한 개수를 반환한다.
+// count > 0: 앞에서부터 최대 count개, count < 0: 뒤에서부터 최대 |count|개, count == 0: 전부
+func (l *List) Remove(count int, value string) int {
+	removed := 0
+	fromTail := count < 0
+	if fromTail {
+		count = -count
+	}
+
+	node := l.head
+	if fromTail {
+		node = l.tail
+	}
+
+	for node != nil && (count == 0 || removed < count) {
+		next := node.next
+		if fromTail {
+			next = node.prev
+		}
+
+		limit := 0
+		if count != 0 {
+			limit = count - removed
+		}
+		before := len(node.entries)
+		n := node.removeMatches(value, fromTail, limit)
+		l.bytes += len(node.entries) - before
+		removed += n
+		l.Length -= n
+
+		if node.count == 0 {
+			l.unlink(node)
+		}
+		node = next
+	}
+
+	if removed > 0 {
+		l.compact()
+	}
+	return removed
+}
+
+// start ~ stop 범위(양 끝 포함)만 남기고 나머지를 삭제한다. 음수 인덱스 지원.
+// 범위가 비어있으면 모든 요소를 삭제한다.
+func (l *List) Trim(start, stop int) {
+	if start < 0 {
+		start = l.Length + start
+	}
+	if stop < 0 {
+		stop = l.Length + stop
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop > l.Length-1 {
+		stop = l.Length - 1
+	}
+
+	if start > stop {
+		l.head, l.tail, l.Length = nil, nil, 0
+		l.bytes, l.nodes = 0, 0
+		return
+	}
+
+	removeTail := l.Length - 1 - stop
+	l.dropFront(start)
+	l.dropBack(removeTail)
+}
+
+// value와 같은 요소의 인덱스를 찾는다 (LPOS).
+//   - rank: 몇 번째 일치부터 반환할지. 음수면 뒤에서부터 찾는다. 0이면 안 된다.
+//   - count: 최대 반환 개수. 0이면 전부
+//   - maxlen: 최대 비교 횟수. 0이면 제한 없음
+//
+// 인덱스는 뒤에서부터 찾더라도 앞에서부터 센 값이다.
+func (l *List) Pos(value string, rank, count, maxlen int) []int {
+	result := []int{}
+	fromTail := rank < 0
+	if fromTail {
+		rank = -rank
 	}
 
+	index, step := 0, 1
+	if fromTail {
+		index, step = l.Length-1, -1
+	}
+
+	matched, compared := 0, 0
+	l.each(fromTail, func(current []byte) bool {
+		if maxlen != 0 && compared >= maxlen {
+			return false
+		}
+		compared++
+
+		if string(current) == value {
+			matched++
+			if matched >= rank {
+				result = append(result, index)
+				if count != 0 && len(result) >= count {
+					return false
+				}
+			}
+		}
+		index += step
+		return true
+	})
 	return result
 }
+
+// ========== 헬퍼 메서드 ==========
+
+// index 위치의 요소가 들어있는 노드와, 그 노드 안에서의 순번을 반환한다.
+// 음수 인덱스 지원. 범위를 벗어나면 (nil, 0)
+// head와 tail 중 가까운 쪽에서부터 노드 단위로 건너뛴다.
+func (l *List) locate(index int) (*listNode, int) {
+	if index < 0 {
+		index = l.Length + index
+	}
+	if index < 0 || index >= l.Length {
+		return nil, 0
+	}
+
+	if index < l.Length/2 {
+		node := l.head
+		for index >= node.count {
+			index -= node.count
+			node = node.next
+		}
+		return node, index
+	}
+
+	node, last := l.tail, l.Length-1
+	for last-index >= node.count {
+		last -= node.count
+		node = node.prev
+	}
+	return node, node.count - 1 - (last - index)
+}
+
+// 요소들을 앞에서부터(fromTail이면 뒤에서부터) 차례로 fn에 넘긴다.
+// fn이 false를 반환하면 멈춘다. 넘겨받은 슬라이스는 fn 안에서만 유효하다.
+func (l *List) each(fromTail bool, fn func(value []byte) bool) {
+	if !fromTail {
+		for node := l.head; node != nil; node = node.next {
+			for offset := 0; offset < len(node.entries); {
+				value, next := readListEntry(node.entries, offset)
+				if !fn(value) {
+					return
+				}
+				offset = next
+			}
+		}
+		return
+	}
+
+	for node := l.tail; node != nil; node = node.prev {
+		for offset := len(node.entries); offset > 0; {
+			offset = prevListEntry(node.entries, offset)
+			value, _ := readListEntry(node.entries, offset)
+			if !fn(value) {
+				return
+			}
+		}
+	}
+}
+
+// 노드를 리스트에서 떼어낸다. O(1)
+func (l *List) unlink(node *listNode) {
+	l.bytes -= len(node.entries)
+	l.nodes--
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		l.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		l.tail = node.prev
+	}
+	node.prev, node.next = nil, nil
+}
+
+// 노드가 최대 크기를 넘으면 반으로 나눈다. 나눈 결과도 크면 다시 나눈다.
+// 요소가 하나뿐인 노드는 크기와 상관없이 나누지 않는다.
+func (l *List) split(node *listNode) {
+	if len(node.entries) <= listNodeMaxBytes || node.count < 2 {
+		return
+	}
+
+	mid := node.count / 2
+	offset := node.offsetAt(mid)
+
+	right := &listNode{
+		entries: slices.Clone(node.entries[offset:]),
+		count:   node.count - mid,
+		prev:    node,
+		next:    node.next,
+	}
+	if node.next != nil {
+		node.next.prev = right
+	} else {
+		l.tail = right
+	}
+	node.next = right
+	l.nodes++
+
+	node.entries = slices.Clip(node.entries[:offset])
+	node.count = mid
+
+	l.split(node)
+	l.split(right)
+}
+
+// 이웃한 노드를 합쳐도 최대 크기를 넘지 않으면 합친다.
+// 삭제로 작아진 노드들이 쌓여 노드 수가 불어나는 것을 막는다.
+func (l *List) compact() {
+	for node := l.head; node != nil && node.next != nil; {
+		next := node.next
+		if len(node.entries)+len(next.entries) > listNodeMaxBytes {
+			node = next
+			continue
+		}
+		l.setEntries(node, append(node.entries, next.entries...))
+		node.count += next.count
+		l.unlink(next)
+	}
+}
+
+// 앞에서부터 n개의 요소를 삭제한다. 통째로 지워지는 노드는 한 번에 떼어낸다.
+func (l *List) dropFront(n int) {
+	for n > 0 && l.head != nil {
+		node := l.head
+		if node.count <= n {
+			n -= node.count
+			l.Length -= node.count
+			l.unlink(node)
+			continue
+		}
+
+		l.setEntries(node, slices.Clone(node.entries[node.offsetAt(n):]))
+		node.count -= n
+		l.Length -= n
+		n = 0
+	}
+}
+
+// 뒤에서부터 n개의 요소를 삭제한다. 통째로 지워지는 노드는 한 번에 떼어낸다.
+func (l *List) dropBack(n int) {
+	for n > 0 && l.tail != nil {
+		node := l.tail
+		if node.count <= n {
+			n -= node.count
+			l.Length -= node.count
+			l.unlink(node)
+			continue
+		}
+
+		l.setEntries(node, slices.Clip(node.entries[:node.offsetAt(node.count-n)]))
+		node.count -= n
+		l.Length -= n
+		n = 0
+	}
+}
+
+// 노드의 블록을 바꾸고 바이트 수를 맞춘다.
+func (l *List) setEntries(node *listNode, entries []byte) {
+	l.bytes += len(entries) - len(node.entries)
+	node.entries = entries
+}
+
+// 노드의 entries[start:end]를 data로 바꾸고 바이트 수를 맞춘다.
+func (l *List) splice(node *listNode, start, end int, data []byte) {
+	l.bytes += len(data) - (end - start)
+	node.splice(start, end, data)
+}
+
+// ========== listNode ==========
+
+// size 바이트짜리 요소를 더 넣어도 최대 크기를 넘지 않으면 true.
+// 빈 노드에는 크기와 상관없이 넣을 수 있다.
+func (n *listNode) fits(size int) bool {
+	return n.count == 0 || len(n.entries)+size <= listNodeMaxBytes
+}
+
+// 노드 안에서 i번째 요소가 시작하는 바이트 위치. i == count면 블록의 끝.
+// 앞과 뒤 중 가까운 쪽에서부터 탐색한다.
+func (n *listNode) offsetAt(i int) int {
+	if i < n.count/2 {
+		offset := 0
+		for ; i > 0; i-- {
+			_, offset = readListEntry(n.entries, offset)
+		}
+		return offset
+	}
+
+	offset := len(n.entries)
+	for j := n.count; j > i; j-- {
+		offset = prevListEntry(n.entries, offset)
+	}
+	return offset
+}
+
+// entries[start:end]를 data로 바꾼다.
+func (n *listNode) splice(start, end int, data []byte) {
+	entries := make([]byte, 0, len(n.entries)-(end-start)+len(data))
+	entries = append(entries, n.entries[:start]...)
+	entries = append(entries, data...)
+	entries = append(entries, n.entries[end:]...)
+	n.entries = entries
+}
+
+// value와 같은 요소를 앞에서부터(fromTail이면 뒤에서부터) 최대 limit개(0이면 전부) 삭제한다.
+// 삭제한 개수를 반환한다.
+func (n *listNode) removeMatches(value string, fromTail bool, limit int) int {
+	offsets := make([]int, 0, n.count+1)
+	for offset := 0; offset < len(n.entries); {
+		offsets = append(offsets, offset)
+		_, offset = readListEntry(n.entries, offset)
+	}
+	offsets = append(offsets, len(n.entries))
+
+	remove := make([]bool, n.count)
+	removed := 0
+	for k := 0; k < n.count && (limit == 0 || removed < limit); k++ {
+		i := k
+		if fromTail {
+			i = n.count - 1 - k
+		}
+		current, _ := readListEntry(n.entries, offsets[i])
+		if string(current) == value {
+			remove[i] = true
+			removed++
+		}
+	}
+	if removed == 0 {
+		return 0
+	}
+
+	entries := make([]byte, 0, len(n.entries))
+	for i := 0; i < n.count; i++ {
+		if !remove[i] {
+			entries = append(entries, n.entries[offsets[i]:offsets[i+1]]...)
+		}
+	}
+	n.entries = entries
+	n.count -= removed
+	return removed
+}
+
+// ========== 요소 인코딩 ==========
+
+// 요소 하나를 [uvarint 길이][데이터][backlen] 형태로 인코딩한다.
+func encodeListEntry(value string) []byte {
+	size := uvarintLen(len(value)) + len(value)
+	entry := make([]byte, 0, size+uvarintLen(size))
+	entry = binary.AppendUvarint(entry, uint64(len(value)))
+	entry = append(entry, value...)
+
+	// backlen: uvarint를 거꾸로 써서 블록 끝에서부터 읽을 수 있게 한다
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], uint64(size))
+	for i := n - 1; i >= 0; i-- {
+		entry = append(entry, buf[i])
+	}
+	return entry
+}
+
+// offset에서 시작하는 요소의 데이터와 다음 요소의 시작 위치를 반환한다.
+// 데이터는 블록을 가리키는 슬라이스이므로 보관하려면 복사해야 한다.
+func readListEntry(entries []byte, offset int) ([]byte, int) {
+	length, n := binary.Uvarint(entries[offset:])
+	start := offset + n
+	end := start + int(length)
+	return entries[start:end], end + uvarintLen(end-offset)
+}
+
+// end 바로 앞에서 끝나는 요소의 시작 위치를 backlen으로 찾는다.
+func prevListEntry(entries []byte, end int) int {
+	size, shift := 0, 0
+	i := end - 1
+	for {
+		b := entries[i]
+		size |= int(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+		i--
+	}
+	return i - size
+}
+
+// uvarint로 인코딩했을 때의 바이트 수
+func uvarintLen(v int) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
@@ -1,6 +1,10 @@
 package storage
 
-import "testing"
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
 
 func TestLPush_SingleElement(t *testing.T) {
 	// given
@@ -13,11 +17,11 @@ func TestLPush_SingleElement(t *testing.T) {
 	if list.Length != 1 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 1", list.Length)
 	}
-	if list.Head.Value != "a" {
-		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: a", list.Head.Value)
+	if head, _ := list.Index(0); head != "a" {
+		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: a", head)
 	}
-	if list.Tail.Value != "a" {
-		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: a", list.Tail.Value)
+	if tail, _ := list.Index(-1); tail != "a" {
+		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: a", tail)
 	}
 }
 
@@ -34,18 +38,15 @@ func TestLPush_MultipleElements(t *testing.T) {
 	if list.Length != 3 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 3", list.Length)
 	}
-	if list.Head.Value != "c" {
-		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: c", list.Head.Value)
+	if head, _ := list.Index(0); head != "c" {
+		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: c", head)
 	}
-	if list.Tail.Value != "a" {
-		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: a", list.Tail.Value)
+	if tail, _ := list.Index(-1); tail != "a" {
+		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: a", tail)
 	}
-	// 포인터 연결 검증: c → b → a
-	if list.Head.Next.Value != "b" {
-		t.Fatalf("Head.Next 값이 다릅니다. actual: %s, expected: b", list.Head.Next.Value)
-	}
-	if list.Tail.Prev.Value != "b" {
-		t.Fatalf("Tail.Prev 값이 다릅니다. actual: %s, expected: b", list.Tail.Prev.Value)
+	// 순서 검증: c → b → a
+	if middle, _ := list.Index(1); middle != "b" {
+		t.Fatalf("가운데 값이 다릅니다. actual: %s, expected: b", middle)
 	}
 }
 
@@ -60,11 +61,11 @@ func TestRPush_SingleElement(t *testing.T) {
 	if list.Length != 1 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 1", list.Length)
 	}
-	if list.Head.Value != "a" {
-		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: a", list.Head.Value)
+	if head, _ := list.Index(0); head != "a" {
+		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: a", head)
 	}
-	if list.Tail.Value != "a" {
-		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: a", list.Tail.Value)
+	if tail, _ := list.Index(-1); tail != "a" {
+		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: a", tail)
 	}
 }
 
@@ -81,11 +82,11 @@ func TestRPush_MultipleElements(t *testing.T) {
 	if list.Length != 3 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 3", list.Length)
 	}
-	if list.Head.Value != "a" {
-		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: a", list.Head.Value)
+	if head, _ := list.Index(0); head != "a" {
+		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: a", head)
 	}
-	if list.Tail.Value != "c" {
-		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: c", list.Tail.Value)
+	if tail, _ := list.Index(-1); tail != "c" {
+		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: c", tail)
 	}
 }
 
@@ -123,11 +124,11 @@ func TestLPop_SingleElement(t *testing.T) {
 	if list.Length != 0 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 0", list.Length)
 	}
-	if list.Head != nil {
-		t.Fatal("Head가 nil이 아닙니다")
+	if list.head != nil {
+		t.Fatal("head가 nil이 아닙니다")
 	}
-	if list.Tail != nil {
-		t.Fatal("Tail이 nil이 아닙니다")
+	if list.tail != nil {
+		t.Fatal("tail이 nil이 아닙니다")
 	}
 }
 
@@ -148,11 +149,8 @@ func TestLPop_MultipleElements(t *testing.T) {
 	if list.Length != 2 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 2", list.Length)
 	}
-	if list.Head.Value != "b" {
-		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: b", list.Head.Value)
-	}
-	if list.Head.Prev != nil {
-		t.Fatal("새 Head의 Prev가 nil이 아닙니다")
+	if head, _ := list.Index(0); head != "b" {
+		t.Fatalf("Head 값이 다릅니다. actual: %s, expected: b", head)
 	}
 }
 
@@ -187,11 +185,11 @@ func TestRPop_SingleElement(t *testing.T) {
 	if list.Length != 0 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 0", list.Length)
 	}
-	if list.Head != nil {
-		t.Fatal("Head가 nil이 아닙니다")
+	if list.head != nil {
+		t.Fatal("head가 nil이 아닙니다")
 	}
-	if list.Tail != nil {
-		t.Fatal("Tail이 nil이 아닙니다")
+	if list.tail != nil {
+		t.Fatal("tail이 nil이 아닙니다")
 	}
 }
 
@@ -212,11 +210,8 @@ func TestRPop_MultipleElements(t *testing.T) {
 	if list.Length != 2 {
 		t.Fatalf("길이가 다릅니다. actual: %d, expected: 2", list.Length)
 	}
-	if list.Tail.Value != "b" {
-		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: b", list.Tail.Value)
-	}
-	if list.Tail.Next != nil {
-		t.Fatal("새 Tail의 Next가 nil이 아닙니다")
+	if tail, _ := list.Index(-1); tail != "b" {
+		t.Fatalf("Tail 값이 다릅니다. actual: %s, expected: b", tail)
 	}
 }
 
@@ -346,3 +341,327 @@ func TestRange_EmptyList(t *testing.T) {
 		t.Fatalf("빈 배열이 아닙니다. actual: %v", result)
 	}
 }
+
+// 테스트용: 값들을 RPush로 채운 리스트를 만든다.
+func newListOf(values ...string) *List {
+	list := NewList()
+	for _, v := range values {
+		list.RPush(v)
+	}
+	return list
+}
+
+func TestIndex(t *testing.T) {
+	// given
+	list := newListOf("a", "b", "c", "d", "e")
+
+	// when & then: 앞쪽/뒤쪽/음수 인덱스
+	cases := map[int]string{0: "a", 1: "b", 3: "d", 4: "e", -1: "e", -5: "a"}
+	for index, expected := range cases {
+		value, ok := list.Index(index)
+		if !ok || value != expected {
+			t.Errorf("Index(%d) actual: %s, expected: %s", index, value, expected)
+		}
+	}
+
+	if _, ok := list.Index(5); ok {
+		t.Fatal("범위를 벗어난 인덱스가 조회됩니다")
+	}
+	if _, ok := list.Index(-6); ok {
+		t.Fatal("범위를 벗어난 음수 인덱스가 조회됩니다")
+	}
+}
+
+func TestSet(t *testing.T) {
+	// given
+	list := newListOf("a", "b", "c")
+
+	// when
+	ok := list.Set(-1, "z")
+
+	// then
+	if !ok {
+		t.Fatal("Set 실패")
+	}
+	if result := list.Range(0, -1); result[2] != "z" {
+		t.Fatalf("Set 결과: %v", result)
+	}
+	if list.Set(3, "x") {
+		t.Fatal("범위를 벗어난 인덱스에 Set 성공")
+	}
+}
+
+func TestInsert(t *testing.T) {
+	// given
+	list := newListOf("a", "c")
+
+	// when
+	before := list.Insert("c", "b", true)
+	after := list.Insert("c", "d", false)
+	notFound := list.Insert("x", "y", true)
+
+	// then
+	if before != 3 || after != 4 || notFound != -1 {
+		t.Fatalf("반환값 before: %d, after: %d, notFound: %d", before, after, notFound)
+	}
+	expected := []string{"a", "b", "c", "d"}
+	for i, v := range list.Range(0, -1) {
+		if v != expected[i] {
+			t.Fatalf("Insert 결과: %v, expected: %v", list.Range(0, -1), expected)
+		}
+	}
+	if tail, _ := list.Index(-1); tail != "d" {
+		t.Fatalf("Tail 값: %s, expected: d", tail)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	cases := []struct {
+		count    int
+		expected []string
+		removed  int
+	}{
+		{2, []string{"b", "a", "c", "a"}, 2},
+		{-2, []string{"a", "b", "a", "c"}, 2},
+		{0, []string{"b", "c"}, 4},
+	}
+
+	for _, c := range cases {
+		// given
+		list := newListOf("a", "b", "a", "a", "c", "a")
+
+		// when
+		removed := list.Remove(c.count, "a")
+
+		// then
+		result := list.Range(0, -1)
+		if removed != c.removed || len(result) != len(c.expected) {
+			t.Fatalf("Remove(%d) 결과: %v, 삭제 수: %d", c.count, result, removed)
+		}
+		for i := range result {
+			if result[i] != c.expected[i] {
+				t.Fatalf("Remove(%d) 결과: %v, expected: %v", c.count, result, c.expected)
+			}
+		}
+	}
+}
+
+func TestTrim(t *testing.T) {
+	// given
+	list := newListOf("a", "b", "c", "d", "e")
+
+	// when
+	list.Trim(1, -2)
+
+	// then
+	result := list.Range(0, -1)
+	if len(result) != 3 || result[0] != "b" || result[2] != "d" {
+		t.Fatalf("Trim 결과: %v", result)
+	}
+	if list.Length != 3 {
+		t.Fatalf("Trim 후 길이: %d, expected: 3", list.Length)
+	}
+}
+
+func TestTrim_EmptyRange(t *testing.T) {
+	// given
+	list := newListOf("a", "b")
+
+	// when
+	list.Trim(5, 10)
+
+	// then
+	if list.Length != 0 || list.head != nil || list.tail != nil {
+		t.Fatalf("빈 범위 Trim 후 길이: %d", list.Length)
+	}
+}
+
+func TestPos(t *testing.T) {
+	// given: a는 인덱스 0, 2, 4
+	list := newListOf("a", "b", "a", "c", "a")
+
+	cases := []struct {
+		rank, count, maxlen int
+		expected            []int
+	}{
+		{1, 1, 0, []int{0}},
+		{2, 1, 0, []int{2}},
+		{1, 0, 0, []int{0, 2, 4}},
+		{-1, 2, 0, []int{4, 2}},
+		{1, 0, 3, []int{0, 2}},
+		{4, 1, 0, []int{}},
+	}
+
+	for _, c := range cases {
+		result := list.Pos("a", c.rank, c.count, c.maxlen)
+		if len(result) != len(c.expected) {
+			t.Fatalf("Pos(rank=%d, count=%d, maxlen=%d) actual: %v, expected: %v", c.rank, c.count, c.maxlen, result, c.expected)
+		}
+		for i := range result {
+			if result[i] != c.expected[i] {
+				t.Fatalf("Pos(rank=%d, count=%d, maxlen=%d) actual: %v, expected: %v", c.rank, c.count, c.maxlen, result, c.expected)
+			}
+		}
+	}
+}
+
+// ========== 노드 인코딩 (quicklist) ==========
+
+// 노드 수를 센다
+func countListNodes(list *List) int {
+	n := 0
+	for node := list.head; node != nil; node = node.next {
+		n++
+	}
+	return n
+}
+
+func TestList_SmallListUsesSingleNode(t *testing.T) {
+	// given & when
+	list := newListOf("a", "b", "c")
+	list.LPush("z")
+
+	// then
+	if countListNodes(list) != 1 || list.head != list.tail {
+		t.Fatalf("작은 리스트의 노드 수: %d, expected: 1", countListNodes(list))
+	}
+}
+
+func TestList_LargeListSpansNodes(t *testing.T) {
+	// given: 노드 하나에 다 들어가지 않을 만큼 넣는다
+	list := NewList()
+	for i := 0; i < 5000; i++ {
+		list.RPush(strconv.Itoa(i))
+	}
+	for i := 1; i <= 5000; i++ {
+		list.LPush(strconv.Itoa(-i))
+	}
+
+	// then: 노드가 여러 개로 나뉘고, 인덱스 접근은 순서대로
+	if countListNodes(list) < 2 {
+		t.Fatalf("노드 수: %d, expected: 2 이상", countListNodes(list))
+	}
+	if list.Length != 10000 {
+		t.Fatalf("길이: %d, expected: 10000", list.Length)
+	}
+	for _, index := range []int{0, 1, 4999, 5000, 7777, 9999, -1, -10000} {
+		expected := index - 5000
+		if index < 0 {
+			expected = index + 10000 - 5000
+		}
+		if value, _ := list.Index(index); value != strconv.Itoa(expected) {
+			t.Fatalf("Index(%d): %s, expected: %d", index, value, expected)
+		}
+	}
+
+	result := list.Range(4998, 5001)
+	if len(result) != 4 || result[0] != "-2" || result[3] != "1" {
+		t.Fatalf("노드 경계를 걸친 Range: %v", result)
+	}
+}
+
+func TestList_SetSplitsOversizedNode(t *testing.T) {
+	// given
+	list := newListOf("a", "b", "c", "d")
+
+	// when: 노드 최대 크기보다 큰 값으로 바꾼다
+	large := strings.Repeat("x", listNodeMaxBytes)
+	list.Set(1, large)
+
+	// then: 노드가 나뉘어도 순서와 값은 그대로
+	if countListNodes(list) < 2 {
+		t.Fatalf("노드 수: %d, expected: 2 이상", countListNodes(list))
+	}
+	result := list.Range(0, -1)
+	if len(result) != 4 || result[0] != "a" || result[1] != large || result[3] != "d" {
+		t.Fatal("큰 값으로 Set한 뒤 Range 결과가 다릅니다")
+	}
+}
+
+func TestList_PopAcrossNodes(t *testing.T) {
+	// given
+	list := NewList()
+	for i := 0; i < 3000; i++ {
+		list.RPush(strconv.Itoa(i))
+	}
+
+	// when & then: 양쪽에서 모두 꺼내면 노드가 모두 정리된다
+	for i := 0; i < 1500; i++ {
+		if value, _ := list.LPop(); value != strconv.Itoa(i) {
+			t.Fatalf("LPop: %s, expected: %d", value, i)
+		}
+		if value, _ := list.RPop(); value != strconv.Itoa(2999-i) {
+			t.Fatalf("RPop: %s, expected: %d", value, 2999-i)
+		}
+	}
+	if list.Length != 0 || list.head != nil || list.tail != nil {
+		t.Fatalf("모두 꺼낸 뒤 길이: %d", list.Length)
+	}
+}
+
+func TestList_RemoveAndTrimAcrossNodes(t *testing.T) {
+	// given: 짝수 자리는 "x", 홀수 자리는 숫자
+	list := NewList()
+	for i := 0; i < 6000; i++ {
+		if i%2 == 0 {
+			list.RPush("x")
+		} else {
+			list.RPush(strconv.Itoa(i))
+		}
+	}
+
+	// when
+	removed := list.Remove(0, "x")
+	list.Trim(1, -2)
+
+	// then
+	if removed != 3000 || list.Length != 2998 {
+		t.Fatalf("삭제 수: %d, 길이: %d", removed, list.Length)
+	}
+	if head, _ := list.Index(0); head != "3" {
+		t.Fatalf("Trim 후 첫 값: %s, expected: 3", head)
+	}
+	if tail, _ := list.Index(-1); tail != "5997" {
+		t.Fatalf("Trim 후 마지막 값: %s, expected: 5997", tail)
+	}
+	if positions := list.Pos("5997", -1, 1, 0); len(positions) != 1 || positions[0] != 2997 {
+		t.Fatalf("Pos 결과: %v", positions)
+	}
+}
+
+func TestList_ByteAccounting(t *testing.T) {
+	// given
+	list := NewList()
+	large := strings.Repeat("v", 5000)
+	check := func(step string) {
+		t.Helper()
+		bytes, nodes := 0, 0
+		for node := list.head; node != nil; node = node.next {
+			bytes += len(node.entries)
+			nodes++
+		}
+		if list.bytes != bytes || list.nodes != nodes {
+			t.Fatalf("%s 뒤 바이트/노드 수: %d/%d, expected: %d/%d", step, list.bytes, list.nodes, bytes, nodes)
+		}
+	}
+
+	// when & then: 노드를 만들고 나누고 합치고 지우는 연산마다 합계가 실제 노드와 같다
+	for i := 0; i < 5000; i++ {
+		list.RPush(strconv.Itoa(i))
+		list.LPush("x")
+	}
+	check("푸시")
+	list.Set(2500, large)
+	check("Set")
+	list.Insert("100", large, true)
+	check("Insert")
+	list.Remove(0, "x")
+	check("Remove")
+	list.LPop()
+	list.RPop()
+	check("팝")
+	list.Trim(100, -100)
+	check("Trim")
+	list.Trim(1, 0)
+	check("전체 Trim")
+}
@@ -0,0 +1,400 @@
+package storage
+
+import (
+	"inmemory-db/internal/pubsub"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// 크기 추정에 쓰는 고정 오버헤드 (64비트 기준 대략적인 값)
+const (
+	// 키 공간의 dictEntry + Entry 구조체
+	entryOverhead = 160
+	// Dict 요소 하나 (dictEntry: key, value, next) + 버킷 포인터
+	dictEntryOverhead = 48
+	// 리스트 노드 하나 (listNode 구조체)
+	listNodeOverhead = 48
+	// skiplist 노드 하나 (평균 레벨 기준)
+	skiplistNodeOverhead = 64
+	// 스트림 엔트리 하나 (StreamEntry + Fields 슬라이스 헤더)
+	streamEntryOverhead = 48
+	// PEL 엔트리 하나
+	pendingEntryOverhead = 64
+)
+
+// 쓰기 때마다 컬렉션의 크기를 추정할 때 훑을 요소 수 (MEMORY USAGE의 기본 SAMPLES)
+const DefaultMemorySamples = 5
+
+// LFU 카운터의 초깃값. 새 키가 곧바로 축출되지 않게 한다 (Redis LFU_INIT_VAL)
+const lfuInitVal = 5
+
+// LFU 카운터의 증가 확률을 정하는 값 (Redis lfu-log-factor)
+const lfuLogFactor = 10
+
+// LFU 카운터를 1 줄이는 데 걸리는 유휴 시간 (Redis lfu-decay-time, 1분)
+const lfuDecayTime = time.Minute
+
+// 키 공간. Dict에 엔트리 크기 추정치의 합(used)을 더해 관리한다.
+// 엔트리를 넣고 뺄 때 used를 맞추고, 조회할 때 LRU/LFU 접근 정보를 갱신한다.
+type keyspace struct {
+	*Dict[*Entry]
+	used int64
+}
+
+func newKeyspace() *keyspace {
+	return &keyspace{Dict: NewDict[*Entry]()}
+}
+
+// 엔트리를 찾고 접근 정보를 갱신한다.
+func (k *keyspace) Get(key string) (*Entry, bool) {
+	entry, exist := k.Dict.Get(key)
+	if exist {
+		entry.touch()
+	}
+	return entry, exist
+}
+
+// 접근 정보를 바꾸지 않고 엔트리를 찾는다 (OBJECT 등 내부 조회용).
+func (k *keyspace) peek(key string) (*Entry, bool) {
+	return k.Dict.Get(key)
+}
+
+// 엔트리를 넣고 크기를 잰다. 같은 키의 기존 엔트리 크기는 뺀다.
+func (k *keyspace) Set(key string, entry *Entry) bool {
+	if old, exist := k.Dict.Get(key); exist {
+		k.used -= old.size
+	}
+	if entry.accessedAt.Load() == 0 {
+		entry.accessedAt.Store(time.Now().UnixMilli())
+		entry.freq.Store(lfuInitVal)
+	}
+	entry.size = entry.memoryUsage(key, DefaultMemorySamples)
+	k.used += entry.size
+	return k.Dict.Set(key, entry)
+}
+
+// 엔트리를 빼고 그 크기만큼 used를 줄인다.
+func (k *keyspace) Delete(key string) bool {
+	if old, exist := k.Dict.Get(key); exist {
+		k.used -= old.size
+	}
+	return k.Dict.Delete(key)
+}
+
+// 값이 바뀐 엔트리의 크기를 다시 잰다. 키가 없으면 아무것도 하지 않는다.
+func (k *keyspace) remeasure(key string) {
+	entry, exist := k.Dict.Get(key)
+	if !exist {
+		return
+	}
+	size := entry.memoryUsage(key, DefaultMemorySamples)
+	k.used += size - entry.size
+	entry.size = size
+}
+
+// 이 저장소가 쓰는 메모리 추정치(바이트). 샤드를 하나씩 잠그고 더한다
+func (s *Store) UsedMemory() int64 {
+	var used int64
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		used += sh.data.used
+		sh.mu.RUnlock()
+	}
+	return used
+}
+
+// ========== 크기 추정 ==========
+
+// 키와 값이 차지하는 메모리를 추정한다.
+// 컬렉션은 samples개의 요소만 훑어 평균 크기에 요소 수를 곱한다. samples가 0 이하면 모두 훑는다.
+func (e *Entry) memoryUsage(key string, samples int) int64 {
+	size := int64(entryOverhead + len(key))
+
+	switch e.Type {
+	case TypeString:
+		if e.IntEncoded {
+			size += 8
+		} else {
+			size += int64(len(e.Str))
+		}
+
+	case TypeList:
+		// 푸시와 팝마다 불리므로 노드를 훑지 않고 리스트가 관리하는 합계를 쓴다
+		size += int64(e.List.nodes*listNodeOverhead + e.List.bytes)
+
+	case TypeHash:
+		size += dictMemoryUsage(e.Hash, samples, func(field, value string) int {
+			return len(field) + len(value)
+		})
+
+	case TypeSet:
+		if e.Set.dict == nil {
+			size += int64(8 * cap(e.Set.ints))
+		} else {
+			size += dictMemoryUsage(e.Set.dict, samples, func(member string, _ struct{}) int {
+				return len(member)
+			})
+		}
+
+	case TypeZSet:
+		size += dictMemoryUsage(e.ZSet.dict, samples, func(member string, _ float64) int {
+			// member 문자열은 dict와 skiplist가 함께 쓴다
+			return len(member) + 8 + skiplistNodeOverhead
+		})
+
+	case TypeStream:
+		size += streamMemoryUsage(e.Stream, samples)
+	}
+	return size
+}
+
+// Dict의 메모리를 추정한다. elementSize는 요소 하나의 데이터 크기다.
+func dictMemoryUsage[V any](d *Dict[V], samples int, elementSize func(key string, value V) int) int64 {
+	size := int64(8 * len(d.buckets))
+	if d.Len() == 0 {
+		return size
+	}
+
+	sampled, total := 0, 0
+	d.Range(func(key string, value V) bool {
+		total += dictEntryOverhead + elementSize(key, value)
+		sampled++
+		return samples <= 0 || sampled < samples
+	})
+	return size + int64(total)*int64(d.Len())/int64(sampled)
+}
+
+func streamMemoryUsage(st *Stream, samples int) int64 {
+	var size int64
+	if n := len(st.entries); n > 0 {
+		sampled, total := 0, 0
+		for i := 0; i < n && (samples <= 0 || sampled < samples); i++ {
+			total += streamEntryOverhead
+			for _, field := range st.entries[i].Fields {
+				total += 16 + len(field)
+			}
+			sampled++
+		}
+		size += int64(total) * int64(n) / int64(sampled)
+	}
+
+	for name, group := range st.groups {
+		size += int64(len(name) + len(group.pel)*pendingEntryOverhead)
+		for consumer := range group.consumers {
+			size += int64(64 + len(consumer))
+		}
+	}
+	return size
+}
+
+// ========== LRU / LFU ==========
+
+// 키에 접근했음을 기록한다. 읽기 락만 잡은 상태에서도 호출되므로 원자적으로 갱신한다.
+func (e *Entry) touch() {
+	now := time.Now().UnixMilli()
+	counter := e.decayedFreq(now)
+	// 카운터가 클수록 증가 확률이 낮아지는 로그 카운터 (Redis LFULogIncr)
+	if counter < 255 {
+		base := float64(counter) - lfuInitVal
+		if base < 0 {
+			base = 0
+		}
+		if rand.Float64() < 1/(base*lfuLogFactor+1) {
+			counter++
+		}
+	}
+	e.freq.Store(counter)
+	e.accessedAt.Store(now)
+}
+
+// 마지막 접근 이후 지난 시간만큼 줄인 LFU 카운터
+func (e *Entry) decayedFreq(now int64) uint32 {
+	counter := e.freq.Load()
+	periods := uint32((now - e.accessedAt.Load()) / lfuDecayTime.Milliseconds())
+	if periods >= counter {
+		return 0
+	}
+	return counter - periods
+}
+
+// 마지막 접근 이후 지난 시간
+func (e *Entry) idleTime(now int64) time.Duration {
+	return time.Duration(now-e.accessedAt.Load()) * time.Millisecond
+}
+
+// ========== 축출 ==========
+
+// maxmemory를 넘었을 때 지울 키를 고르는 정책 (maxmemory-policy)
+type EvictionPolicy int32
+
+const (
+	NoEviction EvictionPolicy = iota
+	AllKeysLRU
+	AllKeysLFU
+	AllKeysRandom
+	VolatileLRU
+	VolatileLFU
+	VolatileRandom
+	VolatileTTL
+)
+
+var evictionPolicyNames = []string{
+	NoEviction:     "noeviction",
+	AllKeysLRU:     "allkeys-lru",
+	AllKeysLFU:     "allkeys-lfu",
+	AllKeysRandom:  "allkeys-random",
+	VolatileLRU:    "volatile-lru",
+	VolatileLFU:    "volatile-lfu",
+	VolatileRandom: "volatile-random",
+	VolatileTTL:    "volatile-ttl",
+}
+
+func (p EvictionPolicy) String() string {
+	return evictionPolicyNames[p]
+}
+
+// 정책 이름을 EvictionPolicy로 바꾼다. 모르는 이름이면 false.
+func ParseEvictionPolicy(name string) (EvictionPolicy, bool) {
+	for p, n := range evictionPolicyNames {
+		if n == name {
+			return EvictionPolicy(p), true
+		}
+	}
+	return NoEviction, false
+}
+
+// 만료 시간이 있는 키 중에서만 고르는 정책이면 true
+func (p EvictionPolicy) volatile() bool {
+	return p >= VolatileLRU
+}
+
+// 축출 후보. score가 클수록 먼저 지운다
+type evictionCandidate struct {
+	db    *Store
+	key   string
+	score float64
+}
+
+// 키 하나를 축출한다. 데이터베이스마다 samples개의 키를 무작위로 뽑아,
+// 정책에 따라 가장 지우기 좋은 키를 지운다 (Redis의 근사 LRU/LFU와 같은 방식).
+// 지울 후보가 없으면(noeviction이거나 volatile 정책인데 만료 시간이 있는 키가 없으면) false.
+func Evict(dbs []*Store, policy EvictionPolicy, samples int) bool {
+	if policy == NoEviction {
+		return false
+	}
+
+	var best *evictionCandidate
+	for _, db := range dbs {
+		candidate := db.sampleEvictionCandidate(policy, samples)
+		if candidate != nil && (best == nil || candidate.score > best.score) {
+			best = candidate
+		}
+	}
+	if best == nil {
+		return false
+	}
+
+	best.db.evict(best.key)
+	return true
+}
+
+// 키를 samples개 뽑아 가장 지우기 좋은 후보를 반환한다. 후보가 없으면 nil.
+// 표본마다 후보 수에 비례한 확률로 샤드를 골라 그 샤드의 읽기 락만 잡는다.
+// 그래야 어느 샤드에 있든 모든 후보가 같은 확률로 뽑힌다.
+func (s *Store) sampleEvictionCandidate(policy EvictionPolicy, samples int) *evictionCandidate {
+	// 샤드별 후보 수 (volatile 정책이면 만료 시간이 있는 키 수).
+	// 표본을 뽑는 사이에 조금 달라질 수 있지만 확률을 정하는 데만 쓰므로 괜찮다
+	var sizes [shardCount]int
+	total := 0
+	for i, sh := range s.shards {
+		sh.mu.RLock()
+		if policy.volatile() {
+			sizes[i] = sh.heap.Len()
+		} else {
+			sizes[i] = sh.data.Len()
+		}
+		sh.mu.RUnlock()
+		total += sizes[i]
+	}
+	if total == 0 {
+		return nil
+	}
+
+	now := time.Now().UnixMilli()
+	var best *evictionCandidate
+	for i := 0; i < samples; i++ {
+		n := rand.IntN(total)
+		index := 0
+		for n >= sizes[index] {
+			n -= sizes[index]
+			index++
+		}
+
+		candidate := s.sampleShard(s.shards[index], policy, now)
+		if candidate != nil && (best == nil || candidate.score > best.score) {
+			best = candidate
+		}
+	}
+	return best
+}
+
+// 샤드에서 키 하나를 뽑아 점수를 매긴다. 샤드에 후보가 없으면 nil.
+// 엔트리는 락 밖에서 바뀔 수 있으므로 점수는 읽기 락을 잡은 채로 계산한다.
+func (s *Store) sampleShard(sh *shard, policy EvictionPolicy, now int64) *evictionCandidate {
+	sh.mu.RLock()
+	defer sh.mu.RUnlock()
+
+	var key string
+	var entry *Entry
+	if policy.volatile() {
+		key, entry = sh.randomVolatileKey()
+	} else {
+		key, entry, _ = sh.data.Random()
+	}
+	if entry == nil {
+		return nil
+	}
+
+	var score float64
+	switch policy {
+	case AllKeysLRU, VolatileLRU:
+		score = float64(entry.idleTime(now))
+	case AllKeysLFU, VolatileLFU:
+		score = float64(255 - entry.decayedFreq(now))
+	case VolatileTTL:
+		// 만료가 가까울수록 먼저 지운다
+		score = math.MaxInt64 - float64(entry.ExpireAt.UnixMilli())
+	default:
+		score = rand.Float64()
+	}
+	return &evictionCandidate{db: s, key: key, score: score}
+}
+
+// 만료 시간이 있는 키를 무작위로 하나 고른다. 만료 힙은 만료 시간이 있는 키와
+// 하나씩 맞춰져 있으므로 힙 항목에서 뽑는다. 없으면 nil.
+// 샤드의 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (sh *shard) randomVolatileKey() (string, *Entry) {
+	items := sh.heap.items
+	if len(items) == 0 {
+		return "", nil
+	}
+	item := items[rand.IntN(len(items))]
+	entry, exist := sh.data.peek(item.Key)
+	if !exist {
+		return "", nil
+	}
+	return item.Key, entry
+}
+
+// 키를 축출하고 evicted 이벤트를 알린다.
+func (s *Store) evict(key string) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	if _, exist := s.data.peek(key); !exist {
+		return
+	}
+	s.data.Delete(key)
+	s.notifyEvent(pubsub.NotifyEvicted, "evicted", key)
+}
@@ -0,0 +1,143 @@
+package storage
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestUsedMemory(t *testing.T) {
+	// given
+	store := New()
+	if used := store.UsedMemory(); used != 0 {
+		t.Fatalf("빈 저장소 UsedMemory: %d", used)
+	}
+
+	// when
+	store.Set("str", "value")
+	afterSet := store.UsedMemory()
+	store.RPush("list", "a", "b", "c")
+	afterPush := store.UsedMemory()
+
+	// then
+	if afterSet <= 0 || afterPush <= afterSet {
+		t.Fatalf("쓰기 후 UsedMemory가 늘지 않았습니다: %d -> %d", afterSet, afterPush)
+	}
+	store.Del("str", "list")
+	if used := store.UsedMemory(); used != 0 {
+		t.Fatalf("모두 지운 후 UsedMemory: %d, expected: 0", used)
+	}
+}
+
+func TestEvictLRU(t *testing.T) {
+	// given: old만 오래전에 접근했다
+	store := New()
+	store.Set("old", "1")
+	store.Set("new", "2")
+	entry, _ := store.data.peek("old")
+	entry.accessedAt.Store(time.Now().Add(-time.Hour).UnixMilli())
+
+	// when
+	evicted := Evict([]*Store{store}, AllKeysLRU, 20)
+
+	// then
+	if !evicted {
+		t.Fatal("Evict 실패")
+	}
+	if store.Exists("old") != 0 || store.Exists("new") != 1 {
+		t.Fatal("가장 오래 쓰지 않은 키가 축출되지 않았습니다")
+	}
+}
+
+func TestEvictLFU(t *testing.T) {
+	// given: hot의 카운터가 훨씬 크다
+	store := New()
+	store.Set("hot", "1")
+	store.Set("cold", "2")
+	entry, _ := store.data.peek("hot")
+	entry.freq.Store(200)
+
+	// when
+	Evict([]*Store{store}, AllKeysLFU, 20)
+
+	// then
+	if store.Exists("cold") != 0 || store.Exists("hot") != 1 {
+		t.Fatal("가장 적게 쓴 키가 축출되지 않았습니다")
+	}
+}
+
+func TestEvictVolatile(t *testing.T) {
+	// given: 만료 시간이 있는 키는 soon과 later뿐이다
+	store := New()
+	for i := range 10 {
+		store.Set("persistent-"+strconv.Itoa(i), "v")
+	}
+	store.Set("soon", "1")
+	store.ExpireAt("soon", time.Now().Add(time.Minute), 0)
+	store.Set("later", "2")
+	store.ExpireAt("later", time.Now().Add(time.Hour), 0)
+
+	// when & then
+	if !Evict([]*Store{store}, VolatileTTL, 20) {
+		t.Fatal("Evict 실패")
+	}
+	if store.Exists("soon") != 0 || store.Exists("later") != 1 {
+		t.Fatal("만료가 가장 가까운 키가 축출되지 않았습니다")
+	}
+	if !Evict([]*Store{store}, VolatileRandom, 20) || store.Exists("later") != 0 {
+		t.Fatal("만료 시간이 있는 키가 축출되지 않았습니다")
+	}
+	if Evict([]*Store{store}, VolatileLRU, 20) {
+		t.Fatal("만료 시간이 있는 키가 없는데 축출했습니다")
+	}
+	if Evict([]*Store{store}, NoEviction, 20) {
+		t.Fatal("noeviction인데 축출했습니다")
+	}
+	if store.DBSize() != 10 {
+		t.Fatalf("남은 키 수: %d, expected: 10", store.DBSize())
+	}
+}
+
+func TestEvictVolatileTTL_ConcurrentPersist(t *testing.T) {
+	// given: 만료 시간을 계속 지웠다 다시 거는 키
+	store := New()
+	for i := range 100 {
+		key := "key-" + strconv.Itoa(i)
+		store.Set(key, "v")
+		store.ExpireAt(key, time.Now().Add(time.Hour), 0)
+	}
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			key := "key-" + strconv.Itoa(i%100)
+			store.Persist(key)
+			store.ExpireAt(key, time.Now().Add(time.Hour), 0)
+		}
+	}()
+
+	// when & then: 점수를 매기는 동안 만료 시간이 지워져도 패닉하지 않는다 (-race로 경합도 확인한다)
+	for range 1000 {
+		store.sampleEvictionCandidate(VolatileTTL, 5)
+	}
+	close(stop)
+	<-done
+}
+
+func TestParseEvictionPolicy(t *testing.T) {
+	for _, name := range []string{"noeviction", "allkeys-lru", "volatile-ttl"} {
+		policy, ok := ParseEvictionPolicy(name)
+		if !ok || policy.String() != name {
+			t.Fatalf("ParseEvictionPolicy(%q): %v, %v", name, policy, ok)
+		}
+	}
+	if _, ok := ParseEvictionPolicy("lru"); ok {
+		t.Fatal("잘못된 정책 이름을 받아들였습니다")
+	}
+}
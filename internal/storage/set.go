@@ -0,0 +1,194 @@
+package storage
+
+import (
+	"math/rand/v2"
+	"slices"
+	"strconv"
+)
+
+// intset 인코딩을 유지할 최대 요소 수 (Redis의 set-max-intset-entries)
+const setMaxIntsetEntries = 512
+
+// Set은 중복 없는 문자열 집합이다.
+// 모든 요소가 정수이고 크기가 작으면 정렬된 int64 슬라이스(intset)에 담고,
+// 정수가 아닌 요소가 들어오거나 커지면 Dict(hashtable)로 바꾼다. 한 번 바뀌면 되돌리지 않는다.
+type Set struct {
+	ints []int64
+	dict *Dict[struct{}]
+}
+
+func NewSet() *Set {
+	return &Set{}
+}
+
+// 요소를 추가한다. 새로 추가되었으면 true
+func (s *Set) Add(member string) bool {
+	if s.dict == nil {
+		if n, ok := parseSetInt(member); ok {
+			i, found := slices.BinarySearch(s.ints, n)
+			if found {
+				return false
+			}
+			if len(s.ints) < setMaxIntsetEntries {
+				s.ints = slices.Insert(s.ints, i, n)
+				return true
+			}
+		}
+		s.convertToDict()
+	}
+	return s.dict.Set(member, struct{}{})
+}
+
+// 요소를 삭제한다. 삭제했으면 true
+func (s *Set) Remove(member string) bool {
+	if s.dict != nil {
+		return s.dict.Delete(member)
+	}
+
+	n, ok := parseSetInt(member)
+	if !ok {
+		return false
+	}
+	i, found := slices.BinarySearch(s.ints, n)
+	if !found {
+		return false
+	}
+	s.ints = slices.Delete(s.ints, i, i+1)
+	return true
+}
+
+func (s *Set) Contains(member string) bool {
+	if s.dict != nil {
+		_, exist := s.dict.Get(member)
+		return exist
+	}
+
+	n, ok := parseSetInt(member)
+	if !ok {
+		return false
+	}
+	_, found := slices.BinarySearch(s.ints, n)
+	return found
+}
+
+// 요소 개수
+func (s *Set) Len() int {
+	if s.dict != nil {
+		return s.dict.Len()
+	}
+	return len(s.ints)
+}
+
+// 모든 요소를 fn에 넘긴다. fn이 false를 반환하면 멈춘다.
+// 순회 중에 Set을 변경하면 안 된다.
+func (s *Set) Range(fn func(member string) bool) {
+	if s.dict != nil {
+		s.dict.Range(func(member string, _ struct{}) bool {
+			return fn(member)
+		})
+		return
+	}
+	for _, n := range s.ints {
+		if !fn(strconv.FormatInt(n, 10)) {
+			return
+		}
+	}
+}
+
+// 모든 요소. intset이면 오름차순이다.
+func (s *Set) Members() []string {
+	result := make([]string, 0, s.Len())
+	s.Range(func(member string) bool {
+		result = append(result, member)
+		return true
+	})
+	return result
+}
+
+// 무작위 요소 하나. 비어있으면 ("", false)
+func (s *Set) Random() (string, bool) {
+	if s.dict != nil {
+		member, _, ok := s.dict.Random()
+		return member, ok
+	}
+	if len(s.ints) == 0 {
+		return "", false
+	}
+	return strconv.FormatInt(s.ints[rand.IntN(len(s.ints))], 10), true
+}
+
+// 무작위 요소들을 고른다 (SRANDMEMBER).
+//   - count > 0: 서로 다른 요소를 최대 count개
+//   - count < 0: 같은 요소가 여러 번 나올 수 있고, 정확히 |count|개
+func (s *Set) RandomMembers(count int) []string {
+	result := []string{}
+	if count < 0 {
+		for i := 0; i < -count; i++ {
+			member, _ := s.Random()
+			result = append(result, member)
+		}
+		return result
+	}
+
+	if count >= s.Len() {
+		result = s.Members()
+		rand.Shuffle(len(result), func(i, j int) {
+			result[i], result[j] = result[j], result[i]
+		})
+		return result
+	}
+
+	picked := make(map[string]struct{}, count)
+	for len(picked) < count {
+		member, _ := s.Random()
+		if _, dup := picked[member]; dup {
+			continue
+		}
+		picked[member] = struct{}{}
+		result = append(result, member)
+	}
+	return result
+}
+
+// cursor부터 요소를 순회해서 fn에 넘기고 다음 커서를 반환한다 (SSCAN).
+// intset은 작으므로 한 번에 모두 반환하고 커서 0을 돌려준다.
+func (s *Set) Scan(cursor uint64, count int, fn func(member string)) uint64 {
+	if s.dict != nil {
+		return scanDict(s.dict, cursor, count, func(member string, _ struct{}) {
+			fn(member)
+		})
+	}
+	for _, n := range s.ints {
+		fn(strconv.FormatInt(n, 10))
+	}
+	return 0
+}
+
+// 내부 인코딩 이름 (OBJECT ENCODING 용)
+func (s *Set) Encoding() string {
+	if s.dict != nil {
+		return "hashtable"
+	}
+	return "intset"
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// intset을 Dict로 바꾼다.
+func (s *Set) convertToDict() {
+	s.dict = NewDict[struct{}]()
+	for _, n := range s.ints {
+		s.dict.Set(strconv.FormatInt(n, 10), struct{}{})
+	}
+	s.ints = nil
+}
+
+// member가 정수의 표준 표기("01", "+1" 등이 아닌)일 때만 정수로 본다.
+// 그래야 intset에서 꺼낸 값이 넣은 문자열과 같다.
+func parseSetInt(member string) (int64, bool) {
+	n, err := strconv.ParseInt(member, 10, 64)
+	if err != nil || strconv.FormatInt(n, 10) != member {
+		return 0, false
+	}
+	return n, true
+}
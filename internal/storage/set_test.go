@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestSet_IntsetEncoding(t *testing.T) {
+	// given
+	set := NewSet()
+
+	// when: 정수만 넣는다
+	set.Add("3")
+	set.Add("1")
+	set.Add("2")
+	duplicate := set.Add("1")
+
+	// then: 정렬된 intset으로 저장된다
+	if set.Encoding() != "intset" {
+		t.Fatalf("인코딩: %s, expected: intset", set.Encoding())
+	}
+	if duplicate {
+		t.Fatal("중복 요소가 추가됨")
+	}
+	members := set.Members()
+	if len(members) != 3 || members[0] != "1" || members[2] != "3" {
+		t.Fatalf("Members: %v", members)
+	}
+}
+
+func TestSet_ConvertsToHashtable(t *testing.T) {
+	cases := []struct {
+		name   string
+		member string
+	}{
+		{"정수가 아닌 문자열", "abc"},
+		{"표준 표기가 아닌 정수", "007"},
+	}
+
+	for _, c := range cases {
+		// given
+		set := NewSet()
+		set.Add("1")
+
+		// when
+		set.Add(c.member)
+
+		// then: 넣은 문자열 그대로 보존된다
+		if set.Encoding() != "hashtable" {
+			t.Fatalf("%s: 인코딩 %s, expected: hashtable", c.name, set.Encoding())
+		}
+		if !set.Contains(c.member) || !set.Contains("1") || set.Len() != 2 {
+			t.Fatalf("%s: Members %v", c.name, set.Members())
+		}
+	}
+}
+
+func TestSet_ConvertsWhenIntsetIsFull(t *testing.T) {
+	// given
+	set := NewSet()
+	for i := 0; i < setMaxIntsetEntries; i++ {
+		set.Add(strconv.Itoa(i))
+	}
+	if set.Encoding() != "intset" {
+		t.Fatalf("인코딩: %s, expected: intset", set.Encoding())
+	}
+
+	// when
+	set.Add(strconv.Itoa(setMaxIntsetEntries))
+
+	// then
+	if set.Encoding() != "hashtable" || set.Len() != setMaxIntsetEntries+1 {
+		t.Fatalf("인코딩: %s, 길이: %d", set.Encoding(), set.Len())
+	}
+}
+
+func TestSet_Remove(t *testing.T) {
+	// given
+	set := NewSet()
+	set.Add("1")
+	set.Add("2")
+
+	// when & then
+	if !set.Remove("1") || set.Remove("1") || set.Remove("abc") {
+		t.Fatal("Remove 반환값이 다릅니다")
+	}
+	if set.Contains("1") || !set.Contains("2") {
+		t.Fatalf("Members: %v", set.Members())
+	}
+}
+
+func TestSet_RandomMembers(t *testing.T) {
+	// given
+	set := NewSet()
+	for _, member := range []string{"a", "b", "c"} {
+		set.Add(member)
+	}
+
+	// when
+	distinct := set.RandomMembers(2)
+	repeated := set.RandomMembers(-5)
+
+	// then
+	if len(distinct) != 2 || distinct[0] == distinct[1] {
+		t.Fatalf("양수 count: %v", distinct)
+	}
+	if len(repeated) != 5 {
+		t.Fatalf("음수 count: %v", repeated)
+	}
+	for _, member := range repeated {
+		if !set.Contains(member) {
+			t.Fatalf("집합에 없는 요소: %s", member)
+		}
+	}
+}
@@ -3,9 +3,12 @@ package storage
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"inmemory-db/internal/persistence"
+	"inmemory-db/internal/pubsub"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -16,299 +19,325 @@ var ErrWrongType = errors.New("WRONGTYPE Operation against a key holding the wro
 const (
 	TypeString EntryType = iota
 	TypeList
+	TypeHash
+	TypeSet
+	TypeZSet
+	TypeStream
 )
 
 type Entry struct {
-	Type     EntryType
-	Str      string
-	List     *List
-	ExpireAt *time.Time
+	Type EntryType
+	Str  string
+	// 표준 정수 표기의 문자열은 Str 대신 Int에 저장한다 (int 인코딩)
+	Int        int64
+	IntEncoded bool
+	List       *List
+	Hash       *Dict[string]
+	Set        *Set
+	ZSet       *ZSet
+	Stream     *Stream
+	ExpireAt   *time.Time
+
+	// 키와 값의 크기 추정치(바이트). 키 공간에 넣거나 값이 바뀔 때 다시 잰다
+	size int64
+	// 마지막 접근 시각(유닉스 밀리초)과 LFU 카운터. 읽기 락 아래에서도 갱신된다
+	accessedAt atomic.Int64
+	freq       atomic.Uint32
 }
 type Store struct {
-	data map[string]*Entry
-	mu   sync.RWMutex
-	heap *MinHeap
+	// 키 공간을 나눈 샤드들. 명령어는 자기가 다루는 키의 샤드 락만 잡는다
+	shards []*shard
+	// 샤드들을 하나로 보는 키 공간. 커서 기반 SCAN을 위해 샤드마다 Go map 대신 Dict를 쓴다
+	data shardedKeyspace
 	done chan struct{}
+	// StopExpiry를 여러 번 불러도 done을 한 번만 닫는다
+	stopOnce sync.Once
+	notify   NotifyFunc
+	// 만료 시각을 계산하고 비교할 때 쓰는 현재 시각
+	clock Clock
+
+	// 키별 블로킹 명령어 대기열 (FIFO). 샤드 락을 잡은 채로 blockedMu를 잡을 수 있지만 반대는 안 된다
+	blockedMu sync.Mutex
+	blocked   map[string][]*waiter
+	// 데이터가 들어와서 대기열을 처리해야 하는 키 (blockedMu로 보호)
+	ready []string
+	// 락 없이 빠르게 확인하기 위한 값. 대기 중인 waiter 수와 ready가 비어 있지 않은지
+	waiting  atomic.Int32
+	hasReady atomic.Bool
+
+	// 백그라운드 만료 루프의 초당 실행 횟수와 통계
+	expiryHz      atomic.Int32
+	expiredKeys   atomic.Int64
+	expireCapHits atomic.Int64
+	// 만료됐지만 아직 지워지지 않은 키 비율(%)의 추정치. float64 비트로 저장한다
+	expiredStale atomic.Uint64
+	// 지난 주기가 시간 제한으로 멈춘 샤드. 만료 루프 고루틴만 쓴다
+	expireCursor int
 }
 
-func New() *Store {
-	return &Store{
-		data: make(map[string]*Entry),
-		heap: NewMinHeap(),
-		done: make(chan struct{}),
-	}
-}
-
-func (s *Store) Set(key, value string) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	s.data[key] = &Entry{Type: TypeString, Str: value}
-}
-
-func (s *Store) Get(key string) (string, bool) {
-	// 서로다른 고루틴 간의 읽기 작업에서는 블로킹 없이 동시에 통과
-	// A 고루틴이 쓰기 작업 도중, B 고루틴이 데이터를 읽고 있다면 데이터 불일치 현상이 생길 수 있기때문에
-	// RLock(읽기)이 걸려있으면 Lock(쓰기)은 대기, Lock이 걸려있으면 RLock은 대기
-	s.mu.Lock()
-	defer s.mu.Unlock() // isExpired 내부함수에서 데이터 쓰기작업이 포함되어있어 Lock으로 변경
+// 키스페이스 이벤트를 받는 함수. class는 pubsub.Notify* 플래그다.
+// 샤드 락을 잡은 상태에서 호출되므로 블로킹되거나 Store를 다시 호출하면 안 된다.
+// 서로 다른 샤드의 이벤트는 동시에 알려질 수 있다.
+type NotifyFunc func(class int, event, key string)
 
-	entry, exist := s.data[key]
+// New에 넘기는 저장소 설정
+type Option func(*Store)
 
-	if !exist {
-		return "", false
-	} else {
-		if entry.Type != TypeString || s.isExpired(key) {
-			return "", false
-		}
+// 만료 시각을 계산할 시계를 정한다. 주지 않으면 SystemClock을 쓴다.
+func WithClock(clock Clock) Option {
+	return func(s *Store) {
+		s.clock = clock
 	}
-
-	return entry.Str, exist
 }
 
-// 키가 존재하지 않으면 새 리스트를 생성한다
-// 키가 존재하지만 TypeList가 아니면 ErrWrongType을 반환한다
-func (s *Store) LPush(key string, values ...string) (int, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entry, exist := s.data[key]
-
-	if !exist || s.isExpired(key) {
-		newEntry := Entry{Type: TypeList, List: NewList()}
-		entry = &newEntry
-		s.data[key] = entry
+func New(options ...Option) *Store {
+	shards := make([]*shard, shardCount)
+	for i := range shards {
+		shards[i] = newShard()
 	}
-
-	if entry.Type != TypeList {
-		return 0, ErrWrongType
+	store := &Store{
+		shards:  shards,
+		data:    shardedKeyspace(shards),
+		done:    make(chan struct{}),
+		blocked: make(map[string][]*waiter),
+		clock:   SystemClock,
 	}
-
-	for _, v := range values {
-		entry.List.LPush(v)
+	for _, option := range options {
+		option(store)
 	}
-
-	return entry.List.Length, nil
+	store.expiryHz.Store(DefaultExpiryHz)
+	return store
 }
 
-func (s *Store) RPush(key string, values ...string) (int, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	entry, exist := s.data[key]
-
-	if !exist || s.isExpired(key) {
-		newEntry := Entry{Type: TypeList, List: NewList()}
-		entry = &newEntry
-		s.data[key] = entry
-	}
+// 저장소 시계의 현재 시각. 상대 시간(EX, PX 등)을 만료 시각으로 바꿀 때 쓴다.
+func (s *Store) Now() time.Time {
+	return s.clock.Now()
+}
 
-	if entry.Type != TypeList {
-		return 0, ErrWrongType
-	}
+// 키스페이스 이벤트를 받을 함수를 등록한다. 서버 시작 전에 호출해야 한다.
+func (s *Store) SetNotifier(notify NotifyFunc) {
+	s.notify = notify
+}
 
-	for _, v := range values {
-		entry.List.RPush(v)
-	}
+func (s *Store) Set(key, value string) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
 
-	return entry.List.Length, nil
+	s.setLocked(key, value)
 }
 
-// 빈 리스트가 되면 키를 삭제한다 (Redis 동작)
-func (s *Store) LPop(key string) (string, bool, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+func (s *Store) Get(key string) (string, bool) {
+	// 서로다른 고루틴 간의 읽기 작업에서는 블로킹 없이 동시에 통과
+	// A 고루틴이 쓰기 작업 도중, B 고루틴이 데이터를 읽고 있다면 데이터 불일치 현상이 생길 수 있기때문에
+	// RLock(읽기)이 걸려있으면 Lock(쓰기)은 대기, Lock이 걸려있으면 RLock은 대기
+	// 만료된 키는 지우지 않고 없는 것으로만 보므로 읽기 락으로 충분하다
+	locks := s.rlock(key)
+	defer s.runlock(locks)
 
-	entry, exist := s.data[key]
+	entry, exist := s.data.Get(key)
 
-	if !exist {
-		return "", exist, nil
+	if !exist || s.isExpired(key) {
+		s.notifyKeyMiss(key)
+		return "", false
 	}
-
-	if entry.Type != TypeList {
-		return "", false, ErrWrongType
+	if entry.Type != TypeString {
+		return "", false
 	}
 
-	if s.isExpired(key) {
-		return "", false, nil
-	}
+	return entry.StringValue(), exist
+}
 
-	value, result := entry.List.LPop()
+// EXPIRE 계열 명령어의 조건 플래그 (NX/XX/GT/LT). 0이면 항상 설정한다
+type ExpireCondition int
 
-	if entry.List.Length == 0 {
-		delete(s.data, key)
-	}
+const (
+	// 만료 시간이 없을 때만
+	ExpireNX ExpireCondition = 1 << iota
+	// 만료 시간이 있을 때만
+	ExpireXX
+	// 새 만료 시각이 기존보다 늦을 때만. 만료 시간이 없으면 무한대로 본다
+	ExpireGT
+	// 새 만료 시각이 기존보다 이를 때만. 만료 시간이 없으면 무한대로 본다
+	ExpireLT
+)
 
-	return value, result, nil
+// 키에 만료 시간을 설정한다.
+// 키가 존재하면 1, 존재하지 않으면 0을 반환한다.
+func (s *Store) Expire(key string, seconds int) int {
+	return s.ExpireAt(key, s.clock.Now().Add(time.Duration(seconds)*time.Second), 0)
 }
-func (s *Store) RPop(key string) (string, bool, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
 
-	entry, exist := s.data[key]
+// 키의 만료 시각을 at으로 설정한다. at이 이미 지났으면 키를 바로 삭제한다.
+// 설정(또는 삭제)했으면 1, 키가 없거나 조건을 만족하지 않으면 0을 반환한다.
+func (s *Store) ExpireAt(key string, at time.Time, condition ExpireCondition) int {
+	locks := s.lock(key)
+	defer s.unlock(locks)
 
-	if !exist {
-		return "", exist, nil
+	if !s.exists(key) {
+		return 0
 	}
+	entry, _ := s.data.Get(key)
 
-	if entry.Type != TypeList {
-		return "", false, ErrWrongType
+	current := entry.ExpireAt
+	if condition&ExpireNX != 0 && current != nil {
+		return 0
 	}
-
-	if s.isExpired(key) {
-		return "", false, nil
+	if condition&ExpireXX != 0 && current == nil {
+		return 0
 	}
-
-	value, result := entry.List.RPop()
-
-	if entry.List.Length == 0 {
-		delete(s.data, key)
+	if condition&ExpireGT != 0 && (current == nil || !at.After(*current)) {
+		return 0
+	}
+	if condition&ExpireLT != 0 && current != nil && !at.Before(*current) {
+		return 0
 	}
 
-	return value, result, nil
+	if !at.After(s.clock.Now()) {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+		return 1
+	}
+	s.setExpireLocked(key, entry, at)
+	s.notifyEvent(pubsub.NotifyGeneric, "expire", key)
+	return 1
 }
 
-func (s *Store) LRange(key string, start, stop int) ([]string, error) {
-	s.mu.Lock()
-	defer s.mu.Unlock()
+// 키의 남은 수명(초, 반올림)을 반환한다.
+// TTL이 없으면 -1, 키가 존재하지 않으면 -2를 반환한다.
+func (s *Store) TTL(key string) int {
+	ttl := s.PTTL(key)
+	if ttl < 0 {
+		return int(ttl)
+	}
+	return int((ttl + 500) / 1000)
+}
 
-	entry, exist := s.data[key]
+// 키의 남은 수명(밀리초)을 반환한다.
+// TTL이 없으면 -1, 키가 존재하지 않으면 -2를 반환한다.
+func (s *Store) PTTL(key string) int64 {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
 
+	entry, exist := s.data.Get(key)
 	if !exist {
-		return []string{}, nil
-	}
-
-	if entry.Type != TypeList {
-		return nil, ErrWrongType
+		return -2
 	}
-
-	if s.isExpired(key) {
-		return []string{}, nil
+	if entry.ExpireAt == nil {
+		return -1
 	}
-
-	result := entry.List.Range(start, stop)
-
-	return result, nil
-}
-
-// 키에 만료 시간을 설정한다.
-// 키가 존재하면 1, 존재하지 않으면 0을 반환한다.
-func (s *Store) Expire(key string, seconds int) int {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	entry, exist := s.data[key]
-
-	if exist {
-		expire := time.Now().Add(time.Duration(seconds) * time.Second)
-		entry.ExpireAt = &expire
-		s.heap.Push(&HeapItem{Key: key, ExpireAt: expire})
-		return 1
-	} else {
-		return 0
+	// 만료 시간이 지났으면 아직 지워지지 않았어도 없는 키로 본다
+	ttl := entry.ExpireAt.Sub(s.clock.Now()).Milliseconds()
+	if ttl < 0 {
+		return -2
 	}
+	return ttl
 }
 
-// 키의 남은 수명(초)을 반환한다.
+// 키가 만료되는 유닉스 시각(밀리초)을 반환한다.
 // TTL이 없으면 -1, 키가 존재하지 않으면 -2를 반환한다.
-func (s *Store) TTL(key string) int {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
-	entry, exist := s.data[key]
+func (s *Store) PExpireTime(key string) int64 {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
 
-	if exist {
-		if entry.ExpireAt == nil {
-			return -1
-		}
-		return int(time.Until(*entry.ExpireAt).Seconds())
-	} else {
+	entry, exist := s.data.Get(key)
+	if !exist || (entry.ExpireAt != nil && entry.ExpireAt.Before(s.clock.Now())) {
 		return -2
 	}
-}
-
-// 키를 삭제한다. 삭제된 키의 개수(0 또는 1)를 반환한다.
-func (s *Store) Del(key string) int {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	_, exist := s.data[key]
-	if !exist {
-		return 0
-	} else {
-		delete(s.data, key)
-		return 1
+	if entry.ExpireAt == nil {
+		return -1
 	}
+	return entry.ExpireAt.UnixMilli()
 }
 
 // 키에서 만료 시간을 제거한다.
 // TTL이 존재하고 제거했으면 1, 아니면 0을 반환한다.
 func (s *Store) Persist(key string) int {
-	s.mu.Lock()
-	defer s.mu.Unlock()
-	entry, exist := s.data[key]
+	locks := s.lock(key)
+	defer s.unlock(locks)
+	entry, exist := s.data.Get(key)
 
 	if !exist || entry.ExpireAt == nil {
 		return 0
 	} else {
-		entry.ExpireAt = nil
+		s.clearExpireLocked(key, entry)
+		s.notifyEvent(pubsub.NotifyGeneric, "persist", key)
 		return 1
 	}
 }
 
-// 키가 만료되었는지 확인하고, 만료되었으면 삭제한다.
-// mu.Lock()을 잡고 있는 상태에서 호출해야 한다 (내부용).
+// 엔트리의 만료 시각을 설정하고 만료 힙 항목을 맞춘다.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) setExpireLocked(key string, entry *Entry, at time.Time) {
+	entry.ExpireAt = &at
+	s.shardFor(key).heap.Set(key, at)
+}
+
+// 엔트리의 만료 시간을 없애고 만료 힙에서 뺀다.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) clearExpireLocked(key string, entry *Entry) {
+	entry.ExpireAt = nil
+	s.shardFor(key).heap.Remove(key)
+}
+
+// 키가 만료되었는지 확인한다. 삭제는 하지 않으므로 읽기 락만 잡은 상태에서도 호출할 수 있다.
+// 만료된 키는 읽기에서는 없는 것으로 보고, 실제 삭제는 다음에 그 키의 쓰기 락을 잡을 때(expireIfNeeded)나
+// 백그라운드 만료 루프에 맡긴다.
+// 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
 func (s *Store) isExpired(key string) bool {
-	expire := s.data[key].ExpireAt
+	entry, exist := s.data.peek(key)
+	return exist && entry.ExpireAt != nil && entry.ExpireAt.Before(s.clock.Now())
+}
 
-	if expire == nil {
+// 키가 만료되었으면 삭제하고 expired 이벤트를 알린다. 삭제했으면 true.
+// 키의 샤드 쓰기 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) expireIfNeeded(key string) bool {
+	if !s.isExpired(key) {
 		return false
 	}
+	s.data.Delete(key)
+	s.expiredKeys.Add(1)
+	s.notifyEvent(pubsub.NotifyExpired, "expired", key)
+	return true
+}
 
-	if expire.Before(time.Now()) {
-		delete(s.data, key)
-		return true
-	} else {
-		return false
+// 조회한 키가 없음을 알린다 (keymiss). 값을 바꾸지 않으므로 읽기 락만 잡은 상태에서도 호출할 수 있다.
+func (s *Store) notifyKeyMiss(key string) {
+	if s.notify != nil {
+		s.notify(pubsub.NotifyKeyMiss, "keymiss", key)
 	}
 }
 
-// 백그라운드 만료 처리를 시작한다.
-// 1초마다 힙을 확인하고 만료된 키를 삭제한다.
-func (s *Store) StartExpiry() {
-	go func() {
-		ticker := time.NewTicker(1 * time.Second)
-		defer ticker.Stop()
-
-		for {
-			select {
-			case <-s.done:
-				return
-			case <-ticker.C:
-				s.mu.Lock()
-				for {
-					item := s.heap.Peek()
-					if item == nil || !item.ExpireAt.Before(time.Now()) {
-						break
-					}
-					s.heap.Pop()
-
-					entry, exist := s.data[item.Key]
-					if exist && entry.ExpireAt != nil && entry.ExpireAt.Equal(item.ExpireAt) {
-						delete(s.data, item.Key)
-					}
-				}
-				s.mu.Unlock()
-			}
-		}
-	}()
+// 키스페이스 이벤트를 알린다. 등록된 알림 함수가 없으면 아무것도 하지 않는다.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) notifyEvent(class int, event, key string) {
+	// 이벤트는 값을 바꾼 뒤에 알리므로, 여기서 바뀐 엔트리의 크기를 다시 잰다
+	s.data.remeasure(key)
+	if s.notify != nil {
+		s.notify(class, event, key)
+	}
 }
 
-// 백그라운드 만료 처리를 중지한다.
-func (s *Store) StopExpiry() {
-	close(s.done)
+// 저장소 하나를 0번 데이터베이스로 스냅샷 파일에 저장한다.
+func (s *Store) Save(path string) error {
+	return SaveAll(path, []*Store{s})
 }
 
-func (s *Store) Save(path string) error {
-	s.mu.RLock()
-	defer s.mu.RUnlock()
+// 데이터베이스들을 하나의 스냅샷 파일에 저장한다. dbs[i]의 키는 i번 데이터베이스로 기록된다.
+// 임시 파일에 다 쓰고 디스크에 내린 뒤 바꿔치기하므로, 쓰다가 실패하거나 죽어도 이전 스냅샷은 남는다.
+func SaveAll(path string, dbs []*Store) error {
+	tmp := path + ".tmp"
+	if err := writeSnapshot(tmp, dbs); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
 
+func writeSnapshot(path string, dbs []*Store) error {
 	file, err := os.Create(path)
 	if err != nil {
 		return err
@@ -318,31 +347,86 @@ func (s *Store) Save(path string) error {
 	encoder := persistence.NewEncoder(file)
 	encoder.WriteHeader()
 
-	for key, entry := range s.data {
+	for i, db := range dbs {
+		db.writeEntries(encoder, i)
+	}
+
+	encoder.WriteEOF()
+	encoder.WriteChecksum()
+	if err := encoder.Flush(); err != nil {
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// 키들을 인코더에 쓴다. 0번이 아닌 데이터베이스는 앞에 데이터베이스 번호(SelectDB)를 기록한다.
+// SelectDB가 없는 엔트리는 0번으로 읽히므로, 데이터베이스가 하나뿐인 스냅샷은 이전 형식과 같다.
+func (s *Store) writeEntries(encoder *persistence.Encoder, index int) {
+	locks := s.rlockAll()
+	defer s.runlock(locks)
+
+	if s.data.Len() == 0 {
+		return
+	}
+	if index != 0 {
+		encoder.WriteSelectDB(index)
+	}
+
+	s.data.Range(func(key string, entry *Entry) bool {
 		// 이미 만료된 키는 저장할 필요 없으니 건너뛴다.
-		// isExpired()는 삭제(쓰기)를 하기 때문에 RLock 상태에서 호출 불가
-		// 읽기 전용으로 만료 여부만 확인한다.
-		if entry.ExpireAt != nil && entry.ExpireAt.Before(time.Now()) {
-			continue
+		if entry.ExpireAt != nil && entry.ExpireAt.Before(s.clock.Now()) {
+			return true
 		}
 
 		switch entry.Type {
 		case TypeString:
-			encoder.WriteStringEntry(key, entry.Str, entry.ExpireAt)
+			encoder.WriteStringEntry(key, entry.StringValue(), entry.ExpireAt)
 
 		case TypeList:
 			values := entry.List.Range(0, entry.List.Length-1)
 			encoder.WriteListEntry(key, values, entry.ExpireAt)
 
-		}
-	}
+		case TypeHash:
+			fields := make([]string, 0, entry.Hash.Len())
+			values := make([]string, 0, entry.Hash.Len())
+			entry.Hash.Range(func(field, value string) bool {
+				fields = append(fields, field)
+				values = append(values, value)
+				return true
+			})
+			encoder.WriteHashEntry(key, fields, values, entry.ExpireAt)
+
+		case TypeSet:
+			encoder.WriteSetEntry(key, entry.Set.Members(), entry.ExpireAt)
+
+		case TypeZSet:
+			members := make([]string, 0, entry.ZSet.Len())
+			scores := make([]float64, 0, entry.ZSet.Len())
+			entry.ZSet.Range(func(member string, score float64) bool {
+				members = append(members, member)
+				scores = append(scores, score)
+				return true
+			})
+			encoder.WriteZSetEntry(key, members, scores, entry.ExpireAt)
 
-	encoder.WriteEOF()
-	encoder.WriteChecksum()
-	return encoder.Flush()
+		case TypeStream:
+			encoder.WriteStreamEntry(key, streamToPersistence(entry.Stream), entry.ExpireAt)
+		}
+		return true
+	})
 }
 
+// 스냅샷 파일을 읽어 저장소 하나에 불러온다. 모든 키를 0번 데이터베이스의 것으로 본다.
 func (s *Store) Load(path string) error {
+	return LoadAll(path, []*Store{s})
+}
+
+// 스냅샷 파일을 읽어 키를 기록된 번호의 데이터베이스(dbs[i])에 불러온다.
+// 파일이 없으면 아무것도 하지 않는다.
+func LoadAll(path string, dbs []*Store) error {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -371,36 +455,78 @@ func (s *Store) Load(path string) error {
 			break
 		}
 
-		if entry.ExpireAt != nil && entry.ExpireAt.Before(time.Now()) {
+		if entry.DB >= len(dbs) {
+			return fmt.Errorf("스냅샷의 데이터베이스 번호 %d가 데이터베이스 수(%d)를 넘습니다", entry.DB, len(dbs))
+		}
+		db := dbs[entry.DB]
+		if entry.ExpireAt != nil && entry.ExpireAt.Before(db.clock.Now()) {
 			continue
 		}
+		db.loadEntry(entry)
+	}
+	return nil
+}
 
-		switch entry.Type {
-		case persistence.TypeString:
-			s.data[entry.Key] = &Entry{
-				Type:     TypeString,
-				Str:      entry.Value,
-				ExpireAt: entry.ExpireAt,
-			}
-
-		case persistence.TypeList:
-			list := NewList()
-			for _, v := range entry.Values {
-				list.RPush(v)
-			}
-			s.data[entry.Key] = &Entry{
-				Type:     TypeList,
-				List:     list,
-				ExpireAt: entry.ExpireAt,
-			}
+// 디코딩한 엔트리 하나를 저장소에 넣는다.
+func (s *Store) loadEntry(entry *persistence.DecodedEntry) {
+	locks := s.lock(entry.Key)
+	defer s.unlock(locks)
+
+	switch entry.Type {
+	case persistence.TypeString:
+		stringEntry := newStringEntry(entry.Value)
+		stringEntry.ExpireAt = entry.ExpireAt
+		s.data.Set(entry.Key, stringEntry)
+
+	case persistence.TypeList:
+		list := NewList()
+		for _, v := range entry.Values {
+			list.RPush(v)
 		}
-
-		if entry.ExpireAt != nil {
-			s.heap.Push(&HeapItem{
-				Key:      entry.Key,
-				ExpireAt: *entry.ExpireAt,
-			})
+		s.data.Set(entry.Key, &Entry{
+			Type:     TypeList,
+			List:     list,
+			ExpireAt: entry.ExpireAt,
+		})
+
+	case persistence.TypeHash:
+		hash := NewDict[string]()
+		for i, field := range entry.Fields {
+			hash.Set(field, entry.Values[i])
+		}
+		s.data.Set(entry.Key, &Entry{
+			Type:     TypeHash,
+			Hash:     hash,
+			ExpireAt: entry.ExpireAt,
+		})
+
+	case persistence.TypeSet:
+		set := NewSet()
+		for _, member := range entry.Values {
+			set.Add(member)
 		}
+		s.data.Set(entry.Key, &Entry{
+			Type:     TypeSet,
+			Set:      set,
+			ExpireAt: entry.ExpireAt,
+		})
+
+	case persistence.TypeZSet:
+		zset := NewZSet()
+		for i, member := range entry.Values {
+			zset.Set(member, entry.Scores[i])
+		}
+		s.data.Set(entry.Key, &Entry{
+			Type:     TypeZSet,
+			ZSet:     zset,
+			ExpireAt: entry.ExpireAt,
+		})
+
+	case persistence.TypeStream:
+		s.data.Set(entry.Key, &Entry{
+			Type:     TypeStream,
+			Stream:   streamFromPersistence(entry.Stream),
+			ExpireAt: entry.ExpireAt,
+		})
 	}
-	return nil
 }
@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"inmemory-db/internal/pubsub"
+	"slices"
+	"sync"
+)
+
+// 두 저장소의 락을 함께 잡는 작업(MOVE, SWAPDB)을 직렬화한다.
+// 한 번에 하나만 두 락을 잡으므로 서로 반대 순서로 잡다가 교착되는 일이 없다.
+var pairMu sync.Mutex
+
+// 키를 source 저장소에서 destination 저장소로 옮긴다. 만료 시간도 함께 옮긴다.
+// source에 키가 없거나 destination에 이미 같은 키가 있으면 false.
+func Move(source, destination *Store, key string) (bool, error) {
+	if source == destination {
+		return false, ErrSameObject
+	}
+
+	pairMu.Lock()
+	defer pairMu.Unlock()
+	sourceLocks := source.lock(key)
+	defer source.unlock(sourceLocks)
+	destinationLocks := destination.lock(key)
+	defer destination.unlock(destinationLocks)
+
+	if !source.exists(key) || destination.exists(key) {
+		return false, nil
+	}
+
+	entry, _ := source.data.Get(key)
+	source.data.Delete(key)
+	source.notifyEvent(pubsub.NotifyGeneric, "move_from", key)
+
+	destination.data.Set(key, entry)
+	destination.notifyEvent(pubsub.NotifyNew, "new", key)
+	destination.notifyEvent(pubsub.NotifyGeneric, "move_to", key)
+	destination.serveBlocked(key)
+	return true, nil
+}
+
+// 두 저장소의 내용(샤드별 키 공간과 만료 힙)을 맞바꾼다 (SWAPDB).
+// 모든 저장소가 같은 규칙으로 키를 샤드에 배정하므로 같은 번호의 샤드끼리 바꾸면 된다.
+// 각 저장소에서 대기 중인 블로킹 명령어는 바뀐 내용으로 다시 처리를 시도한다.
+func Swap(a, b *Store) {
+	if a == b {
+		return
+	}
+
+	pairMu.Lock()
+	defer pairMu.Unlock()
+	aLocks := a.lockAll()
+	defer a.unlock(aLocks)
+	bLocks := b.lockAll()
+	defer b.unlock(bLocks)
+
+	for i := range a.shards {
+		x, y := a.shards[i], b.shards[i]
+		x.data, y.data = y.data, x.data
+		x.heap, y.heap = y.heap, x.heap
+	}
+
+	a.serveAllBlocked()
+	b.serveAllBlocked()
+}
+
+// 모든 키를 지운다 (FLUSHDB). async면 지운 값의 해체를 별도 고루틴에서 한다.
+func (s *Store) Flush(async bool) {
+	locks := s.lockAll()
+	defer s.unlock(locks)
+
+	old := make(shardedKeyspace, len(s.shards))
+	for i, sh := range s.shards {
+		old[i] = &shard{data: sh.data}
+		sh.data = newKeyspace()
+		sh.heap = NewMinHeap()
+	}
+
+	if async && old.Len() > 0 {
+		go old.Range(func(key string, entry *Entry) bool {
+			entry.release()
+			return true
+		})
+	}
+}
+
+// 대기 중인 클라이언트가 있는 모든 키를 처리 대상으로 기록한다. 락을 풀 때 처리된다.
+// 모든 샤드의 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) serveAllBlocked() {
+	s.blockedMu.Lock()
+	defer s.blockedMu.Unlock()
+
+	for key := range s.blocked {
+		if !slices.Contains(s.ready, key) {
+			s.ready = append(s.ready, key)
+		}
+	}
+	s.hasReady.Store(len(s.ready) > 0)
+}
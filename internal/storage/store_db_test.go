@@ -0,0 +1,168 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMove(t *testing.T) {
+	// given
+	source, destination := New(), New()
+	source.Set("key", "v")
+	at := time.Now().Add(time.Minute)
+	source.ExpireAt("key", at, 0)
+	source.Set("both", "1")
+	destination.Set("both", "2")
+
+	// when & then
+	if _, err := Move(source, source, "key"); err != ErrSameObject {
+		t.Fatalf("같은 저장소 Move 에러: %v", err)
+	}
+	if moved, _ := Move(source, destination, "both"); moved {
+		t.Fatal("대상에 이미 있는 키를 옮겼습니다")
+	}
+	if moved, _ := Move(source, destination, "missing"); moved {
+		t.Fatal("없는 키를 옮겼습니다")
+	}
+	if moved, _ := Move(source, destination, "key"); !moved {
+		t.Fatal("Move 실패")
+	}
+	if source.Exists("key") != 0 {
+		t.Fatal("Move 후 원래 저장소에 키가 남아 있습니다")
+	}
+	if expireTime := destination.PExpireTime("key"); expireTime != at.UnixMilli() {
+		t.Fatalf("Move 후 PExpireTime: %d, expected: %d", expireTime, at.UnixMilli())
+	}
+}
+
+func TestSwap(t *testing.T) {
+	// given
+	a, b := New(), New()
+	a.Set("in-a", "1")
+	b.Set("in-b", "2")
+	b.ExpireAt("in-b", time.Now().Add(time.Minute), 0)
+
+	// when
+	Swap(a, b)
+
+	// then
+	if a.Exists("in-b") != 1 || a.Exists("in-a") != 0 {
+		t.Fatal("Swap 후 a의 내용이 다릅니다")
+	}
+	if b.Exists("in-a") != 1 || b.Exists("in-b") != 0 {
+		t.Fatal("Swap 후 b의 내용이 다릅니다")
+	}
+	if item := a.shardFor("in-b").heap.Peek(); item == nil || item.Key != "in-b" {
+		t.Fatalf("Swap 후 a의 만료 힙: %+v", item)
+	}
+}
+
+func TestSwap_ServesBlockedClients(t *testing.T) {
+	// given: a에서 빈 리스트를 기다리는 BLPOP
+	a, b := New(), New()
+	b.RPush("queue", "job")
+	result := make(chan string, 1)
+	go func() {
+		_, value, _, _ := a.BlockingPop(context.Background(), []string{"queue"}, true)
+		result <- value
+	}()
+	waitForBlocked(t, a, 1)
+
+	// when
+	Swap(a, b)
+
+	// then
+	select {
+	case value := <-result:
+		if value != "job" {
+			t.Fatalf("깨어난 BLPOP 값: %s", value)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Swap 후 BLPOP이 깨어나지 않았습니다")
+	}
+}
+
+func TestFlush(t *testing.T) {
+	// given
+	store := New()
+	store.Set("a", "1")
+	store.RPush("l", "x")
+	store.ExpireAt("a", time.Now().Add(time.Minute), 0)
+
+	// when
+	store.Flush(true)
+
+	// then
+	if size := store.DBSize(); size != 0 {
+		t.Fatalf("Flush 후 DBSize: %d", size)
+	}
+	for _, sh := range store.shards {
+		if item := sh.heap.Peek(); item != nil {
+			t.Fatalf("Flush 후 만료 힙: %+v", item)
+		}
+	}
+}
+
+func TestSaveAllAndLoadAll(t *testing.T) {
+	// given: 0번과 2번 데이터베이스에 같은 이름의 키
+	path := filepath.Join(t.TempDir(), "dump.rdb")
+	dbs := []*Store{New(), New(), New()}
+	dbs[0].Set("key", "zero")
+	dbs[2].Set("key", "two")
+
+	// when
+	if err := SaveAll(path, dbs); err != nil {
+		t.Fatalf("SaveAll 에러: %v", err)
+	}
+	loaded := []*Store{New(), New(), New()}
+	if err := LoadAll(path, loaded); err != nil {
+		t.Fatalf("LoadAll 에러: %v", err)
+	}
+
+	// then
+	if value, _ := loaded[0].Get("key"); value != "zero" {
+		t.Fatalf("0번 key: %s", value)
+	}
+	if loaded[1].DBSize() != 0 {
+		t.Fatal("1번 데이터베이스가 비어있지 않습니다")
+	}
+	if value, _ := loaded[2].Get("key"); value != "two" {
+		t.Fatalf("2번 key: %s", value)
+	}
+	if err := LoadAll(path, []*Store{New()}); err == nil {
+		t.Fatal("데이터베이스 수가 부족한데 LoadAll이 성공했습니다")
+	}
+}
+
+func TestSaveAll_FailureKeepsPreviousSnapshot(t *testing.T) {
+	// given: 이전 스냅샷이 있고, 임시 파일 자리에 디렉터리가 있어 새로 쓸 수 없다
+	path := filepath.Join(t.TempDir(), "dump.rdb")
+	old := New()
+	old.Set("key", "old")
+	if err := old.Save(path); err != nil {
+		t.Fatalf("Save 에러: %v", err)
+	}
+	if err := os.Mkdir(path+".tmp", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// when
+	current := New()
+	current.Set("key", "new")
+	err := current.Save(path)
+
+	// then: 실패해도 이전 스냅샷은 그대로 읽힌다
+	if err == nil {
+		t.Fatal("Save가 실패하지 않았습니다")
+	}
+	loaded := New()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load 에러: %v", err)
+	}
+	if value, _ := loaded.Get("key"); value != "old" {
+		t.Fatalf("key: %s, expected: old", value)
+	}
+}
@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// 백그라운드 만료 루프의 기본 초당 실행 횟수 (Redis hz)
+const DefaultExpiryHz = 10
+
+// 설정할 수 있는 초당 실행 횟수의 범위
+const (
+	MinExpiryHz = 1
+	MaxExpiryHz = 500
+)
+
+const (
+	// 한 주기에 쓸 수 있는 시간. 주기 간격에 대한 비율(%)이다 (Redis ACTIVE_EXPIRE_CYCLE_SLOW_TIME_PERC)
+	expireCycleTimePercent = 25
+	// 샤드 락을 한 번 잡고 지우는 최대 키 수. 대량 만료 중에도 다른 명령어가 끼어들 수 있게 한다
+	expireBatchSize = 20
+	// 만료됐지만 남아 있는 키의 비율을 추정할 때 샤드마다 뽑는 만료 힙 항목 수
+	expireStaleSamples = 20
+	// 만료됐지만 남아 있는 키가 이 비율(%)을 넘으면 다음 주기를 앞당긴다
+	expireAcceptableStalePercent = 10
+)
+
+// 만료 처리 통계
+type ExpiryStats struct {
+	// 만료 시간이 있는 키 수
+	Volatile int
+	// 만료로 지운 키 수 (조회 시 삭제와 백그라운드 삭제를 합친 값)
+	Expired int64
+	// 만료됐지만 아직 지워지지 않은 키 비율(%)의 추정치
+	StalePercent float64
+	// 시간 제한에 걸려 중간에 멈춘 주기 수
+	TimeCapReached int64
+}
+
+// 백그라운드 만료 처리를 시작한다.
+// 초당 hz번 샤드별 힙에서 시각이 지난 키를 지운다. 한 주기는 주기 간격의 25%까지만 쓰고,
+// 지울 키가 남아 있으면 다음 주기가 멈춘 샤드부터 이어서 처리한다.
+// 시간 제한에 걸렸거나 만료됐지만 남아 있는 키가 많으면 주기 간격을 줄여 더 자주 실행한다.
+func (s *Store) StartExpiry() {
+	go func() {
+		timer := time.NewTimer(s.expiryPeriod())
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-s.done:
+				return
+			case <-timer.C:
+				capped := s.activeExpireCycle()
+
+				period := s.expiryPeriod()
+				if capped || s.stalePercent() > expireAcceptableStalePercent {
+					period /= 4
+				}
+				timer.Reset(period)
+			}
+		}
+	}()
+}
+
+// 백그라운드 만료 처리를 중지한다. 여러 번 호출해도 된다.
+func (s *Store) StopExpiry() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// 백그라운드 만료 루프의 초당 실행 횟수를 바꾼다. 범위를 벗어나면 가까운 경계값을 쓴다.
+// 다음 주기부터 적용된다.
+func (s *Store) SetExpiryHz(hz int) {
+	s.expiryHz.Store(int32(min(max(hz, MinExpiryHz), MaxExpiryHz)))
+}
+
+// 백그라운드 만료 루프의 초당 실행 횟수
+func (s *Store) ExpiryHz() int {
+	return int(s.expiryHz.Load())
+}
+
+// 만료 처리 통계를 반환한다.
+func (s *Store) ExpiryStats() ExpiryStats {
+	stats := ExpiryStats{
+		Expired:        s.expiredKeys.Load(),
+		StalePercent:   s.stalePercent(),
+		TimeCapReached: s.expireCapHits.Load(),
+	}
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		stats.Volatile += sh.heap.Len()
+		sh.mu.RUnlock()
+	}
+	return stats
+}
+
+// 주기 간격
+func (s *Store) expiryPeriod() time.Duration {
+	return time.Second / time.Duration(s.expiryHz.Load())
+}
+
+func (s *Store) stalePercent() float64 {
+	return math.Float64frombits(s.expiredStale.Load())
+}
+
+// 만료 주기 한 번. 지난 주기가 멈춘 샤드부터 돌면서 시각이 지난 키를 지우고,
+// 시간 제한을 넘기면 그 샤드를 기억해 두고 멈춘다. 시간 제한에 걸렸으면 true.
+func (s *Store) activeExpireCycle() bool {
+	deadline := time.Now().Add(s.expiryPeriod() * expireCycleTimePercent / 100)
+
+	defer s.sampleStale()
+
+	start := s.expireCursor
+	s.expireCursor = 0
+	for i := range s.shards {
+		index := (start + i) % len(s.shards)
+		for s.expireShard(s.shards[index], expireBatchSize) {
+			if time.Now().After(deadline) {
+				s.expireCursor = index
+				s.expireCapHits.Add(1)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// 샤드의 만료 힙에서 시각이 지난 키를 최대 limit개 지운다.
+// 지울 키가 더 남아 있으면 true.
+func (s *Store) expireShard(sh *shard, limit int) bool {
+	sh.mu.Lock()
+	defer sh.mu.Unlock()
+
+	now := s.clock.Now()
+	for range limit {
+		item := sh.heap.Peek()
+		if item == nil || !item.ExpireAt.Before(now) {
+			return false
+		}
+		if !s.expireIfNeeded(item.Key) {
+			// 키 공간에 없는 항목이면 힙에서만 뺀다
+			sh.heap.Pop()
+		}
+	}
+
+	item := sh.heap.Peek()
+	return item != nil && item.ExpireAt.Before(now)
+}
+
+// 샤드마다 만료 힙 항목을 무작위로 뽑아 시각이 지난 비율을 재고,
+// 이전 추정치와 섞어 만료됐지만 남아 있는 키 비율을 갱신한다 (Redis expired_stale_perc).
+func (s *Store) sampleStale() {
+	now := s.clock.Now()
+	sampled, stale := 0, 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		if n := len(sh.heap.items); n > 0 {
+			for range min(n, expireStaleSamples) {
+				sampled++
+				if sh.heap.items[rand.IntN(n)].ExpireAt.Before(now) {
+					stale++
+				}
+			}
+		}
+		sh.mu.RUnlock()
+	}
+
+	current := 0.0
+	if sampled > 0 {
+		current = float64(stale) * 100 / float64(sampled)
+	}
+	estimate := current*0.05 + s.stalePercent()*0.95
+	s.expiredStale.Store(math.Float64bits(estimate))
+}
+
+// 누적 만료 통계(지운 키 수, 시간 제한에 걸린 주기 수)를 0으로 되돌린다 (CONFIG RESETSTAT).
+func (s *Store) ResetExpiryStats() {
+	s.expiredKeys.Store(0)
+	s.expireCapHits.Store(0)
+}
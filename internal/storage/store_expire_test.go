@@ -0,0 +1,151 @@
+package storage
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// 샤드별 만료 힙 항목 수의 합
+func heapLen(store *Store) int {
+	n := 0
+	for _, sh := range store.shards {
+		n += sh.heap.Len()
+	}
+	return n
+}
+
+// EXPIRE로는 지난 시각을 줄 수 없으므로(바로 삭제된다) 만료 시각을 직접 과거로 맞춘다.
+func setExpiredAt(store *Store, key string, at time.Time) {
+	locks := store.lock(key)
+	defer store.unlock(locks)
+	entry, _ := store.data.Get(key)
+	store.setExpireLocked(key, entry, at)
+}
+
+func TestExpiryHeapIsBounded(t *testing.T) {
+	// given
+	store := New()
+	for i := range 100 {
+		key := fmt.Sprintf("key:%d", i)
+		store.Set(key, "v")
+		store.Expire(key, 100)
+	}
+
+	// when: 만료 시간을 여러 번 바꾸고, 일부는 PERSIST, 덮어쓰기, 삭제, 이름 변경
+	for i := range 100 {
+		key := fmt.Sprintf("key:%d", i)
+		for seconds := range 5 {
+			store.Expire(key, 200+seconds)
+		}
+		switch i % 5 {
+		case 0:
+			store.Persist(key)
+		case 1:
+			store.Set(key, "overwritten")
+		case 2:
+			store.Del(key)
+		case 3:
+			store.Rename(key, key+":renamed")
+		}
+	}
+
+	// then: 힙에는 만료 시간이 남아 있는 키만 하나씩 있다
+	if n := heapLen(store); n != 40 {
+		t.Fatalf("만료 힙 크기: %d, expected: 40", n)
+	}
+	if volatile := store.ExpiryStats().Volatile; volatile != 40 {
+		t.Fatalf("Volatile: %d, expected: 40", volatile)
+	}
+}
+
+func TestSetKeepTTLStaysInHeap(t *testing.T) {
+	// given
+	store := New()
+	store.Set("key", "v")
+	store.Expire("key", 100)
+
+	// when
+	store.SetWithOptions("key", "v2", SetOptions{KeepTTL: true})
+
+	// then
+	if n := heapLen(store); n != 1 {
+		t.Fatalf("만료 힙 크기: %d, expected: 1", n)
+	}
+}
+
+func TestActiveExpireCycleIsTimeBudgeted(t *testing.T) {
+	// given: 만료 시각이 지난 키가 많고, 한 주기에 쓸 수 있는 시간이 매우 짧다
+	store := New()
+	past := time.Now().Add(-time.Minute)
+	for i := range 20000 {
+		key := fmt.Sprintf("key:%d", i)
+		store.Set(key, "v")
+		setExpiredAt(store, key, past.Add(time.Duration(i)*time.Millisecond))
+	}
+	store.SetExpiryHz(MaxExpiryHz)
+
+	// when: 주기를 한 번 실행
+	capped := store.activeExpireCycle()
+
+	// then: 시간 제한에 걸려 일부만 지우고, 남은 키는 다음 주기들이 이어서 지운다
+	stats := store.ExpiryStats()
+	if !capped || stats.TimeCapReached != 1 || stats.Expired == 0 || stats.Expired >= 20000 {
+		t.Fatalf("한 주기 결과: capped=%v %+v", capped, stats)
+	}
+	for store.activeExpireCycle() {
+	}
+	stats = store.ExpiryStats()
+	if stats.Expired != 20000 || stats.Volatile != 0 || store.DBSize() != 0 {
+		t.Fatalf("모든 주기 후: %+v, DBSize: %d", stats, store.DBSize())
+	}
+}
+
+func TestExpiryStalePercent(t *testing.T) {
+	// given: 만료 시간이 있는 키의 절반이 만료 시각이 지났다
+	store := New()
+	for i := range 200 {
+		key := fmt.Sprintf("key:%d", i)
+		store.Set(key, "v")
+		if i%2 == 0 {
+			setExpiredAt(store, key, time.Now().Add(-time.Second))
+		} else {
+			store.Expire(key, 100)
+		}
+	}
+
+	// when: 추정만 여러 번 갱신
+	for range 100 {
+		store.sampleStale()
+	}
+
+	// then: 추정치가 50% 근처로 올라간다
+	if stale := store.ExpiryStats().StalePercent; stale < 30 || stale > 70 {
+		t.Fatalf("StalePercent: %f, expected: 약 50", stale)
+	}
+}
+
+func TestSetExpiryHzClamps(t *testing.T) {
+	// given
+	store := New()
+
+	// when && then
+	store.SetExpiryHz(0)
+	if hz := store.ExpiryHz(); hz != MinExpiryHz {
+		t.Fatalf("hz: %d, expected: %d", hz, MinExpiryHz)
+	}
+	store.SetExpiryHz(100000)
+	if hz := store.ExpiryHz(); hz != MaxExpiryHz {
+		t.Fatalf("hz: %d, expected: %d", hz, MaxExpiryHz)
+	}
+}
+
+func TestStopExpiryTwice(t *testing.T) {
+	// given
+	store := New()
+	store.StartExpiry()
+
+	// when & then: 두 번 불러도 패닉하지 않는다
+	store.StopExpiry()
+	store.StopExpiry()
+}
@@ -0,0 +1,336 @@
+package storage
+
+import (
+	"errors"
+	"inmemory-db/internal/glob"
+	"inmemory-db/internal/pubsub"
+	"math"
+	"math/rand/v2"
+	"strconv"
+)
+
+var (
+	ErrHashValueNotInteger = errors.New("hash value is not an integer")
+	ErrHashValueNotFloat   = errors.New("hash value is not a float")
+	ErrIncrOverflow        = errors.New("increment or decrement would overflow")
+	ErrIncrNaNOrInfinity   = errors.New("increment would produce NaN or Infinity")
+)
+
+// 필드와 값을 저장한다. fieldValues는 field1, value1, field2, value2, ... 순서다.
+// 키가 없으면 새 해시를 만들고, 새로 추가된 필드 수를 반환한다.
+func (s *Store) HSet(key string, fieldValues ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupOrCreateHash(key)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for i := 0; i+1 < len(fieldValues); i += 2 {
+		if entry.Hash.Set(fieldValues[i], fieldValues[i+1]) {
+			added++
+		}
+	}
+	s.notifyEvent(pubsub.NotifyHash, "hset", key)
+	return added, nil
+}
+
+// 필드가 없을 때만 저장한다. 저장했으면 true
+func (s *Store) HSetNX(key, field, value string) (bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupOrCreateHash(key)
+	if err != nil {
+		return false, err
+	}
+	if _, exist := entry.Hash.Get(field); exist {
+		return false, nil
+	}
+
+	entry.Hash.Set(field, value)
+	s.notifyEvent(pubsub.NotifyHash, "hset", key)
+	return true, nil
+}
+
+// 필드 값을 조회한다. 키나 필드가 없으면 ("", false, nil)
+func (s *Store) HGet(key, field string) (string, bool, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil || entry == nil {
+		return "", false, err
+	}
+	value, exist := entry.Hash.Get(field)
+	return value, exist, nil
+}
+
+// 여러 필드 값을 한 번에 조회한다. 없는 필드는 exists[i]가 false다.
+func (s *Store) HMGet(key string, fields ...string) (values []string, exists []bool, err error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values = make([]string, len(fields))
+	exists = make([]bool, len(fields))
+	if entry == nil {
+		return values, exists, nil
+	}
+	for i, field := range fields {
+		values[i], exists[i] = entry.Hash.Get(field)
+	}
+	return values, exists, nil
+}
+
+// 필드들을 삭제하고 삭제한 개수를 반환한다. 빈 해시가 되면 키를 삭제한다.
+func (s *Store) HDel(key string, fields ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, field := range fields {
+		if entry.Hash.Delete(field) {
+			removed++
+		}
+	}
+	if removed > 0 {
+		s.notifyEvent(pubsub.NotifyHash, "hdel", key)
+	}
+	if entry.Hash.Len() == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+	return removed, nil
+}
+
+func (s *Store) HExists(key, field string) (bool, error) {
+	_, exist, err := s.HGet(key, field)
+	return exist, err
+}
+
+// 필드 개수. 키가 없으면 0
+func (s *Store) HLen(key string) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return entry.Hash.Len(), nil
+}
+
+// 필드 값의 길이. 키나 필드가 없으면 0
+func (s *Store) HStrLen(key, field string) (int, error) {
+	value, _, err := s.HGet(key, field)
+	return len(value), err
+}
+
+// 모든 필드 이름
+func (s *Store) HKeys(key string) ([]string, error) {
+	return s.hashCollect(key, func(result []string, field, value string) []string {
+		return append(result, field)
+	})
+}
+
+// 모든 필드 값
+func (s *Store) HVals(key string) ([]string, error) {
+	return s.hashCollect(key, func(result []string, field, value string) []string {
+		return append(result, value)
+	})
+}
+
+// 모든 필드와 값을 field1, value1, field2, value2, ... 순서로 반환한다.
+func (s *Store) HGetAll(key string) ([]string, error) {
+	return s.hashCollect(key, func(result []string, field, value string) []string {
+		return append(result, field, value)
+	})
+}
+
+// 필드 값에 정수 delta를 더하고 결과를 반환한다. 필드가 없으면 0에서 시작한다.
+func (s *Store) HIncrBy(key, field string, delta int64) (int64, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupOrCreateHash(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	if raw, exist := entry.Hash.Get(field); exist {
+		current, err = strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return 0, ErrHashValueNotInteger
+		}
+	}
+	if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+		return 0, ErrIncrOverflow
+	}
+
+	current += delta
+	entry.Hash.Set(field, strconv.FormatInt(current, 10))
+	s.notifyEvent(pubsub.NotifyHash, "hincrby", key)
+	return current, nil
+}
+
+// 필드 값에 실수 delta를 더하고 결과를 문자열로 반환한다. 필드가 없으면 0에서 시작한다.
+func (s *Store) HIncrByFloat(key, field string, delta float64) (string, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupOrCreateHash(key)
+	if err != nil {
+		return "", err
+	}
+
+	var current float64
+	if raw, exist := entry.Hash.Get(field); exist {
+		current, err = strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsNaN(current) || math.IsInf(current, 0) {
+			return "", ErrHashValueNotFloat
+		}
+	}
+
+	current += delta
+	if math.IsNaN(current) || math.IsInf(current, 0) {
+		return "", ErrIncrNaNOrInfinity
+	}
+
+	result := strconv.FormatFloat(current, 'f', -1, 64)
+	entry.Hash.Set(field, result)
+	s.notifyEvent(pubsub.NotifyHash, "hincrbyfloat", key)
+	return result, nil
+}
+
+// 무작위 필드를 고른다 (HRANDFIELD).
+//   - count > 0: 서로 다른 필드를 최대 count개
+//   - count < 0: 같은 필드가 여러 번 나올 수 있고, 정확히 |count|개
+//
+// fields[i]의 값은 values[i]다. 키가 없으면 빈 슬라이스를 반환한다.
+func (s *Store) HRandField(key string, count int) (fields, values []string, err error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil || entry == nil || count == 0 {
+		return []string{}, []string{}, err
+	}
+
+	if count < 0 {
+		for i := 0; i < -count; i++ {
+			field, value, _ := entry.Hash.Random()
+			fields = append(fields, field)
+			values = append(values, value)
+		}
+		return fields, values, nil
+	}
+
+	// 전부 또는 대부분을 골라야 하면 모두 모아서 섞는다
+	if count >= entry.Hash.Len() {
+		entry.Hash.Range(func(field, value string) bool {
+			fields = append(fields, field)
+			values = append(values, value)
+			return true
+		})
+		rand.Shuffle(len(fields), func(i, j int) {
+			fields[i], fields[j] = fields[j], fields[i]
+			values[i], values[j] = values[j], values[i]
+		})
+		return fields, values, nil
+	}
+
+	picked := make(map[string]struct{}, count)
+	for len(picked) < count {
+		field, value, _ := entry.Hash.Random()
+		if _, dup := picked[field]; dup {
+			continue
+		}
+		picked[field] = struct{}{}
+		fields = append(fields, field)
+		values = append(values, value)
+	}
+	return fields, values, nil
+}
+
+// 커서 기반으로 필드를 순회한다 (HSCAN).
+// pattern이 비어있지 않으면 일치하는 필드만 반환한다.
+// 다음 커서와 field1, value1, field2, value2, ... 를 반환하며, 커서가 0이면 순회가 끝난 것이다.
+func (s *Store) HScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil || entry == nil {
+		return 0, []string{}, err
+	}
+
+	result := []string{}
+	next := scanDict(entry.Hash, cursor, count, func(field, value string) {
+		if pattern == "" || glob.Match(pattern, field) {
+			result = append(result, field, value)
+		}
+	})
+	return next, result, nil
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// 해시 엔트리를 찾는다. 키가 없거나 만료되었으면 nil
+// 키가 해시가 아니면 ErrWrongType을 반환한다. 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupHash(key string) (*Entry, error) {
+	entry, exist := s.data.Get(key)
+	if !exist || s.isExpired(key) {
+		return nil, nil
+	}
+	if entry.Type != TypeHash {
+		return nil, ErrWrongType
+	}
+	return entry, nil
+}
+
+// 해시 엔트리를 찾고, 없으면 새로 만든다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupOrCreateHash(key string) (*Entry, error) {
+	entry, err := s.lookupHash(key)
+	if err != nil || entry != nil {
+		return entry, err
+	}
+
+	entry = &Entry{Type: TypeHash, Hash: NewDict[string]()}
+	s.data.Set(key, entry)
+	s.notifyEvent(pubsub.NotifyNew, "new", key)
+	return entry, nil
+}
+
+// 해시의 모든 필드를 collect로 모은다. 키가 없으면 빈 슬라이스
+func (s *Store) hashCollect(key string, collect func(result []string, field, value string) []string) ([]string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupHash(key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []string{}
+	if entry == nil {
+		return result, nil
+	}
+	entry.Hash.Range(func(field, value string) bool {
+		result = collect(result, field, value)
+		return true
+	})
+	return result, nil
+}
@@ -0,0 +1,257 @@
+package storage
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func TestHSet_AndHGet(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	added, err := store.HSet("user", "name", "gopher", "lang", "go")
+	again, _ := store.HSet("user", "name", "gopher2", "age", "10")
+
+	// then
+	if err != nil || added != 2 || again != 1 {
+		t.Fatalf("HSet 반환값: %d, %d, err: %v", added, again, err)
+	}
+	if value, ok, _ := store.HGet("user", "name"); !ok || value != "gopher2" {
+		t.Fatalf("HGet name: %s, %v", value, ok)
+	}
+	if _, ok, _ := store.HGet("user", "missing"); ok {
+		t.Fatal("없는 필드가 조회됨")
+	}
+	if length, _ := store.HLen("user"); length != 3 {
+		t.Fatalf("HLen: %d, expected: 3", length)
+	}
+}
+
+func TestHash_WrongType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("str", "value")
+	store.HSet("hash", "f", "v")
+
+	// when & then
+	if _, err := store.HSet("str", "f", "v"); err != ErrWrongType {
+		t.Fatalf("HSet 에러: %v", err)
+	}
+	if _, _, err := store.HGet("str", "f"); err != ErrWrongType {
+		t.Fatalf("HGet 에러: %v", err)
+	}
+	if _, err := store.LPush("hash", "a"); err != ErrWrongType {
+		t.Fatalf("LPush 에러: %v", err)
+	}
+}
+
+func TestHMGet(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("user", "name", "gopher")
+
+	// when
+	values, exists, err := store.HMGet("user", "name", "missing")
+
+	// then
+	if err != nil || values[0] != "gopher" || !exists[0] || exists[1] {
+		t.Fatalf("HMGet: %v, %v, err: %v", values, exists, err)
+	}
+}
+
+func TestHDel_DeletesEmptyKey(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("user", "name", "gopher", "lang", "go")
+
+	// when
+	removed, _ := store.HDel("user", "name", "lang", "missing")
+
+	// then
+	if removed != 2 {
+		t.Fatalf("HDel: %d, expected: 2", removed)
+	}
+	if exist, _ := store.HExists("user", "name"); exist {
+		t.Fatal("삭제된 필드가 남아있습니다")
+	}
+	if _, exist := store.data.Get("user"); exist {
+		t.Fatal("빈 해시의 키가 삭제되지 않았습니다")
+	}
+}
+
+func TestHKeysHValsHGetAll(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("user", "a", "1", "b", "2")
+
+	// when
+	keys, _ := store.HKeys("user")
+	values, _ := store.HVals("user")
+	all, _ := store.HGetAll("user")
+	missing, _ := store.HGetAll("missing")
+
+	// then
+	sort.Strings(keys)
+	sort.Strings(values)
+	if len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Fatalf("HKeys: %v", keys)
+	}
+	if len(values) != 2 || values[0] != "1" || values[1] != "2" {
+		t.Fatalf("HVals: %v", values)
+	}
+	if len(all) != 4 {
+		t.Fatalf("HGetAll: %v", all)
+	}
+	for i := 0; i < len(all); i += 2 {
+		if (all[i] == "a" && all[i+1] != "1") || (all[i] == "b" && all[i+1] != "2") {
+			t.Fatalf("HGetAll 필드와 값이 짝이 맞지 않습니다: %v", all)
+		}
+	}
+	if len(missing) != 0 {
+		t.Fatalf("없는 키 HGetAll: %v", missing)
+	}
+}
+
+func TestHIncrBy(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("counter", "text", "abc", "max", strconv.FormatInt(1<<62, 10))
+
+	// when
+	first, _ := store.HIncrBy("counter", "n", 5)
+	second, _ := store.HIncrBy("counter", "n", -2)
+	_, notInteger := store.HIncrBy("counter", "text", 1)
+	_, overflow := store.HIncrBy("counter", "max", 1<<62)
+
+	// then
+	if first != 5 || second != 3 {
+		t.Fatalf("HIncrBy: %d, %d", first, second)
+	}
+	if notInteger != ErrHashValueNotInteger {
+		t.Fatalf("정수가 아닌 값 에러: %v", notInteger)
+	}
+	if overflow != ErrIncrOverflow {
+		t.Fatalf("오버플로 에러: %v", overflow)
+	}
+}
+
+func TestHIncrByFloat(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("price", "text", "abc")
+
+	// when
+	result, _ := store.HIncrByFloat("price", "n", 10.5)
+	result, _ = store.HIncrByFloat("price", "n", 0.1)
+	_, notFloat := store.HIncrByFloat("price", "text", 1)
+
+	// then
+	if result != "10.6" {
+		t.Fatalf("HIncrByFloat: %s, expected: 10.6", result)
+	}
+	if notFloat != ErrHashValueNotFloat {
+		t.Fatalf("실수가 아닌 값 에러: %v", notFloat)
+	}
+}
+
+func TestHSetNX(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	first, _ := store.HSetNX("user", "name", "gopher")
+	second, _ := store.HSetNX("user", "name", "other")
+
+	// then
+	if !first || second {
+		t.Fatalf("HSetNX: %v, %v", first, second)
+	}
+	if value, _, _ := store.HGet("user", "name"); value != "gopher" {
+		t.Fatalf("HGet: %s, expected: gopher", value)
+	}
+}
+
+func TestHRandField(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("user", "a", "1", "b", "2", "c", "3")
+
+	// when
+	distinct, _, _ := store.HRandField("user", 2)
+	all, _, _ := store.HRandField("user", 10)
+	repeated, values, _ := store.HRandField("user", -5)
+
+	// then
+	if len(distinct) != 2 || distinct[0] == distinct[1] {
+		t.Fatalf("양수 count: %v", distinct)
+	}
+	if len(all) != 3 {
+		t.Fatalf("길이보다 큰 count: %v", all)
+	}
+	if len(repeated) != 5 || len(values) != 5 {
+		t.Fatalf("음수 count: %v", repeated)
+	}
+}
+
+func TestHScan_VisitsAllFields(t *testing.T) {
+	// given
+	store := New()
+	for i := 0; i < 50; i++ {
+		store.HSet("big", "field:"+strconv.Itoa(i), strconv.Itoa(i))
+	}
+	store.HSet("big", "other", "x")
+
+	// when: MATCH로 field:* 만 순회
+	seen := make(map[string]string)
+	cursor := uint64(0)
+	for {
+		next, result, err := store.HScan("big", cursor, "field:*", 5)
+		if err != nil {
+			t.Fatalf("에러 발생: %v", err)
+		}
+		for i := 0; i < len(result); i += 2 {
+			seen[result[i]] = result[i+1]
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	// then
+	if len(seen) != 50 {
+		t.Fatalf("순회한 필드 수: %d, expected: 50", len(seen))
+	}
+	if _, exist := seen["other"]; exist {
+		t.Fatal("MATCH와 일치하지 않는 필드가 반환됨")
+	}
+}
+
+func TestSaveAndLoad_HashEntries(t *testing.T) {
+	// given
+	store := New()
+	store.HSet("user", "name", "gopher", "lang", "go")
+	store.Expire("user", 100)
+	path := filepath.Join(t.TempDir(), "hash.rdb")
+	store.Save(path)
+
+	// when
+	loaded := New()
+	err := loaded.Load(path)
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	if value, ok, _ := loaded.HGet("user", "lang"); !ok || value != "go" {
+		t.Fatalf("lang: %s, exist: %v", value, ok)
+	}
+	if length, _ := loaded.HLen("user"); length != 2 {
+		t.Fatalf("HLen: %d, expected: 2", length)
+	}
+	if ttl := loaded.TTL("user"); ttl <= 0 || ttl > 100 {
+		t.Fatalf("TTL: %d", ttl)
+	}
+}
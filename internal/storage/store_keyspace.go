@@ -0,0 +1,390 @@
+package storage
+
+import (
+	"errors"
+	"inmemory-db/internal/glob"
+	"inmemory-db/internal/pubsub"
+	"math/rand/v2"
+)
+
+var ErrSameObject = errors.New("source and destination objects are the same")
+
+// UNLINK에서 백그라운드로 해제할 값의 최소 요소 수 (Redis LAZYFREE_THRESHOLD)
+const lazyFreeThreshold = 64
+
+// 키들 중 존재하는 키의 개수를 반환한다. 같은 키를 여러 번 주면 여러 번 센다.
+func (s *Store) Exists(keys ...string) int {
+	locks := s.rlock(keys...)
+	defer s.runlock(locks)
+
+	count := 0
+	for _, key := range keys {
+		if s.exists(key) {
+			count++
+		}
+	}
+	return count
+}
+
+// 키에 저장된 값의 타입 이름을 반환한다. 키가 없으면 "none".
+func (s *Store) Type(key string) string {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	if !s.exists(key) {
+		return "none"
+	}
+	entry, _ := s.data.Get(key)
+	return entry.Type.String()
+}
+
+// 키 이름을 바꾼다. 만료 시간도 함께 옮기고, destination이 있으면 덮어쓴다.
+// source가 없으면 ErrNoSuchKey를 반환한다.
+func (s *Store) Rename(source, destination string) error {
+	locks := s.lock(source, destination)
+	defer s.unlock(locks)
+
+	if !s.exists(source) {
+		return ErrNoSuchKey
+	}
+	s.renameLocked(source, destination)
+	return nil
+}
+
+// destination이 없을 때만 키 이름을 바꾼다. 바꿨으면 true.
+// source가 없으면 ErrNoSuchKey를 반환한다.
+func (s *Store) RenameNX(source, destination string) (bool, error) {
+	locks := s.lock(source, destination)
+	defer s.unlock(locks)
+
+	if !s.exists(source) {
+		return false, ErrNoSuchKey
+	}
+	if s.exists(destination) {
+		return false, nil
+	}
+	s.renameLocked(source, destination)
+	return true, nil
+}
+
+// source의 값을 destination에 복사한다. 만료 시간도 함께 복사한다.
+// source가 없거나, destination이 있는데 replace가 아니면 false.
+func (s *Store) Copy(source, destination string, replace bool) (bool, error) {
+	locks := s.lock(source, destination)
+	defer s.unlock(locks)
+
+	if source == destination {
+		return false, ErrSameObject
+	}
+	if !s.exists(source) {
+		return false, nil
+	}
+	existed := s.exists(destination)
+	if existed && !replace {
+		return false, nil
+	}
+
+	original, _ := s.data.Get(source)
+	entry := original.clone()
+	if !existed {
+		s.notifyEvent(pubsub.NotifyNew, "new", destination)
+	}
+	s.data.Set(destination, entry)
+	s.notifyEvent(pubsub.NotifyGeneric, "copy_to", destination)
+	s.serveBlocked(destination)
+	return true, nil
+}
+
+// 저장된 키의 개수. 아직 지워지지 않은 만료된 키도 포함한다 (Redis와 같다).
+// 샤드를 하나씩 잠그고 세므로, 다른 연결이 쓰는 중이면 순간의 정확한 값은 아닐 수 있다.
+func (s *Store) DBSize() int {
+	n := 0
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		n += sh.data.Len()
+		sh.mu.RUnlock()
+	}
+	return n
+}
+
+// 무작위 키 하나를 반환한다. 키가 없으면 false.
+// 무작위 샤드부터 차례로 키가 있는 샤드를 찾는다. 도중에 만난 만료된 키는 지우고 다른 키를 고른다.
+func (s *Store) RandomKey() (string, bool) {
+	start := rand.IntN(shardCount)
+	for i := range shardCount {
+		if key, ok := s.randomKeyIn(s.shards[(start+i)%shardCount]); ok {
+			return key, true
+		}
+	}
+	return "", false
+}
+
+// 샤드에서 만료되지 않은 무작위 키 하나를 반환한다. 샤드가 비었으면 false.
+func (s *Store) randomKeyIn(sh *shard) (string, bool) {
+	locks := sh.only
+	locks.lock()
+	defer s.unlock(locks)
+
+	for {
+		key, _, ok := sh.data.Random()
+		if !ok {
+			return "", false
+		}
+		if !s.expireIfNeeded(key) {
+			return key, true
+		}
+	}
+}
+
+// 커서 기반으로 키를 순회한다 (SCAN).
+// pattern이 비어있지 않으면 일치하는 키만, typeName이 비어있지 않으면 그 타입의 키만 반환한다.
+// 순회 내내 존재한 키는 테이블 크기가 바뀌어도 최소 한 번 반환된다. 다음 커서가 0이면 순회가 끝난 것이다.
+//
+// 샤드를 0번부터 차례로 훑는다. 커서의 아래 비트는 샤드 번호, 나머지는 그 샤드 Dict의 커서다.
+func (s *Store) Scan(cursor uint64, pattern string, count int, typeName string) (uint64, []string) {
+	index, dictCursor := int(cursor%shardCount), cursor/shardCount
+	var keys []string
+	visited := 0
+	for visited < count {
+		sh := s.shards[index]
+		sh.mu.RLock()
+
+		var candidates []string
+		dictCursor = scanDict(sh.data.Dict, dictCursor, count-visited, func(key string, entry *Entry) {
+			visited++
+			if pattern != "" && !glob.Match(pattern, key) {
+				return
+			}
+			if typeName != "" && entry.Type.String() != typeName {
+				return
+			}
+			candidates = append(candidates, key)
+		})
+		keys = append(keys, s.liveKeys(candidates)...)
+		sh.mu.RUnlock()
+
+		if dictCursor == 0 {
+			index++
+			if index == shardCount {
+				return 0, keys
+			}
+		}
+	}
+	return dictCursor*shardCount + uint64(index), keys
+}
+
+// pattern과 일치하는 모든 키를 반환한다 (KEYS).
+// 키 공간 전체를 훑으므로 작은 데이터셋에서만 써야 한다. 한 번에 샤드 하나씩 잠근다.
+func (s *Store) Keys(pattern string) []string {
+	var keys []string
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		var candidates []string
+		sh.data.Range(func(key string, entry *Entry) bool {
+			if pattern == "" || glob.Match(pattern, key) {
+				candidates = append(candidates, key)
+			}
+			return true
+		})
+		keys = append(keys, s.liveKeys(candidates)...)
+		sh.mu.RUnlock()
+	}
+	return keys
+}
+
+// 키들 중 존재하는 키의 개수를 반환한다 (TOUCH).
+func (s *Store) Touch(keys ...string) int {
+	return s.Exists(keys...)
+}
+
+// 키들을 삭제한다. 삭제된 키의 개수를 반환한다.
+func (s *Store) Del(keys ...string) int {
+	locks := s.lock(keys...)
+	defer s.unlock(locks)
+
+	count := 0
+	for _, key := range keys {
+		if s.deleteLocked(key) != nil {
+			count++
+		}
+	}
+	return count
+}
+
+// 키들을 삭제하되, 큰 값은 요청을 처리하는 고루틴이 아닌 별도 고루틴에서 해체한다.
+// 삭제된 키의 개수를 반환한다.
+func (s *Store) Unlink(keys ...string) int {
+	locks := s.lock(keys...)
+	defer s.unlock(locks)
+
+	count := 0
+	var large []*Entry
+	for _, key := range keys {
+		entry := s.deleteLocked(key)
+		if entry == nil {
+			continue
+		}
+		count++
+		if entry.length() >= lazyFreeThreshold {
+			large = append(large, entry)
+		}
+	}
+
+	if len(large) > 0 {
+		go func() {
+			for _, entry := range large {
+				entry.release()
+			}
+		}()
+	}
+	return count
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// 만료된 키를 빼고 나머지 키만 반환한다. 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) liveKeys(keys []string) []string {
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if !s.isExpired(key) {
+			result = append(result, key)
+		}
+	}
+	return result
+}
+
+// 키를 지우고 지운 엔트리를 반환한다. 키가 없거나 만료되었으면 nil.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) deleteLocked(key string) *Entry {
+	if !s.exists(key) {
+		return nil
+	}
+	entry, _ := s.data.Get(key)
+	s.data.Delete(key)
+	s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	return entry
+}
+
+// source를 destination으로 옮긴다. source가 있는지는 호출하는 쪽에서 확인한다.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) renameLocked(source, destination string) {
+	if source == destination {
+		return
+	}
+
+	entry, _ := s.data.Get(source)
+	s.data.Delete(source)
+	s.notifyEvent(pubsub.NotifyGeneric, "rename_from", source)
+
+	if !s.exists(destination) {
+		s.notifyEvent(pubsub.NotifyNew, "new", destination)
+	}
+	s.data.Set(destination, entry)
+	s.notifyEvent(pubsub.NotifyGeneric, "rename_to", destination)
+	s.serveBlocked(destination)
+}
+
+// TYPE 명령어가 응답하는 타입 이름
+func (t EntryType) String() string {
+	switch t {
+	case TypeString:
+		return "string"
+	case TypeList:
+		return "list"
+	case TypeHash:
+		return "hash"
+	case TypeSet:
+		return "set"
+	case TypeZSet:
+		return "zset"
+	case TypeStream:
+		return "stream"
+	default:
+		return "none"
+	}
+}
+
+// 타입 이름(TYPE 명령어의 응답)을 EntryType으로 바꾼다. 모르는 이름이면 false.
+func ParseEntryType(name string) (EntryType, bool) {
+	for t := TypeString; t <= TypeStream; t++ {
+		if t.String() == name {
+			return t, true
+		}
+	}
+	return 0, false
+}
+
+// 값의 요소 수. 문자열은 1이다.
+func (e *Entry) length() int {
+	switch e.Type {
+	case TypeList:
+		return e.List.Length
+	case TypeHash:
+		return e.Hash.Len()
+	case TypeSet:
+		return e.Set.Len()
+	case TypeZSet:
+		return e.ZSet.Len()
+	case TypeStream:
+		return e.Stream.Len()
+	default:
+		return 1
+	}
+}
+
+// 값과 만료 시간을 깊은 복사한다 (COPY).
+func (e *Entry) clone() *Entry {
+	copied := &Entry{
+		Type:       e.Type,
+		Str:        e.Str,
+		Int:        e.Int,
+		IntEncoded: e.IntEncoded,
+	}
+	if e.ExpireAt != nil {
+		at := *e.ExpireAt
+		copied.ExpireAt = &at
+	}
+
+	switch e.Type {
+	case TypeList:
+		copied.List = NewList()
+		for _, value := range e.List.Range(0, e.List.Length-1) {
+			copied.List.RPush(value)
+		}
+	case TypeHash:
+		copied.Hash = NewDict[string]()
+		e.Hash.Range(func(field, value string) bool {
+			copied.Hash.Set(field, value)
+			return true
+		})
+	case TypeSet:
+		copied.Set = NewSet()
+		e.Set.Range(func(member string) bool {
+			copied.Set.Add(member)
+			return true
+		})
+	case TypeZSet:
+		copied.ZSet = NewZSet()
+		e.ZSet.Range(func(member string, score float64) bool {
+			copied.ZSet.Set(member, score)
+			return true
+		})
+	case TypeStream:
+		// 스냅샷 변환을 거치면 컨슈머 그룹과 PEL까지 새로 만들어진다
+		copied.Stream = streamFromPersistence(streamToPersistence(e.Stream))
+	}
+	return copied
+}
+
+// 값에 대한 참조를 끊는다. 큰 리스트는 노드 사이의 링크도 끊어서
+// GC가 긴 포인터 체인을 따라가지 않고 노드를 각각 회수할 수 있게 한다.
+func (e *Entry) release() {
+	if e.List != nil {
+		for node := e.List.head; node != nil; {
+			next := node.next
+			node.prev, node.next, node.entries = nil, nil, nil
+			node = next
+		}
+	}
+	e.List, e.Hash, e.Set, e.ZSet, e.Stream = nil, nil, nil, nil, nil
+}
@@ -0,0 +1,238 @@
+package storage
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestExistsAndType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("s", "v")
+	store.RPush("l", "a")
+	store.HSet("h", "f", "v")
+	store.SAdd("set", "m")
+	store.ZAdd("z", ZAddOptions{}, ZMember{Member: "m", Score: 1})
+	store.Set("expired", "v")
+	store.ExpireAt("expired", time.Now().Add(time.Millisecond), 0)
+	time.Sleep(5 * time.Millisecond)
+
+	// when & then
+	if n := store.Exists("s", "s", "l", "missing", "expired"); n != 3 {
+		t.Fatalf("Exists: %d, expected: 3", n)
+	}
+	expected := map[string]string{"s": "string", "l": "list", "h": "hash", "set": "set", "z": "zset", "missing": "none", "expired": "none"}
+	for key, typ := range expected {
+		if actual := store.Type(key); actual != typ {
+			t.Fatalf("Type %s: %s, expected: %s", key, actual, typ)
+		}
+	}
+}
+
+func TestRename_CarriesTTL(t *testing.T) {
+	// given
+	store := New()
+	store.Set("src", "v")
+	store.Set("dst", "old")
+	at := time.Now().Add(time.Minute)
+	store.ExpireAt("src", at, 0)
+
+	// when
+	err := store.Rename("src", "dst")
+
+	// then
+	if err != nil {
+		t.Fatalf("Rename 에러: %v", err)
+	}
+	if store.Exists("src") != 0 {
+		t.Fatal("Rename 후 src가 남아 있습니다")
+	}
+	if value, _ := store.Get("dst"); value != "v" {
+		t.Fatalf("Rename 후 dst: %s", value)
+	}
+	if expireTime := store.PExpireTime("dst"); expireTime != at.UnixMilli() {
+		t.Fatalf("Rename 후 PExpireTime: %d, expected: %d", expireTime, at.UnixMilli())
+	}
+	if err := store.Rename("missing", "dst"); err != ErrNoSuchKey {
+		t.Fatalf("없는 키 Rename 에러: %v", err)
+	}
+}
+
+func TestRename_ExpiresUnderNewName(t *testing.T) {
+	// given: 짧은 TTL을 가진 키를 옮긴다
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
+	store.Set("src", "v")
+	store.ExpireAt("src", clock.Now().Add(500*time.Millisecond), 0)
+	store.Rename("src", "dst")
+	store.StartExpiry()
+	defer store.StopExpiry()
+
+	// when: 만료 루프가 돌 때까지 대기
+	clock.Advance(time.Second)
+	time.Sleep(300 * time.Millisecond)
+
+	// then: 새 이름으로 등록된 힙 항목 덕분에 능동 삭제된다
+	locks := store.rlock("dst")
+	_, exist := store.data.Get("dst")
+	store.runlock(locks)
+	if exist {
+		t.Fatal("옮긴 키가 능동 삭제되지 않았습니다")
+	}
+}
+
+func TestRenameNX(t *testing.T) {
+	// given
+	store := New()
+	store.Set("a", "1")
+	store.Set("b", "2")
+
+	// when & then
+	if renamed, err := store.RenameNX("a", "b"); err != nil || renamed {
+		t.Fatalf("RenameNX 기존 키: %v, err: %v", renamed, err)
+	}
+	if renamed, err := store.RenameNX("a", "c"); err != nil || !renamed {
+		t.Fatalf("RenameNX 새 키: %v, err: %v", renamed, err)
+	}
+	if value, _ := store.Get("c"); value != "1" {
+		t.Fatalf("RenameNX 후 c: %s", value)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("src", "a", "b")
+	store.Set("dst", "v")
+
+	// when & then
+	if _, err := store.Copy("src", "src", false); err != ErrSameObject {
+		t.Fatalf("같은 키 Copy 에러: %v", err)
+	}
+	if copied, _ := store.Copy("src", "dst", false); copied {
+		t.Fatal("REPLACE 없이 기존 키를 덮어썼습니다")
+	}
+	if copied, _ := store.Copy("src", "dst", true); !copied {
+		t.Fatal("REPLACE Copy 실패")
+	}
+
+	// 복사본은 원본과 독립적이다
+	store.RPush("src", "c")
+	if values, _ := store.LRange("dst", 0, -1); len(values) != 2 || values[0] != "a" || values[1] != "b" {
+		t.Fatalf("복사본 값: %v", values)
+	}
+	if copied, _ := store.Copy("missing", "other", false); copied {
+		t.Fatal("없는 키를 복사했습니다")
+	}
+}
+
+func TestRandomKeyAndDBSize(t *testing.T) {
+	// given
+	store := New()
+	if _, ok := store.RandomKey(); ok {
+		t.Fatal("빈 저장소에서 RandomKey가 키를 반환했습니다")
+	}
+	store.Set("a", "1")
+	store.Set("b", "2")
+
+	// when
+	key, ok := store.RandomKey()
+
+	// then
+	if !ok || (key != "a" && key != "b") {
+		t.Fatalf("RandomKey: %q, %v", key, ok)
+	}
+	if size := store.DBSize(); size != 2 {
+		t.Fatalf("DBSize: %d, expected: 2", size)
+	}
+}
+
+func TestDelAndUnlink(t *testing.T) {
+	// given
+	store := New()
+	store.Set("a", "1")
+	store.Set("b", "2")
+	for i := 0; i < lazyFreeThreshold*2; i++ {
+		store.RPush("big", strconv.Itoa(i))
+	}
+
+	// when & then
+	if n := store.Del("a", "missing", "a"); n != 1 {
+		t.Fatalf("Del: %d, expected: 1", n)
+	}
+	if n := store.Unlink("b", "big"); n != 2 {
+		t.Fatalf("Unlink: %d, expected: 2", n)
+	}
+	if n := store.Exists("a", "b", "big"); n != 0 {
+		t.Fatalf("삭제 후 Exists: %d", n)
+	}
+}
+
+func TestScan_ReturnsEveryKeyDuringResize(t *testing.T) {
+	// given
+	store := New()
+	for i := 0; i < 100; i++ {
+		store.Set("key:"+strconv.Itoa(i), "v")
+	}
+
+	// when: 순회 도중 키를 더 넣어 테이블을 키운다
+	seen := make(map[string]bool)
+	cursor := uint64(0)
+	for round := 0; ; round++ {
+		var keys []string
+		cursor, keys = store.Scan(cursor, "key:*", 10, "")
+		for _, key := range keys {
+			seen[key] = true
+		}
+		if round == 2 {
+			for i := 0; i < 1000; i++ {
+				store.Set("extra:"+strconv.Itoa(i), "v")
+			}
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// then: 처음부터 있던 키는 모두 반환된다
+	for i := 0; i < 100; i++ {
+		if !seen["key:"+strconv.Itoa(i)] {
+			t.Fatalf("key:%d가 반환되지 않았습니다", i)
+		}
+	}
+	if len(seen) != 100 {
+		t.Fatalf("MATCH와 다른 키가 섞였습니다: %d", len(seen))
+	}
+}
+
+func TestScan_TypeFilterAndKeys(t *testing.T) {
+	// given
+	store := New()
+	store.Set("s1", "v")
+	store.Set("s2", "v")
+	store.RPush("l1", "a")
+
+	// when
+	var lists []string
+	cursor := uint64(0)
+	for {
+		var keys []string
+		cursor, keys = store.Scan(cursor, "", 10, "list")
+		lists = append(lists, keys...)
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// then
+	if len(lists) != 1 || lists[0] != "l1" {
+		t.Fatalf("TYPE list Scan: %v", lists)
+	}
+	if keys := store.Keys("s*"); len(keys) != 2 {
+		t.Fatalf("Keys s*: %v", keys)
+	}
+	if keys := store.Keys(""); len(keys) != 3 {
+		t.Fatalf("Keys: %v", keys)
+	}
+}
@@ -0,0 +1,494 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"inmemory-db/internal/pubsub"
+)
+
+// 키가 존재하지 않으면 새 리스트를 생성한다
+// 키가 존재하지만 TypeList가 아니면 ErrWrongType을 반환한다
+func (s *Store) LPush(key string, values ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, exist := s.data.Get(key)
+
+	if !exist || s.isExpired(key) {
+		newEntry := Entry{Type: TypeList, List: NewList()}
+		entry = &newEntry
+		s.data.Set(key, entry)
+		s.notifyEvent(pubsub.NotifyNew, "new", key)
+	}
+
+	if entry.Type != TypeList {
+		return 0, ErrWrongType
+	}
+
+	for _, v := range values {
+		entry.List.LPush(v)
+	}
+	s.notifyEvent(pubsub.NotifyList, "lpush", key)
+
+	// 대기 중인 BLPOP 등이 요소를 가져가기 전의 길이를 반환한다
+	length := entry.List.Length
+	s.serveBlocked(key)
+
+	return length, nil
+}
+
+func (s *Store) RPush(key string, values ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, exist := s.data.Get(key)
+
+	if !exist || s.isExpired(key) {
+		newEntry := Entry{Type: TypeList, List: NewList()}
+		entry = &newEntry
+		s.data.Set(key, entry)
+		s.notifyEvent(pubsub.NotifyNew, "new", key)
+	}
+
+	if entry.Type != TypeList {
+		return 0, ErrWrongType
+	}
+
+	for _, v := range values {
+		entry.List.RPush(v)
+	}
+	s.notifyEvent(pubsub.NotifyList, "rpush", key)
+
+	// 대기 중인 BLPOP 등이 요소를 가져가기 전의 길이를 반환한다
+	length := entry.List.Length
+	s.serveBlocked(key)
+
+	return length, nil
+}
+
+// 빈 리스트가 되면 키를 삭제한다 (Redis 동작)
+func (s *Store) LPop(key string) (string, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, exist := s.data.Get(key)
+
+	if !exist {
+		return "", exist, nil
+	}
+
+	if entry.Type != TypeList {
+		return "", false, ErrWrongType
+	}
+
+	if s.isExpired(key) {
+		return "", false, nil
+	}
+
+	value, result := s.popLocked(key, entry, true)
+
+	return value, result, nil
+}
+func (s *Store) RPop(key string) (string, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, exist := s.data.Get(key)
+
+	if !exist {
+		return "", exist, nil
+	}
+
+	if entry.Type != TypeList {
+		return "", false, ErrWrongType
+	}
+
+	if s.isExpired(key) {
+		return "", false, nil
+	}
+
+	value, result := s.popLocked(key, entry, false)
+
+	return value, result, nil
+}
+
+// 리스트의 왼쪽(left=true) 또는 오른쪽 끝에서 요소를 꺼낸다.
+// 빈 리스트가 되면 키를 삭제한다 (Redis 동작)
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) popLocked(key string, entry *Entry, left bool) (string, bool) {
+	var value string
+	var result bool
+	if left {
+		value, result = entry.List.LPop()
+		s.notifyEvent(pubsub.NotifyList, "lpop", key)
+	} else {
+		value, result = entry.List.RPop()
+		s.notifyEvent(pubsub.NotifyList, "rpop", key)
+	}
+
+	if entry.List.Length == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+	return value, result
+}
+
+// source 리스트의 한쪽 끝에서 요소를 꺼내 destination 리스트의 한쪽 끝에 넣는다.
+// source가 없으면 ("", false, nil)을 반환한다.
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) moveLocked(source, destination string, fromLeft, toLeft bool) (string, bool, error) {
+	srcEntry, err := s.lookupList(source)
+	if err != nil || srcEntry == nil {
+		return "", false, err
+	}
+
+	dstEntry, err := s.lookupList(destination)
+	if err != nil {
+		return "", false, err
+	}
+
+	value, _ := s.popLocked(source, srcEntry, fromLeft)
+
+	// source == destination 이고 마지막 요소였다면 pop에서 키가 삭제되었으므로 다시 조회한다
+	if source == destination {
+		dstEntry, _ = s.lookupList(destination)
+	}
+	if dstEntry == nil {
+		dstEntry = &Entry{Type: TypeList, List: NewList()}
+		s.data.Set(destination, dstEntry)
+		s.notifyEvent(pubsub.NotifyNew, "new", destination)
+	}
+
+	if toLeft {
+		dstEntry.List.LPush(value)
+		s.notifyEvent(pubsub.NotifyList, "lpush", destination)
+	} else {
+		dstEntry.List.RPush(value)
+		s.notifyEvent(pubsub.NotifyList, "rpush", destination)
+	}
+	s.serveBlocked(destination)
+
+	return value, true, nil
+}
+
+// 리스트 엔트리를 조회한다. 없거나 만료되었으면 nil을 반환한다.
+// 키가 존재하지만 TypeList가 아니면 ErrWrongType을 반환한다.
+// 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupList(key string) (*Entry, error) {
+	entry, exist := s.data.Get(key)
+	if !exist || s.isExpired(key) {
+		return nil, nil
+	}
+	if entry.Type != TypeList {
+		return nil, ErrWrongType
+	}
+	return entry, nil
+}
+
+// BLPOP/BRPOP. 여러 키 중 요소가 있는 첫 번째 키에서 요소를 꺼낸다.
+// 모든 키가 비어있으면 다른 클라이언트가 요소를 넣을 때까지 기다린다.
+// ctx가 끝나면(타임아웃, CLIENT UNBLOCK) ok=false를 반환한다.
+func (s *Store) BlockingPop(ctx context.Context, keys []string, left bool) (key, value string, ok bool, err error) {
+	locks := s.lock(keys...)
+	defer s.unlock(locks)
+
+	for _, k := range keys {
+		entry, err := s.lookupList(k)
+		if err != nil {
+			return "", "", false, err
+		}
+		if entry != nil {
+			value, _ := s.popLocked(k, entry, left)
+			return k, value, true, nil
+		}
+	}
+
+	w := &waiter{
+		keys: keys,
+		serve: func(k string, entry *Entry) bool {
+			if entry.Type != TypeList {
+				return false
+			}
+			key = k
+			value, ok = s.popLocked(k, entry, left)
+			return ok
+		},
+	}
+	if !s.waitLocked(ctx, w, locks) {
+		return "", "", false, nil
+	}
+	return key, value, true, nil
+}
+
+// BLMOVE. source가 비어있으면 다른 클라이언트가 요소를 넣을 때까지 기다린 뒤
+// 꺼낸 요소를 destination에 넣는다.
+// ctx가 끝나면(타임아웃, CLIENT UNBLOCK) ok=false를 반환한다.
+func (s *Store) BlockingMove(ctx context.Context, source, destination string, fromLeft, toLeft bool) (value string, ok bool, err error) {
+	locks := s.lock(source, destination)
+	defer s.unlock(locks)
+
+	value, ok, err = s.moveLocked(source, destination, fromLeft, toLeft)
+	if err != nil || ok {
+		return value, ok, err
+	}
+
+	w := &waiter{
+		keys:  []string{source},
+		locks: []string{source, destination},
+		serve: func(k string, entry *Entry) bool {
+			if entry.Type != TypeList {
+				return false
+			}
+			// destination이 다른 타입이면 에러로 응답하고 대기를 끝낸다
+			value, ok, err = s.moveLocked(source, destination, fromLeft, toLeft)
+			return ok || err != nil
+		},
+	}
+	if !s.waitLocked(ctx, w, locks) {
+		return "", false, nil
+	}
+	return value, ok, err
+}
+
+func (s *Store) LRange(key string, start, stop int) ([]string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, exist := s.data.Get(key)
+
+	if !exist {
+		s.notifyKeyMiss(key)
+		return []string{}, nil
+	}
+
+	if entry.Type != TypeList {
+		return nil, ErrWrongType
+	}
+
+	if s.isExpired(key) {
+		s.notifyKeyMiss(key)
+		return []string{}, nil
+	}
+
+	result := entry.List.Range(start, stop)
+
+	return result, nil
+}
+
+var (
+	ErrNoSuchKey       = errors.New("no such key")
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// 키가 이미 리스트로 존재할 때만 왼쪽에 넣는다 (LPUSHX).
+// 키가 없으면 0을 반환한다.
+func (s *Store) LPushX(key string, values ...string) (int, error) {
+	return s.pushExisting(key, true, values)
+}
+
+// 키가 이미 리스트로 존재할 때만 오른쪽에 넣는다 (RPUSHX).
+func (s *Store) RPushX(key string, values ...string) (int, error) {
+	return s.pushExisting(key, false, values)
+}
+
+func (s *Store) pushExisting(key string, left bool, values []string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	for _, v := range values {
+		if left {
+			entry.List.LPush(v)
+		} else {
+			entry.List.RPush(v)
+		}
+	}
+	if left {
+		s.notifyEvent(pubsub.NotifyList, "lpush", key)
+	} else {
+		s.notifyEvent(pubsub.NotifyList, "rpush", key)
+	}
+
+	length := entry.List.Length
+	s.serveBlocked(key)
+	return length, nil
+}
+
+// 왼쪽에서 최대 count개를 꺼낸다 (LPOP key count).
+// 키가 없으면 (nil, false, nil)을 반환한다.
+func (s *Store) LPopCount(key string, count int) ([]string, bool, error) {
+	return s.popCount(key, count, true)
+}
+
+// 오른쪽에서 최대 count개를 꺼낸다 (RPOP key count).
+func (s *Store) RPopCount(key string, count int) ([]string, bool, error) {
+	return s.popCount(key, count, false)
+}
+
+func (s *Store) popCount(key string, count int, left bool) ([]string, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return nil, false, err
+	}
+
+	result := make([]string, 0, min(count, entry.List.Length))
+	for len(result) < count && entry.List.Length > 0 {
+		var value string
+		if left {
+			value, _ = entry.List.LPop()
+		} else {
+			value, _ = entry.List.RPop()
+		}
+		result = append(result, value)
+	}
+
+	// count가 0이면 아무것도 꺼내지 않았으므로 알리지 않는다
+	if len(result) > 0 {
+		if left {
+			s.notifyEvent(pubsub.NotifyList, "lpop", key)
+		} else {
+			s.notifyEvent(pubsub.NotifyList, "rpop", key)
+		}
+	}
+	if entry.List.Length == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+	return result, true, nil
+}
+
+// 리스트 길이를 반환한다. 키가 없으면 0
+func (s *Store) LLen(key string) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return entry.List.Length, nil
+}
+
+// index 위치의 요소를 반환한다. 음수 인덱스 지원.
+// 키가 없거나 범위를 벗어나면 ("", false, nil)
+func (s *Store) LIndex(key string, index int) (string, bool, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return "", false, err
+	}
+
+	value, ok := entry.List.Index(index)
+	return value, ok, nil
+}
+
+// index 위치의 요소를 value로 바꾼다.
+// 키가 없으면 ErrNoSuchKey, 범위를 벗어나면 ErrIndexOutOfRange를 반환한다.
+func (s *Store) LSet(key string, index int, value string) error {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		return ErrNoSuchKey
+	}
+
+	if !entry.List.Set(index, value) {
+		return ErrIndexOutOfRange
+	}
+	s.notifyEvent(pubsub.NotifyList, "lset", key)
+	return nil
+}
+
+// pivot 앞(before=true) 또는 뒤에 value를 넣는다.
+// 넣은 뒤의 길이를 반환한다. 키가 없으면 0, pivot을 찾지 못하면 -1
+func (s *Store) LInsert(key string, before bool, pivot, value string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	length := entry.List.Insert(pivot, value, before)
+	if length > 0 {
+		s.notifyEvent(pubsub.NotifyList, "linsert", key)
+	}
+	return length, nil
+}
+
+// value와 같은 요소를 최대 |count|개 삭제한다. 삭제한 개수를 반환한다.
+// count > 0이면 앞에서부터, count < 0이면 뒤에서부터, 0이면 전부 삭제한다.
+func (s *Store) LRem(key string, count int, value string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	removed := entry.List.Remove(count, value)
+	if removed > 0 {
+		s.notifyEvent(pubsub.NotifyList, "lrem", key)
+	}
+	if entry.List.Length == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+	return removed, nil
+}
+
+// start ~ stop 범위만 남긴다. 빈 리스트가 되면 키를 삭제한다.
+func (s *Store) LTrim(key string, start, stop int) error {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return err
+	}
+
+	entry.List.Trim(start, stop)
+	s.notifyEvent(pubsub.NotifyList, "ltrim", key)
+	if entry.List.Length == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+	return nil
+}
+
+// value와 같은 요소의 인덱스를 찾는다. 인자는 List.Pos와 같다.
+// 키가 없으면 빈 슬라이스를 반환한다.
+func (s *Store) LPos(key, value string, rank, count, maxlen int) ([]int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupList(key)
+	if err != nil || entry == nil {
+		return []int{}, err
+	}
+	return entry.List.Pos(value, rank, count, maxlen), nil
+}
+
+// source의 한쪽 끝에서 요소를 꺼내 destination의 한쪽 끝에 넣는다 (LMOVE).
+// source가 없으면 ("", false, nil)
+func (s *Store) LMove(source, destination string, fromLeft, toLeft bool) (string, bool, error) {
+	locks := s.lock(source, destination)
+	defer s.unlock(locks)
+
+	return s.moveLocked(source, destination, fromLeft, toLeft)
+}
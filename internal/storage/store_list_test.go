@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLPushX_NonExistentKey(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	length, err := store.LPushX("mylist", "a")
+
+	// then: 키가 없으면 생성하지 않는다
+	if err != nil || length != 0 {
+		t.Fatalf("length: %d, err: %v", length, err)
+	}
+	if n, _ := store.LLen("mylist"); n != 0 {
+		t.Fatalf("키가 생성되었습니다. 길이: %d", n)
+	}
+}
+
+func TestRPushX_ExistingKey(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a")
+
+	// when
+	length, err := store.RPushX("mylist", "b", "c")
+
+	// then
+	if err != nil || length != 3 {
+		t.Fatalf("length: %d, err: %v", length, err)
+	}
+}
+
+func TestLPopCount(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "b", "c")
+
+	// when: 길이보다 많이 꺼낸다
+	values, exist, err := store.LPopCount("mylist", 5)
+
+	// then: 있는 만큼만 꺼내고 키는 삭제된다
+	if err != nil || !exist || len(values) != 3 || values[0] != "a" {
+		t.Fatalf("values: %v, exist: %v, err: %v", values, exist, err)
+	}
+	if _, exist, _ := store.RPopCount("mylist", 1); exist {
+		t.Fatal("빈 리스트의 키가 남아있습니다")
+	}
+}
+
+func TestLLen_WrongType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("str", "value")
+
+	// when
+	_, err := store.LLen("str")
+
+	// then
+	if err != ErrWrongType {
+		t.Fatalf("에러: %v, expected: ErrWrongType", err)
+	}
+}
+
+func TestLIndex(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "b", "c")
+
+	// when
+	value, ok, err := store.LIndex("mylist", -1)
+
+	// then
+	if err != nil || !ok || value != "c" {
+		t.Fatalf("value: %s, ok: %v, err: %v", value, ok, err)
+	}
+}
+
+func TestLSet_Errors(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a")
+
+	// when & then
+	if err := store.LSet("unknown", 0, "x"); err != ErrNoSuchKey {
+		t.Fatalf("에러: %v, expected: ErrNoSuchKey", err)
+	}
+	if err := store.LSet("mylist", 1, "x"); err != ErrIndexOutOfRange {
+		t.Fatalf("에러: %v, expected: ErrIndexOutOfRange", err)
+	}
+	if err := store.LSet("mylist", 0, "x"); err != nil {
+		t.Fatalf("에러: %v", err)
+	}
+}
+
+func TestLInsert(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "c")
+
+	// when
+	length, _ := store.LInsert("mylist", true, "c", "b")
+	missing, _ := store.LInsert("unknown", true, "c", "b")
+
+	// then
+	if length != 3 || missing != 0 {
+		t.Fatalf("length: %d, missing: %d", length, missing)
+	}
+}
+
+func TestLRem_DeletesEmptyList(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "a")
+
+	// when
+	removed, _ := store.LRem("mylist", 0, "a")
+
+	// then
+	if removed != 2 {
+		t.Fatalf("삭제 수: %d, expected: 2", removed)
+	}
+	if store.Del("mylist") != 0 {
+		t.Fatal("빈 리스트의 키가 남아있습니다")
+	}
+}
+
+func TestLTrim(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "b", "c", "d")
+
+	// when
+	store.LTrim("mylist", 1, 2)
+
+	// then
+	values, _ := store.LRange("mylist", 0, -1)
+	if len(values) != 2 || values[0] != "b" || values[1] != "c" {
+		t.Fatalf("LTrim 결과: %v", values)
+	}
+}
+
+func TestLPos(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "b", "a")
+
+	// when
+	positions, err := store.LPos("mylist", "a", 1, 0, 0)
+
+	// then
+	if err != nil || len(positions) != 2 || positions[1] != 2 {
+		t.Fatalf("positions: %v, err: %v", positions, err)
+	}
+}
+
+func TestLMove(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("src", "a", "b")
+	store.RPush("dst", "x")
+
+	// when: src 왼쪽 → dst 오른쪽
+	value, ok, err := store.LMove("src", "dst", true, false)
+
+	// then
+	if err != nil || !ok || value != "a" {
+		t.Fatalf("value: %s, ok: %v, err: %v", value, ok, err)
+	}
+	dst, _ := store.LRange("dst", 0, -1)
+	if len(dst) != 2 || dst[1] != "a" {
+		t.Fatalf("destination: %v", dst)
+	}
+}
+
+func TestLMove_Rotate(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a", "b", "c")
+
+	// when: 같은 리스트에서 오른쪽 → 왼쪽 (회전)
+	store.LMove("mylist", "mylist", false, true)
+
+	// then
+	values, _ := store.LRange("mylist", 0, -1)
+	if len(values) != 3 || values[0] != "c" || values[2] != "b" {
+		t.Fatalf("회전 결과: %v", values)
+	}
+}
+
+func TestLMove_WrongTypeDestination(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("src", "a")
+	store.Set("dst", "string")
+
+	// when
+	_, _, err := store.LMove("src", "dst", true, true)
+
+	// then: 에러이고 source는 변하지 않는다
+	if err != ErrWrongType {
+		t.Fatalf("에러: %v, expected: ErrWrongType", err)
+	}
+	if n, _ := store.LLen("src"); n != 1 {
+		t.Fatalf("source 길이: %d, expected: 1", n)
+	}
+}
+
+func TestLLen_Expired(t *testing.T) {
+	// given
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
+	store.RPush("mylist", "a")
+	store.Expire("mylist", 1)
+
+	// when
+	clock.Advance(1100 * time.Millisecond)
+	length, err := store.LLen("mylist")
+
+	// then
+	if err != nil || length != 0 {
+		t.Fatalf("length: %d, err: %v", length, err)
+	}
+}
@@ -0,0 +1,116 @@
+package storage
+
+import "time"
+
+// 만료 힙 항목 하나 (포인터 + HeapItem 구조체 + 키 색인 map 요소). 키 문자열은 키 공간과 함께 쓴다
+const heapItemOverhead = 96
+
+// 짧은 문자열을 embstr로 보는 최대 길이 (Redis OBJ_ENCODING_EMBSTR_SIZE_LIMIT)
+const embstrSizeLimit = 44
+
+// OBJECT 명령어로 보는 키의 내부 정보
+type ObjectInfo struct {
+	Encoding string
+	// 마지막 접근 이후 지난 시간
+	Idle time.Duration
+	// 시간에 따라 줄어든 LFU 카운터
+	Freq int
+	// 값을 공유하지 않으므로 항상 1이다
+	RefCount int
+}
+
+// 데이터베이스 하나의 메모리 사용 내역 (MEMORY STATS 용)
+type MemoryStats struct {
+	Keys int
+	// 키와 값이 차지하는 바이트
+	Dataset int64
+	// 키 공간 해시 테이블과 엔트리 구조체가 차지하는 바이트
+	MainOverhead int64
+	// 만료 힙이 차지하는 바이트
+	ExpiresOverhead int64
+}
+
+// 키의 내부 정보를 반환한다. 키가 없으면 false.
+// 조회만 하므로 접근 정보를 바꾸지 않고 읽기 락만 잡는다.
+func (s *Store) Object(key string) (ObjectInfo, bool) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, exist := s.lookupNoTouch(key)
+	if !exist {
+		return ObjectInfo{}, false
+	}
+	return ObjectInfo{
+		Encoding: entry.encoding(),
+		Idle:     entry.idleTime(time.Now().UnixMilli()),
+		Freq:     int(entry.decayedFreq(time.Now().UnixMilli())),
+		RefCount: 1,
+	}, true
+}
+
+// 키와 값이 차지하는 메모리 추정치(바이트)를 반환한다. 키가 없으면 false.
+// 컬렉션은 samples개의 요소로 추정하고, samples가 0이면 모든 요소를 훑는다.
+func (s *Store) MemoryUsage(key string, samples int) (int64, bool) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, exist := s.lookupNoTouch(key)
+	if !exist {
+		return 0, false
+	}
+	return entry.memoryUsage(key, samples), true
+}
+
+// 메모리 사용 내역을 반환한다.
+// 쓰기 때마다 맞춰 둔 샤드별 합계만 읽으므로 데이터 크기와 상관없이 O(샤드 수)다.
+func (s *Store) MemoryStats() MemoryStats {
+	var stats MemoryStats
+	for _, sh := range s.shards {
+		sh.mu.RLock()
+		keys := sh.data.Len()
+		stats.Keys += keys
+		stats.Dataset += sh.data.used - int64(keys*entryOverhead)
+		stats.MainOverhead += int64(8*len(sh.data.buckets) + keys*entryOverhead)
+		stats.ExpiresOverhead += int64(heapItemOverhead * len(sh.heap.items))
+		sh.mu.RUnlock()
+	}
+	return stats
+}
+
+// 만료되지 않은 엔트리를 접근 정보를 바꾸지 않고 찾는다. 만료된 키를 지우지는 않는다.
+// 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupNoTouch(key string) (*Entry, bool) {
+	entry, exist := s.data.peek(key)
+	if !exist || (entry.ExpireAt != nil && entry.ExpireAt.Before(s.clock.Now())) {
+		return nil, false
+	}
+	return entry, true
+}
+
+// 값의 내부 인코딩 이름 (OBJECT ENCODING)
+func (e *Entry) encoding() string {
+	switch e.Type {
+	case TypeString:
+		if e.IntEncoded {
+			return "int"
+		}
+		if len(e.Str) <= embstrSizeLimit {
+			return "embstr"
+		}
+		return "raw"
+	case TypeList:
+		// 노드 하나에 모두 들어가면 listpack, 여러 노드로 나뉘면 quicklist
+		if e.List.head == e.List.tail {
+			return "listpack"
+		}
+		return "quicklist"
+	case TypeHash:
+		return "hashtable"
+	case TypeSet:
+		return e.Set.Encoding()
+	case TypeZSet:
+		return "skiplist"
+	default:
+		return "stream"
+	}
+}
@@ -0,0 +1,92 @@
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestObjectEncoding(t *testing.T) {
+	// given
+	store := New()
+	store.Set("int", "12345")
+	store.Set("short", "hello")
+	store.Set("long", strings.Repeat("x", 100))
+	store.SAdd("ints", "1", "2")
+	store.SAdd("members", "a")
+	store.RPush("list", "a")
+
+	// when & then
+	expected := map[string]string{
+		"int":     "int",
+		"short":   "embstr",
+		"long":    "raw",
+		"ints":    "intset",
+		"members": "hashtable",
+		"list":    "listpack",
+	}
+	for key, encoding := range expected {
+		info, exist := store.Object(key)
+		if !exist || info.Encoding != encoding {
+			t.Fatalf("%s 인코딩: %q, expected: %q", key, info.Encoding, encoding)
+		}
+	}
+	if _, exist := store.Object("missing"); exist {
+		t.Fatal("없는 키의 Object가 있다고 나왔습니다")
+	}
+}
+
+func TestObjectDoesNotTouch(t *testing.T) {
+	// given: 한 시간 동안 접근하지 않은 키
+	store := New()
+	store.Set("key", "v")
+	entry, _ := store.data.peek("key")
+	entry.accessedAt.Store(time.Now().Add(-time.Hour).UnixMilli())
+
+	// when
+	store.Object("key")
+	store.MemoryUsage("key", 0)
+	info, _ := store.Object("key")
+
+	// then
+	if info.Idle < time.Hour-time.Minute {
+		t.Fatalf("Object 조회가 접근 시각을 바꿨습니다: idle %v", info.Idle)
+	}
+	if info.Freq != 0 {
+		t.Fatalf("한 시간 지난 LFU 카운터: %d, expected: 0", info.Freq)
+	}
+	store.Get("key")
+	if info, _ := store.Object("key"); info.Idle > time.Second {
+		t.Fatalf("GET 후 idle: %v", info.Idle)
+	}
+}
+
+func TestMemoryUsageAndStats(t *testing.T) {
+	// given
+	store := New()
+	values := make([]string, 1000)
+	for i := range values {
+		values[i] = strings.Repeat("v", i%50)
+	}
+	store.RPush("list", values...)
+	store.Set("expiring", "v")
+	store.ExpireAt("expiring", time.Now().Add(time.Minute), 0)
+
+	// when
+	usage, exist := store.MemoryUsage("list", 0)
+	stats := store.MemoryStats()
+
+	// then
+	if !exist || usage < 25*1000 {
+		t.Fatalf("MemoryUsage: %d, %v", usage, exist)
+	}
+	if _, exist := store.MemoryUsage("missing", 5); exist {
+		t.Fatal("없는 키의 MemoryUsage가 있다고 나왔습니다")
+	}
+	if stats.Keys != 2 || stats.Dataset <= 0 || stats.MainOverhead <= 0 || stats.ExpiresOverhead != heapItemOverhead {
+		t.Fatalf("MemoryStats: %+v", stats)
+	}
+	if total := stats.Dataset + int64(2*entryOverhead); total != store.UsedMemory() {
+		t.Fatalf("dataset + 엔트리 오버헤드: %d, UsedMemory: %d", total, store.UsedMemory())
+	}
+}
@@ -0,0 +1,400 @@
+package storage
+
+import (
+	"inmemory-db/internal/glob"
+	"inmemory-db/internal/pubsub"
+	"sort"
+)
+
+// 요소들을 추가하고 새로 추가된 개수를 반환한다. 키가 없으면 새 집합을 만든다.
+func (s *Store) SAdd(key string, members ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupOrCreateSet(key)
+	if err != nil {
+		return 0, err
+	}
+
+	added := 0
+	for _, member := range members {
+		if entry.Set.Add(member) {
+			added++
+		}
+	}
+	if added > 0 {
+		s.notifyEvent(pubsub.NotifySet, "sadd", key)
+	}
+	return added, nil
+}
+
+// 요소들을 삭제하고 삭제한 개수를 반환한다. 빈 집합이 되면 키를 삭제한다.
+func (s *Store) SRem(key string, members ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, member := range members {
+		if entry.Set.Remove(member) {
+			removed++
+		}
+	}
+	if removed > 0 {
+		s.notifyEvent(pubsub.NotifySet, "srem", key)
+	}
+	s.deleteIfEmptySet(key, entry)
+	return removed, nil
+}
+
+func (s *Store) SIsMember(key, member string) (bool, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil || entry == nil {
+		return false, err
+	}
+	return entry.Set.Contains(member), nil
+}
+
+// 여러 요소의 포함 여부를 한 번에 확인한다.
+func (s *Store) SMIsMember(key string, members ...string) ([]bool, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]bool, len(members))
+	if entry == nil {
+		return result, nil
+	}
+	for i, member := range members {
+		result[i] = entry.Set.Contains(member)
+	}
+	return result, nil
+}
+
+// 요소 개수. 키가 없으면 0
+func (s *Store) SCard(key string) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return entry.Set.Len(), nil
+}
+
+// 모든 요소. 키가 없으면 빈 슬라이스
+func (s *Store) SMembers(key string) ([]string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return []string{}, nil
+	}
+	return entry.Set.Members(), nil
+}
+
+// 무작위 요소를 최대 count개 꺼낸다. 빈 집합이 되면 키를 삭제한다.
+func (s *Store) SPop(key string, count int) ([]string, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil || entry == nil {
+		return []string{}, err
+	}
+
+	result := entry.Set.RandomMembers(min(count, entry.Set.Len()))
+	for _, member := range result {
+		entry.Set.Remove(member)
+	}
+	if len(result) > 0 {
+		s.notifyEvent(pubsub.NotifySet, "spop", key)
+	}
+	s.deleteIfEmptySet(key, entry)
+	return result, nil
+}
+
+// 무작위 요소를 고른다 (SRANDMEMBER). count의 의미는 Set.RandomMembers와 같다.
+func (s *Store) SRandMember(key string, count int) ([]string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil || entry == nil || count == 0 {
+		return []string{}, err
+	}
+	return entry.Set.RandomMembers(count), nil
+}
+
+// source의 member를 destination으로 옮긴다. 옮겼으면 true
+func (s *Store) SMove(source, destination, member string) (bool, error) {
+	locks := s.lock(source, destination)
+	defer s.unlock(locks)
+
+	src, err := s.lookupSet(source)
+	if err != nil {
+		return false, err
+	}
+	if _, err := s.lookupSet(destination); err != nil {
+		return false, err
+	}
+	if src == nil || !src.Set.Contains(member) {
+		return false, nil
+	}
+	if source == destination {
+		return true, nil
+	}
+
+	src.Set.Remove(member)
+	s.notifyEvent(pubsub.NotifySet, "srem", source)
+	s.deleteIfEmptySet(source, src)
+
+	dst, _ := s.lookupOrCreateSet(destination)
+	dst.Set.Add(member)
+	s.notifyEvent(pubsub.NotifySet, "sadd", destination)
+	return true, nil
+}
+
+// 모든 집합의 교집합. 없는 키는 빈 집합으로 본다.
+func (s *Store) SInter(keys ...string) ([]string, error) {
+	return s.setAlgebra(keys, interSets)
+}
+
+// 모든 집합의 합집합
+func (s *Store) SUnion(keys ...string) ([]string, error) {
+	return s.setAlgebra(keys, unionSets)
+}
+
+// 첫 번째 집합에서 나머지 집합들의 요소를 뺀 차집합
+func (s *Store) SDiff(keys ...string) ([]string, error) {
+	return s.setAlgebra(keys, diffSets)
+}
+
+// 교집합을 destination에 저장하고 요소 개수를 반환한다.
+func (s *Store) SInterStore(destination string, keys ...string) (int, error) {
+	return s.setAlgebraStore(destination, keys, interSets, "sinterstore")
+}
+
+// 합집합을 destination에 저장하고 요소 개수를 반환한다.
+func (s *Store) SUnionStore(destination string, keys ...string) (int, error) {
+	return s.setAlgebraStore(destination, keys, unionSets, "sunionstore")
+}
+
+// 차집합을 destination에 저장하고 요소 개수를 반환한다.
+func (s *Store) SDiffStore(destination string, keys ...string) (int, error) {
+	return s.setAlgebraStore(destination, keys, diffSets, "sdiffstore")
+}
+
+// 교집합의 크기만 센다. limit이 0보다 크면 limit에 도달하는 즉시 멈춘다.
+func (s *Store) SInterCard(limit int, keys ...string) (int, error) {
+	locks := s.rlock(keys...)
+	defer s.runlock(locks)
+
+	sets, err := s.lookupSets(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	eachInter(sets, func(string) bool {
+		count++
+		return limit == 0 || count < limit
+	})
+	return count, nil
+}
+
+// 커서 기반으로 요소를 순회한다 (SSCAN).
+// pattern이 비어있지 않으면 일치하는 요소만 반환한다. 커서가 0이면 순회가 끝난 것이다.
+func (s *Store) SScan(key string, cursor uint64, pattern string, count int) (uint64, []string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupSet(key)
+	if err != nil || entry == nil {
+		return 0, []string{}, err
+	}
+
+	result := []string{}
+	next := entry.Set.Scan(cursor, count, func(member string) {
+		if pattern == "" || glob.Match(pattern, member) {
+			result = append(result, member)
+		}
+	})
+	return next, result, nil
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// 집합 엔트리를 찾는다. 키가 없거나 만료되었으면 nil
+// 키가 집합이 아니면 ErrWrongType을 반환한다. 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupSet(key string) (*Entry, error) {
+	entry, exist := s.data.Get(key)
+	if !exist || s.isExpired(key) {
+		return nil, nil
+	}
+	if entry.Type != TypeSet {
+		return nil, ErrWrongType
+	}
+	return entry, nil
+}
+
+// 집합 엔트리를 찾고, 없으면 새로 만든다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupOrCreateSet(key string) (*Entry, error) {
+	entry, err := s.lookupSet(key)
+	if err != nil || entry != nil {
+		return entry, err
+	}
+
+	entry = &Entry{Type: TypeSet, Set: NewSet()}
+	s.data.Set(key, entry)
+	s.notifyEvent(pubsub.NotifyNew, "new", key)
+	return entry, nil
+}
+
+// 여러 키의 집합을 찾는다. 없는 키는 nil이다. 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupSets(keys []string) ([]*Set, error) {
+	sets := make([]*Set, len(keys))
+	for i, key := range keys {
+		entry, err := s.lookupSet(key)
+		if err != nil {
+			return nil, err
+		}
+		if entry != nil {
+			sets[i] = entry.Set
+		}
+	}
+	return sets, nil
+}
+
+// 빈 집합이 되었으면 키를 삭제한다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) deleteIfEmptySet(key string, entry *Entry) {
+	if entry.Set.Len() == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+}
+
+func (s *Store) setAlgebra(keys []string, op func(sets []*Set) *Set) ([]string, error) {
+	locks := s.rlock(keys...)
+	defer s.runlock(locks)
+
+	sets, err := s.lookupSets(keys)
+	if err != nil {
+		return nil, err
+	}
+	return op(sets).Members(), nil
+}
+
+// 연산 결과를 destination에 저장한다. 기존 값은 타입과 상관없이 덮어쓰고,
+// 결과가 비어있으면 destination을 삭제한다.
+func (s *Store) setAlgebraStore(destination string, keys []string, op func(sets []*Set) *Set, event string) (int, error) {
+	locks := s.lock(append([]string{destination}, keys...)...)
+	defer s.unlock(locks)
+
+	sets, err := s.lookupSets(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	result := op(sets)
+	_, existed := s.data.Get(destination)
+	if result.Len() == 0 {
+		if existed {
+			s.data.Delete(destination)
+			s.notifyEvent(pubsub.NotifyGeneric, "del", destination)
+		}
+		return 0, nil
+	}
+
+	if !existed {
+		s.notifyEvent(pubsub.NotifyNew, "new", destination)
+	}
+	s.data.Set(destination, &Entry{Type: TypeSet, Set: result})
+	s.notifyEvent(pubsub.NotifySet, event, destination)
+	return result.Len(), nil
+}
+
+// 교집합의 요소를 차례로 fn에 넘긴다. fn이 false를 반환하면 멈춘다.
+// 가장 작은 집합을 기준으로 나머지 집합에 모두 있는지 확인한다.
+func eachInter(sets []*Set, fn func(member string) bool) {
+	if len(sets) == 0 {
+		return
+	}
+	for _, set := range sets {
+		if set == nil {
+			return
+		}
+	}
+
+	sorted := make([]*Set, len(sets))
+	copy(sorted, sets)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Len() < sorted[j].Len()
+	})
+
+	sorted[0].Range(func(member string) bool {
+		for _, other := range sorted[1:] {
+			if !other.Contains(member) {
+				return true
+			}
+		}
+		return fn(member)
+	})
+}
+
+func interSets(sets []*Set) *Set {
+	result := NewSet()
+	eachInter(sets, func(member string) bool {
+		result.Add(member)
+		return true
+	})
+	return result
+}
+
+func unionSets(sets []*Set) *Set {
+	result := NewSet()
+	for _, set := range sets {
+		if set == nil {
+			continue
+		}
+		set.Range(func(member string) bool {
+			result.Add(member)
+			return true
+		})
+	}
+	return result
+}
+
+func diffSets(sets []*Set) *Set {
+	result := NewSet()
+	if len(sets) == 0 || sets[0] == nil {
+		return result
+	}
+
+	sets[0].Range(func(member string) bool {
+		for _, other := range sets[1:] {
+			if other != nil && other.Contains(member) {
+				return true
+			}
+		}
+		result.Add(member)
+		return true
+	})
+	return result
+}
@@ -0,0 +1,252 @@
+package storage
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// 결과를 정렬해서 기대값과 비교한다
+func assertMembers(t *testing.T, actual []string, expected ...string) {
+	t.Helper()
+	sort.Strings(actual)
+	sort.Strings(expected)
+	if len(actual) != len(expected) {
+		t.Fatalf("actual: %v, expected: %v", actual, expected)
+	}
+	for i := range actual {
+		if actual[i] != expected[i] {
+			t.Fatalf("actual: %v, expected: %v", actual, expected)
+		}
+	}
+}
+
+func TestSAdd_AndMembership(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	added, err := store.SAdd("tags", "go", "redis", "go")
+
+	// then
+	if err != nil || added != 2 {
+		t.Fatalf("SAdd: %d, err: %v", added, err)
+	}
+	if ok, _ := store.SIsMember("tags", "go"); !ok {
+		t.Fatal("SIsMember(go)가 false")
+	}
+	if result, _ := store.SMIsMember("tags", "redis", "java"); !result[0] || result[1] {
+		t.Fatalf("SMIsMember: %v", result)
+	}
+	if count, _ := store.SCard("tags"); count != 2 {
+		t.Fatalf("SCard: %d, expected: 2", count)
+	}
+}
+
+func TestSet_WrongType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("str", "value")
+	store.SAdd("set", "a")
+
+	// when & then
+	if _, err := store.SAdd("str", "a"); err != ErrWrongType {
+		t.Fatalf("SAdd 에러: %v", err)
+	}
+	if _, err := store.SInter("set", "str"); err != ErrWrongType {
+		t.Fatalf("SInter 에러: %v", err)
+	}
+	if _, err := store.SMove("set", "str", "a"); err != ErrWrongType {
+		t.Fatalf("SMove 에러: %v", err)
+	}
+}
+
+func TestSRem_DeletesEmptyKey(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("set", "a", "b")
+
+	// when
+	removed, _ := store.SRem("set", "a", "b", "c")
+
+	// then
+	if removed != 2 {
+		t.Fatalf("SRem: %d, expected: 2", removed)
+	}
+	if _, exist := store.data.Get("set"); exist {
+		t.Fatal("빈 집합의 키가 삭제되지 않았습니다")
+	}
+}
+
+func TestSPop(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("set", "a", "b", "c")
+
+	// when
+	popped, _ := store.SPop("set", 2)
+	rest, _ := store.SMembers("set")
+	all, _ := store.SPop("set", 10)
+
+	// then
+	if len(popped) != 2 || len(rest) != 1 || len(all) != 1 {
+		t.Fatalf("popped: %v, rest: %v, all: %v", popped, rest, all)
+	}
+	assertMembers(t, append(popped, rest...), "a", "b", "c")
+	if _, exist := store.data.Get("set"); exist {
+		t.Fatal("빈 집합의 키가 삭제되지 않았습니다")
+	}
+}
+
+func TestSMove(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("src", "a")
+
+	// when
+	moved, _ := store.SMove("src", "dst", "a")
+	missing, _ := store.SMove("src", "dst", "a")
+
+	// then
+	if !moved || missing {
+		t.Fatalf("SMove: %v, %v", moved, missing)
+	}
+	if ok, _ := store.SIsMember("dst", "a"); !ok {
+		t.Fatal("destination에 요소가 없습니다")
+	}
+	if _, exist := store.data.Get("src"); exist {
+		t.Fatal("빈 source 키가 삭제되지 않았습니다")
+	}
+}
+
+func TestSetAlgebra(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("a", "1", "2", "3", "x")
+	store.SAdd("b", "2", "3", "4")
+	store.SAdd("c", "3", "x")
+
+	// when & then
+	inter, _ := store.SInter("a", "b")
+	assertMembers(t, inter, "2", "3")
+
+	union, _ := store.SUnion("a", "b", "missing")
+	assertMembers(t, union, "1", "2", "3", "4", "x")
+
+	diff, _ := store.SDiff("a", "b", "c")
+	assertMembers(t, diff, "1")
+
+	empty, _ := store.SInter("a", "missing")
+	assertMembers(t, empty)
+}
+
+func TestSetAlgebraStore(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("a", "1", "2", "3")
+	store.SAdd("b", "2", "3", "4")
+	store.Set("dst", "string value")
+
+	// when: 기존 값은 타입과 상관없이 덮어쓴다
+	count, err := store.SInterStore("dst", "a", "b")
+
+	// then
+	if err != nil || count != 2 {
+		t.Fatalf("SInterStore: %d, err: %v", count, err)
+	}
+	members, _ := store.SMembers("dst")
+	assertMembers(t, members, "2", "3")
+
+	// when: 결과가 비어있으면 destination을 삭제한다
+	count, _ = store.SDiffStore("dst", "a", "a")
+
+	// then
+	if count != 0 {
+		t.Fatalf("SDiffStore: %d, expected: 0", count)
+	}
+	if _, exist := store.data.Get("dst"); exist {
+		t.Fatal("빈 결과의 destination이 삭제되지 않았습니다")
+	}
+
+	if count, _ := store.SUnionStore("dst", "a", "b"); count != 4 {
+		t.Fatalf("SUnionStore: %d, expected: 4", count)
+	}
+}
+
+func TestSInterCard(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("a", "1", "2", "3", "4")
+	store.SAdd("b", "1", "2", "3", "5")
+
+	// when & then
+	if count, _ := store.SInterCard(0, "a", "b"); count != 3 {
+		t.Fatalf("SInterCard: %d, expected: 3", count)
+	}
+	if count, _ := store.SInterCard(2, "a", "b"); count != 2 {
+		t.Fatalf("LIMIT 2 SInterCard: %d, expected: 2", count)
+	}
+}
+
+func TestSScan(t *testing.T) {
+	// given: intset과 hashtable 두 가지 인코딩
+	store := New()
+	store.SAdd("ints", "1", "2", "10")
+	for i := 0; i < 100; i++ {
+		store.SAdd("strs", "member:"+strconv.Itoa(i))
+	}
+
+	// when: intset은 한 번에 끝난다
+	next, members, _ := store.SScan("ints", 0, "1*", 1)
+
+	// then
+	if next != 0 {
+		t.Fatalf("intset SScan 커서: %d, expected: 0", next)
+	}
+	assertMembers(t, members, "1", "10")
+
+	// when: hashtable은 커서가 0이 될 때까지 순회한다
+	seen := make(map[string]bool)
+	cursor := uint64(0)
+	for {
+		next, members, _ := store.SScan("strs", cursor, "", 10)
+		for _, member := range members {
+			seen[member] = true
+		}
+		if cursor = next; cursor == 0 {
+			break
+		}
+	}
+
+	// then
+	if len(seen) != 100 {
+		t.Fatalf("순회한 요소 수: %d, expected: 100", len(seen))
+	}
+}
+
+func TestSaveAndLoad_SetEntries(t *testing.T) {
+	// given
+	store := New()
+	store.SAdd("ints", "1", "2", "3")
+	store.SAdd("strs", "a", "b")
+	path := filepath.Join(t.TempDir(), "set.rdb")
+	store.Save(path)
+
+	// when
+	loaded := New()
+	err := loaded.Load(path)
+
+	// then: 인코딩도 다시 정해진다
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	ints, _ := loaded.SMembers("ints")
+	assertMembers(t, ints, "1", "2", "3")
+	strs, _ := loaded.SMembers("strs")
+	assertMembers(t, strs, "a", "b")
+	entry, _ := loaded.data.Get("ints")
+	if encoding := entry.Set.Encoding(); encoding != "intset" {
+		t.Fatalf("인코딩: %s, expected: intset", encoding)
+	}
+}
@@ -0,0 +1,197 @@
+package storage
+
+import (
+	"hash/maphash"
+	"slices"
+	"sync"
+)
+
+// 키 공간을 나누는 샤드 수 (2의 거듭제곱)
+const shardCount = 16
+
+// 키를 샤드에 배정하는 해시 시드. 모든 저장소가 같은 시드를 써야
+// SWAPDB처럼 샤드를 통째로 맞바꿔도 키가 제자리에 있다.
+var shardSeed = maphash.MakeSeed()
+
+// 샤드는 키 공간의 일부와 그 키들의 만료 힙을 갖고, 자기 락으로 보호한다.
+// 다른 샤드의 키를 다루는 연결과는 서로 기다리지 않는다.
+type shard struct {
+	mu   sync.RWMutex
+	data *keyspace
+	heap *MinHeap
+
+	// 이 샤드 하나만 담은 락 목록. 키 하나짜리 명령어가 매번 슬라이스를 할당하지 않게 미리 만들어 둔다
+	only shardLocks
+}
+
+func newShard() *shard {
+	sh := &shard{data: newKeyspace(), heap: NewMinHeap()}
+	sh.only = shardLocks{sh}
+	return sh
+}
+
+// 엔트리의 만료 시간에 맞춰 만료 힙 항목을 넣거나 고치거나 뺀다.
+// 샤드의 쓰기 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (sh *shard) trackExpire(key string, entry *Entry) {
+	if entry.ExpireAt == nil {
+		sh.heap.Remove(key)
+	} else {
+		sh.heap.Set(key, *entry.ExpireAt)
+	}
+}
+
+// 키가 속한 샤드 번호
+func shardIndex(key string) int {
+	return int(maphash.String(shardSeed, key) & (shardCount - 1))
+}
+
+// 샤드들의 키 공간을 하나의 키 공간처럼 다룬다.
+// 키별 연산은 그 키의 샤드로 보내므로 해당 샤드의 락만 잡고 있으면 되고,
+// 모든 샤드를 훑는 연산(Len, Range 등)은 모든 샤드의 락을 잡고 있어야 한다.
+type shardedKeyspace []*shard
+
+func (k shardedKeyspace) Get(key string) (*Entry, bool) {
+	return k[shardIndex(key)].data.Get(key)
+}
+
+func (k shardedKeyspace) peek(key string) (*Entry, bool) {
+	return k[shardIndex(key)].data.peek(key)
+}
+
+// 엔트리를 넣고, 엔트리의 만료 시간에 맞춰 만료 힙 항목을 넣거나 뺀다.
+func (k shardedKeyspace) Set(key string, entry *Entry) bool {
+	sh := k[shardIndex(key)]
+	added := sh.data.Set(key, entry)
+	sh.trackExpire(key, entry)
+	return added
+}
+
+// 엔트리와 만료 힙 항목을 함께 지운다.
+func (k shardedKeyspace) Delete(key string) bool {
+	sh := k[shardIndex(key)]
+	sh.heap.Remove(key)
+	return sh.data.Delete(key)
+}
+
+// 값을 바꾼 엔트리의 크기를 다시 재고, 만료 시간이 바뀌었으면(PERSIST, SET 등) 만료 힙도 맞춘다.
+func (k shardedKeyspace) remeasure(key string) {
+	sh := k[shardIndex(key)]
+	sh.data.remeasure(key)
+	if entry, exist := sh.data.peek(key); exist {
+		sh.trackExpire(key, entry)
+	}
+}
+
+// 모든 샤드의 키 수
+func (k shardedKeyspace) Len() int {
+	n := 0
+	for _, sh := range k {
+		n += sh.data.Len()
+	}
+	return n
+}
+
+// 모든 샤드의 키를 차례로 순회한다. fn이 false를 반환하면 멈춘다.
+func (k shardedKeyspace) Range(fn func(key string, entry *Entry) bool) {
+	stopped := false
+	for _, sh := range k {
+		sh.data.Range(func(key string, entry *Entry) bool {
+			stopped = !fn(key, entry)
+			return !stopped
+		})
+		if stopped {
+			return
+		}
+	}
+}
+
+// 키가 속한 샤드
+func (s *Store) shardFor(key string) *shard {
+	return s.shards[shardIndex(key)]
+}
+
+// 락을 잡은 샤드 목록. 샤드 번호 순으로 정렬되어 있다
+type shardLocks []*shard
+
+// 키들이 속한 샤드의 쓰기 락을 샤드 번호 순서대로 잡는다.
+// 여러 샤드를 잡는 명령어끼리도 항상 같은 순서로 잡으므로 교착되지 않는다.
+// 락을 잡은 뒤 키들 중 만료된 키를 지우므로, 쓰기 명령어는 만료된 키를 보지 않는다.
+func (s *Store) lock(keys ...string) shardLocks {
+	locks := s.shardsOf(keys)
+	locks.lock()
+	for _, key := range keys {
+		s.expireIfNeeded(key)
+	}
+	return locks
+}
+
+// 키들이 속한 샤드의 읽기 락을 샤드 번호 순서대로 잡는다.
+// 읽기 명령어용이다. 만료된 키는 지우지 않으므로 isExpired로 걸러야 한다.
+func (s *Store) rlock(keys ...string) shardLocks {
+	locks := s.shardsOf(keys)
+	for _, sh := range locks {
+		sh.mu.RLock()
+	}
+	return locks
+}
+
+// 모든 샤드의 쓰기 락을 잡는다 (FLUSHDB, SWAPDB 등 키 공간 전체를 바꾸는 연산).
+func (s *Store) lockAll() shardLocks {
+	locks := shardLocks(s.shards)
+	locks.lock()
+	return locks
+}
+
+// 모든 샤드의 읽기 락을 잡는다 (스냅샷 저장 등).
+func (s *Store) rlockAll() shardLocks {
+	locks := shardLocks(s.shards)
+	for _, sh := range locks {
+		sh.mu.RLock()
+	}
+	return locks
+}
+
+// lock, lockAll로 잡은 락을 풀고, 그동안 데이터가 들어온 키에서 기다리던 블로킹 명령어를 처리한다.
+func (s *Store) unlock(locks shardLocks) {
+	locks.unlock()
+	s.serveReady()
+}
+
+// rlock, rlockAll로 잡은 락을 푼다.
+func (s *Store) runlock(locks shardLocks) {
+	for _, sh := range locks {
+		sh.mu.RUnlock()
+	}
+}
+
+// 키들이 속한 샤드를 중복 없이 번호 순으로 모은다.
+func (s *Store) shardsOf(keys []string) shardLocks {
+	if len(keys) == 1 {
+		return s.shardFor(keys[0]).only
+	}
+
+	indexes := make([]int, 0, len(keys))
+	for _, key := range keys {
+		indexes = append(indexes, shardIndex(key))
+	}
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+
+	locks := make(shardLocks, len(indexes))
+	for i, index := range indexes {
+		locks[i] = s.shards[index]
+	}
+	return locks
+}
+
+func (l shardLocks) lock() {
+	for _, sh := range l {
+		sh.mu.Lock()
+	}
+}
+
+func (l shardLocks) unlock() {
+	for _, sh := range l {
+		sh.mu.Unlock()
+	}
+}
@@ -0,0 +1,155 @@
+package storage
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestShardsOf(t *testing.T) {
+	// given: 여러 샤드에 흩어진 키와 중복 키
+	store := New()
+	keys := []string{}
+	for i := range 512 {
+		keys = append(keys, "key:"+strconv.Itoa(i), "key:"+strconv.Itoa(i))
+	}
+
+	// when
+	locks := store.shardsOf(keys)
+
+	// then: 샤드 번호 순서대로, 중복 없이
+	seen := map[*shard]bool{}
+	last := -1
+	for _, sh := range locks {
+		index := -1
+		for i, candidate := range store.shards {
+			if candidate == sh {
+				index = i
+			}
+		}
+		if index <= last || seen[sh] {
+			t.Fatalf("샤드 순서가 잘못되었습니다: %d 다음 %d", last, index)
+		}
+		seen[sh] = true
+		last = index
+	}
+	if len(locks) != shardCount {
+		t.Fatalf("잡은 샤드 수: %d, expected: %d", len(locks), shardCount)
+	}
+}
+
+func TestConcurrentMultiKeyCommands(t *testing.T) {
+	// given: 서로 다른 샤드의 키를 반대 순서로 다루는 명령어들
+	store := New()
+	var wg sync.WaitGroup
+
+	// when: 교착 상태가 생기면 테스트가 끝나지 않는다
+	for worker := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				a, b := "a:"+strconv.Itoa(i%10), "b:"+strconv.Itoa(i%7)
+				if worker%2 == 1 {
+					a, b = b, a
+				}
+				store.MSet(a, "1", b, "2")
+				store.RPush("list:"+a, "x")
+				store.LMove("list:"+a, "list:"+b, true, false)
+				store.Copy(a, b, true)
+				store.Del(a, b)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// then
+	if size := store.DBSize(); size > 17 {
+		t.Fatalf("남은 키 수: %d", size)
+	}
+}
+
+func TestScanAcrossShards(t *testing.T) {
+	// given
+	store := New()
+	for i := range 1000 {
+		store.Set(fmt.Sprintf("key:%d", i), "v")
+	}
+
+	// when: 커서가 0이 될 때까지 작은 COUNT로 순회
+	found := map[string]bool{}
+	cursor := uint64(0)
+	for {
+		var keys []string
+		cursor, keys = store.Scan(cursor, "", 7, "")
+		for _, key := range keys {
+			found[key] = true
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+
+	// then
+	if len(found) != 1000 {
+		t.Fatalf("SCAN으로 찾은 키 수: %d, expected: 1000", len(found))
+	}
+}
+
+func TestReadSkipsExpiredKeyWithoutDeleting(t *testing.T) {
+	// given: 만료 시각이 지난 키
+	store := New()
+	store.Set("session", "abc")
+	store.RPush("queue", "a")
+	store.ExpireAt("session", time.Now().Add(20*time.Millisecond), 0)
+	store.ExpireAt("queue", time.Now().Add(20*time.Millisecond), 0)
+	time.Sleep(30 * time.Millisecond)
+
+	// when: 읽기 명령어로 조회
+	_, exist := store.Get("session")
+	values, _ := store.LRange("queue", 0, -1)
+
+	// then: 없는 키로 보이지만 아직 키 공간에는 남아 있다
+	if exist || len(values) != 0 {
+		t.Fatalf("만료된 키가 조회되었습니다: %v %v", exist, values)
+	}
+	if _, stored := store.data.peek("session"); !stored {
+		t.Fatal("읽기 락에서 만료된 키를 지웠습니다")
+	}
+
+	// when: 같은 키에 쓰기 명령어를 실행
+	store.RPush("queue", "b")
+
+	// then: 쓰기 락을 잡을 때 지워지고 새 리스트로 시작한다
+	if values, _ := store.LRange("queue", 0, -1); len(values) != 1 || values[0] != "b" {
+		t.Fatalf("queue: %v, expected: [b]", values)
+	}
+}
+
+func TestConcurrentReadsAndWritesOnExpiringKeys(t *testing.T) {
+	// given
+	store := New()
+	var wg sync.WaitGroup
+
+	// when: 읽기와 만료/쓰기가 같은 키에서 섞인다 (-race로 확인)
+	for worker := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				key := "key:" + strconv.Itoa(i%5)
+				if worker%2 == 0 {
+					store.Set(key, "v")
+					store.ExpireAt(key, time.Now().Add(time.Millisecond), 0)
+				} else {
+					store.Get(key)
+					store.Exists(key)
+					store.Keys("*")
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
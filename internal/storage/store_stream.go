@@ -0,0 +1,904 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"inmemory-db/internal/persistence"
+	"inmemory-db/internal/pubsub"
+	"time"
+)
+
+var (
+	ErrStreamIDTooSmall = errors.New("The ID specified in XADD is equal or smaller than the target stream top item")
+	ErrStreamIDZero     = errors.New("The ID specified in XADD must be greater than 0-0")
+	ErrStreamExhausted  = errors.New("The stream has exhausted the last possible ID, unable to add more items")
+	ErrBusyGroup        = errors.New("BUSYGROUP Consumer Group name already exists")
+	ErrXGroupNoKey      = errors.New("The XGROUP subcommand requires the key to exist. Note that for CREATE you may want to use the MKSTREAM option to create an empty stream automatically.")
+)
+
+// 스트림 키나 소비자 그룹이 없을 때의 에러
+type NoGroupError struct {
+	Key, Group string
+}
+
+func (e *NoGroupError) Error() string {
+	return fmt.Sprintf("NOGROUP No such key '%s' or consumer group '%s'", e.Key, e.Group)
+}
+
+// XADD에 넘기는 ID
+type XAddID struct {
+	ID      StreamID
+	AutoMs  bool // "*": 밀리초와 시퀀스를 모두 자동으로 만든다
+	AutoSeq bool // "ms-*": 시퀀스만 자동으로 만든다
+}
+
+// XADD의 MAXLEN/MINID 옵션
+type StreamTrim struct {
+	ByMinID bool
+	MaxLen  int
+	MinID   StreamID
+	// 한 번에 삭제할 최대 엔트리 수. 0이면 제한 없음
+	Limit int
+}
+
+// XADD 옵션
+type XAddOptions struct {
+	NoMkStream bool // 키가 없으면 스트림을 만들지 않는다
+	Trim       *StreamTrim
+}
+
+// XREAD/XREADGROUP의 STREAMS 인자 하나
+type XReadStream struct {
+	Key string
+	// 이 ID 다음 엔트리부터 읽는다
+	ID StreamID
+	// "$": 지금 스트림의 마지막 ID 다음부터 (XREAD)
+	Last bool
+	// ">": 그룹에 아직 전달하지 않은 엔트리 (XREADGROUP)
+	New bool
+}
+
+// XREAD/XREADGROUP 결과의 스트림 하나
+type StreamReadResult struct {
+	Key     string
+	Entries []StreamEntry
+}
+
+// XPENDING 요약
+type PendingSummary struct {
+	Count     int
+	Min, Max  StreamID
+	Consumers []ConsumerPending
+}
+
+type ConsumerPending struct {
+	Name  string
+	Count int
+}
+
+// XPENDING 확장 형식의 옵션
+type PendingOptions struct {
+	Start, End StreamID
+	Count      int
+	// 비어있지 않으면 이 소비자의 엔트리만
+	Consumer string
+	MinIdle  time.Duration
+}
+
+// XPENDING 확장 형식의 엔트리 하나
+type PendingInfo struct {
+	ID            StreamID
+	Consumer      string
+	Idle          time.Duration
+	DeliveryCount int
+}
+
+// XCLAIM 옵션
+type XClaimOptions struct {
+	// IDLE/TIME으로 지정한 전달 시각. nil이면 지금
+	DeliveryTime *time.Time
+	// RETRYCOUNT. nil이면 전달 횟수를 1 늘린다 (JUSTID면 그대로)
+	RetryCount *int
+	// PEL에 없는 엔트리도 스트림에 있으면 가져온다
+	Force  bool
+	JustID bool
+	// 그룹의 마지막 전달 ID를 이 값으로 올린다
+	LastID *StreamID
+}
+
+// XINFO STREAM 결과
+type StreamInfo struct {
+	Length       int
+	LastID       StreamID
+	MaxDeletedID StreamID
+	EntriesAdded uint64
+	Groups       int
+	// 비어있으면 nil
+	FirstEntry, LastEntry *StreamEntry
+}
+
+// XINFO GROUPS 결과의 그룹 하나
+type GroupInfo struct {
+	Name            string
+	Consumers       int
+	Pending         int
+	LastDeliveredID StreamID
+	// 알 수 없으면 -1
+	EntriesRead int64
+	Lag         int64
+}
+
+// XINFO CONSUMERS 결과의 소비자 하나
+type ConsumerInfo struct {
+	Name    string
+	Pending int
+	Idle    time.Duration
+	// 엔트리를 받은 적이 없으면 -1
+	Inactive time.Duration
+}
+
+// 스트림에 엔트리를 추가하고 ID를 반환한다.
+// NOMKSTREAM인데 키가 없으면 ok=false
+func (s *Store) XAdd(key string, options XAddOptions, id XAddID, fields []string) (StreamID, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return StreamID{}, false, err
+	}
+	if entry == nil && options.NoMkStream {
+		return StreamID{}, false, nil
+	}
+
+	// ID가 잘못되었을 때 빈 스트림이 남지 않도록 만들기 전에 검사한다
+	stream := NewStream()
+	if entry != nil {
+		stream = entry.Stream
+	}
+	newID, err := nextXAddID(stream, id, s.clock.Now())
+	if err != nil {
+		return StreamID{}, false, err
+	}
+
+	if entry == nil {
+		entry = s.createStream(key)
+	}
+	entry.Stream.Add(newID, fields)
+	s.notifyEvent(pubsub.NotifyStream, "xadd", key)
+
+	if options.Trim != nil && trimStream(entry.Stream, *options.Trim) > 0 {
+		s.notifyEvent(pubsub.NotifyStream, "xtrim", key)
+	}
+	s.serveBlocked(key)
+
+	return newID, true, nil
+}
+
+// 엔트리 개수
+func (s *Store) XLen(key string) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return entry.Stream.Len(), nil
+}
+
+// start 이상 end 이하의 엔트리를 최대 count개(음수면 전부) 반환한다.
+// reverse면 end부터 거꾸로 반환한다 (XREVRANGE).
+func (s *Store) XRange(key string, start, end StreamID, reverse bool, count int) ([]StreamEntry, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return []StreamEntry{}, nil
+	}
+	return entry.Stream.Range(start, end, reverse, count), nil
+}
+
+// 각 스트림에서 ID 다음 엔트리를 최대 count개(음수면 전부)씩 읽는다.
+// 엔트리가 있는 스트림만 결과에 들어간다.
+func (s *Store) XRead(streams []XReadStream, count int) ([]StreamReadResult, error) {
+	locks := s.rlock(streamKeys(streams)...)
+	defer s.runlock(locks)
+
+	if err := s.resolveLastIDs(streams); err != nil {
+		return nil, err
+	}
+	return s.readStreamsLocked(streams, count)
+}
+
+// XREAD BLOCK. 읽을 엔트리가 없으면 다른 클라이언트가 XADD할 때까지 기다린다.
+// ctx가 끝나면(타임아웃, CLIENT UNBLOCK) nil을 반환한다.
+func (s *Store) BlockingXRead(ctx context.Context, streams []XReadStream, count int) ([]StreamReadResult, error) {
+	locks := s.lock(streamKeys(streams)...)
+	defer s.unlock(locks)
+
+	// "$"는 기다리기 시작한 시점의 마지막 ID다
+	if err := s.resolveLastIDs(streams); err != nil {
+		return nil, err
+	}
+	results, err := s.readStreamsLocked(streams, count)
+	if err != nil || len(results) > 0 {
+		return results, err
+	}
+
+	ids := make(map[string]StreamID, len(streams))
+	for _, stream := range streams {
+		ids[stream.Key] = stream.ID
+	}
+
+	w := &waiter{
+		keys: streamKeys(streams),
+		serve: func(k string, entry *Entry) bool {
+			if entry.Type != TypeStream {
+				return false
+			}
+			entries := readAfter(entry.Stream, ids[k], count)
+			if len(entries) == 0 {
+				return false
+			}
+			results = []StreamReadResult{{Key: k, Entries: entries}}
+			return true
+		},
+	}
+	if !s.waitLocked(ctx, w, locks) {
+		return nil, nil
+	}
+	return results, nil
+}
+
+// XREAD 계열 명령어가 읽는 스트림 키들
+func streamKeys(streams []XReadStream) []string {
+	keys := make([]string, len(streams))
+	for i, stream := range streams {
+		keys[i] = stream.Key
+	}
+	return keys
+}
+
+// 소비자 그룹을 만든다. last면 스트림의 마지막 ID부터 전달한다 ("$").
+// entriesRead가 음수면 알 수 있는 경우에만 추정한다.
+func (s *Store) XGroupCreate(key, group string, id StreamID, last, mkStream bool, entriesRead int64) error {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return err
+	}
+	if entry == nil {
+		if !mkStream {
+			return ErrXGroupNoKey
+		}
+		entry = s.createStream(key)
+	}
+
+	stream := entry.Stream
+	if last {
+		id = stream.LastID
+	}
+	if entriesRead < 0 {
+		entriesRead = estimateEntriesRead(stream, id)
+	}
+	if stream.CreateGroup(group, id, entriesRead) == nil {
+		return ErrBusyGroup
+	}
+	s.notifyEvent(pubsub.NotifyStream, "xgroup-create", key)
+	return nil
+}
+
+// 소비자 그룹을 삭제한다. 삭제했으면 true
+func (s *Store) XGroupDestroy(key, group string) (bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return false, err
+	}
+	if entry == nil {
+		return false, ErrXGroupNoKey
+	}
+	if !entry.Stream.DestroyGroup(group) {
+		return false, nil
+	}
+	s.notifyEvent(pubsub.NotifyStream, "xgroup-destroy", key)
+	return true, nil
+}
+
+// 소비자 그룹으로 읽는다.
+// ">"면 그룹에 아직 전달하지 않은 엔트리를 consumer에게 전달하고 PEL에 기록한다 (noAck면 기록하지 않음).
+// ID를 지정하면 consumer의 PEL에서 그 ID 다음 엔트리들을 다시 읽는다.
+func (s *Store) XReadGroup(group, consumer string, streams []XReadStream, count int, noAck bool) ([]StreamReadResult, error) {
+	locks := s.lock(streamKeys(streams)...)
+	defer s.unlock(locks)
+
+	return s.readGroupLocked(group, consumer, streams, count, noAck)
+}
+
+// XREADGROUP BLOCK. ">"로 읽을 엔트리가 없으면 다른 클라이언트가 XADD할 때까지 기다린다.
+// ctx가 끝나면(타임아웃, CLIENT UNBLOCK) nil을 반환한다.
+func (s *Store) BlockingXReadGroup(ctx context.Context, group, consumer string, streams []XReadStream, count int, noAck bool) ([]StreamReadResult, error) {
+	locks := s.lock(streamKeys(streams)...)
+	defer s.unlock(locks)
+
+	results, err := s.readGroupLocked(group, consumer, streams, count, noAck)
+	if err != nil || len(results) > 0 {
+		return results, err
+	}
+
+	w := &waiter{
+		keys: streamKeys(streams),
+		serve: func(k string, entry *Entry) bool {
+			if entry.Type != TypeStream {
+				return false
+			}
+			// 기다리는 동안 그룹이 삭제되었으면 에러로 응답한다
+			g := entry.Stream.Group(group)
+			if g == nil {
+				err = &NoGroupError{Key: k, Group: group}
+				return true
+			}
+			now := s.clock.Now()
+			entries := deliverNew(entry.Stream, g, g.Consumer(consumer, now), count, noAck, now)
+			if len(entries) == 0 {
+				return false
+			}
+			results = []StreamReadResult{{Key: k, Entries: entries}}
+			return true
+		},
+	}
+	if !s.waitLocked(ctx, w, locks) {
+		return nil, nil
+	}
+	return results, err
+}
+
+// PEL에서 엔트리들을 제거하고 제거한 개수를 반환한다. 키나 그룹이 없으면 0
+func (s *Store) XAck(key, group string, ids ...StreamID) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	g := entry.Stream.Group(group)
+	if g == nil {
+		return 0, nil
+	}
+
+	acked := 0
+	for _, id := range ids {
+		if g.Ack(id) {
+			acked++
+		}
+	}
+	return acked, nil
+}
+
+// XPENDING key group. PEL의 개수, 가장 작은/큰 ID, 소비자별 개수를 반환한다.
+func (s *Store) XPendingSummary(key, group string) (PendingSummary, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	_, g, err := s.lookupGroup(key, group)
+	if err != nil {
+		return PendingSummary{}, err
+	}
+
+	summary := PendingSummary{Count: g.PendingLen()}
+	if summary.Count == 0 {
+		return summary, nil
+	}
+	summary.Min = g.pelIDs[0]
+	summary.Max = g.pelIDs[len(g.pelIDs)-1]
+	for _, consumer := range g.Consumers() {
+		if consumer.Pending > 0 {
+			summary.Consumers = append(summary.Consumers, ConsumerPending{Name: consumer.Name, Count: consumer.Pending})
+		}
+	}
+	return summary, nil
+}
+
+// XPENDING key group [IDLE min-idle] start end count [consumer]
+func (s *Store) XPending(key, group string, options PendingOptions) ([]PendingInfo, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	_, g, err := s.lookupGroup(key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	result := []PendingInfo{}
+	if options.Count <= 0 {
+		return result, nil
+	}
+	g.RangePending(options.Start, options.End, func(pending *PendingEntry) bool {
+		if options.Consumer != "" && pending.Consumer.Name != options.Consumer {
+			return true
+		}
+		idle := now.Sub(pending.DeliveryTime)
+		if idle < options.MinIdle {
+			return true
+		}
+		result = append(result, PendingInfo{
+			ID:            pending.ID,
+			Consumer:      pending.Consumer.Name,
+			Idle:          idle,
+			DeliveryCount: pending.DeliveryCount,
+		})
+		return len(result) < options.Count
+	})
+	return result, nil
+}
+
+// minIdle 이상 처리되지 않은 PEL 엔트리들을 consumer에게 넘기고, 넘긴 엔트리를 반환한다.
+// 스트림에서 이미 삭제된 엔트리는 PEL에서 제거한다.
+// JUSTID면 엔트리의 Fields는 nil이다.
+func (s *Store) XClaim(key, group, consumer string, minIdle time.Duration, ids []StreamID, options XClaimOptions) ([]StreamEntry, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, g, err := s.lookupGroup(key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	deliveryTime := now
+	if options.DeliveryTime != nil {
+		deliveryTime = *options.DeliveryTime
+	}
+	if options.LastID != nil && g.LastID.Less(*options.LastID) {
+		g.LastID = *options.LastID
+	}
+
+	c := g.Consumer(consumer, now)
+	result := []StreamEntry{}
+	for _, id := range ids {
+		streamEntry, exist := entry.Stream.Get(id)
+		pending := g.Pending(id)
+		if pending == nil {
+			if !options.Force || !exist {
+				continue
+			}
+			pending = g.insertPending(id, c, now)
+		}
+
+		if minIdle > 0 && now.Sub(pending.DeliveryTime) < minIdle {
+			continue
+		}
+		if !exist {
+			g.Ack(id)
+			continue
+		}
+
+		g.Transfer(pending, c)
+		pending.DeliveryTime = deliveryTime
+		if options.RetryCount != nil {
+			pending.DeliveryCount = *options.RetryCount
+		} else if !options.JustID {
+			pending.DeliveryCount++
+		}
+		c.ActiveTime = now
+
+		if options.JustID {
+			streamEntry.Fields = nil
+		}
+		result = append(result, streamEntry)
+	}
+	return result, nil
+}
+
+// start부터 PEL을 훑으며 minIdle 이상 처리되지 않은 엔트리를 최대 count개 consumer에게 넘긴다.
+// 다음 호출에 쓸 커서(끝까지 훑었으면 0-0), 넘긴 엔트리, PEL에서 제거한 삭제된 엔트리의 ID를 반환한다.
+func (s *Store) XAutoClaim(key, group, consumer string, minIdle time.Duration, start StreamID, count int, justID bool) (StreamID, []StreamEntry, []StreamID, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, g, err := s.lookupGroup(key, group)
+	if err != nil {
+		return StreamID{}, nil, nil, err
+	}
+
+	now := s.clock.Now()
+	c := g.Consumer(consumer, now)
+	claimed := []StreamEntry{}
+	deleted := []StreamID{}
+
+	// 한 번에 너무 오래 훑지 않도록 count의 10배까지만 본다 (Redis 동작)
+	attempts := count * 10
+	i := g.searchPending(start)
+	for attempts > 0 && len(claimed) < count && i < len(g.pelIDs) {
+		attempts--
+		pending := g.pel[g.pelIDs[i]]
+		if now.Sub(pending.DeliveryTime) < minIdle {
+			i++
+			continue
+		}
+
+		streamEntry, exist := entry.Stream.Get(pending.ID)
+		if !exist {
+			deleted = append(deleted, pending.ID)
+			g.Ack(pending.ID)
+			continue
+		}
+
+		g.Transfer(pending, c)
+		pending.DeliveryTime = now
+		if !justID {
+			pending.DeliveryCount++
+		} else {
+			streamEntry.Fields = nil
+		}
+		c.ActiveTime = now
+		claimed = append(claimed, streamEntry)
+		i++
+	}
+
+	next := StreamID{}
+	if i < len(g.pelIDs) {
+		next = g.pelIDs[i]
+	}
+	return next, claimed, deleted, nil
+}
+
+// XINFO STREAM. 키가 없으면 ErrNoSuchKey
+func (s *Store) XInfoStream(key string) (StreamInfo, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return StreamInfo{}, err
+	}
+	if entry == nil {
+		return StreamInfo{}, ErrNoSuchKey
+	}
+
+	stream := entry.Stream
+	info := StreamInfo{
+		Length:       stream.Len(),
+		LastID:       stream.LastID,
+		MaxDeletedID: stream.MaxDeletedID,
+		EntriesAdded: stream.EntriesAdded,
+		Groups:       len(stream.groups),
+	}
+	if first, last, ok := stream.Bounds(); ok {
+		info.FirstEntry, info.LastEntry = &first, &last
+	}
+	return info, nil
+}
+
+// XINFO GROUPS. 그룹 이름 순서로 반환한다. 키가 없으면 ErrNoSuchKey
+func (s *Store) XInfoGroups(key string) ([]GroupInfo, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, ErrNoSuchKey
+	}
+
+	result := []GroupInfo{}
+	for _, g := range entry.Stream.Groups() {
+		result = append(result, GroupInfo{
+			Name:            g.Name,
+			Consumers:       len(g.consumers),
+			Pending:         g.PendingLen(),
+			LastDeliveredID: g.LastID,
+			EntriesRead:     g.EntriesRead,
+			Lag:             entry.Stream.Lag(g),
+		})
+	}
+	return result, nil
+}
+
+// XINFO CONSUMERS. 소비자 이름 순서로 반환한다.
+func (s *Store) XInfoConsumers(key, group string) ([]ConsumerInfo, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	_, g, err := s.lookupGroup(key, group)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.clock.Now()
+	result := []ConsumerInfo{}
+	for _, c := range g.Consumers() {
+		info := ConsumerInfo{Name: c.Name, Pending: c.Pending, Idle: now.Sub(c.SeenTime), Inactive: -1}
+		if !c.ActiveTime.IsZero() {
+			info.Inactive = now.Sub(c.ActiveTime)
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// 키가 없거나 만료되었으면 nil, 스트림이 아니면 ErrWrongType을 반환한다.
+// 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupStream(key string) (*Entry, error) {
+	entry, exist := s.data.Get(key)
+	if !exist || s.isExpired(key) {
+		return nil, nil
+	}
+	if entry.Type != TypeStream {
+		return nil, ErrWrongType
+	}
+	return entry, nil
+}
+
+// 빈 스트림을 만든다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+// 다른 타입과 달리 엔트리가 모두 사라져도 키를 삭제하지 않는다 (Redis 동작).
+func (s *Store) createStream(key string) *Entry {
+	entry := &Entry{Type: TypeStream, Stream: NewStream()}
+	s.data.Set(key, entry)
+	s.notifyEvent(pubsub.NotifyNew, "new", key)
+	return entry
+}
+
+// 스트림과 소비자 그룹을 찾는다. 둘 중 하나라도 없으면 NoGroupError
+func (s *Store) lookupGroup(key, group string) (*Entry, *ConsumerGroup, error) {
+	entry, err := s.lookupStream(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	if entry == nil || entry.Stream.Group(group) == nil {
+		return nil, nil, &NoGroupError{Key: key, Group: group}
+	}
+	return entry, entry.Stream.Group(group), nil
+}
+
+// "$"로 지정한 스트림의 ID를 지금의 마지막 ID로 바꾼다. 키가 없으면 0-0
+func (s *Store) resolveLastIDs(streams []XReadStream) error {
+	for i := range streams {
+		entry, err := s.lookupStream(streams[i].Key)
+		if err != nil {
+			return err
+		}
+		if streams[i].Last {
+			streams[i].ID = StreamID{}
+			if entry != nil {
+				streams[i].ID = entry.Stream.LastID
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Store) readStreamsLocked(streams []XReadStream, count int) ([]StreamReadResult, error) {
+	var results []StreamReadResult
+	for _, stream := range streams {
+		entry, err := s.lookupStream(stream.Key)
+		if err != nil {
+			return nil, err
+		}
+		if entry == nil {
+			continue
+		}
+		if entries := readAfter(entry.Stream, stream.ID, count); len(entries) > 0 {
+			results = append(results, StreamReadResult{Key: stream.Key, Entries: entries})
+		}
+	}
+	return results, nil
+}
+
+func (s *Store) readGroupLocked(group, consumer string, streams []XReadStream, count int, noAck bool) ([]StreamReadResult, error) {
+	// 하나라도 그룹이 없으면 아무것도 읽지 않는다
+	groups := make([]*ConsumerGroup, len(streams))
+	entries := make([]*Entry, len(streams))
+	for i, stream := range streams {
+		entry, g, err := s.lookupGroup(stream.Key, group)
+		if err != nil {
+			return nil, err
+		}
+		entries[i], groups[i] = entry, g
+	}
+
+	now := s.clock.Now()
+	var results []StreamReadResult
+	for i, stream := range streams {
+		g := groups[i]
+		c := g.Consumer(consumer, now)
+
+		if stream.New {
+			if delivered := deliverNew(entries[i].Stream, g, c, count, noAck, now); len(delivered) > 0 {
+				results = append(results, StreamReadResult{Key: stream.Key, Entries: delivered})
+			}
+			continue
+		}
+
+		// 이력 읽기는 엔트리가 없어도 스트림을 결과에 넣는다
+		history := []StreamEntry{}
+		start, ok := stream.ID.Next()
+		if ok {
+			g.RangePending(start, MaxStreamID, func(pending *PendingEntry) bool {
+				if pending.Consumer != c {
+					return true
+				}
+				streamEntry, exist := entries[i].Stream.Get(pending.ID)
+				if !exist {
+					streamEntry = StreamEntry{ID: pending.ID}
+				}
+				history = append(history, streamEntry)
+				return count < 0 || len(history) < count
+			})
+		}
+		results = append(results, StreamReadResult{Key: stream.Key, Entries: history})
+	}
+	return results, nil
+}
+
+// id 다음 엔트리를 최대 count개(음수면 전부) 읽는다.
+func readAfter(stream *Stream, id StreamID, count int) []StreamEntry {
+	start, ok := id.Next()
+	if !ok {
+		return nil
+	}
+	return stream.Range(start, MaxStreamID, false, count)
+}
+
+// 그룹에 아직 전달하지 않은 엔트리를 consumer에게 전달한다. now는 전달 시각이다.
+func deliverNew(stream *Stream, g *ConsumerGroup, c *Consumer, count int, noAck bool, now time.Time) []StreamEntry {
+	entries := readAfter(stream, g.LastID, count)
+	if len(entries) == 0 {
+		return entries
+	}
+
+	for _, entry := range entries {
+		if !noAck {
+			g.Deliver(entry.ID, c, now)
+		}
+	}
+	g.LastID = entries[len(entries)-1].ID
+	if g.LastID == stream.LastID {
+		g.EntriesRead = int64(stream.EntriesAdded)
+	} else if g.EntriesRead >= 0 {
+		g.EntriesRead += int64(len(entries))
+	}
+	c.ActiveTime = now
+	return entries
+}
+
+// XADD의 ID를 정한다. 스트림의 마지막 ID보다 커야 한다. 자동 ID는 now의 밀리초를 쓴다.
+func nextXAddID(stream *Stream, id XAddID, now time.Time) (StreamID, error) {
+	switch {
+	case id.AutoMs:
+		next, ok := stream.NextID(uint64(now.UnixMilli()))
+		if !ok {
+			return StreamID{}, ErrStreamExhausted
+		}
+		return next, nil
+
+	case id.AutoSeq:
+		if id.ID.Ms < stream.LastID.Ms {
+			return StreamID{}, ErrStreamIDTooSmall
+		}
+		if id.ID.Ms > stream.LastID.Ms {
+			return StreamID{Ms: id.ID.Ms}, nil
+		}
+		next, ok := stream.LastID.Next()
+		if !ok || next.Ms != id.ID.Ms {
+			return StreamID{}, ErrStreamIDTooSmall
+		}
+		return next, nil
+	}
+
+	if id.ID == (StreamID{}) {
+		return StreamID{}, ErrStreamIDZero
+	}
+	if !stream.LastID.Less(id.ID) {
+		return StreamID{}, ErrStreamIDTooSmall
+	}
+	return id.ID, nil
+}
+
+func trimStream(stream *Stream, trim StreamTrim) int {
+	if trim.ByMinID {
+		return stream.TrimMinID(trim.MinID, trim.Limit)
+	}
+	return stream.TrimMaxLen(trim.MaxLen, trim.Limit)
+}
+
+// XGROUP CREATE에서 ENTRIESREAD를 지정하지 않았을 때 그룹이 읽은 엔트리 수를 추정한다.
+// 마지막 ID 이후라면 전부, 삭제된 적이 없는 스트림의 첫 엔트리 이전이라면 0이다.
+func estimateEntriesRead(stream *Stream, id StreamID) int64 {
+	if !id.Less(stream.LastID) {
+		return int64(stream.EntriesAdded)
+	}
+	first, _, ok := stream.Bounds()
+	if ok && stream.MaxDeletedID == (StreamID{}) && id.Less(first.ID) {
+		return 0
+	}
+	return -1
+}
+
+// 스냅샷에 저장할 형태로 바꾼다.
+func streamToPersistence(stream *Stream) *persistence.StreamData {
+	data := &persistence.StreamData{
+		Entries:      make([]persistence.StreamEntry, 0, stream.Len()),
+		LastID:       persistence.StreamID(stream.LastID),
+		EntriesAdded: stream.EntriesAdded,
+		MaxDeletedID: persistence.StreamID(stream.MaxDeletedID),
+	}
+	for _, entry := range stream.entries {
+		data.Entries = append(data.Entries, persistence.StreamEntry{ID: persistence.StreamID(entry.ID), Fields: entry.Fields})
+	}
+
+	for _, g := range stream.Groups() {
+		group := persistence.StreamGroup{
+			Name:        g.Name,
+			LastID:      persistence.StreamID(g.LastID),
+			EntriesRead: g.EntriesRead,
+		}
+		g.RangePending(StreamID{}, MaxStreamID, func(pending *PendingEntry) bool {
+			group.Pending = append(group.Pending, persistence.StreamPending{
+				ID:            persistence.StreamID(pending.ID),
+				Consumer:      pending.Consumer.Name,
+				DeliveryTime:  pending.DeliveryTime,
+				DeliveryCount: uint32(pending.DeliveryCount),
+			})
+			return true
+		})
+		for _, c := range g.Consumers() {
+			group.Consumers = append(group.Consumers, persistence.StreamConsumer{
+				Name:       c.Name,
+				SeenTime:   c.SeenTime,
+				ActiveTime: c.ActiveTime,
+			})
+		}
+		data.Groups = append(data.Groups, group)
+	}
+	return data
+}
+
+// 스냅샷에서 읽은 스트림을 복원한다.
+func streamFromPersistence(data *persistence.StreamData) *Stream {
+	stream := NewStream()
+	stream.entries = make([]StreamEntry, 0, len(data.Entries))
+	for _, entry := range data.Entries {
+		stream.entries = append(stream.entries, StreamEntry{ID: StreamID(entry.ID), Fields: entry.Fields})
+	}
+	stream.LastID = StreamID(data.LastID)
+	stream.EntriesAdded = data.EntriesAdded
+	stream.MaxDeletedID = StreamID(data.MaxDeletedID)
+
+	for _, group := range data.Groups {
+		g := stream.CreateGroup(group.Name, StreamID(group.LastID), group.EntriesRead)
+		for _, consumer := range group.Consumers {
+			g.consumers[consumer.Name] = &Consumer{
+				Name:       consumer.Name,
+				SeenTime:   consumer.SeenTime,
+				ActiveTime: consumer.ActiveTime,
+			}
+		}
+		for _, pending := range group.Pending {
+			c, exist := g.consumers[pending.Consumer]
+			if !exist {
+				c = &Consumer{Name: pending.Consumer}
+				g.consumers[pending.Consumer] = c
+			}
+			p := g.insertPending(StreamID(pending.ID), c, pending.DeliveryTime)
+			p.DeliveryCount = int(pending.DeliveryCount)
+		}
+	}
+	return stream
+}
@@ -0,0 +1,350 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestXAdd_IDs(t *testing.T) {
+	// given
+	store := New()
+
+	// when & then
+	if id, _, err := store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{5, 1}}, []string{"a", "1"}); err != nil || id != (StreamID{5, 1}) {
+		t.Fatalf("명시한 ID: %v, err: %v", id, err)
+	}
+	if id, _, _ := store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{Ms: 5}, AutoSeq: true}, []string{"a", "2"}); id != (StreamID{5, 2}) {
+		t.Fatalf("시퀀스 자동 생성: %v", id)
+	}
+	if _, _, err := store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{5, 2}}, []string{"a", "3"}); err != ErrStreamIDTooSmall {
+		t.Fatalf("같은 ID 에러: %v", err)
+	}
+	if _, _, err := store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{Ms: 4}, AutoSeq: true}, []string{"a", "3"}); err != ErrStreamIDTooSmall {
+		t.Fatalf("더 작은 밀리초 에러: %v", err)
+	}
+	if id, _, _ := store.XAdd("s", XAddOptions{}, XAddID{AutoMs: true}, []string{"a", "3"}); !(StreamID{5, 2}).Less(id) {
+		t.Fatalf("자동 ID가 마지막 ID보다 작음: %v", id)
+	}
+	if _, _, err := store.XAdd("zero", XAddOptions{}, XAddID{}, []string{"a", "1"}); err != ErrStreamIDZero {
+		t.Fatalf("0-0 에러: %v", err)
+	}
+	if _, exist := store.data.Get("zero"); exist {
+		t.Fatal("실패한 XADD가 빈 스트림을 남김")
+	}
+	if length, _ := store.XLen("s"); length != 3 {
+		t.Fatalf("XLen: %d, expected: 3", length)
+	}
+}
+
+func TestXAdd_NoMkStreamAndTrim(t *testing.T) {
+	// given
+	store := New()
+
+	// when: NOMKSTREAM이면 키를 만들지 않는다
+	_, ok, _ := store.XAdd("s", XAddOptions{NoMkStream: true}, XAddID{AutoMs: true}, []string{"a", "1"})
+
+	// then
+	if ok {
+		t.Fatal("NOMKSTREAM인데 스트림이 만들어짐")
+	}
+
+	// when: MAXLEN 2로 추가한다
+	for i := uint64(1); i <= 5; i++ {
+		store.XAdd("s", XAddOptions{Trim: &StreamTrim{MaxLen: 2}}, XAddID{ID: StreamID{i, 0}}, []string{"n", "v"})
+	}
+
+	// then
+	entries, _ := store.XRange("s", StreamID{}, MaxStreamID, false, -1)
+	if len(entries) != 2 || entries[0].ID != (StreamID{4, 0}) {
+		t.Fatalf("XRange: %v", entries)
+	}
+}
+
+func TestStream_WrongType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("str", "value")
+
+	// when & then
+	if _, _, err := store.XAdd("str", XAddOptions{}, XAddID{AutoMs: true}, []string{"a", "1"}); err != ErrWrongType {
+		t.Fatalf("XAdd 에러: %v", err)
+	}
+	if _, err := store.XRead([]XReadStream{{Key: "str"}}, -1); err != ErrWrongType {
+		t.Fatalf("XRead 에러: %v", err)
+	}
+}
+
+func TestXRead_AfterID(t *testing.T) {
+	// given
+	store := New()
+	store.XAdd("a", XAddOptions{}, XAddID{ID: StreamID{1, 0}}, []string{"n", "1"})
+	store.XAdd("a", XAddOptions{}, XAddID{ID: StreamID{2, 0}}, []string{"n", "2"})
+	store.XAdd("b", XAddOptions{}, XAddID{ID: StreamID{1, 0}}, []string{"n", "1"})
+
+	// when: b는 이미 다 읽었다
+	results, err := store.XRead([]XReadStream{{Key: "a", ID: StreamID{1, 0}}, {Key: "b", ID: StreamID{1, 0}}, {Key: "missing"}}, -1)
+
+	// then: 엔트리가 있는 스트림만 결과에 들어간다
+	if err != nil || len(results) != 1 {
+		t.Fatalf("XRead: %v, err: %v", results, err)
+	}
+	if results[0].Key != "a" || len(results[0].Entries) != 1 || results[0].Entries[0].ID != (StreamID{2, 0}) {
+		t.Fatalf("XRead: %v", results)
+	}
+}
+
+func TestBlockingXRead_WaitsForXAdd(t *testing.T) {
+	// given: "$"로 기다리는 두 클라이언트
+	store := New()
+	store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{1, 0}}, []string{"n", "old"})
+	results := make(chan []StreamReadResult, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			result, _ := store.BlockingXRead(context.Background(), []XReadStream{{Key: "s", Last: true}}, -1)
+			results <- result
+		}()
+	}
+	waitForBlocked(t, store, 2)
+
+	// when
+	store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{2, 0}}, []string{"n", "new"})
+
+	// then: 두 클라이언트 모두 새 엔트리만 받는다 (리스트와 달리 소비하지 않는다)
+	for i := 0; i < 2; i++ {
+		r := <-results
+		if len(r) != 1 || len(r[0].Entries) != 1 || r[0].Entries[0].ID != (StreamID{2, 0}) {
+			t.Fatalf("결과: %v", r)
+		}
+	}
+}
+
+func TestBlockingXRead_Timeout(t *testing.T) {
+	// given
+	store := New()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// when
+	results, err := store.BlockingXRead(ctx, []XReadStream{{Key: "s", Last: true}}, -1)
+
+	// then
+	if err != nil || results != nil {
+		t.Fatalf("결과: %v, err: %v", results, err)
+	}
+	if store.BlockedClients() != 0 {
+		t.Fatal("대기자가 남아있음")
+	}
+}
+
+func TestXGroupCreate(t *testing.T) {
+	// given
+	store := New()
+
+	// when & then
+	if err := store.XGroupCreate("s", "g", StreamID{}, false, false, -1); err != ErrXGroupNoKey {
+		t.Fatalf("키 없음 에러: %v", err)
+	}
+	if err := store.XGroupCreate("s", "g", StreamID{}, true, true, -1); err != nil {
+		t.Fatalf("MKSTREAM 에러: %v", err)
+	}
+	if err := store.XGroupCreate("s", "g", StreamID{}, false, false, -1); err != ErrBusyGroup {
+		t.Fatalf("중복 그룹 에러: %v", err)
+	}
+	if destroyed, _ := store.XGroupDestroy("s", "g"); !destroyed {
+		t.Fatal("XGroupDestroy가 false")
+	}
+	if destroyed, _ := store.XGroupDestroy("s", "g"); destroyed {
+		t.Fatal("없는 그룹을 삭제함")
+	}
+}
+
+func TestXReadGroup_DeliverAndAck(t *testing.T) {
+	// given
+	store := New()
+	for i := uint64(1); i <= 3; i++ {
+		store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{i, 0}}, []string{"n", "v"})
+	}
+	store.XGroupCreate("s", "g", StreamID{}, false, false, -1)
+
+	// when: alice가 두 개, bob이 나머지를 받는다
+	first, _ := store.XReadGroup("g", "alice", []XReadStream{{Key: "s", New: true}}, 2, false)
+	second, _ := store.XReadGroup("g", "bob", []XReadStream{{Key: "s", New: true}}, -1, false)
+	empty, _ := store.XReadGroup("g", "bob", []XReadStream{{Key: "s", New: true}}, -1, false)
+
+	// then
+	if len(first) != 1 || len(first[0].Entries) != 2 || len(second[0].Entries) != 1 || empty != nil {
+		t.Fatalf("first: %v, second: %v, empty: %v", first, second, empty)
+	}
+	summary, _ := store.XPendingSummary("s", "g")
+	if summary.Count != 3 || summary.Min != (StreamID{1, 0}) || summary.Max != (StreamID{3, 0}) || len(summary.Consumers) != 2 {
+		t.Fatalf("XPendingSummary: %+v", summary)
+	}
+
+	// when: alice가 하나를 처리하고 자기 이력을 다시 읽는다
+	acked, _ := store.XAck("s", "g", StreamID{1, 0}, StreamID{9, 0})
+	history, _ := store.XReadGroup("g", "alice", []XReadStream{{Key: "s"}}, -1, false)
+
+	// then
+	if acked != 1 {
+		t.Fatalf("XAck: %d, expected: 1", acked)
+	}
+	if len(history) != 1 || len(history[0].Entries) != 1 || history[0].Entries[0].ID != (StreamID{2, 0}) {
+		t.Fatalf("이력: %v", history)
+	}
+	groups, _ := store.XInfoGroups("s")
+	if len(groups) != 1 || groups[0].EntriesRead != 3 || groups[0].Lag != 0 || groups[0].Pending != 2 {
+		t.Fatalf("XInfoGroups: %+v", groups)
+	}
+}
+
+func TestXReadGroup_NoGroup(t *testing.T) {
+	// given
+	store := New()
+	store.XAdd("s", XAddOptions{}, XAddID{AutoMs: true}, []string{"n", "v"})
+
+	// when
+	_, err := store.XReadGroup("missing", "alice", []XReadStream{{Key: "s", New: true}}, -1, false)
+
+	// then
+	var noGroup *NoGroupError
+	if !errors.As(err, &noGroup) || noGroup.Key != "s" || noGroup.Group != "missing" {
+		t.Fatalf("에러: %v", err)
+	}
+}
+
+func TestBlockingXReadGroup_WaitsForXAdd(t *testing.T) {
+	// given
+	store := New()
+	store.XGroupCreate("s", "g", StreamID{}, true, true, -1)
+	results := make(chan []StreamReadResult)
+	go func() {
+		result, _ := store.BlockingXReadGroup(context.Background(), "g", "alice", []XReadStream{{Key: "s", New: true}}, -1, false)
+		results <- result
+	}()
+	waitForBlocked(t, store, 1)
+
+	// when
+	store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{1, 0}}, []string{"n", "v"})
+
+	// then: 받은 엔트리는 PEL에 들어간다
+	r := <-results
+	if len(r) != 1 || len(r[0].Entries) != 1 {
+		t.Fatalf("결과: %v", r)
+	}
+	if pending, _ := store.XPending("s", "g", PendingOptions{End: MaxStreamID, Count: 10}); len(pending) != 1 || pending[0].Consumer != "alice" {
+		t.Fatalf("XPending: %+v", pending)
+	}
+}
+
+func TestXClaim(t *testing.T) {
+	// given: alice가 받은 엔트리 두 개 중 하나는 스트림에서 잘려나갔다
+	store := New()
+	store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{1, 0}}, []string{"n", "1"})
+	store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{2, 0}}, []string{"n", "2"})
+	store.XGroupCreate("s", "g", StreamID{}, false, false, -1)
+	store.XReadGroup("g", "alice", []XReadStream{{Key: "s", New: true}}, -1, false)
+	store.XAdd("s", XAddOptions{Trim: &StreamTrim{MaxLen: 2}}, XAddID{ID: StreamID{3, 0}}, []string{"n", "3"})
+
+	// when: 아직 충분히 오래되지 않았다
+	claimed, _ := store.XClaim("s", "g", "bob", time.Hour, []StreamID{{2, 0}}, XClaimOptions{})
+
+	// then
+	if len(claimed) != 0 {
+		t.Fatalf("minIdle 전에 넘어감: %v", claimed)
+	}
+
+	// when
+	claimed, _ = store.XClaim("s", "g", "bob", 0, []StreamID{{1, 0}, {2, 0}}, XClaimOptions{})
+
+	// then: 삭제된 엔트리는 PEL에서 빠진다
+	if len(claimed) != 1 || claimed[0].ID != (StreamID{2, 0}) {
+		t.Fatalf("XClaim: %v", claimed)
+	}
+	pending, _ := store.XPending("s", "g", PendingOptions{End: MaxStreamID, Count: 10})
+	if len(pending) != 1 || pending[0].Consumer != "bob" || pending[0].DeliveryCount != 2 {
+		t.Fatalf("XPending: %+v", pending)
+	}
+
+	// when: FORCE면 PEL에 없던 엔트리도 가져온다
+	retryCount := 5
+	claimed, _ = store.XClaim("s", "g", "bob", 0, []StreamID{{3, 0}}, XClaimOptions{Force: true, JustID: true, RetryCount: &retryCount})
+
+	// then
+	if len(claimed) != 1 || claimed[0].Fields != nil {
+		t.Fatalf("FORCE JUSTID: %v", claimed)
+	}
+	if pending, _ := store.XPending("s", "g", PendingOptions{Start: StreamID{3, 0}, End: MaxStreamID, Count: 10}); pending[0].DeliveryCount != 5 {
+		t.Fatalf("RETRYCOUNT: %+v", pending)
+	}
+}
+
+func TestXAutoClaim(t *testing.T) {
+	// given
+	store := New()
+	for i := uint64(1); i <= 5; i++ {
+		store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{i, 0}}, []string{"n", "v"})
+	}
+	store.XGroupCreate("s", "g", StreamID{}, false, false, -1)
+	store.XReadGroup("g", "alice", []XReadStream{{Key: "s", New: true}}, -1, false)
+
+	// when: 두 개씩 넘긴다
+	next, claimed, deleted, err := store.XAutoClaim("s", "g", "bob", 0, StreamID{}, 2, false)
+
+	// then: 다음 커서는 세 번째 엔트리다
+	if err != nil || len(claimed) != 2 || len(deleted) != 0 || next != (StreamID{3, 0}) {
+		t.Fatalf("next: %v, claimed: %v, deleted: %v, err: %v", next, claimed, deleted, err)
+	}
+
+	// when: 끝까지 넘긴다
+	next, claimed, _, _ = store.XAutoClaim("s", "g", "bob", 0, next, 10, false)
+
+	// then
+	if len(claimed) != 3 || next != (StreamID{}) {
+		t.Fatalf("next: %v, claimed: %v", next, claimed)
+	}
+	consumers, _ := store.XInfoConsumers("s", "g")
+	if len(consumers) != 2 || consumers[0].Name != "alice" || consumers[0].Pending != 0 || consumers[1].Pending != 5 {
+		t.Fatalf("XInfoConsumers: %+v", consumers)
+	}
+}
+
+func TestSaveAndLoad_StreamEntries(t *testing.T) {
+	// given: 그룹과 PEL이 있는 스트림
+	store := New()
+	for i := uint64(1); i <= 3; i++ {
+		store.XAdd("s", XAddOptions{}, XAddID{ID: StreamID{i, 0}}, []string{"n", "v"})
+	}
+	store.XGroupCreate("s", "g", StreamID{}, false, false, -1)
+	store.XReadGroup("g", "alice", []XReadStream{{Key: "s", New: true}}, 2, false)
+	store.XClaim("s", "g", "bob", 0, []StreamID{{2, 0}}, XClaimOptions{})
+	path := filepath.Join(t.TempDir(), "stream.rdb")
+	store.Save(path)
+
+	// when
+	loaded := New()
+	err := loaded.Load(path)
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	info, _ := loaded.XInfoStream("s")
+	if info.Length != 3 || info.LastID != (StreamID{3, 0}) || info.EntriesAdded != 3 || info.Groups != 1 {
+		t.Fatalf("XInfoStream: %+v", info)
+	}
+	pending, _ := loaded.XPending("s", "g", PendingOptions{End: MaxStreamID, Count: 10})
+	if len(pending) != 2 || pending[0].Consumer != "alice" || pending[1].Consumer != "bob" || pending[1].DeliveryCount != 2 {
+		t.Fatalf("XPending: %+v", pending)
+	}
+
+	// when: 복원한 그룹은 이어서 읽는다
+	results, _ := loaded.XReadGroup("g", "alice", []XReadStream{{Key: "s", New: true}}, -1, false)
+
+	// then
+	if len(results) != 1 || len(results[0].Entries) != 1 || results[0].Entries[0].ID != (StreamID{3, 0}) {
+		t.Fatalf("XReadGroup: %v", results)
+	}
+}
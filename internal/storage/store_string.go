@@ -0,0 +1,430 @@
+package storage
+
+import (
+	"errors"
+	"inmemory-db/internal/pubsub"
+	"math"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrNotInteger     = errors.New("value is not an integer or out of range")
+	ErrNotFloat       = errors.New("value is not a valid float")
+	ErrStringTooLarge = errors.New("string exceeds maximum allowed size (proto-max-bulk-len)")
+)
+
+// 문자열 값의 최대 크기 (Redis proto-max-bulk-len 기본값)
+const maxStringSize = 512 * 1024 * 1024
+
+// GETEX 옵션
+type GetExOptions struct {
+	// 새 만료 시각 (EX/PX/EXAT/PXAT). nil이면 바꾸지 않는다
+	ExpireAt *time.Time
+	// 만료 시간을 제거한다 (PERSIST)
+	Persist bool
+}
+
+// SET 옵션
+type SetOptions struct {
+	// 만료 시각 (EX/PX/EXAT/PXAT). nil이면 만료 시간을 제거한다
+	ExpireAt *time.Time
+	// 키가 없을 때만 저장한다 (NX)
+	NX bool
+	// 키가 있을 때만 저장한다 (XX)
+	XX bool
+	// 기존 만료 시간을 유지한다 (KEEPTTL)
+	KeepTTL bool
+	// 이전 값을 반환한다 (GET). 기존 값이 문자열이 아니면 ErrWrongType
+	Get bool
+}
+
+// 문자열 엔트리를 만든다. 표준 정수 표기면 int 인코딩으로 저장한다.
+func newStringEntry(value string) *Entry {
+	entry := &Entry{Type: TypeString}
+	entry.setString(value)
+	return entry
+}
+
+// 문자열 엔트리의 값
+func (e *Entry) StringValue() string {
+	if e.IntEncoded {
+		return strconv.FormatInt(e.Int, 10)
+	}
+	return e.Str
+}
+
+// 문자열 값을 바꾼다. 만료 시간은 그대로 둔다.
+func (e *Entry) setString(value string) {
+	if n, ok := parseSetInt(value); ok {
+		e.setInt(n)
+		return
+	}
+	e.Str, e.Int, e.IntEncoded = value, 0, false
+}
+
+func (e *Entry) setInt(n int64) {
+	e.Str, e.Int, e.IntEncoded = "", n, true
+}
+
+// 정수 값에 delta를 더하고 결과를 반환한다. 키가 없으면 0에서 시작한다.
+func (s *Store) IncrBy(key string, delta int64) (int64, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var current int64
+	if entry != nil {
+		if entry.IntEncoded {
+			current = entry.Int
+		} else if current, err = strconv.ParseInt(entry.Str, 10, 64); err != nil {
+			return 0, ErrNotInteger
+		}
+	}
+	if (delta > 0 && current > math.MaxInt64-delta) || (delta < 0 && current < math.MinInt64-delta) {
+		return 0, ErrIncrOverflow
+	}
+
+	current += delta
+	if entry == nil {
+		entry = s.createString(key)
+	}
+	entry.setInt(current)
+	s.notifyEvent(pubsub.NotifyString, "incrby", key)
+	return current, nil
+}
+
+// 값에 실수 delta를 더하고 결과를 문자열로 반환한다. 키가 없으면 0에서 시작한다.
+func (s *Store) IncrByFloat(key string, delta float64) (string, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil {
+		return "", err
+	}
+
+	var current float64
+	if entry != nil {
+		current, err = strconv.ParseFloat(entry.StringValue(), 64)
+		if err != nil || math.IsNaN(current) || math.IsInf(current, 0) {
+			return "", ErrNotFloat
+		}
+	}
+
+	current += delta
+	if math.IsNaN(current) || math.IsInf(current, 0) {
+		return "", ErrIncrNaNOrInfinity
+	}
+
+	result := strconv.FormatFloat(current, 'f', -1, 64)
+	if entry == nil {
+		entry = s.createString(key)
+	}
+	entry.setString(result)
+	s.notifyEvent(pubsub.NotifyString, "incrbyfloat", key)
+	return result, nil
+}
+
+// 값 뒤에 value를 붙이고 새 길이를 반환한다. 키가 없으면 value로 만든다.
+func (s *Store) Append(key, value string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var current string
+	if entry != nil {
+		current = entry.StringValue()
+	}
+	if len(current)+len(value) > maxStringSize {
+		return 0, ErrStringTooLarge
+	}
+
+	if entry == nil {
+		entry = s.createString(key)
+	}
+	entry.setString(current + value)
+	s.notifyEvent(pubsub.NotifyString, "append", key)
+	return len(current) + len(value), nil
+}
+
+// 값의 길이. 키가 없으면 0
+func (s *Store) StrLen(key string) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return len(entry.StringValue()), nil
+}
+
+// start부터 end까지(양 끝 포함)의 부분 문자열. 음수 인덱스는 끝에서부터 센다.
+func (s *Store) GetRange(key string, start, end int) (string, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil || entry == nil {
+		return "", err
+	}
+
+	value := entry.StringValue()
+	length := len(value)
+	if start < 0 {
+		start = max(length+start, 0)
+	}
+	if end < 0 {
+		end = max(length+end, 0)
+	}
+	end = min(end, length-1)
+	if length == 0 || start > end {
+		return "", nil
+	}
+	return value[start : end+1], nil
+}
+
+// offset 위치부터 value로 덮어쓰고 새 길이를 반환한다.
+// 값이 offset보다 짧으면 0 바이트로 채운다. 키가 없고 value가 비어있으면 만들지 않는다.
+func (s *Store) SetRange(key string, offset int, value string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil {
+		return 0, err
+	}
+
+	var current string
+	if entry != nil {
+		current = entry.StringValue()
+	}
+	if value == "" {
+		return len(current), nil
+	}
+	if offset+len(value) > maxStringSize {
+		return 0, ErrStringTooLarge
+	}
+
+	buf := []byte(current)
+	if need := offset + len(value); need > len(buf) {
+		buf = append(buf, make([]byte, need-len(buf))...)
+	}
+	copy(buf[offset:], value)
+
+	if entry == nil {
+		entry = s.createString(key)
+	}
+	entry.setString(string(buf))
+	s.notifyEvent(pubsub.NotifyString, "setrange", key)
+	return len(buf), nil
+}
+
+// 옵션에 따라 값을 저장한다. 조건(NX/XX) 때문에 저장하지 않았으면 set이 false다.
+// options.Get이면 이전 값과 존재 여부도 반환한다.
+func (s *Store) SetWithOptions(key, value string, options SetOptions) (old string, exist, set bool, err error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	var current *Entry
+	if options.Get {
+		if current, err = s.lookupString(key); err != nil {
+			return "", false, false, err
+		}
+		if current != nil {
+			old, exist = current.StringValue(), true
+		}
+	} else if s.exists(key) {
+		current, _ = s.data.Get(key)
+	}
+
+	if (options.NX && current != nil) || (options.XX && current == nil) {
+		return old, exist, false, nil
+	}
+
+	var keepExpire *time.Time
+	if options.KeepTTL && current != nil {
+		keepExpire = current.ExpireAt
+	}
+
+	s.setLocked(key, value)
+	entry, _ := s.data.Get(key)
+	switch {
+	case options.ExpireAt != nil:
+		s.setExpireLocked(key, entry, *options.ExpireAt)
+		s.notifyEvent(pubsub.NotifyGeneric, "expire", key)
+	case keepExpire != nil:
+		s.setExpireLocked(key, entry, *keepExpire)
+	}
+	return old, exist, true, nil
+}
+
+// 여러 키의 값을 조회한다. 키가 없거나 문자열이 아니면 exists[i]가 false다.
+func (s *Store) MGet(keys ...string) (values []string, exists []bool) {
+	locks := s.rlock(keys...)
+	defer s.runlock(locks)
+
+	values = make([]string, len(keys))
+	exists = make([]bool, len(keys))
+	for i, key := range keys {
+		entry, err := s.lookupString(key)
+		if err == nil && entry != nil {
+			values[i], exists[i] = entry.StringValue(), true
+		}
+	}
+	return values, exists
+}
+
+// 여러 키에 값을 저장한다. keyValues는 key1, value1, key2, value2, ... 순서다.
+func (s *Store) MSet(keyValues ...string) {
+	locks := s.lock(pairKeys(keyValues)...)
+	defer s.unlock(locks)
+
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		s.setLocked(keyValues[i], keyValues[i+1])
+	}
+}
+
+// 모든 키가 없을 때만 한꺼번에 저장한다. 저장했으면 true
+func (s *Store) MSetNX(keyValues ...string) bool {
+	locks := s.lock(pairKeys(keyValues)...)
+	defer s.unlock(locks)
+
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		if s.exists(keyValues[i]) {
+			return false
+		}
+	}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		s.setLocked(keyValues[i], keyValues[i+1])
+	}
+	return true
+}
+
+// 키가 없을 때만 저장한다. 저장했으면 true
+func (s *Store) SetNX(key, value string) bool {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	if s.exists(key) {
+		return false
+	}
+	s.setLocked(key, value)
+	return true
+}
+
+// 새 값을 저장하고 이전 값을 반환한다. 만료 시간은 제거된다.
+func (s *Store) GetSet(key, value string) (string, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil {
+		return "", false, err
+	}
+
+	var old string
+	if entry != nil {
+		old = entry.StringValue()
+	}
+	s.setLocked(key, value)
+	return old, entry != nil, nil
+}
+
+// 값을 반환하고 키를 삭제한다.
+func (s *Store) GetDel(key string) (string, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil || entry == nil {
+		return "", false, err
+	}
+
+	s.data.Delete(key)
+	s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	return entry.StringValue(), true, nil
+}
+
+// 값을 반환하면서 만료 시간을 바꾸거나 제거한다.
+// 새 만료 시각이 이미 지났으면 키를 삭제한다.
+func (s *Store) GetEx(key string, options GetExOptions) (string, bool, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupString(key)
+	if err != nil || entry == nil {
+		return "", false, err
+	}
+	value := entry.StringValue()
+
+	switch {
+	case options.ExpireAt != nil && !options.ExpireAt.After(s.clock.Now()):
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	case options.ExpireAt != nil:
+		s.setExpireLocked(key, entry, *options.ExpireAt)
+		s.notifyEvent(pubsub.NotifyGeneric, "expire", key)
+	case options.Persist && entry.ExpireAt != nil:
+		s.clearExpireLocked(key, entry)
+		s.notifyEvent(pubsub.NotifyGeneric, "persist", key)
+	}
+	return value, true, nil
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// 키가 없거나 만료되었으면 nil, 문자열이 아니면 ErrWrongType을 반환한다.
+// 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupString(key string) (*Entry, error) {
+	entry, exist := s.data.Get(key)
+	if !exist || s.isExpired(key) {
+		return nil, nil
+	}
+	if entry.Type != TypeString {
+		return nil, ErrWrongType
+	}
+	return entry, nil
+}
+
+// 빈 문자열 엔트리를 만든다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) createString(key string) *Entry {
+	entry := newStringEntry("")
+	s.data.Set(key, entry)
+	s.notifyEvent(pubsub.NotifyNew, "new", key)
+	return entry
+}
+
+// 타입과 상관없이 키에 문자열을 저장하고 만료 시간을 제거한다 (SET).
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) setLocked(key, value string) {
+	if !s.exists(key) {
+		s.notifyEvent(pubsub.NotifyNew, "new", key)
+	}
+	s.data.Set(key, newStringEntry(value))
+	s.notifyEvent(pubsub.NotifyString, "set", key)
+}
+
+// key1, value1, key2, value2, ... 에서 키만 모은다.
+func pairKeys(keyValues []string) []string {
+	keys := make([]string, 0, len(keyValues)/2)
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		keys = append(keys, keyValues[i])
+	}
+	return keys
+}
+
+// 키가 있고 만료되지 않았으면 true. 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) exists(key string) bool {
+	_, exist := s.data.Get(key)
+	return exist && !s.isExpired(key)
+}
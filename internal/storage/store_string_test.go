@@ -0,0 +1,342 @@
+package storage
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSet_IntEncoding(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	store.Set("n", "12345")
+	store.Set("padded", "007")
+	store.Set("text", "hello")
+
+	// then: 표준 정수 표기만 int 인코딩된다
+	if entry, _ := store.data.Get("n"); !entry.IntEncoded || entry.Int != 12345 || entry.Str != "" {
+		t.Fatalf("n 엔트리: %+v", entry)
+	}
+	padded, _ := store.data.Get("padded")
+	text, _ := store.data.Get("text")
+	if padded.IntEncoded || text.IntEncoded {
+		t.Fatal("정수가 아닌 값이 int 인코딩됨")
+	}
+	if value, _ := store.Get("n"); value != "12345" {
+		t.Fatalf("Get n: %s", value)
+	}
+	if value, _ := store.Get("padded"); value != "007" {
+		t.Fatalf("Get padded: %s", value)
+	}
+}
+
+func TestIncrBy(t *testing.T) {
+	// given
+	store := New()
+	store.Set("text", "abc")
+	store.Set("max", "9223372036854775807")
+
+	// when & then
+	if n, err := store.IncrBy("counter", 1); err != nil || n != 1 {
+		t.Fatalf("없는 키 IncrBy: %d, err: %v", n, err)
+	}
+	if n, err := store.IncrBy("counter", -5); err != nil || n != -4 {
+		t.Fatalf("IncrBy -5: %d, err: %v", n, err)
+	}
+	if _, err := store.IncrBy("text", 1); err != ErrNotInteger {
+		t.Fatalf("문자열 IncrBy 에러: %v", err)
+	}
+	if _, err := store.IncrBy("max", 1); err != ErrIncrOverflow {
+		t.Fatalf("오버플로 에러: %v", err)
+	}
+	if _, err := store.IncrBy("counter", math.MinInt64); err != ErrIncrOverflow {
+		t.Fatalf("언더플로 에러: %v", err)
+	}
+	store.LPush("list", "a")
+	if _, err := store.IncrBy("list", 1); err != ErrWrongType {
+		t.Fatalf("리스트 IncrBy 에러: %v", err)
+	}
+}
+
+func TestIncrBy_KeepsTTL(t *testing.T) {
+	// given
+	store := New()
+	store.Set("counter", "1")
+	store.Expire("counter", 100)
+
+	// when
+	store.IncrBy("counter", 1)
+	store.Append("counter", "0")
+
+	// then
+	if ttl := store.TTL("counter"); ttl <= 0 {
+		t.Fatalf("TTL: %d", ttl)
+	}
+	if value, _ := store.Get("counter"); value != "20" {
+		t.Fatalf("Get: %s", value)
+	}
+}
+
+func TestIncrByFloat(t *testing.T) {
+	// given
+	store := New()
+	store.Set("f", "10.5")
+	store.Set("text", "abc")
+
+	// when & then
+	if value, err := store.IncrByFloat("f", 0.1); err != nil || value != "10.6" {
+		t.Fatalf("IncrByFloat: %s, err: %v", value, err)
+	}
+	value, err := store.IncrByFloat("f", -0.6)
+	if entry, _ := store.data.Get("f"); err != nil || value != "10" || !entry.IntEncoded {
+		t.Fatalf("정수 결과 IncrByFloat: %s, err: %v", value, err)
+	}
+	if _, err := store.IncrByFloat("text", 1); err != ErrNotFloat {
+		t.Fatalf("문자열 IncrByFloat 에러: %v", err)
+	}
+	if _, err := store.IncrByFloat("f", math.MaxFloat64); err != nil {
+		t.Fatalf("큰 값 IncrByFloat 에러: %v", err)
+	}
+	if _, err := store.IncrByFloat("f", math.MaxFloat64); err != ErrIncrNaNOrInfinity {
+		t.Fatalf("무한대 IncrByFloat 에러: %v", err)
+	}
+}
+
+func TestAppendAndStrLen(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	first, _ := store.Append("s", "hello")
+	second, _ := store.Append("s", " world")
+
+	// then
+	if first != 5 || second != 11 {
+		t.Fatalf("Append: %d, %d", first, second)
+	}
+	if length, _ := store.StrLen("s"); length != 11 {
+		t.Fatalf("StrLen: %d", length)
+	}
+	if length, err := store.StrLen("missing"); err != nil || length != 0 {
+		t.Fatalf("없는 키 StrLen: %d, err: %v", length, err)
+	}
+}
+
+func TestGetRange(t *testing.T) {
+	// given
+	store := New()
+	store.Set("s", "This is a string")
+
+	cases := []struct {
+		start, end int
+		expected   string
+	}{
+		{0, 3, "This"},
+		{-3, -1, "ing"},
+		{0, -1, "This is a string"},
+		{10, 100, "string"},
+		{5, 2, ""},
+		{-100, 3, "This"},
+	}
+
+	for _, c := range cases {
+		// when
+		value, err := store.GetRange("s", c.start, c.end)
+
+		// then
+		if err != nil || value != c.expected {
+			t.Fatalf("GetRange(%d, %d): %q, err: %v", c.start, c.end, value, err)
+		}
+	}
+}
+
+func TestSetRange(t *testing.T) {
+	// given
+	store := New()
+	store.Set("s", "Hello World")
+
+	// when & then
+	if length, _ := store.SetRange("s", 6, "Redis"); length != 11 {
+		t.Fatalf("SetRange: %d", length)
+	}
+	if value, _ := store.Get("s"); value != "Hello Redis" {
+		t.Fatalf("Get: %s", value)
+	}
+	if length, _ := store.SetRange("padded", 3, "x"); length != 4 {
+		t.Fatalf("0 바이트 채움 SetRange: %d", length)
+	}
+	if value, _ := store.Get("padded"); value != "\x00\x00\x00x" {
+		t.Fatalf("Get padded: %q", value)
+	}
+	length, _ := store.SetRange("empty", 5, "")
+	if _, exist := store.data.Get("empty"); length != 0 || exist {
+		t.Fatalf("빈 값 SetRange가 키를 만듦: %d", length)
+	}
+	if _, err := store.SetRange("s", maxStringSize, "x"); err != ErrStringTooLarge {
+		t.Fatalf("최대 크기 SetRange 에러: %v", err)
+	}
+}
+
+func TestMSetAndMGet(t *testing.T) {
+	// given
+	store := New()
+	store.LPush("list", "a")
+
+	// when
+	store.MSet("a", "1", "b", "2")
+	values, exists := store.MGet("a", "missing", "list", "b")
+
+	// then: 없는 키와 문자열이 아닌 키는 nil
+	if !exists[0] || exists[1] || exists[2] || !exists[3] || values[0] != "1" || values[3] != "2" {
+		t.Fatalf("MGet: %v, %v", values, exists)
+	}
+}
+
+func TestMSetNX(t *testing.T) {
+	// given
+	store := New()
+	store.Set("a", "1")
+
+	// when & then: 키가 하나라도 있으면 아무것도 저장하지 않는다
+	if store.MSetNX("a", "x", "b", "2") {
+		t.Fatal("기존 키가 있는데 MSetNX 성공")
+	}
+	if _, ok := store.Get("b"); ok {
+		t.Fatal("MSetNX가 일부 키를 저장함")
+	}
+	if !store.MSetNX("b", "2", "c", "3") {
+		t.Fatal("MSetNX 실패")
+	}
+	if !store.SetNX("d", "4") || store.SetNX("d", "5") {
+		t.Fatal("SetNX 결과가 다름")
+	}
+	if value, _ := store.Get("d"); value != "4" {
+		t.Fatalf("Get d: %s", value)
+	}
+}
+
+func TestGetSetAndGetDel(t *testing.T) {
+	// given
+	store := New()
+	store.Set("k", "old")
+	store.Expire("k", 100)
+
+	// when & then: GETSET은 TTL을 지운다
+	if old, ok, err := store.GetSet("k", "new"); err != nil || !ok || old != "old" {
+		t.Fatalf("GetSet: %s, %v, err: %v", old, ok, err)
+	}
+	if ttl := store.TTL("k"); ttl != -1 {
+		t.Fatalf("GetSet 후 TTL: %d", ttl)
+	}
+	if value, ok, _ := store.GetDel("k"); !ok || value != "new" {
+		t.Fatalf("GetDel: %s, %v", value, ok)
+	}
+	if _, ok, _ := store.GetDel("k"); ok {
+		t.Fatal("삭제된 키가 GetDel로 조회됨")
+	}
+}
+
+func TestGetEx(t *testing.T) {
+	// given
+	store := New()
+	store.Set("k", "v")
+
+	// when & then
+	at := time.Now().Add(time.Hour)
+	if value, ok, _ := store.GetEx("k", GetExOptions{ExpireAt: &at}); !ok || value != "v" {
+		t.Fatalf("GetEx EX: %s, %v", value, ok)
+	}
+	if ttl := store.TTL("k"); ttl <= 0 {
+		t.Fatalf("GetEx 후 TTL: %d", ttl)
+	}
+	store.GetEx("k", GetExOptions{Persist: true})
+	if ttl := store.TTL("k"); ttl != -1 {
+		t.Fatalf("PERSIST 후 TTL: %d", ttl)
+	}
+
+	// when & then: 지난 시각이면 키를 삭제한다
+	past := time.Now().Add(-time.Second)
+	if value, ok, _ := store.GetEx("k", GetExOptions{ExpireAt: &past}); !ok || value != "v" {
+		t.Fatalf("과거 시각 GetEx: %s, %v", value, ok)
+	}
+	if _, ok := store.Get("k"); ok {
+		t.Fatal("과거 시각 GetEx 후에도 키가 있음")
+	}
+}
+
+func TestSaveAndLoad_IntEncodedString(t *testing.T) {
+	// given
+	path := filepath.Join(t.TempDir(), "dump.rdb")
+	store := New()
+	store.Set("n", "42")
+	store.IncrBy("n", 1)
+	store.Save(path)
+
+	// when
+	loaded := New()
+	if err := loaded.Load(path); err != nil {
+		t.Fatalf("Load 에러: %v", err)
+	}
+
+	// then
+	if entry, _ := loaded.data.Get("n"); !entry.IntEncoded || entry.Int != 43 {
+		t.Fatalf("로드된 엔트리: %+v", entry)
+	}
+}
+
+func TestSetWithOptions(t *testing.T) {
+	// given
+	store := New()
+	store.Set("k", "v1")
+	store.Expire("k", 100)
+
+	// when & then: NX는 키가 있으면 저장하지 않는다
+	if _, _, set, _ := store.SetWithOptions("k", "v2", SetOptions{NX: true}); set {
+		t.Fatal("NX인데 저장됨")
+	}
+	if _, _, set, _ := store.SetWithOptions("missing", "v", SetOptions{XX: true}); set {
+		t.Fatal("XX인데 없는 키에 저장됨")
+	}
+
+	// when & then: KEEPTTL은 기존 만료 시간을 유지하고, GET은 이전 값을 반환한다
+	old, exist, set, err := store.SetWithOptions("k", "v2", SetOptions{XX: true, KeepTTL: true, Get: true})
+	if err != nil || !set || !exist || old != "v1" {
+		t.Fatalf("SetWithOptions: %s, %v, %v, err: %v", old, exist, set, err)
+	}
+	if ttl := store.TTL("k"); ttl <= 0 {
+		t.Fatalf("KEEPTTL 후 TTL: %d", ttl)
+	}
+
+	// when & then: 옵션이 없으면 만료 시간을 제거한다
+	store.SetWithOptions("k", "v3", SetOptions{})
+	if ttl := store.TTL("k"); ttl != -1 {
+		t.Fatalf("SET 후 TTL: %d", ttl)
+	}
+
+	// when & then: 만료 시각과 함께 저장한다
+	at := time.Now().Add(time.Hour)
+	store.SetWithOptions("k", "v4", SetOptions{ExpireAt: &at})
+	if ttl := store.TTL("k"); ttl <= 0 {
+		t.Fatalf("EX 후 TTL: %d", ttl)
+	}
+}
+
+func TestSetWithOptions_GetWrongType(t *testing.T) {
+	// given
+	store := New()
+	store.LPush("list", "a")
+
+	// when
+	_, _, set, err := store.SetWithOptions("list", "v", SetOptions{Get: true})
+
+	// then: 문자열이 아니면 저장하지 않는다
+	if err != ErrWrongType || set {
+		t.Fatalf("set: %v, err: %v", set, err)
+	}
+	if length, _ := store.LLen("list"); length != 1 {
+		t.Fatalf("LLen: %d", length)
+	}
+}
@@ -442,12 +442,13 @@ func TestTTL_NonExistentKey(t *testing.T) {
 
 func TestLazyDeletion(t *testing.T) {
 	// given: TTL 1초 설정
-	store := New()
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
 	store.Set("session", "abc")
 	store.Expire("session", 1)
 
-	// when: 2초 대기 후 Get
-	time.Sleep(2 * time.Second)
+	// when: 2초 뒤 Get
+	clock.Advance(2 * time.Second)
 	value, exist := store.Get("session")
 
 	// then: 만료되어 존재하지 않음
@@ -458,12 +459,13 @@ func TestLazyDeletion(t *testing.T) {
 
 func TestLazyDeletion_List(t *testing.T) {
 	// given: 리스트에 TTL 설정
-	store := New()
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
 	store.LPush("mylist", "a", "b")
 	store.Expire("mylist", 1)
 
-	// when: 2초 대기 후 LRange
-	time.Sleep(2 * time.Second)
+	// when: 2초 뒤 LRange
+	clock.Advance(2 * time.Second)
 	result, err := store.LRange("mylist", 0, -1)
 
 	// then: 만료되어 빈 배열
@@ -586,17 +588,91 @@ func TestExpireOverwrite(t *testing.T) {
 	}
 }
 
+func TestExpireAt_Milliseconds(t *testing.T) {
+	// given
+	store := New()
+	store.Set("key", "value")
+
+	// when: 1500ms 뒤 만료
+	at := time.Now().Add(1500 * time.Millisecond)
+	result := store.ExpireAt("key", at, 0)
+
+	// then: 밀리초 단위로 유지되고, TTL은 초 단위로 반올림
+	if result != 1 {
+		t.Fatalf("ExpireAt 결과: %d, expected: 1", result)
+	}
+	if pttl := store.PTTL("key"); pttl <= 1000 || pttl > 1500 {
+		t.Fatalf("PTTL 범위 초과. actual: %d", pttl)
+	}
+	if ttl := store.TTL("key"); ttl != 1 && ttl != 2 {
+		t.Fatalf("TTL이 다릅니다. actual: %d", ttl)
+	}
+	if expireTime := store.PExpireTime("key"); expireTime != at.UnixMilli() {
+		t.Fatalf("PExpireTime: %d, expected: %d", expireTime, at.UnixMilli())
+	}
+}
+
+func TestExpireAt_PastDeletesKey(t *testing.T) {
+	// given
+	store := New()
+	store.Set("key", "value")
+
+	// when: 이미 지난 시각
+	result := store.ExpireAt("key", time.Now().Add(-time.Second), 0)
+
+	// then: 키가 바로 삭제됨
+	if result != 1 {
+		t.Fatalf("ExpireAt 결과: %d, expected: 1", result)
+	}
+	if _, exist := store.Get("key"); exist {
+		t.Fatal("과거 시각으로 만료한 키가 남아 있습니다")
+	}
+}
+
+func TestExpireAt_Conditions(t *testing.T) {
+	// given: TTL 없는 키
+	store := New()
+	store.Set("key", "value")
+	later := time.Now().Add(100 * time.Second)
+	sooner := time.Now().Add(10 * time.Second)
+
+	// when & then
+	if result := store.ExpireAt("key", later, ExpireXX); result != 0 {
+		t.Fatalf("TTL 없는 키에 XX: %d, expected: 0", result)
+	}
+	if result := store.ExpireAt("key", later, ExpireGT); result != 0 {
+		t.Fatalf("TTL 없는 키에 GT: %d, expected: 0", result)
+	}
+	if result := store.ExpireAt("key", later, ExpireNX); result != 1 {
+		t.Fatalf("TTL 없는 키에 NX: %d, expected: 1", result)
+	}
+	if result := store.ExpireAt("key", sooner, ExpireNX); result != 0 {
+		t.Fatalf("TTL 있는 키에 NX: %d, expected: 0", result)
+	}
+	if result := store.ExpireAt("key", sooner, ExpireGT); result != 0 {
+		t.Fatalf("더 이른 시각으로 GT: %d, expected: 0", result)
+	}
+	if result := store.ExpireAt("key", sooner, ExpireLT|ExpireXX); result != 1 {
+		t.Fatalf("더 이른 시각으로 LT XX: %d, expected: 1", result)
+	}
+	if expireTime := store.PExpireTime("key"); expireTime != sooner.UnixMilli() {
+		t.Fatalf("PExpireTime: %d, expected: %d", expireTime, sooner.UnixMilli())
+	}
+}
+
 func TestActiveDeletion(t *testing.T) {
 	// given: 백그라운드 만료 시작, TTL 1초 설정
-	store := New()
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
 	store.StartExpiry()
 	defer store.StopExpiry()
 
 	store.Set("session", "abc")
 	store.Expire("session", 1)
 
-	// when: 3초 대기 (1초 TTL + 1초 ticker 간격 + 여유)
-	time.Sleep(3 * time.Second)
+	// when: 시계를 2초 옮기고 만료 루프가 몇 번 돌 때까지 대기
+	clock.Advance(2 * time.Second)
+	time.Sleep(300 * time.Millisecond)
 
 	// then: Active Deletion으로 키가 삭제됨
 	ttl := store.TTL("session")
@@ -710,11 +786,12 @@ func TestSave_ListEntries(t *testing.T) {
 
 func TestSave_SkipExpiredKeys(t *testing.T) {
 	// given: 만료된 키 1개 + 유효한 키 1개
-	store := New()
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
 	store.Set("expired", "old")
 	store.Set("valid", "new")
 	store.Expire("expired", 1)
-	time.Sleep(2 * time.Second)
+	clock.Advance(2 * time.Second)
 
 	path := filepath.Join(t.TempDir(), "skip.rdb")
 
@@ -856,11 +933,12 @@ func TestLoad_WithTTL(t *testing.T) {
 
 func TestLoad_SkipExpiredKeys(t *testing.T) {
 	// given: 만료된 키 + 유효한 키를 Save
-	store := New()
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
 	store.Set("expired", "old")
 	store.Set("valid", "new")
 	store.Expire("expired", 1)
-	time.Sleep(2 * time.Second)
+	clock.Advance(2 * time.Second)
 
 	path := filepath.Join(t.TempDir(), "expired.rdb")
 	store.Save(path)
@@ -924,6 +1002,112 @@ func TestLoad_EmptyStore(t *testing.T) {
 	}
 }
 
+// ===== 키스페이스 알림 =====
+
+// 알림 함수로 받은 이벤트를 "event:key" 형태로 모은다.
+func recordEvents(store *Store) *[]string {
+	var mu sync.Mutex
+	events := []string{}
+	store.SetNotifier(func(class int, event, key string) {
+		mu.Lock()
+		defer mu.Unlock()
+		events = append(events, event+":"+key)
+	})
+	return &events
+}
+
+func TestNotify_SetAndDel(t *testing.T) {
+	// given
+	store := New()
+	events := recordEvents(store)
+
+	// when
+	store.Set("key", "v1")
+	store.Set("key", "v2")
+	store.Del("key")
+
+	// then: 새 키 생성 시에만 new 이벤트가 발생한다
+	expected := []string{"new:key", "set:key", "set:key", "del:key"}
+	if fmt.Sprint(*events) != fmt.Sprint(expected) {
+		t.Fatalf("이벤트: %v, expected: %v", *events, expected)
+	}
+}
+
+func TestNotify_ListPopDeletesKey(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a")
+	events := recordEvents(store)
+
+	// when: 마지막 요소를 꺼내 리스트가 비게 된다
+	store.LPop("mylist")
+
+	// then
+	expected := []string{"lpop:mylist", "del:mylist"}
+	if fmt.Sprint(*events) != fmt.Sprint(expected) {
+		t.Fatalf("이벤트: %v, expected: %v", *events, expected)
+	}
+}
+
+func TestNotify_PopZeroCount(t *testing.T) {
+	// given
+	store := New()
+	store.RPush("mylist", "a")
+	events := recordEvents(store)
+
+	// when: 0개를 꺼낸다
+	store.LPopCount("mylist", 0)
+	store.RPopCount("mylist", 0)
+
+	// then: 꺼낸 요소가 없으면 이벤트도 없다
+	if len(*events) != 0 {
+		t.Fatalf("이벤트: %v, expected: 없음", *events)
+	}
+}
+
+func TestNotify_LazyExpired(t *testing.T) {
+	// given
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
+	store.Set("session", "abc")
+	store.Expire("session", 1)
+	events := recordEvents(store)
+
+	// when: 만료 후 조회하고, 같은 키에 쓰기 명령어를 실행한다
+	clock.Advance(1100 * time.Millisecond)
+	store.Get("session")
+	store.Set("session", "def")
+
+	// then: 조회는 읽기 락이라 지우지 않고 keymiss만 알리고, 쓰기 락을 잡을 때 expired 후 새로 생성된다
+	expected := []string{"keymiss:session", "expired:session", "new:session", "set:session"}
+	if fmt.Sprint(*events) != fmt.Sprint(expected) {
+		t.Fatalf("이벤트: %v, expected: %v", *events, expected)
+	}
+}
+
+func TestNotify_ActiveExpired(t *testing.T) {
+	// given
+	clock := NewManualClock(time.Now())
+	store := New(WithClock(clock))
+	store.Set("session", "abc")
+	store.Expire("session", 1)
+	events := recordEvents(store)
+
+	// when: 백그라운드 만료 처리
+	store.StartExpiry()
+	defer store.StopExpiry()
+	clock.Advance(2 * time.Second)
+	time.Sleep(300 * time.Millisecond)
+
+	// then
+	locks := store.lockAll()
+	defer store.unlock(locks)
+	expected := []string{"expired:session"}
+	if fmt.Sprint(*events) != fmt.Sprint(expected) {
+		t.Fatalf("이벤트: %v, expected: %v", *events, expected)
+	}
+}
+
 func BenchmarkSet(b *testing.B) {
 	// given: store 초기화 && 할당 통계 활성화 && store 생성 등 셋업 시간은 제외
 	store := New()
@@ -985,3 +1169,46 @@ func BenchmarkConcurrentGet(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkConcurrentSet(b *testing.B) {
+	store := New()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			store.Set(fmt.Sprintf("key:%d", i%1000), "value")
+			i++
+		}
+	})
+}
+
+// 모든 고루틴이 같은 키를 읽는다. 같은 샤드라도 읽기끼리는 서로 기다리지 않아야 코어 수만큼 늘어난다
+func BenchmarkConcurrentHotGet(b *testing.B) {
+	store := New()
+	store.Set("hot", "value")
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			store.Get("hot")
+		}
+	})
+}
+
+func BenchmarkConcurrentLRange(b *testing.B) {
+	store := New()
+	for i := 0; i < 100; i++ {
+		store.RPush("list", fmt.Sprintf("val:%d", i))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			store.LRange("list", 0, 9)
+		}
+	})
+}
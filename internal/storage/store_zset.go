@@ -0,0 +1,515 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"inmemory-db/internal/glob"
+	"inmemory-db/internal/pubsub"
+	"math"
+)
+
+var ErrScoreNaN = errors.New("resulting score is not a number (NaN)")
+
+// ZADD 옵션
+type ZAddOptions struct {
+	NX bool // 새 원소만 추가한다
+	XX bool // 이미 있는 원소만 갱신한다
+	GT bool // 새 점수가 더 클 때만 갱신한다
+	LT bool // 새 점수가 더 작을 때만 갱신한다
+	CH bool // 반환값에 점수가 바뀐 원소 수도 더한다
+}
+
+// ZUNIONSTORE/ZINTERSTORE에서 같은 원소의 점수를 합치는 방법
+type ZAggregate int
+
+const (
+	ZAggregateSum ZAggregate = iota
+	ZAggregateMin
+	ZAggregateMax
+)
+
+// 원소들을 추가하거나 점수를 갱신한다.
+// 새로 추가된 원소 수를 반환하고, CH면 점수가 바뀐 원소 수도 더한다.
+func (s *Store) ZAdd(key string, options ZAddOptions, members ...ZMember) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil {
+		return 0, err
+	}
+	if entry == nil {
+		if options.XX {
+			return 0, nil
+		}
+		entry = s.createZSet(key)
+	}
+
+	added, changed := 0, 0
+	for _, m := range members {
+		result, _ := zaddOne(entry.ZSet, options, m.Member, m.Score, false)
+		switch result {
+		case zaddAdded:
+			added++
+		case zaddUpdated:
+			changed++
+		}
+	}
+
+	if added+changed > 0 {
+		s.notifyEvent(pubsub.NotifyZSet, "zadd", key)
+	}
+	s.deleteIfEmptyZSet(key, entry)
+	s.serveBlocked(key)
+
+	if options.CH {
+		return added + changed, nil
+	}
+	return added, nil
+}
+
+// ZADD ... INCR. 원소의 점수에 increment를 더하고 새 점수를 반환한다.
+// NX/XX/GT/LT 조건 때문에 갱신하지 않았으면 ok=false
+func (s *Store) ZAddIncr(key string, options ZAddOptions, member string, increment float64) (score float64, ok bool, err error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil {
+		return 0, false, err
+	}
+	if entry == nil {
+		if options.XX {
+			return 0, false, nil
+		}
+		entry = s.createZSet(key)
+	}
+
+	result, score := zaddOne(entry.ZSet, options, member, increment, true)
+	if result == zaddNaN {
+		s.deleteIfEmptyZSet(key, entry)
+		return 0, false, ErrScoreNaN
+	}
+	if result == zaddAdded || result == zaddUpdated {
+		s.notifyEvent(pubsub.NotifyZSet, "zincr", key)
+	}
+	s.deleteIfEmptyZSet(key, entry)
+	s.serveBlocked(key)
+
+	if result == zaddSkipped {
+		return 0, false, nil
+	}
+	return score, true, nil
+}
+
+// ZINCRBY. 원소의 점수에 increment를 더하고 새 점수를 반환한다. 원소가 없으면 0에서 시작한다.
+func (s *Store) ZIncrBy(key, member string, increment float64) (float64, error) {
+	score, _, err := s.ZAddIncr(key, ZAddOptions{}, member, increment)
+	return score, err
+}
+
+// 원소들을 삭제하고 삭제한 개수를 반환한다. 빈 집합이 되면 키를 삭제한다.
+func (s *Store) ZRem(key string, members ...string) (int, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, member := range members {
+		if entry.ZSet.Remove(member) {
+			removed++
+		}
+	}
+	if removed > 0 {
+		s.notifyEvent(pubsub.NotifyZSet, "zrem", key)
+	}
+	s.deleteIfEmptyZSet(key, entry)
+	return removed, nil
+}
+
+// 원소 개수. 키가 없으면 0
+func (s *Store) ZCard(key string) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return entry.ZSet.Len(), nil
+}
+
+// 원소의 점수. 키나 원소가 없으면 ok=false
+func (s *Store) ZScore(key, member string) (float64, bool, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return 0, false, err
+	}
+	score, exist := entry.ZSet.Score(member)
+	return score, exist, nil
+}
+
+// 원소의 순위 (0부터 시작). reverse면 점수가 큰 쪽부터 센다 (ZREVRANK).
+func (s *Store) ZRank(key, member string, reverse bool) (int, bool, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return 0, false, err
+	}
+	rank, exist := entry.ZSet.Rank(member, reverse)
+	return rank, exist, nil
+}
+
+// 순위 범위의 원소들 (ZRANGE key start stop [REV])
+func (s *Store) ZRangeByRank(key string, start, stop int, reverse bool) ([]ZMember, error) {
+	return s.zrange(key, func(z *ZSet) []ZMember {
+		return z.RangeByRank(start, stop, reverse)
+	})
+}
+
+// 점수 범위의 원소들 (ZRANGE key min max BYSCORE [REV] [LIMIT offset count])
+// count가 음수면 전부 반환한다.
+func (s *Store) ZRangeByScore(key string, r ScoreRange, reverse bool, offset, count int) ([]ZMember, error) {
+	return s.zrange(key, func(z *ZSet) []ZMember {
+		return z.RangeByScore(r, reverse, offset, count)
+	})
+}
+
+// 사전순 범위의 원소들 (ZRANGE key min max BYLEX [REV] [LIMIT offset count])
+func (s *Store) ZRangeByLex(key string, r LexRange, reverse bool, offset, count int) ([]ZMember, error) {
+	return s.zrange(key, func(z *ZSet) []ZMember {
+		return z.RangeByLex(r, reverse, offset, count)
+	})
+}
+
+// 점수 범위에 속하는 원소 수
+func (s *Store) ZCount(key string, r ScoreRange) (int, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return 0, err
+	}
+	return entry.ZSet.CountByScore(r), nil
+}
+
+// 점수가 가장 작은 원소를 최대 count개 꺼낸다.
+func (s *Store) ZPopMin(key string, count int) ([]ZMember, error) {
+	return s.zpop(key, count, false)
+}
+
+// 점수가 가장 큰 원소를 최대 count개 꺼낸다.
+func (s *Store) ZPopMax(key string, count int) ([]ZMember, error) {
+	return s.zpop(key, count, true)
+}
+
+// BZPOPMIN/BZPOPMAX. 여러 키 중 원소가 있는 첫 번째 키에서 원소를 꺼낸다.
+// 모든 키가 비어있으면 다른 클라이언트가 원소를 넣을 때까지 기다린다.
+// ctx가 끝나면(타임아웃, CLIENT UNBLOCK) ok=false를 반환한다.
+func (s *Store) BlockingZPop(ctx context.Context, keys []string, max bool) (key string, member ZMember, ok bool, err error) {
+	locks := s.lock(keys...)
+	defer s.unlock(locks)
+
+	for _, k := range keys {
+		entry, err := s.lookupZSet(k)
+		if err != nil {
+			return "", ZMember{}, false, err
+		}
+		if entry != nil {
+			return k, s.zpopLocked(k, entry, 1, max)[0], true, nil
+		}
+	}
+
+	w := &waiter{
+		keys: keys,
+		serve: func(k string, entry *Entry) bool {
+			if entry.Type != TypeZSet || entry.ZSet.Len() == 0 {
+				return false
+			}
+			key = k
+			member = s.zpopLocked(k, entry, 1, max)[0]
+			return true
+		},
+	}
+	if !s.waitLocked(ctx, w, locks) {
+		return "", ZMember{}, false, nil
+	}
+	return key, member, true, nil
+}
+
+// 여러 정렬된 집합(또는 일반 집합, 점수 1)의 합집합을 destination에 저장하고 원소 수를 반환한다.
+// weights가 있으면 각 집합의 점수에 곱한다. 기존 값은 타입과 상관없이 덮어쓴다.
+func (s *Store) ZUnionStore(destination string, keys []string, weights []float64, aggregate ZAggregate) (int, error) {
+	return s.zsetAlgebraStore(destination, keys, weights, aggregate, false)
+}
+
+// 여러 정렬된 집합의 교집합을 destination에 저장하고 원소 수를 반환한다.
+func (s *Store) ZInterStore(destination string, keys []string, weights []float64, aggregate ZAggregate) (int, error) {
+	return s.zsetAlgebraStore(destination, keys, weights, aggregate, true)
+}
+
+// 커서 기반으로 원소를 순회한다 (ZSCAN).
+// pattern이 비어있지 않으면 일치하는 원소만 반환한다. 커서가 0이면 순회가 끝난 것이다.
+func (s *Store) ZScan(key string, cursor uint64, pattern string, count int) (uint64, []ZMember, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return 0, []ZMember{}, err
+	}
+
+	result := []ZMember{}
+	next := entry.ZSet.Scan(cursor, count, func(member string, score float64) {
+		if pattern == "" || glob.Match(pattern, member) {
+			result = append(result, ZMember{Member: member, Score: score})
+		}
+	})
+	return next, result, nil
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// zaddOne의 결과
+const (
+	zaddSkipped = iota // 조건 때문에 건너뜀 (점수가 같아 바뀌지 않은 경우 포함)
+	zaddAdded
+	zaddUpdated
+	zaddNaN
+)
+
+// ZADD의 원소 하나를 처리한다. incr면 score를 기존 점수에 더한다.
+// 결과와 최종 점수를 반환한다.
+func zaddOne(z *ZSet, options ZAddOptions, member string, score float64, incr bool) (int, float64) {
+	current, exist := z.Score(member)
+	if !exist {
+		if options.XX {
+			return zaddSkipped, 0
+		}
+		z.Set(member, score)
+		return zaddAdded, score
+	}
+
+	if options.NX {
+		return zaddSkipped, current
+	}
+	if incr {
+		score += current
+		if math.IsNaN(score) {
+			return zaddNaN, current
+		}
+	}
+	if (options.GT && score <= current) || (options.LT && score >= current) {
+		return zaddSkipped, current
+	}
+	if score == current {
+		return zaddSkipped, current
+	}
+
+	z.Set(member, score)
+	return zaddUpdated, score
+}
+
+// 정렬된 집합 엔트리를 찾는다. 키가 없거나 만료되었으면 nil
+// 키가 정렬된 집합이 아니면 ErrWrongType을 반환한다. 키의 샤드 읽기 락 이상을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) lookupZSet(key string) (*Entry, error) {
+	entry, exist := s.data.Get(key)
+	if !exist || s.isExpired(key) {
+		return nil, nil
+	}
+	if entry.Type != TypeZSet {
+		return nil, ErrWrongType
+	}
+	return entry, nil
+}
+
+// 빈 정렬된 집합을 만든다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) createZSet(key string) *Entry {
+	entry := &Entry{Type: TypeZSet, ZSet: NewZSet()}
+	s.data.Set(key, entry)
+	s.notifyEvent(pubsub.NotifyNew, "new", key)
+	return entry
+}
+
+// 빈 정렬된 집합이 되었으면 키를 삭제한다. 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) deleteIfEmptyZSet(key string, entry *Entry) {
+	if entry.ZSet.Len() == 0 {
+		s.data.Delete(key)
+		s.notifyEvent(pubsub.NotifyGeneric, "del", key)
+	}
+}
+
+func (s *Store) zrange(key string, fn func(z *ZSet) []ZMember) ([]ZMember, error) {
+	locks := s.rlock(key)
+	defer s.runlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return []ZMember{}, nil
+	}
+	return fn(entry.ZSet), nil
+}
+
+func (s *Store) zpop(key string, count int, max bool) ([]ZMember, error) {
+	locks := s.lock(key)
+	defer s.unlock(locks)
+
+	entry, err := s.lookupZSet(key)
+	if err != nil || entry == nil {
+		return []ZMember{}, err
+	}
+	return s.zpopLocked(key, entry, count, max), nil
+}
+
+// 키의 샤드 락을 잡고 있는 상태에서 호출해야 한다 (내부용).
+func (s *Store) zpopLocked(key string, entry *Entry, count int, max bool) []ZMember {
+	result := entry.ZSet.Pop(count, max)
+	if len(result) > 0 {
+		if max {
+			s.notifyEvent(pubsub.NotifyZSet, "zpopmax", key)
+		} else {
+			s.notifyEvent(pubsub.NotifyZSet, "zpopmin", key)
+		}
+	}
+	s.deleteIfEmptyZSet(key, entry)
+	return result
+}
+
+// ZUNIONSTORE/ZINTERSTORE 공통 처리
+func (s *Store) zsetAlgebraStore(destination string, keys []string, weights []float64, aggregate ZAggregate, inter bool) (int, error) {
+	locks := s.lock(append([]string{destination}, keys...)...)
+	defer s.unlock(locks)
+
+	// 일반 집합도 점수 1인 정렬된 집합으로 취급한다
+	sources := make([]map[string]float64, len(keys))
+	for i, key := range keys {
+		entry, exist := s.data.Get(key)
+		if !exist || s.isExpired(key) {
+			continue
+		}
+
+		weight := 1.0
+		if weights != nil {
+			weight = weights[i]
+		}
+		source := make(map[string]float64)
+		switch entry.Type {
+		case TypeZSet:
+			entry.ZSet.Range(func(member string, score float64) bool {
+				source[member] = zsetWeighted(score, weight)
+				return true
+			})
+		case TypeSet:
+			entry.Set.Range(func(member string) bool {
+				source[member] = zsetWeighted(1, weight)
+				return true
+			})
+		default:
+			return 0, ErrWrongType
+		}
+		sources[i] = source
+	}
+
+	result := NewZSet()
+	if inter {
+		// 가장 작은 집합을 기준으로 모든 집합에 있는 원소만 남긴다
+		smallest := -1
+		for i, source := range sources {
+			if source == nil {
+				smallest = -1
+				break
+			}
+			if smallest < 0 || len(source) < len(sources[smallest]) {
+				smallest = i
+			}
+		}
+		if smallest >= 0 {
+			for member, score := range sources[smallest] {
+				inAll := true
+				for i, source := range sources {
+					if i == smallest {
+						continue
+					}
+					other, exist := source[member]
+					if !exist {
+						inAll = false
+						break
+					}
+					score = zsetAggregate(score, other, aggregate)
+				}
+				if inAll {
+					result.Set(member, score)
+				}
+			}
+		}
+	} else {
+		for _, source := range sources {
+			for member, score := range source {
+				if current, exist := result.Score(member); exist {
+					score = zsetAggregate(current, score, aggregate)
+				}
+				result.Set(member, score)
+			}
+		}
+	}
+
+	event := "zunionstore"
+	if inter {
+		event = "zinterstore"
+	}
+
+	_, existed := s.data.Get(destination)
+	if result.Len() == 0 {
+		if existed {
+			s.data.Delete(destination)
+			s.notifyEvent(pubsub.NotifyGeneric, "del", destination)
+		}
+		return 0, nil
+	}
+
+	if !existed {
+		s.notifyEvent(pubsub.NotifyNew, "new", destination)
+	}
+	s.data.Set(destination, &Entry{Type: TypeZSet, ZSet: result})
+	s.notifyEvent(pubsub.NotifyZSet, event, destination)
+	s.serveBlocked(destination)
+	return result.Len(), nil
+}
+
+// 점수에 가중치를 곱한다. inf * 0 처럼 NaN이 되면 0으로 본다 (Redis 동작).
+func zsetWeighted(score, weight float64) float64 {
+	result := score * weight
+	if math.IsNaN(result) {
+		return 0
+	}
+	return result
+}
+
+func zsetAggregate(a, b float64, aggregate ZAggregate) float64 {
+	switch aggregate {
+	case ZAggregateMin:
+		return math.Min(a, b)
+	case ZAggregateMax:
+		return math.Max(a, b)
+	default:
+		// inf + -inf 처럼 NaN이 되면 0으로 본다 (Redis 동작)
+		result := a + b
+		if math.IsNaN(result) {
+			return 0
+		}
+		return result
+	}
+}
@@ -0,0 +1,277 @@
+package storage
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+)
+
+// 원소 이름을 이어 붙인 문자열로 비교한다
+func zmembersString(members []ZMember) string {
+	result := ""
+	for _, m := range members {
+		result += m.Member
+	}
+	return result
+}
+
+func TestZAdd_Options(t *testing.T) {
+	// given
+	store := New()
+	store.ZAdd("z", ZAddOptions{}, ZMember{"a", 1}, ZMember{"b", 2})
+
+	cases := []struct {
+		name     string
+		options  ZAddOptions
+		member   ZMember
+		expected int
+		score    float64
+	}{
+		{"NX는 기존 원소를 건너뛴다", ZAddOptions{NX: true}, ZMember{"a", 10}, 0, 1},
+		{"XX는 새 원소를 건너뛴다", ZAddOptions{XX: true}, ZMember{"c", 10}, 0, 0},
+		{"GT는 더 작은 점수로 갱신하지 않는다", ZAddOptions{GT: true, CH: true}, ZMember{"b", 1}, 0, 2},
+		{"GT는 더 큰 점수로 갱신한다", ZAddOptions{GT: true, CH: true}, ZMember{"b", 5}, 1, 5},
+		{"LT는 더 작은 점수로 갱신한다", ZAddOptions{LT: true, CH: true}, ZMember{"a", 0}, 1, 0},
+		{"CH 없이 갱신하면 0", ZAddOptions{}, ZMember{"a", 3}, 0, 3},
+	}
+
+	for _, c := range cases {
+		// when
+		count, err := store.ZAdd("z", c.options, c.member)
+
+		// then
+		if err != nil || count != c.expected {
+			t.Fatalf("%s: %d, err: %v", c.name, count, err)
+		}
+		if score, _, _ := store.ZScore("z", c.member.Member); score != c.score {
+			t.Fatalf("%s: 점수 %v, expected: %v", c.name, score, c.score)
+		}
+	}
+}
+
+func TestZAddIncr(t *testing.T) {
+	// given
+	store := New()
+
+	// when
+	first, ok1, _ := store.ZAddIncr("z", ZAddOptions{}, "a", 1.5)
+	second, ok2, _ := store.ZAddIncr("z", ZAddOptions{}, "a", 2)
+	_, skipped, _ := store.ZAddIncr("z", ZAddOptions{NX: true}, "a", 1)
+
+	// then
+	if !ok1 || first != 1.5 || !ok2 || second != 3.5 {
+		t.Fatalf("ZAddIncr: %v %v, %v %v", first, ok1, second, ok2)
+	}
+	if skipped {
+		t.Fatal("NX인데 갱신됨")
+	}
+}
+
+func TestZIncrBy_NaN(t *testing.T) {
+	// given
+	store := New()
+	store.ZAdd("z", ZAddOptions{}, ZMember{"a", math.Inf(1)})
+
+	// when: inf + -inf = NaN
+	_, err := store.ZIncrBy("z", "a", math.Inf(-1))
+
+	// then
+	if err != ErrScoreNaN {
+		t.Fatalf("에러: %v", err)
+	}
+	if score, _, _ := store.ZScore("z", "a"); !math.IsInf(score, 1) {
+		t.Fatalf("점수가 바뀜: %v", score)
+	}
+}
+
+func TestZSet_WrongType(t *testing.T) {
+	// given
+	store := New()
+	store.Set("str", "value")
+	store.ZAdd("z", ZAddOptions{}, ZMember{"a", 1})
+
+	// when & then
+	if _, err := store.ZAdd("str", ZAddOptions{}, ZMember{"a", 1}); err != ErrWrongType {
+		t.Fatalf("ZAdd 에러: %v", err)
+	}
+	if _, err := store.ZUnionStore("dest", []string{"z", "str"}, nil, ZAggregateSum); err != ErrWrongType {
+		t.Fatalf("ZUnionStore 에러: %v", err)
+	}
+}
+
+func TestZRank_AndRanges(t *testing.T) {
+	// given
+	store := New()
+	store.ZAdd("z", ZAddOptions{}, ZMember{"a", 1}, ZMember{"b", 2}, ZMember{"c", 3})
+
+	// when & then
+	if rank, ok, _ := store.ZRank("z", "b", false); !ok || rank != 1 {
+		t.Fatalf("ZRank: %d", rank)
+	}
+	if rank, ok, _ := store.ZRank("z", "a", true); !ok || rank != 2 {
+		t.Fatalf("ZRank(rev): %d", rank)
+	}
+	if _, ok, _ := store.ZRank("z", "x", false); ok {
+		t.Fatal("없는 원소의 순위가 있음")
+	}
+	if members, _ := store.ZRangeByRank("z", 0, -1, true); zmembersString(members) != "cba" {
+		t.Fatalf("ZRangeByRank: %v", members)
+	}
+	if members, _ := store.ZRangeByScore("z", ScoreRange{Min: math.Inf(-1), Max: 2}, false, 0, -1); zmembersString(members) != "ab" {
+		t.Fatalf("ZRangeByScore: %v", members)
+	}
+	if count, _ := store.ZCount("z", ScoreRange{Min: 2, Max: math.Inf(1)}); count != 2 {
+		t.Fatalf("ZCount: %d", count)
+	}
+	if members, _ := store.ZRangeByRank("missing", 0, -1, false); len(members) != 0 {
+		t.Fatalf("없는 키: %v", members)
+	}
+}
+
+func TestZPop_DeletesEmptyKey(t *testing.T) {
+	// given
+	store := New()
+	store.ZAdd("z", ZAddOptions{}, ZMember{"a", 1}, ZMember{"b", 2})
+
+	// when
+	highest, _ := store.ZPopMax("z", 1)
+	rest, _ := store.ZPopMin("z", 10)
+
+	// then
+	if zmembersString(highest) != "b" || zmembersString(rest) != "a" {
+		t.Fatalf("ZPopMax: %v, ZPopMin: %v", highest, rest)
+	}
+	if _, exist := store.data.Get("z"); exist {
+		t.Fatal("빈 정렬된 집합이 남아있음")
+	}
+}
+
+func TestBlockingZPop_WaitsForZAdd(t *testing.T) {
+	// given
+	store := New()
+	type result struct {
+		key    string
+		member ZMember
+		ok     bool
+	}
+	results := make(chan result)
+
+	go func() {
+		key, member, ok, _ := store.BlockingZPop(context.Background(), []string{"z"}, false)
+		results <- result{key, member, ok}
+	}()
+	waitForBlocked(t, store, 1)
+
+	// when
+	store.ZAdd("z", ZAddOptions{}, ZMember{"b", 2}, ZMember{"a", 1})
+
+	// then: 가장 작은 원소를 가져간다
+	r := <-results
+	if !r.ok || r.key != "z" || r.member.Member != "a" || r.member.Score != 1 {
+		t.Fatalf("결과: %+v", r)
+	}
+	if count, _ := store.ZCard("z"); count != 1 {
+		t.Fatalf("ZCard: %d, expected: 1", count)
+	}
+}
+
+func TestZUnionStore_WeightsAndAggregate(t *testing.T) {
+	// given: 일반 집합의 원소는 점수 1로 센다
+	store := New()
+	store.ZAdd("z1", ZAddOptions{}, ZMember{"a", 1}, ZMember{"b", 2})
+	store.ZAdd("z2", ZAddOptions{}, ZMember{"b", 3}, ZMember{"c", 4})
+	store.SAdd("s", "c")
+
+	// when
+	count, err := store.ZUnionStore("dest", []string{"z1", "z2", "s"}, []float64{1, 2, 10}, ZAggregateSum)
+
+	// then
+	if err != nil || count != 3 {
+		t.Fatalf("ZUnionStore: %d, err: %v", count, err)
+	}
+	expected := map[string]float64{"a": 1, "b": 8, "c": 18}
+	for member, score := range expected {
+		if actual, _, _ := store.ZScore("dest", member); actual != score {
+			t.Fatalf("%s 점수: %v, expected: %v", member, actual, score)
+		}
+	}
+}
+
+func TestZInterStore_Aggregate(t *testing.T) {
+	// given
+	store := New()
+	store.ZAdd("z1", ZAddOptions{}, ZMember{"a", 1}, ZMember{"b", 5})
+	store.ZAdd("z2", ZAddOptions{}, ZMember{"b", 3}, ZMember{"c", 4})
+	store.Set("dest", "old")
+
+	// when
+	count, err := store.ZInterStore("dest", []string{"z1", "z2"}, nil, ZAggregateMax)
+
+	// then: 기존 값은 덮어쓴다
+	if err != nil || count != 1 {
+		t.Fatalf("ZInterStore: %d, err: %v", count, err)
+	}
+	if score, _, _ := store.ZScore("dest", "b"); score != 5 {
+		t.Fatalf("b 점수: %v, expected: 5", score)
+	}
+
+	// when: 결과가 비면 destination을 삭제한다
+	count, _ = store.ZInterStore("dest", []string{"z1", "missing"}, nil, ZAggregateSum)
+
+	// then
+	if _, exist := store.data.Get("dest"); count != 0 || exist {
+		t.Fatalf("빈 교집합: %d, exist: %v", count, exist)
+	}
+}
+
+func TestZScan_AllMembers(t *testing.T) {
+	// given
+	store := New()
+	for i := 0; i < 100; i++ {
+		store.ZAdd("z", ZAddOptions{}, ZMember{string(rune('a'+i%26)) + string(rune('a'+i/26)), float64(i)})
+	}
+
+	// when: 커서가 0이 될 때까지 순회한다
+	seen := map[string]bool{}
+	cursor := uint64(0)
+	for {
+		next, members, err := store.ZScan("z", cursor, "", 10)
+		if err != nil {
+			t.Fatalf("에러 발생: %v", err)
+		}
+		for _, m := range members {
+			seen[m.Member] = true
+		}
+		if next == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	// then
+	if len(seen) != 100 {
+		t.Fatalf("순회한 원소 수: %d, expected: 100", len(seen))
+	}
+}
+
+func TestSaveAndLoad_ZSetEntries(t *testing.T) {
+	// given
+	store := New()
+	store.ZAdd("z", ZAddOptions{}, ZMember{"a", 1.5}, ZMember{"b", math.Inf(1)}, ZMember{"c", -2})
+	path := filepath.Join(t.TempDir(), "zset.rdb")
+	store.Save(path)
+
+	// when
+	loaded := New()
+	err := loaded.Load(path)
+
+	// then
+	if err != nil {
+		t.Fatalf("에러 발생: %v", err)
+	}
+	members, _ := loaded.ZRangeByRank("z", 0, -1, false)
+	if zmembersString(members) != "cab" || !math.IsInf(members[2].Score, 1) || members[1].Score != 1.5 {
+		t.Fatalf("복원된 원소: %v", members)
+	}
+}
@@ -0,0 +1,393 @@
+package storage
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidStreamID = errors.New("Invalid stream ID specified as stream command argument")
+
+// 스트림 엔트리의 ID. <밀리초>-<시퀀스> 형식이다.
+type StreamID struct {
+	Ms, Seq uint64
+}
+
+// 가능한 가장 큰 ID (XRANGE의 "+")
+var MaxStreamID = StreamID{Ms: math.MaxUint64, Seq: math.MaxUint64}
+
+// "ms-seq" 또는 "ms" 형식의 ID를 파싱한다. 시퀀스가 없으면 missingSeq를 쓴다.
+func ParseStreamID(raw string, missingSeq uint64) (StreamID, error) {
+	msPart, seqPart, hasSeq := strings.Cut(raw, "-")
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return StreamID{}, ErrInvalidStreamID
+	}
+	if !hasSeq {
+		return StreamID{Ms: ms, Seq: missingSeq}, nil
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return StreamID{}, ErrInvalidStreamID
+	}
+	return StreamID{Ms: ms, Seq: seq}, nil
+}
+
+func (id StreamID) String() string {
+	return strconv.FormatUint(id.Ms, 10) + "-" + strconv.FormatUint(id.Seq, 10)
+}
+
+// id가 other보다 앞이면 음수, 같으면 0, 뒤면 양수
+func (id StreamID) Compare(other StreamID) int {
+	switch {
+	case id.Ms != other.Ms:
+		if id.Ms < other.Ms {
+			return -1
+		}
+		return 1
+	case id.Seq != other.Seq:
+		if id.Seq < other.Seq {
+			return -1
+		}
+		return 1
+	}
+	return 0
+}
+
+func (id StreamID) Less(other StreamID) bool {
+	return id.Compare(other) < 0
+}
+
+// 바로 다음 ID. 가장 큰 ID면 false
+func (id StreamID) Next() (StreamID, bool) {
+	switch {
+	case id.Seq < math.MaxUint64:
+		return StreamID{Ms: id.Ms, Seq: id.Seq + 1}, true
+	case id.Ms < math.MaxUint64:
+		return StreamID{Ms: id.Ms + 1}, true
+	}
+	return id, false
+}
+
+// 바로 이전 ID. 0-0이면 false
+func (id StreamID) Prev() (StreamID, bool) {
+	switch {
+	case id.Seq > 0:
+		return StreamID{Ms: id.Ms, Seq: id.Seq - 1}, true
+	case id.Ms > 0:
+		return StreamID{Ms: id.Ms - 1, Seq: math.MaxUint64}, true
+	}
+	return id, false
+}
+
+// 스트림 엔트리. Fields는 [field1, value1, field2, value2, ...] 형태다.
+// PEL에는 남아있지만 스트림에서 삭제된 엔트리는 Fields가 nil이다.
+type StreamEntry struct {
+	ID     StreamID
+	Fields []string
+}
+
+// Stream은 ID 순서로 추가만 되는 로그다.
+// 엔트리는 ID 순서의 슬라이스에 두고, 잘라낼 때(MAXLEN/MINID)는 앞에서부터 버린다.
+type Stream struct {
+	entries []StreamEntry
+
+	LastID StreamID
+	// 지금까지 XADD로 추가된 엔트리 수 (삭제된 것 포함)
+	EntriesAdded uint64
+	// 잘라내서 삭제된 엔트리 중 가장 큰 ID
+	MaxDeletedID StreamID
+
+	groups map[string]*ConsumerGroup
+}
+
+func NewStream() *Stream {
+	return &Stream{groups: make(map[string]*ConsumerGroup)}
+}
+
+// 엔트리 개수
+func (st *Stream) Len() int {
+	return len(st.entries)
+}
+
+// 엔트리를 추가한다. id가 LastID보다 큰지는 호출하는 쪽에서 확인해야 한다.
+func (st *Stream) Add(id StreamID, fields []string) {
+	st.entries = append(st.entries, StreamEntry{ID: id, Fields: fields})
+	st.LastID = id
+	st.EntriesAdded++
+}
+
+// 자동 생성할 다음 ID. 시계가 뒤로 가도 LastID보다 큰 ID를 만든다.
+func (st *Stream) NextID(ms uint64) (StreamID, bool) {
+	if ms > st.LastID.Ms {
+		return StreamID{Ms: ms}, true
+	}
+	return st.LastID.Next()
+}
+
+// ID로 엔트리를 찾는다.
+func (st *Stream) Get(id StreamID) (StreamEntry, bool) {
+	i := st.search(id)
+	if i < len(st.entries) && st.entries[i].ID == id {
+		return st.entries[i], true
+	}
+	return StreamEntry{}, false
+}
+
+// start 이상 end 이하의 엔트리를 최대 count개(음수면 전부) 반환한다.
+// reverse면 end부터 거꾸로 반환한다.
+func (st *Stream) Range(start, end StreamID, reverse bool, count int) []StreamEntry {
+	result := []StreamEntry{}
+	if end.Less(start) {
+		return result
+	}
+
+	from, to := st.search(start), st.search(end)
+	if to < len(st.entries) && st.entries[to].ID == end {
+		to++
+	}
+
+	if reverse {
+		for i := to - 1; i >= from && count != 0; i-- {
+			result = append(result, st.entries[i])
+			count--
+		}
+		return result
+	}
+	for i := from; i < to && count != 0; i++ {
+		result = append(result, st.entries[i])
+		count--
+	}
+	return result
+}
+
+// 첫 번째와 마지막 엔트리. 비어있으면 ok=false
+func (st *Stream) Bounds() (first, last StreamEntry, ok bool) {
+	if len(st.entries) == 0 {
+		return StreamEntry{}, StreamEntry{}, false
+	}
+	return st.entries[0], st.entries[len(st.entries)-1], true
+}
+
+// 엔트리가 maxLen개 이하가 되도록 오래된 것부터 삭제한다.
+// limit이 양수면 최대 limit개까지만 삭제한다. 삭제한 개수를 반환한다.
+func (st *Stream) TrimMaxLen(maxLen, limit int) int {
+	return st.trimFront(len(st.entries)-maxLen, limit)
+}
+
+// minID보다 작은 ID의 엔트리를 삭제한다. limit은 TrimMaxLen과 같다.
+func (st *Stream) TrimMinID(minID StreamID, limit int) int {
+	return st.trimFront(st.search(minID), limit)
+}
+
+// ========== 소비자 그룹 ==========
+
+// 그룹을 만든다. 같은 이름의 그룹이 있으면 nil
+func (st *Stream) CreateGroup(name string, lastID StreamID, entriesRead int64) *ConsumerGroup {
+	if _, exist := st.groups[name]; exist {
+		return nil
+	}
+	group := &ConsumerGroup{
+		Name:        name,
+		LastID:      lastID,
+		EntriesRead: entriesRead,
+		pel:         make(map[StreamID]*PendingEntry),
+		consumers:   make(map[string]*Consumer),
+	}
+	st.groups[name] = group
+	return group
+}
+
+func (st *Stream) Group(name string) *ConsumerGroup {
+	return st.groups[name]
+}
+
+// 그룹을 삭제한다. 삭제했으면 true
+func (st *Stream) DestroyGroup(name string) bool {
+	if _, exist := st.groups[name]; !exist {
+		return false
+	}
+	delete(st.groups, name)
+	return true
+}
+
+// 이름 순서로 정렬된 그룹 목록
+func (st *Stream) Groups() []*ConsumerGroup {
+	groups := make([]*ConsumerGroup, 0, len(st.groups))
+	for _, group := range st.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	return groups
+}
+
+// 그룹이 마지막으로 전달한 엔트리 이후로 아직 읽지 않은 엔트리 수 (XINFO GROUPS의 lag).
+// 알 수 없으면 -1
+func (st *Stream) Lag(group *ConsumerGroup) int64 {
+	if group.EntriesRead < 0 {
+		return -1
+	}
+	return int64(st.EntriesAdded) - group.EntriesRead
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// id 이상인 첫 엔트리의 인덱스
+func (st *Stream) search(id StreamID) int {
+	return sort.Search(len(st.entries), func(i int) bool {
+		return !st.entries[i].ID.Less(id)
+	})
+}
+
+// 앞에서부터 n개(limit이 양수면 최대 limit개)를 삭제한다.
+func (st *Stream) trimFront(n, limit int) int {
+	if limit > 0 && n > limit {
+		n = limit
+	}
+	if n <= 0 {
+		return 0
+	}
+
+	st.MaxDeletedID = st.entries[n-1].ID
+	// 앞부분을 잘라낸 슬라이스는 배열을 계속 붙잡고 있으므로 새로 복사한다
+	st.entries = append([]StreamEntry(nil), st.entries[n:]...)
+	return n
+}
+
+// ConsumerGroup은 스트림의 소비자 그룹이다.
+// 전달했지만 아직 XACK하지 않은 엔트리들은 PEL(pending entries list)에 ID 순서로 둔다.
+type ConsumerGroup struct {
+	Name string
+	// 그룹에 마지막으로 전달한 엔트리의 ID
+	LastID StreamID
+	// 그룹이 읽은 엔트리 수. 알 수 없으면 -1
+	EntriesRead int64
+
+	pel       map[StreamID]*PendingEntry
+	pelIDs    []StreamID
+	consumers map[string]*Consumer
+}
+
+// 그룹에 속한 소비자
+type Consumer struct {
+	Name string
+	// 마지막으로 명령어를 보낸 시각
+	SeenTime time.Time
+	// 마지막으로 엔트리를 받은 시각. 받은 적이 없으면 zero
+	ActiveTime time.Time
+	// PEL에서 이 소비자가 가진 엔트리 수
+	Pending int
+}
+
+// PEL의 엔트리
+type PendingEntry struct {
+	ID            StreamID
+	Consumer      *Consumer
+	DeliveryTime  time.Time
+	DeliveryCount int
+}
+
+// 소비자를 찾고, 없으면 만든다.
+func (g *ConsumerGroup) Consumer(name string, now time.Time) *Consumer {
+	consumer, exist := g.consumers[name]
+	if !exist {
+		consumer = &Consumer{Name: name}
+		g.consumers[name] = consumer
+	}
+	consumer.SeenTime = now
+	return consumer
+}
+
+// 소비자를 찾는다. 없으면 nil
+func (g *ConsumerGroup) LookupConsumer(name string) *Consumer {
+	return g.consumers[name]
+}
+
+// 이름 순서로 정렬된 소비자 목록
+func (g *ConsumerGroup) Consumers() []*Consumer {
+	consumers := make([]*Consumer, 0, len(g.consumers))
+	for _, consumer := range g.consumers {
+		consumers = append(consumers, consumer)
+	}
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].Name < consumers[j].Name })
+	return consumers
+}
+
+// PEL에 있는 엔트리 수
+func (g *ConsumerGroup) PendingLen() int {
+	return len(g.pelIDs)
+}
+
+func (g *ConsumerGroup) Pending(id StreamID) *PendingEntry {
+	return g.pel[id]
+}
+
+// 엔트리를 consumer에게 전달한 것으로 PEL에 기록한다.
+// 이미 PEL에 있으면 소비자를 바꾸고 전달 횟수를 늘린다.
+func (g *ConsumerGroup) Deliver(id StreamID, consumer *Consumer, now time.Time) *PendingEntry {
+	if pending, exist := g.pel[id]; exist {
+		g.Transfer(pending, consumer)
+		pending.DeliveryTime = now
+		pending.DeliveryCount++
+		return pending
+	}
+
+	pending := g.insertPending(id, consumer, now)
+	pending.DeliveryCount = 1
+	return pending
+}
+
+// PEL 엔트리의 소비자를 바꾼다.
+func (g *ConsumerGroup) Transfer(pending *PendingEntry, consumer *Consumer) {
+	if pending.Consumer == consumer {
+		return
+	}
+	pending.Consumer.Pending--
+	pending.Consumer = consumer
+	consumer.Pending++
+}
+
+// PEL에서 엔트리를 제거한다 (XACK). 제거했으면 true
+func (g *ConsumerGroup) Ack(id StreamID) bool {
+	pending, exist := g.pel[id]
+	if !exist {
+		return false
+	}
+	pending.Consumer.Pending--
+	delete(g.pel, id)
+	i := g.searchPending(id)
+	g.pelIDs = append(g.pelIDs[:i], g.pelIDs[i+1:]...)
+	return true
+}
+
+// start 이상 end 이하의 PEL 엔트리를 ID 순서로 fn에 넘긴다. fn이 false를 반환하면 멈춘다.
+// 순회 중에 Ack를 호출하면 안 된다.
+func (g *ConsumerGroup) RangePending(start, end StreamID, fn func(pending *PendingEntry) bool) {
+	for i := g.searchPending(start); i < len(g.pelIDs) && !end.Less(g.pelIDs[i]); i++ {
+		if !fn(g.pel[g.pelIDs[i]]) {
+			return
+		}
+	}
+}
+
+// 전달 횟수가 0인 PEL 엔트리를 ID 순서 자리에 넣는다 (XCLAIM FORCE, 스냅샷 복원).
+func (g *ConsumerGroup) insertPending(id StreamID, consumer *Consumer, deliveryTime time.Time) *PendingEntry {
+	pending := &PendingEntry{ID: id, Consumer: consumer, DeliveryTime: deliveryTime}
+	g.pel[id] = pending
+	i := g.searchPending(id)
+	g.pelIDs = append(g.pelIDs, StreamID{})
+	copy(g.pelIDs[i+1:], g.pelIDs[i:])
+	g.pelIDs[i] = id
+	consumer.Pending++
+	return pending
+}
+
+// id 이상인 첫 PEL 엔트리의 인덱스
+func (g *ConsumerGroup) searchPending(id StreamID) int {
+	return sort.Search(len(g.pelIDs), func(i int) bool {
+		return !g.pelIDs[i].Less(id)
+	})
+}
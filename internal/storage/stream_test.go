@@ -0,0 +1,142 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseStreamID(t *testing.T) {
+	cases := []struct {
+		raw        string
+		missingSeq uint64
+		expected   StreamID
+		ok         bool
+	}{
+		{"1-2", 0, StreamID{1, 2}, true},
+		{"5", 0, StreamID{5, 0}, true},
+		{"5", 9, StreamID{5, 9}, true},
+		{"abc", 0, StreamID{}, false},
+		{"1-x", 0, StreamID{}, false},
+		{"-1", 0, StreamID{}, false},
+	}
+
+	for _, c := range cases {
+		// when
+		id, err := ParseStreamID(c.raw, c.missingSeq)
+
+		// then
+		if (err == nil) != c.ok || id != c.expected {
+			t.Fatalf("ParseStreamID(%q): %v, err: %v", c.raw, id, err)
+		}
+	}
+}
+
+func TestStreamID_NextAndPrev(t *testing.T) {
+	// when & then: 시퀀스가 넘치면 밀리초로 올라간다
+	if next, ok := (StreamID{1, MaxStreamID.Seq}).Next(); !ok || next != (StreamID{2, 0}) {
+		t.Fatalf("Next: %v", next)
+	}
+	if _, ok := MaxStreamID.Next(); ok {
+		t.Fatal("가장 큰 ID의 Next가 성공함")
+	}
+	if prev, ok := (StreamID{2, 0}).Prev(); !ok || prev != (StreamID{1, MaxStreamID.Seq}) {
+		t.Fatalf("Prev: %v", prev)
+	}
+	if _, ok := (StreamID{}).Prev(); ok {
+		t.Fatal("0-0의 Prev가 성공함")
+	}
+}
+
+func TestStream_Range(t *testing.T) {
+	// given
+	stream := NewStream()
+	for i := uint64(1); i <= 5; i++ {
+		stream.Add(StreamID{i, 0}, []string{"n", "v"})
+	}
+
+	cases := []struct {
+		name       string
+		start, end StreamID
+		reverse    bool
+		count      int
+		expected   []uint64
+	}{
+		{"전체", StreamID{}, MaxStreamID, false, -1, []uint64{1, 2, 3, 4, 5}},
+		{"양 끝 포함", StreamID{2, 0}, StreamID{4, 0}, false, -1, []uint64{2, 3, 4}},
+		{"역순 COUNT", StreamID{}, MaxStreamID, true, 2, []uint64{5, 4}},
+		{"범위 사이", StreamID{2, 1}, StreamID{3, 5}, false, -1, []uint64{3}},
+		{"start > end", StreamID{4, 0}, StreamID{2, 0}, false, -1, nil},
+	}
+
+	for _, c := range cases {
+		// when
+		entries := stream.Range(c.start, c.end, c.reverse, c.count)
+
+		// then
+		if len(entries) != len(c.expected) {
+			t.Fatalf("%s: %v", c.name, entries)
+		}
+		for i, entry := range entries {
+			if entry.ID.Ms != c.expected[i] {
+				t.Fatalf("%s: %v", c.name, entries)
+			}
+		}
+	}
+}
+
+func TestStream_Trim(t *testing.T) {
+	// given
+	stream := NewStream()
+	for i := uint64(1); i <= 10; i++ {
+		stream.Add(StreamID{i, 0}, []string{"n", "v"})
+	}
+
+	// when & then: LIMIT만큼만 삭제한다
+	if trimmed := stream.TrimMaxLen(5, 2); trimmed != 2 || stream.Len() != 8 {
+		t.Fatalf("TrimMaxLen LIMIT: %d, Len: %d", trimmed, stream.Len())
+	}
+	if trimmed := stream.TrimMaxLen(5, 0); trimmed != 3 || stream.Len() != 5 {
+		t.Fatalf("TrimMaxLen: %d, Len: %d", trimmed, stream.Len())
+	}
+	if trimmed := stream.TrimMinID(StreamID{8, 0}, 0); trimmed != 2 || stream.Len() != 3 {
+		t.Fatalf("TrimMinID: %d, Len: %d", trimmed, stream.Len())
+	}
+	if stream.MaxDeletedID != (StreamID{7, 0}) || stream.EntriesAdded != 10 {
+		t.Fatalf("MaxDeletedID: %v, EntriesAdded: %d", stream.MaxDeletedID, stream.EntriesAdded)
+	}
+}
+
+func TestConsumerGroup_PendingOrderAndCounts(t *testing.T) {
+	// given
+	stream := NewStream()
+	group := stream.CreateGroup("g", StreamID{}, 0)
+	now := time.Now()
+	alice := group.Consumer("alice", now)
+	bob := group.Consumer("bob", now)
+
+	// when: 순서와 상관없이 넣어도 PEL은 ID 순서다
+	group.Deliver(StreamID{3, 0}, alice, now)
+	group.Deliver(StreamID{1, 0}, alice, now)
+	group.Deliver(StreamID{2, 0}, bob, now)
+	redelivered := group.Deliver(StreamID{1, 0}, bob, now)
+	group.Ack(StreamID{3, 0})
+
+	// then
+	var ids []StreamID
+	group.RangePending(StreamID{}, MaxStreamID, func(pending *PendingEntry) bool {
+		ids = append(ids, pending.ID)
+		return true
+	})
+	if len(ids) != 2 || ids[0] != (StreamID{1, 0}) || ids[1] != (StreamID{2, 0}) {
+		t.Fatalf("PEL: %v", ids)
+	}
+	if redelivered.DeliveryCount != 2 || redelivered.Consumer != bob {
+		t.Fatalf("다시 전달된 엔트리: %+v", redelivered)
+	}
+	if alice.Pending != 0 || bob.Pending != 2 {
+		t.Fatalf("alice: %d, bob: %d", alice.Pending, bob.Pending)
+	}
+	if stream.CreateGroup("g", StreamID{}, 0) != nil {
+		t.Fatal("같은 이름의 그룹이 만들어짐")
+	}
+}
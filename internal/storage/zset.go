@@ -0,0 +1,267 @@
+package storage
+
+// 정렬된 집합의 원소
+type ZMember struct {
+	Member string
+	Score  float64
+}
+
+// 점수 범위. Exclusive면 경계값을 포함하지 않는다 (ZRANGE BYSCORE의 "(1.5").
+type ScoreRange struct {
+	Min, Max                   float64
+	MinExclusive, MaxExclusive bool
+}
+
+// 사전순 범위의 한쪽 경계 (ZRANGE BYLEX의 "[a", "(a", "-", "+").
+type LexBound struct {
+	Value     string
+	Exclusive bool
+	// -1이면 "-" (가장 작은 값), 1이면 "+" (가장 큰 값), 0이면 Value를 쓴다
+	Infinite int
+}
+
+type LexRange struct {
+	Min, Max LexBound
+}
+
+// ZSet은 정렬된 집합이다.
+// 점수 순서는 skiplist가, member로 점수 찾기는 Dict가 맡는다.
+type ZSet struct {
+	dict *Dict[float64]
+	zsl  *skiplist
+}
+
+func NewZSet() *ZSet {
+	return &ZSet{
+		dict: NewDict[float64](),
+		zsl:  newSkiplist(),
+	}
+}
+
+// 원소 개수
+func (z *ZSet) Len() int {
+	return z.dict.Len()
+}
+
+func (z *ZSet) Score(member string) (float64, bool) {
+	return z.dict.Get(member)
+}
+
+// 원소를 넣거나 점수를 바꾼다. 새로 추가되었으면 true
+func (z *ZSet) Set(member string, score float64) bool {
+	if current, exist := z.dict.Get(member); exist {
+		if current != score {
+			z.zsl.delete(current, member)
+			z.zsl.insert(score, member)
+			z.dict.Set(member, score)
+		}
+		return false
+	}
+
+	z.zsl.insert(score, member)
+	z.dict.Set(member, score)
+	return true
+}
+
+// 원소를 삭제한다. 삭제했으면 true
+func (z *ZSet) Remove(member string) bool {
+	score, exist := z.dict.Get(member)
+	if !exist {
+		return false
+	}
+	z.zsl.delete(score, member)
+	z.dict.Delete(member)
+	return true
+}
+
+// 원소의 순위 (0부터 시작). reverse면 점수가 큰 쪽부터 센다.
+func (z *ZSet) Rank(member string, reverse bool) (int, bool) {
+	score, exist := z.dict.Get(member)
+	if !exist {
+		return 0, false
+	}
+	rank := z.zsl.rank(score, member)
+	if reverse {
+		return z.Len() - rank, true
+	}
+	return rank - 1, true
+}
+
+// 순위 범위(양 끝 포함)의 원소들. 음수 인덱스 지원. reverse면 점수가 큰 쪽부터 센다.
+func (z *ZSet) RangeByRank(start, stop int, reverse bool) []ZMember {
+	length := z.Len()
+	if start < 0 {
+		start = length + start
+	}
+	if stop < 0 {
+		stop = length + stop
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop > length-1 {
+		stop = length - 1
+	}
+	if start > stop {
+		return []ZMember{}
+	}
+
+	var x *skiplistNode
+	if reverse {
+		x = z.zsl.byRank(length - start)
+	} else {
+		x = z.zsl.byRank(start + 1)
+	}
+	return z.collect(x, reverse, 0, stop-start+1, func(*skiplistNode) bool { return true })
+}
+
+// 점수 범위의 원소들. offset개를 건너뛰고 최대 count개(음수면 전부)를 반환한다.
+// reverse면 점수가 큰 쪽부터 반환한다.
+func (z *ZSet) RangeByScore(r ScoreRange, reverse bool, offset, count int) []ZMember {
+	var x *skiplistNode
+	inRange := r.aboveMin
+	if reverse {
+		x = z.zsl.lastInScoreRange(r)
+	} else {
+		x = z.zsl.firstInScoreRange(r)
+		inRange = r.belowMax
+	}
+	return z.collect(x, reverse, offset, count, func(n *skiplistNode) bool { return inRange(n.score) })
+}
+
+// 사전순 범위의 원소들. 모든 점수가 같다고 가정한다. offset과 count는 RangeByScore와 같다.
+func (z *ZSet) RangeByLex(r LexRange, reverse bool, offset, count int) []ZMember {
+	var x *skiplistNode
+	inRange := r.aboveMin
+	if reverse {
+		x = z.zsl.lastInLexRange(r)
+	} else {
+		x = z.zsl.firstInLexRange(r)
+		inRange = r.belowMax
+	}
+	return z.collect(x, reverse, offset, count, func(n *skiplistNode) bool { return inRange(n.member) })
+}
+
+// 점수 범위에 속하는 원소 수. 양 끝의 순위 차이로 계산한다. O(log n)
+func (z *ZSet) CountByScore(r ScoreRange) int {
+	first := z.zsl.firstInScoreRange(r)
+	if first == nil {
+		return 0
+	}
+	last := z.zsl.lastInScoreRange(r)
+	return z.zsl.rank(last.score, last.member) - z.zsl.rank(first.score, first.member) + 1
+}
+
+// 점수가 가장 작은(max면 가장 큰) 원소를 최대 count개 꺼낸다.
+func (z *ZSet) Pop(count int, max bool) []ZMember {
+	result := make([]ZMember, 0, min(count, z.Len()))
+	for len(result) < count && z.Len() > 0 {
+		x := z.zsl.header.level[0].forward
+		if max {
+			x = z.zsl.tail
+		}
+		result = append(result, ZMember{Member: x.member, Score: x.score})
+		z.Remove(x.member)
+	}
+	return result
+}
+
+// 모든 원소를 점수 순서대로 fn에 넘긴다. fn이 false를 반환하면 멈춘다.
+// 순회 중에 ZSet을 변경하면 안 된다.
+func (z *ZSet) Range(fn func(member string, score float64) bool) {
+	for x := z.zsl.header.level[0].forward; x != nil; x = x.level[0].forward {
+		if !fn(x.member, x.score) {
+			return
+		}
+	}
+}
+
+// cursor부터 원소를 순회해서 fn에 넘기고 다음 커서를 반환한다 (ZSCAN).
+func (z *ZSet) Scan(cursor uint64, count int, fn func(member string, score float64)) uint64 {
+	return scanDict(z.dict, cursor, count, fn)
+}
+
+// ========== 헬퍼 메서드 ==========
+
+// x부터 한 방향으로 걸으며 inRange를 만족하는 동안 원소를 모은다.
+// 처음 offset개는 건너뛰고, count개(음수면 제한 없음)를 모으면 멈춘다.
+func (z *ZSet) collect(x *skiplistNode, reverse bool, offset, count int, inRange func(*skiplistNode) bool) []ZMember {
+	next := func(n *skiplistNode) *skiplistNode {
+		if reverse {
+			return n.backward
+		}
+		return n.level[0].forward
+	}
+
+	for ; x != nil && offset > 0; offset-- {
+		x = next(x)
+	}
+
+	result := []ZMember{}
+	for ; x != nil && count != 0 && inRange(x); x = next(x) {
+		result = append(result, ZMember{Member: x.member, Score: x.score})
+		count--
+	}
+	return result
+}
+
+// ========== 범위 ==========
+
+func (r ScoreRange) empty() bool {
+	return r.Min > r.Max || (r.Min == r.Max && (r.MinExclusive || r.MaxExclusive))
+}
+
+// value가 최솟값 조건을 만족하면 true
+func (r ScoreRange) aboveMin(value float64) bool {
+	if r.MinExclusive {
+		return value > r.Min
+	}
+	return value >= r.Min
+}
+
+// value가 최댓값 조건을 만족하면 true
+func (r ScoreRange) belowMax(value float64) bool {
+	if r.MaxExclusive {
+		return value < r.Max
+	}
+	return value <= r.Max
+}
+
+func (r LexRange) empty() bool {
+	if r.Min.Infinite == 1 || r.Max.Infinite == -1 {
+		return true
+	}
+	if r.Min.Infinite != 0 || r.Max.Infinite != 0 {
+		return false
+	}
+	return r.Min.Value > r.Max.Value ||
+		(r.Min.Value == r.Max.Value && (r.Min.Exclusive || r.Max.Exclusive))
+}
+
+// value가 최솟값 조건을 만족하면 true
+func (r LexRange) aboveMin(value string) bool {
+	switch r.Min.Infinite {
+	case -1:
+		return true
+	case 1:
+		return false
+	}
+	if r.Min.Exclusive {
+		return value > r.Min.Value
+	}
+	return value >= r.Min.Value
+}
+
+// value가 최댓값 조건을 만족하면 true
+func (r LexRange) belowMax(value string) bool {
+	switch r.Max.Infinite {
+	case 1:
+		return true
+	case -1:
+		return false
+	}
+	if r.Max.Exclusive {
+		return value < r.Max.Value
+	}
+	return value <= r.Max.Value
+}
@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+// 모든 원소의 순위가 정렬 순서와 일치하는지 확인한다
+func assertZSetOrder(t *testing.T, z *ZSet, expected []ZMember) {
+	t.Helper()
+	if z.Len() != len(expected) || z.zsl.length != len(expected) {
+		t.Fatalf("Len: %d, skiplist: %d, expected: %d", z.Len(), z.zsl.length, len(expected))
+	}
+	for i, m := range expected {
+		rank, ok := z.Rank(m.Member, false)
+		if !ok || rank != i {
+			t.Fatalf("Rank(%s): %d, expected: %d", m.Member, rank, i)
+		}
+		node := z.zsl.byRank(i + 1)
+		if node == nil || node.member != m.Member {
+			t.Fatalf("byRank(%d): %v, expected: %s", i+1, node, m.Member)
+		}
+	}
+}
+
+func TestZSet_RankAfterRandomUpdates(t *testing.T) {
+	// given
+	z := NewZSet()
+	scores := map[string]float64{}
+
+	// when: 무작위로 넣고, 점수를 바꾸고, 지운다
+	for i := 0; i < 2000; i++ {
+		member := "m" + strconv.Itoa(rand.IntN(300))
+		if rand.IntN(4) == 0 {
+			z.Remove(member)
+			delete(scores, member)
+		} else {
+			score := float64(rand.IntN(50))
+			z.Set(member, score)
+			scores[member] = score
+		}
+	}
+
+	// then: 순위가 (score, member) 정렬 순서와 같다
+	expected := make([]ZMember, 0, len(scores))
+	for member, score := range scores {
+		expected = append(expected, ZMember{Member: member, Score: score})
+	}
+	sort.Slice(expected, func(i, j int) bool {
+		if expected[i].Score != expected[j].Score {
+			return expected[i].Score < expected[j].Score
+		}
+		return expected[i].Member < expected[j].Member
+	})
+	assertZSetOrder(t, z, expected)
+}
+
+func TestZSet_RangeByRank(t *testing.T) {
+	// given
+	z := NewZSet()
+	z.Set("a", 1)
+	z.Set("b", 2)
+	z.Set("c", 3)
+
+	cases := []struct {
+		start, stop int
+		reverse     bool
+		expected    string
+	}{
+		{0, -1, false, "abc"},
+		{1, 1, false, "b"},
+		{-2, -1, false, "bc"},
+		{0, 0, true, "c"},
+		{0, -1, true, "cba"},
+		{5, 10, false, ""},
+	}
+
+	for _, c := range cases {
+		// when
+		result := z.RangeByRank(c.start, c.stop, c.reverse)
+
+		// then
+		actual := ""
+		for _, m := range result {
+			actual += m.Member
+		}
+		if actual != c.expected {
+			t.Fatalf("RangeByRank(%d, %d, %v): %q, expected: %q", c.start, c.stop, c.reverse, actual, c.expected)
+		}
+	}
+}
+
+func TestZSet_RangeByScore(t *testing.T) {
+	// given
+	z := NewZSet()
+	for i := 1; i <= 5; i++ {
+		z.Set(strconv.Itoa(i), float64(i))
+	}
+
+	cases := []struct {
+		name          string
+		r             ScoreRange
+		reverse       bool
+		offset, count int
+		expected      string
+	}{
+		{"양 끝 포함", ScoreRange{Min: 2, Max: 4}, false, 0, -1, "234"},
+		{"양 끝 제외", ScoreRange{Min: 2, Max: 4, MinExclusive: true, MaxExclusive: true}, false, 0, -1, "3"},
+		{"역순", ScoreRange{Min: 2, Max: 4}, true, 0, -1, "432"},
+		{"LIMIT", ScoreRange{Min: 1, Max: 5}, false, 1, 2, "23"},
+		{"역순 LIMIT", ScoreRange{Min: 1, Max: 5}, true, 1, 2, "43"},
+		{"빈 범위", ScoreRange{Min: 3, Max: 3, MinExclusive: true}, false, 0, -1, ""},
+	}
+
+	for _, c := range cases {
+		// when
+		result := z.RangeByScore(c.r, c.reverse, c.offset, c.count)
+
+		// then
+		actual := ""
+		for _, m := range result {
+			actual += m.Member
+		}
+		if actual != c.expected {
+			t.Fatalf("%s: %q, expected: %q", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestZSet_CountByScore(t *testing.T) {
+	// given
+	z := NewZSet()
+	for i := 0; i < 100; i++ {
+		z.Set("m"+strconv.Itoa(i), float64(i))
+	}
+
+	// when & then
+	if count := z.CountByScore(ScoreRange{Min: 10, Max: 19}); count != 10 {
+		t.Fatalf("CountByScore: %d, expected: 10", count)
+	}
+	if count := z.CountByScore(ScoreRange{Min: 10, Max: 19, MinExclusive: true}); count != 9 {
+		t.Fatalf("CountByScore: %d, expected: 9", count)
+	}
+	if count := z.CountByScore(ScoreRange{Min: 200, Max: 300}); count != 0 {
+		t.Fatalf("CountByScore: %d, expected: 0", count)
+	}
+}
+
+func TestZSet_RangeByLex(t *testing.T) {
+	// given: 모든 점수가 같다
+	z := NewZSet()
+	for _, member := range []string{"a", "b", "c", "d", "e"} {
+		z.Set(member, 0)
+	}
+
+	cases := []struct {
+		name     string
+		r        LexRange
+		reverse  bool
+		expected string
+	}{
+		{"전체", LexRange{Min: LexBound{Infinite: -1}, Max: LexBound{Infinite: 1}}, false, "abcde"},
+		{"포함", LexRange{Min: LexBound{Value: "b"}, Max: LexBound{Value: "d"}}, false, "bcd"},
+		{"제외", LexRange{Min: LexBound{Value: "b", Exclusive: true}, Max: LexBound{Value: "d", Exclusive: true}}, false, "c"},
+		{"역순", LexRange{Min: LexBound{Value: "c"}, Max: LexBound{Infinite: 1}}, true, "edc"},
+		{"빈 범위", LexRange{Min: LexBound{Infinite: 1}, Max: LexBound{Infinite: -1}}, false, ""},
+	}
+
+	for _, c := range cases {
+		// when
+		result := z.RangeByLex(c.r, c.reverse, 0, -1)
+
+		// then
+		actual := ""
+		for _, m := range result {
+			actual += m.Member
+		}
+		if actual != c.expected {
+			t.Fatalf("%s: %q, expected: %q", c.name, actual, c.expected)
+		}
+	}
+}
+
+func TestZSet_Pop(t *testing.T) {
+	// given
+	z := NewZSet()
+	z.Set("a", 1)
+	z.Set("b", 2)
+	z.Set("c", 3)
+
+	// when
+	lowest := z.Pop(2, false)
+	highest := z.Pop(5, true)
+
+	// then
+	if len(lowest) != 2 || lowest[0].Member != "a" || lowest[1].Member != "b" {
+		t.Fatalf("Pop(min): %v", lowest)
+	}
+	if len(highest) != 1 || highest[0].Member != "c" {
+		t.Fatalf("Pop(max): %v", highest)
+	}
+	if z.Len() != 0 || z.zsl.tail != nil {
+		t.Fatalf("Len: %d", z.Len())
+	}
+}
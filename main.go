@@ -1,15 +1,41 @@
 package main
 
 import (
+	"flag"
 	"inmemory-db/internal/server"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 )
 
 func main() {
+	configFile := flag.String("config", "", "설정 파일 경로 (명령줄 옵션이 파일의 값보다 우선한다)")
+	applyFlags := server.RegisterConfigFlags(flag.CommandLine)
+	flag.Parse()
+
 	server := server.New(":6379")
+	if *configFile != "" {
+		if err := server.LoadConfigFile(*configFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := applyFlags(server); err != nil {
+		log.Print(err)
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// SIGINT/SIGTERM을 받으면 연결을 정리하고 스냅샷을 쓴 뒤 종료한다
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Printf("%v 신호를 받아 서버를 종료합니다.", sig)
+		server.Shutdown(true)
+	}()
 
 	if err := server.Start(); err != nil {
 		log.Fatal(err)
 	}
-	server.Start()
 }